@@ -4,20 +4,47 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 
 	"redis-explorer/internal/models"
+	"redis-explorer/internal/secrets"
 )
 
 // Config holds all application settings
 type Config struct {
-	Theme             models.ThemeName          `json:"theme"`
-	Connections       []models.ServerConnection `json:"connections"`
-	LastConnectionID  string                    `json:"last_connection_id,omitempty"`
-	KeyScanCount      int                       `json:"key_scan_count"`
-	AutoRefreshSecs   int                       `json:"auto_refresh_secs"`
-	WindowWidth       float32                   `json:"window_width"`
-	WindowHeight      float32                   `json:"window_height"`
+	Theme               models.ThemeName          `json:"theme"`
+	Connections         []models.ServerConnection `json:"connections"`
+	ConnectionGroups    []models.ConnectionGroup  `json:"connection_groups,omitempty"`
+	Favorites           []models.Favorite         `json:"favorites,omitempty"`
+	Scripts             []models.SavedScript      `json:"scripts,omitempty"`
+	KeyTemplates        []models.KeyTemplate      `json:"key_templates,omitempty"`
+	LastConnectionID    string                    `json:"last_connection_id,omitempty"`
+	KeyScanCount        int                       `json:"key_scan_count"`
+	KeyPageSize         int                       `json:"key_page_size"`
+	LookupBatchSize     int                       `json:"lookup_batch_size"`
+	CollectionPageSize  int                       `json:"collection_page_size"`
+	AutoRefreshSecs     int                       `json:"auto_refresh_secs"`
+	MetricsIntervalSecs int                       `json:"metrics_interval_secs"`
+	LiveRefreshSecs     int                       `json:"live_refresh_secs"`
+	DialTimeoutSecs     int                       `json:"dial_timeout_secs"`
+	ReadTimeoutSecs     int                       `json:"read_timeout_secs"`
+	WriteTimeoutSecs    int                       `json:"write_timeout_secs"`
+	LargeDBKeys         int                       `json:"large_db_keys"` // Warn before reading every key of a larger database
+	WatchIntervalSecs   int                       `json:"watch_interval_secs"`
+	GentleScan          bool                      `json:"gentle_scan"`
+	ShowKeyMemory       bool                      `json:"show_key_memory"`
+	ConnectOnLaunch     bool                      `json:"connect_on_launch"` // Connect to LastConnectionID on startup
+	CredentialStore     string                    `json:"credential_store"`
+	WindowWidth         float32                   `json:"window_width"`
+	WindowHeight        float32                   `json:"window_height"`
+	Layout              models.WindowLayout       `json:"layout"`
+	Appearance          models.Appearance         `json:"appearance"`
+	Notifications       models.Notifications      `json:"notifications"`
+	CustomThemes        []models.CustomTheme      `json:"custom_themes,omitempty"`
+	AutoTheme           models.AutoTheme          `json:"auto_theme"`
+	Language            string                    `json:"language,omitempty"` // Empty follows the system
 }
 
 var (
@@ -41,14 +68,38 @@ func DefaultConfig() *Config {
 				UseTLS:   false,
 			},
 		},
-		LastConnectionID: "default",
-		KeyScanCount:     100,
-		AutoRefreshSecs:  0,
-		WindowWidth:      1200,
-		WindowHeight:     800,
+		LastConnectionID:    "default",
+		KeyScanCount:        100,
+		KeyPageSize:         1000,
+		LookupBatchSize:     500,
+		CollectionPageSize:  1000,
+		AutoRefreshSecs:     0,
+		MetricsIntervalSecs: 5,
+		LiveRefreshSecs:     2,
+		DialTimeoutSecs:     5,
+		ReadTimeoutSecs:     3,
+		WriteTimeoutSecs:    3,
+		LargeDBKeys:         100000,
+		WatchIntervalSecs:   5,
+		CredentialStore:     secrets.Keychain,
+		WindowWidth:         1200,
+		WindowHeight:        800,
+		Layout:              defaultLayout,
+		Appearance:          defaultAppearance,
+		AutoTheme:           defaultAutoTheme,
 	}
 }
 
+// defaultAppearance is the text appearance before it is first changed
+var defaultAppearance = models.Appearance{TextSize: 14, WrapValues: true}
+
+// defaultAutoTheme is what the Auto (System) theme switches between before it
+// is first changed
+var defaultAutoTheme = models.AutoTheme{Light: models.ThemeLight, Dark: models.ThemeDark}
+
+// defaultLayout is the window layout before it is first changed
+var defaultLayout = models.WindowLayout{SidebarOffset: 0.18, BrowserOffset: 0.35}
+
 // getConfigPath returns the path to the config file
 func getConfigPath() (string, error) {
 	configDir, err := os.UserConfigDir()
@@ -95,15 +146,69 @@ func Load() (*Config, error) {
 		if instance.KeyScanCount == 0 {
 			instance.KeyScanCount = 100
 		}
+		if instance.KeyPageSize == 0 {
+			instance.KeyPageSize = 1000
+		}
+		if instance.LookupBatchSize == 0 {
+			instance.LookupBatchSize = 500
+		}
+		if instance.CollectionPageSize == 0 {
+			instance.CollectionPageSize = 1000
+		}
+		if instance.MetricsIntervalSecs == 0 {
+			instance.MetricsIntervalSecs = 5
+		}
+		if instance.LiveRefreshSecs == 0 {
+			instance.LiveRefreshSecs = 2
+		}
+		if instance.CredentialStore == "" {
+			instance.CredentialStore = secrets.Keychain
+		}
+		if instance.DialTimeoutSecs == 0 {
+			instance.DialTimeoutSecs = 5
+		}
+		if instance.ReadTimeoutSecs == 0 {
+			instance.ReadTimeoutSecs = 3
+		}
+		if instance.WriteTimeoutSecs == 0 {
+			instance.WriteTimeoutSecs = 3
+		}
+		if instance.LargeDBKeys == 0 {
+			instance.LargeDBKeys = 100000
+		}
+		if instance.WatchIntervalSecs == 0 {
+			instance.WatchIntervalSecs = 5
+		}
 		if instance.WindowWidth == 0 {
 			instance.WindowWidth = 1200
 		}
 		if instance.WindowHeight == 0 {
 			instance.WindowHeight = 800
 		}
+		if instance.Layout.SidebarOffset <= 0 || instance.Layout.SidebarOffset >= 1 {
+			instance.Layout.SidebarOffset = defaultLayout.SidebarOffset
+		}
+		if instance.Layout.BrowserOffset <= 0 || instance.Layout.BrowserOffset >= 1 {
+			instance.Layout.BrowserOffset = defaultLayout.BrowserOffset
+		}
+		// The text size is always saved, so without it no appearance was
+		if instance.Appearance.TextSize == 0 {
+			instance.Appearance = defaultAppearance
+		}
+		if instance.AutoTheme.Light == "" {
+			instance.AutoTheme.Light = defaultAutoTheme.Light
+		}
+		if instance.AutoTheme.Dark == "" {
+			instance.AutoTheme.Dark = defaultAutoTheme.Dark
+		}
 		if len(instance.Connections) == 0 {
 			instance.Connections = DefaultConfig().Connections
 		}
+
+		// Passwords saved in plaintext by older versions move to the credential store
+		if loadCredentials(instance.Connections) {
+			loadErr = saveWithoutLock()
+		}
 	})
 	return instance, loadErr
 }
@@ -132,7 +237,15 @@ func saveWithoutLock() error {
 		configPath = path
 	}
 
-	data, err := json.MarshalIndent(instance, "", "  ")
+	// Write the config with the passwords swapped for credential references
+	conns, err := storeCredentials(instance.Connections, instance.CredentialStore)
+	if err != nil {
+		return err
+	}
+	saved := *instance
+	saved.Connections = conns
+
+	data, err := json.MarshalIndent(&saved, "", "  ")
 	if err != nil {
 		return err
 	}
@@ -152,6 +265,7 @@ func AddConnection(conn models.ServerConnection) error {
 	mu.Lock()
 	defer mu.Unlock()
 	instance.Connections = append(instance.Connections, conn)
+	ensureGroupWithoutLock(conn.Group)
 	return saveWithoutLock()
 }
 
@@ -161,10 +275,12 @@ func UpdateConnection(conn models.ServerConnection) error {
 	defer mu.Unlock()
 	for i, c := range instance.Connections {
 		if c.ID == conn.ID {
+			conn.CredentialRef = c.CredentialRef
 			instance.Connections[i] = conn
 			break
 		}
 	}
+	ensureGroupWithoutLock(conn.Group)
 	return saveWithoutLock()
 }
 
@@ -174,10 +290,189 @@ func RemoveConnection(id string) error {
 	defer mu.Unlock()
 	for i, c := range instance.Connections {
 		if c.ID == id {
+			deleteCredentials(c)
 			instance.Connections = append(instance.Connections[:i], instance.Connections[i+1:]...)
 			break
 		}
 	}
+	instance.Favorites = slices.DeleteFunc(instance.Favorites, func(f models.Favorite) bool {
+		return f.ConnectionID == id
+	})
+	return saveWithoutLock()
+}
+
+// MoveConnection moves a connection into group, placing it before the connection
+// beforeID, or after the group's last connection when beforeID is empty
+func MoveConnection(id, group, beforeID string) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var moved *models.ServerConnection
+	for i, c := range instance.Connections {
+		if c.ID == id {
+			moved = &c
+			instance.Connections = append(instance.Connections[:i], instance.Connections[i+1:]...)
+			break
+		}
+	}
+	if moved == nil {
+		return nil
+	}
+	moved.Group = group
+	ensureGroupWithoutLock(group)
+
+	at := len(instance.Connections)
+	for i, c := range instance.Connections {
+		if beforeID != "" && c.ID == beforeID {
+			at = i
+			break
+		}
+		if beforeID == "" && c.Group == group {
+			at = i + 1
+		}
+	}
+	instance.Connections = append(instance.Connections[:at], append([]models.ServerConnection{*moved}, instance.Connections[at:]...)...)
+	return saveWithoutLock()
+}
+
+// ensureGroupWithoutLock adds an entry for a group named by a connection
+func ensureGroupWithoutLock(name string) {
+	if name == "" {
+		return
+	}
+	for _, g := range instance.ConnectionGroups {
+		if g.Name == name {
+			return
+		}
+	}
+	instance.ConnectionGroups = append(instance.ConnectionGroups, models.ConnectionGroup{Name: name})
+}
+
+// updateGroup applies update to the named connection group, creating it if needed
+func updateGroup(name string, update func(g *models.ConnectionGroup)) error {
+	mu.Lock()
+	defer mu.Unlock()
+	ensureGroupWithoutLock(name)
+	for i := range instance.ConnectionGroups {
+		if instance.ConnectionGroups[i].Name == name {
+			update(&instance.ConnectionGroups[i])
+		}
+	}
+	return saveWithoutLock()
+}
+
+// SetConnectionDelimiter sets the key namespace delimiter of a connection; empty
+// detects it from the keys
+func SetConnectionDelimiter(id, delimiter string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.Connections {
+		if instance.Connections[i].ID == id {
+			instance.Connections[i].Delimiter = delimiter
+		}
+	}
+	return saveWithoutLock()
+}
+
+// SetConnectionWatches saves the watch panel's watches of a connection
+func SetConnectionWatches(id string, watches []models.Watch) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.Connections {
+		if instance.Connections[i].ID == id {
+			instance.Connections[i].Watches = watches
+		}
+	}
+	return saveWithoutLock()
+}
+
+// SetWatchInterval saves how often the watch panel polls, in seconds
+func SetWatchInterval(secs int) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.WatchIntervalSecs = secs
+	return saveWithoutLock()
+}
+
+// SetConnectionKeySort saves the key list sort order of a connection
+func SetConnectionKeySort(id, column string, descending bool) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.Connections {
+		if instance.Connections[i].ID == id {
+			instance.Connections[i].KeySort = column
+			instance.Connections[i].KeySortDesc = descending
+		}
+	}
+	return saveWithoutLock()
+}
+
+// SetConnectionBackup saves how a connection is backed up
+func SetConnectionBackup(id string, backup models.BackupSettings) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.Connections {
+		if instance.Connections[i].ID == id {
+			instance.Connections[i].Backup = backup
+		}
+	}
+	return saveWithoutLock()
+}
+
+// SetGroupColor sets the color tag of a connection group; empty clears it
+func SetGroupColor(name, color string) error {
+	return updateGroup(name, func(g *models.ConnectionGroup) { g.Color = color })
+}
+
+// SetGroupCollapsed records whether a connection group is collapsed in the sidebar
+func SetGroupCollapsed(name string, collapsed bool) error {
+	return updateGroup(name, func(g *models.ConnectionGroup) { g.Collapsed = collapsed })
+}
+
+// RenameGroup renames a connection group, merging it into newName if that
+// group already exists
+func RenameGroup(oldName, newName string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.Connections {
+		if instance.Connections[i].Group == oldName {
+			instance.Connections[i].Group = newName
+		}
+	}
+	exists := false
+	for _, g := range instance.ConnectionGroups {
+		exists = exists || g.Name == newName
+	}
+	for i, g := range instance.ConnectionGroups {
+		if g.Name != oldName {
+			continue
+		}
+		if exists {
+			instance.ConnectionGroups = append(instance.ConnectionGroups[:i], instance.ConnectionGroups[i+1:]...)
+		} else {
+			instance.ConnectionGroups[i].Name = newName
+		}
+		break
+	}
+	ensureGroupWithoutLock(newName)
+	return saveWithoutLock()
+}
+
+// RemoveGroup deletes a connection group, leaving its connections ungrouped
+func RemoveGroup(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.Connections {
+		if instance.Connections[i].Group == name {
+			instance.Connections[i].Group = ""
+		}
+	}
+	for i, g := range instance.ConnectionGroups {
+		if g.Name == name {
+			instance.ConnectionGroups = append(instance.ConnectionGroups[:i], instance.ConnectionGroups[i+1:]...)
+			break
+		}
+	}
 	return saveWithoutLock()
 }
 
@@ -210,3 +505,196 @@ func SetWindowSize(width, height float32) error {
 	instance.WindowHeight = height
 	return saveWithoutLock()
 }
+
+// SetLayout updates the window layout restored at the next start
+func SetLayout(layout models.WindowLayout) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.Layout = layout
+	return saveWithoutLock()
+}
+
+// GetCustomTheme returns the custom theme with the given name, or nil
+func GetCustomTheme(name string) *models.CustomTheme {
+	mu.RLock()
+	defer mu.RUnlock()
+	for i := range instance.CustomThemes {
+		if instance.CustomThemes[i].Name == name {
+			t := instance.CustomThemes[i]
+			return &t
+		}
+	}
+	return nil
+}
+
+// SaveCustomTheme adds a custom theme or replaces the one with its name
+func SaveCustomTheme(theme models.CustomTheme) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for i := range instance.CustomThemes {
+		if instance.CustomThemes[i].Name == theme.Name {
+			instance.CustomThemes[i] = theme
+			return saveWithoutLock()
+		}
+	}
+	instance.CustomThemes = append(instance.CustomThemes, theme)
+	return saveWithoutLock()
+}
+
+// DeleteCustomTheme removes a custom theme, falling back to the dark theme if
+// it was selected and to the default themes where Auto (System) used it
+func DeleteCustomTheme(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.CustomThemes = slices.DeleteFunc(instance.CustomThemes, func(t models.CustomTheme) bool {
+		return t.Name == name
+	})
+	if instance.Theme == models.CustomThemeName(name) {
+		instance.Theme = models.ThemeDark
+	}
+	if instance.AutoTheme.Light == models.CustomThemeName(name) {
+		instance.AutoTheme.Light = defaultAutoTheme.Light
+	}
+	if instance.AutoTheme.Dark == models.CustomThemeName(name) {
+		instance.AutoTheme.Dark = defaultAutoTheme.Dark
+	}
+	return saveWithoutLock()
+}
+
+// SetAutoTheme updates the themes Auto (System) switches between
+func SetAutoTheme(auto models.AutoTheme) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.AutoTheme = auto
+	return saveWithoutLock()
+}
+
+// SetAppearance updates how text and values are shown
+func SetAppearance(appearance models.Appearance) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.Appearance = appearance
+	return saveWithoutLock()
+}
+
+// GetFavorites returns the starred keys and folders of a connection's database
+// in the order they were starred
+func GetFavorites(connectionID string, db int) []models.Favorite {
+	mu.RLock()
+	defer mu.RUnlock()
+	var favorites []models.Favorite
+	for _, f := range instance.Favorites {
+		if f.ConnectionID == connectionID && f.Database == db {
+			favorites = append(favorites, f)
+		}
+	}
+	return favorites
+}
+
+// IsFavorite reports whether a key or folder is starred
+func IsFavorite(fav models.Favorite) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	return slices.Contains(instance.Favorites, fav)
+}
+
+// AddFavorite stars a key or folder
+func AddFavorite(fav models.Favorite) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if slices.Contains(instance.Favorites, fav) {
+		return nil
+	}
+	instance.Favorites = append(instance.Favorites, fav)
+	return saveWithoutLock()
+}
+
+// RemoveFavorite unstars a key or folder
+func RemoveFavorite(fav models.Favorite) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.Favorites = slices.DeleteFunc(instance.Favorites, func(f models.Favorite) bool {
+		return f == fav
+	})
+	return saveWithoutLock()
+}
+
+// GetScripts returns the saved Lua scripts, sorted by name
+func GetScripts() []models.SavedScript {
+	mu.RLock()
+	defer mu.RUnlock()
+	scripts := slices.Clone(instance.Scripts)
+	slices.SortFunc(scripts, func(a, b models.SavedScript) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return scripts
+}
+
+// SaveScript adds a script to the library, replacing any with the same name
+func SaveScript(script models.SavedScript) error {
+	mu.Lock()
+	defer mu.Unlock()
+	i := slices.IndexFunc(instance.Scripts, func(s models.SavedScript) bool {
+		return s.Name == script.Name
+	})
+	if i >= 0 {
+		instance.Scripts[i] = script
+	} else {
+		instance.Scripts = append(instance.Scripts, script)
+	}
+	return saveWithoutLock()
+}
+
+// DeleteScript removes a script from the library
+func DeleteScript(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.Scripts = slices.DeleteFunc(instance.Scripts, func(s models.SavedScript) bool {
+		return s.Name == name
+	})
+	return saveWithoutLock()
+}
+
+// GetKeyTemplates returns the key templates, sorted by name
+func GetKeyTemplates() []models.KeyTemplate {
+	mu.RLock()
+	defer mu.RUnlock()
+	templates := slices.Clone(instance.KeyTemplates)
+	slices.SortFunc(templates, func(a, b models.KeyTemplate) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return templates
+}
+
+// SaveKeyTemplate adds a key template, replacing any with the same name
+func SaveKeyTemplate(template models.KeyTemplate) error {
+	mu.Lock()
+	defer mu.Unlock()
+	i := slices.IndexFunc(instance.KeyTemplates, func(t models.KeyTemplate) bool {
+		return t.Name == template.Name
+	})
+	if i >= 0 {
+		instance.KeyTemplates[i] = template
+	} else {
+		instance.KeyTemplates = append(instance.KeyTemplates, template)
+	}
+	return saveWithoutLock()
+}
+
+// DeleteKeyTemplate removes a key template
+func DeleteKeyTemplate(name string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.KeyTemplates = slices.DeleteFunc(instance.KeyTemplates, func(t models.KeyTemplate) bool {
+		return t.Name == name
+	})
+	return saveWithoutLock()
+}
+
+// SetShowKeyMemory updates whether the key list shows MEMORY USAGE per key
+func SetShowKeyMemory(show bool) error {
+	mu.Lock()
+	defer mu.Unlock()
+	instance.ShowKeyMemory = show
+	return saveWithoutLock()
+}
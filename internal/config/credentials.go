@@ -0,0 +1,136 @@
+package config
+
+import (
+	"encoding/json"
+	"log"
+	"path/filepath"
+
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/secrets"
+)
+
+// connectionSecrets are the passwords of one connection, stored together under
+// its credential reference
+type connectionSecrets struct {
+	Password         string `json:"password,omitempty"`
+	SentinelPassword string `json:"sentinel_password,omitempty"`
+	SSHPassword      string `json:"ssh_password,omitempty"`
+	SSHKeyPassphrase string `json:"ssh_key_passphrase,omitempty"`
+}
+
+func secretsOf(conn models.ServerConnection) connectionSecrets {
+	return connectionSecrets{
+		Password:         conn.Password,
+		SentinelPassword: conn.Sentinel.Password,
+		SSHPassword:      conn.SSH.Password,
+		SSHKeyPassphrase: conn.SSH.KeyPassphrase,
+	}
+}
+
+func (s connectionSecrets) apply(conn *models.ServerConnection) {
+	conn.Password = s.Password
+	conn.Sentinel.Password = s.SentinelPassword
+	conn.SSH.Password = s.SSHPassword
+	conn.SSH.KeyPassphrase = s.SSHKeyPassphrase
+}
+
+var (
+	credentialStores *secrets.Stores
+	// storedSecrets is what each credential reference was last read or written
+	// as, so saving the config only touches the store when a password changed
+	storedSecrets = make(map[string]string)
+	// unreadable holds references that failed to load, e.g. from a locked
+	// keychain; they are kept rather than cleared when the config is saved
+	unreadable = make(map[string]bool)
+)
+
+func stores() *secrets.Stores {
+	if credentialStores == nil {
+		credentialStores = secrets.NewStores(filepath.Dir(configPath))
+	}
+	return credentialStores
+}
+
+// loadCredentials fills in the passwords of each connection from its credential
+// reference and reports whether any were found in plaintext in the config file
+func loadCredentials(conns []models.ServerConnection) (plaintext bool) {
+	for i := range conns {
+		conn := &conns[i]
+		if secretsOf(*conn) != (connectionSecrets{}) {
+			plaintext = true
+			continue
+		}
+		if conn.CredentialRef == "" {
+			continue
+		}
+
+		data, err := stores().Get(conn.CredentialRef)
+		if err != nil {
+			unreadable[conn.CredentialRef] = true
+			log.Printf("warning: failed to read the password of connection %s: %v", conn.Name, err)
+			continue
+		}
+		var s connectionSecrets
+		if err := json.Unmarshal([]byte(data), &s); err != nil {
+			log.Printf("warning: invalid stored password for connection %s: %v", conn.Name, err)
+			continue
+		}
+		s.apply(conn)
+		storedSecrets[conn.CredentialRef] = data
+	}
+	return plaintext
+}
+
+// storeCredentials saves the passwords of each connection in the preferred store,
+// updating their credential references, and returns copies of the connections
+// without passwords for writing to disk
+func storeCredentials(conns []models.ServerConnection, preferred string) ([]models.ServerConnection, error) {
+	saved := make([]models.ServerConnection, len(conns))
+	for i := range conns {
+		conn := &conns[i]
+		s := secretsOf(*conn)
+
+		switch {
+		case s == (connectionSecrets{}) && unreadable[conn.CredentialRef]:
+			// Keep the reference to passwords that couldn't be read
+		case s == (connectionSecrets{}):
+			deleteCredentials(*conn)
+			conn.CredentialRef = ""
+		default:
+			data, err := json.Marshal(s)
+			if err != nil {
+				return nil, err
+			}
+			unchanged := conn.CredentialRef != "" && storedSecrets[conn.CredentialRef] == string(data) &&
+				secrets.Backend(conn.CredentialRef) == preferred
+			if !unchanged {
+				ref, err := stores().Set(preferred, conn.ID, string(data))
+				if err != nil {
+					return nil, err
+				}
+				// Moving between stores leaves nothing behind in the old one
+				if conn.CredentialRef != "" && conn.CredentialRef != ref {
+					deleteCredentials(*conn)
+				}
+				conn.CredentialRef = ref
+				storedSecrets[ref] = string(data)
+				delete(unreadable, ref)
+			}
+		}
+
+		saved[i] = *conn
+		connectionSecrets{}.apply(&saved[i])
+	}
+	return saved, nil
+}
+
+// deleteCredentials removes the stored passwords of a connection
+func deleteCredentials(conn models.ServerConnection) {
+	if conn.CredentialRef == "" {
+		return
+	}
+	if err := stores().Delete(conn.CredentialRef); err != nil {
+		log.Printf("warning: failed to delete the password of connection %s: %v", conn.Name, err)
+	}
+	delete(storedSecrets, conn.CredentialRef)
+}
@@ -0,0 +1,241 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"golang.org/x/crypto/scrypt"
+	"redis-explorer/internal/models"
+)
+
+// transferVersion is the version of the export format written by Export
+const transferVersion = 1
+
+// ErrWrongPassphrase is returned by Import when the passphrase doesn't decrypt
+// the passwords of an export
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// transferFile is an exported configuration. Its connections carry no
+// passwords or credential references; the passwords, if exported at all, are
+// encrypted with a passphrase.
+type transferFile struct {
+	Version int     `json:"version"`
+	Config  *Config `json:"config"`
+	// Secrets holds the connection passwords by connection ID as JSON, sealed
+	// with AES-GCM under a key derived from the passphrase and Salt
+	Secrets []byte `json:"secrets,omitempty"`
+	Salt    []byte `json:"salt,omitempty"`
+}
+
+// ImportResult counts what Import changed
+type ImportResult struct {
+	Added     int  // connections new to this machine
+	Updated   int  // connections replaced by the export's
+	Passwords bool // the export's passwords were decrypted and imported
+}
+
+// Export returns the settings and connections as JSON for Import on another
+// machine. With an empty passphrase the connection passwords are left out,
+// otherwise they are encrypted with it.
+func Export(passphrase string) ([]byte, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	saved := *instance
+	saved.Connections = make([]models.ServerConnection, len(instance.Connections))
+	all := make(map[string]connectionSecrets)
+	for i, conn := range instance.Connections {
+		if s := secretsOf(conn); s != (connectionSecrets{}) {
+			all[conn.ID] = s
+		}
+		connectionSecrets{}.apply(&conn)
+		conn.CredentialRef = ""
+		saved.Connections[i] = conn
+	}
+
+	file := transferFile{Version: transferVersion, Config: &saved}
+	if passphrase != "" && len(all) > 0 {
+		plain, err := json.Marshal(all)
+		if err != nil {
+			return nil, err
+		}
+		file.Salt = make([]byte, 16)
+		if _, err := rand.Read(file.Salt); err != nil {
+			return nil, err
+		}
+		gcm, err := transferCipher(passphrase, file.Salt)
+		if err != nil {
+			return nil, err
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		file.Secrets = gcm.Seal(nonce, nonce, plain, nil)
+	}
+	return json.MarshalIndent(&file, "", "  ")
+}
+
+// ExportHasPasswords reports whether an export holds encrypted passwords, so
+// Import needs their passphrase
+func ExportHasPasswords(data []byte) (bool, error) {
+	var file transferFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return false, fmt.Errorf("not a configuration export: %w", err)
+	}
+	return len(file.Secrets) > 0, nil
+}
+
+// Import merges an export made by Export into the configuration. Connections
+// are matched by ID: those already here are replaced, keeping their local
+// passwords when the export has none for them, and the rest are added. Groups,
+// favorites, scripts and custom themes are merged the same way, and the
+// settings are taken from the export except for window size, layout and
+// password storage, which belong to this machine. With an empty passphrase
+// the export's passwords are not imported.
+func Import(data []byte, passphrase string) (ImportResult, error) {
+	var result ImportResult
+	var file transferFile
+	if err := json.Unmarshal(data, &file); err != nil || file.Config == nil {
+		return result, fmt.Errorf("not a configuration export")
+	}
+	if file.Version > transferVersion {
+		return result, fmt.Errorf("the export was made by a newer version (format %d)", file.Version)
+	}
+
+	imported := make(map[string]connectionSecrets)
+	if len(file.Secrets) > 0 && passphrase != "" {
+		gcm, err := transferCipher(passphrase, file.Salt)
+		if err != nil {
+			return result, err
+		}
+		if len(file.Secrets) < gcm.NonceSize() {
+			return result, fmt.Errorf("the passwords in the export are corrupt")
+		}
+		nonce, sealed := file.Secrets[:gcm.NonceSize()], file.Secrets[gcm.NonceSize():]
+		plain, err := gcm.Open(nil, nonce, sealed, nil)
+		if err != nil {
+			return result, ErrWrongPassphrase
+		}
+		if err := json.Unmarshal(plain, &imported); err != nil {
+			return result, err
+		}
+		result.Passwords = true
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	src := file.Config
+
+	for _, conn := range src.Connections {
+		conn.CredentialRef = ""
+		i := slices.IndexFunc(instance.Connections, func(c models.ServerConnection) bool { return c.ID == conn.ID })
+		if s, ok := imported[conn.ID]; ok {
+			s.apply(&conn)
+		} else if i >= 0 {
+			secretsOf(instance.Connections[i]).apply(&conn)
+		}
+		if i >= 0 {
+			// The local credential reference is reused for the new passwords
+			conn.CredentialRef = instance.Connections[i].CredentialRef
+			instance.Connections[i] = conn
+			result.Updated++
+		} else {
+			instance.Connections = append(instance.Connections, conn)
+			result.Added++
+		}
+		ensureGroupWithoutLock(conn.Group)
+	}
+	for _, group := range src.ConnectionGroups {
+		i := slices.IndexFunc(instance.ConnectionGroups, func(g models.ConnectionGroup) bool { return g.Name == group.Name })
+		if i >= 0 {
+			instance.ConnectionGroups[i] = group
+		} else {
+			instance.ConnectionGroups = append(instance.ConnectionGroups, group)
+		}
+	}
+	for _, fav := range src.Favorites {
+		if !slices.Contains(instance.Favorites, fav) {
+			instance.Favorites = append(instance.Favorites, fav)
+		}
+	}
+	for _, script := range src.Scripts {
+		i := slices.IndexFunc(instance.Scripts, func(s models.SavedScript) bool { return s.Name == script.Name })
+		if i >= 0 {
+			instance.Scripts[i] = script
+		} else {
+			instance.Scripts = append(instance.Scripts, script)
+		}
+	}
+	for _, template := range src.KeyTemplates {
+		i := slices.IndexFunc(instance.KeyTemplates, func(t models.KeyTemplate) bool { return t.Name == template.Name })
+		if i >= 0 {
+			instance.KeyTemplates[i] = template
+		} else {
+			instance.KeyTemplates = append(instance.KeyTemplates, template)
+		}
+	}
+	for _, theme := range src.CustomThemes {
+		i := slices.IndexFunc(instance.CustomThemes, func(t models.CustomTheme) bool { return t.Name == theme.Name })
+		if i >= 0 {
+			instance.CustomThemes[i] = theme
+		} else {
+			instance.CustomThemes = append(instance.CustomThemes, theme)
+		}
+	}
+
+	// Settings missing from the export keep their values here
+	if src.Theme != "" {
+		instance.Theme = src.Theme
+	}
+	if src.AutoTheme.Light != "" && src.AutoTheme.Dark != "" {
+		instance.AutoTheme = src.AutoTheme
+	}
+	if src.Appearance.TextSize != 0 {
+		instance.Appearance = src.Appearance
+	}
+	instance.Language = src.Language
+	for _, setting := range []struct{ dst, src *int }{
+		{&instance.KeyScanCount, &src.KeyScanCount},
+		{&instance.KeyPageSize, &src.KeyPageSize},
+		{&instance.LookupBatchSize, &src.LookupBatchSize},
+		{&instance.CollectionPageSize, &src.CollectionPageSize},
+		{&instance.MetricsIntervalSecs, &src.MetricsIntervalSecs},
+		{&instance.LiveRefreshSecs, &src.LiveRefreshSecs},
+		{&instance.DialTimeoutSecs, &src.DialTimeoutSecs},
+		{&instance.ReadTimeoutSecs, &src.ReadTimeoutSecs},
+		{&instance.WriteTimeoutSecs, &src.WriteTimeoutSecs},
+		{&instance.LargeDBKeys, &src.LargeDBKeys},
+		{&instance.WatchIntervalSecs, &src.WatchIntervalSecs},
+	} {
+		if *setting.src > 0 {
+			*setting.dst = *setting.src
+		}
+	}
+	instance.AutoRefreshSecs = src.AutoRefreshSecs
+	instance.GentleScan = src.GentleScan
+	instance.ShowKeyMemory = src.ShowKeyMemory
+	instance.ConnectOnLaunch = src.ConnectOnLaunch
+	instance.Notifications = src.Notifications
+
+	return result, saveWithoutLock()
+}
+
+// transferCipher derives the AES-GCM cipher of an export's passwords from
+// its passphrase
+func transferCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
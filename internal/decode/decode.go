@@ -0,0 +1,132 @@
+// Package decode turns encoded or serialized Redis values into readable text.
+// Decoders are chained, each one transforming the output of the previous one,
+// e.g. base64 -> gzip -> MessagePack.
+package decode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// maxDetectDepth bounds how many decoders Detect chains together
+const maxDetectDepth = 5
+
+// maxOutputSize caps decompressed output so a small value can't expand without bound
+const maxOutputSize = 64 << 20
+
+// Decoder is one step of a decode chain
+type Decoder interface {
+	// Name is shown in the editor's "View as" selector and must be unique
+	Name() string
+	// Sniff reports whether data looks like this decoder's input. It is used
+	// for auto-detection and should be cheap and conservative.
+	Sniff(data []byte) bool
+	// Decode transforms data, failing if it isn't valid input
+	Decode(data []byte) ([]byte, error)
+}
+
+var (
+	mu       sync.RWMutex
+	decoders = []Decoder{
+		Base64{},
+		Gzip{},
+		Zlib{},
+		MessagePack{},
+		PHPSerialized{},
+		JavaSerialized{},
+		Protobuf{},
+	}
+)
+
+// Register adds a decoder, replacing any registered decoder with the same name
+func Register(d Decoder) {
+	mu.Lock()
+	defer mu.Unlock()
+	for i, existing := range decoders {
+		if existing.Name() == d.Name() {
+			decoders[i] = d
+			return
+		}
+	}
+	decoders = append(decoders, d)
+}
+
+// All returns the registered decoders in registration order
+func All() []Decoder {
+	mu.RLock()
+	defer mu.RUnlock()
+	return append([]Decoder(nil), decoders...)
+}
+
+// Names returns the names of the registered decoders
+func Names() []string {
+	var names []string
+	for _, d := range All() {
+		names = append(names, d.Name())
+	}
+	return names
+}
+
+// Lookup finds a registered decoder by name
+func Lookup(name string) (Decoder, bool) {
+	for _, d := range All() {
+		if d.Name() == name {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// Chain runs data through the named decoders in order
+func Chain(data []byte, names ...string) ([]byte, error) {
+	for _, name := range names {
+		d, ok := Lookup(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown decoder %q", name)
+		}
+		decoded, err := d.Decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		data = decoded
+	}
+	return data, nil
+}
+
+// Detect guesses a decode chain for data by repeatedly applying the first
+// decoder whose Sniff matches and whose Decode succeeds. It returns nil when
+// nothing matches.
+func Detect(data []byte) []string {
+	var chain []string
+	for len(chain) < maxDetectDepth {
+		matched := false
+		for _, d := range All() {
+			if !d.Sniff(data) {
+				continue
+			}
+			decoded, err := d.Decode(data)
+			if err != nil {
+				continue
+			}
+			chain = append(chain, d.Name())
+			data = decoded
+			matched = true
+			break
+		}
+		if !matched {
+			break
+		}
+	}
+	return chain
+}
+
+// Printable returns data as text, falling back to a hex dump for binary data
+func Printable(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+	return strings.TrimRight(hex.Dump(data), "\n")
+}
@@ -0,0 +1,96 @@
+package decode
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// Base64 decodes standard or URL-safe base64, with or without padding
+type Base64 struct{}
+
+// Name implements Decoder
+func (Base64) Name() string { return "Base64" }
+
+// Sniff implements Decoder. Short or unpadded strings are too ambiguous to auto-detect.
+func (Base64) Sniff(data []byte) bool {
+	if len(data) < 8 || len(data)%4 != 0 {
+		return false
+	}
+	for _, b := range data {
+		switch {
+		case b >= 'A' && b <= 'Z', b >= 'a' && b <= 'z', b >= '0' && b <= '9':
+		case b == '+', b == '/', b == '-', b == '_', b == '=':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Decode implements Decoder
+func (Base64) Decode(data []byte) ([]byte, error) {
+	text := string(bytes.TrimSpace(data))
+	for _, enc := range []*base64.Encoding{base64.StdEncoding, base64.URLEncoding, base64.RawStdEncoding, base64.RawURLEncoding} {
+		if decoded, err := enc.DecodeString(text); err == nil {
+			return decoded, nil
+		}
+	}
+	return nil, fmt.Errorf("not valid base64")
+}
+
+// Gzip decompresses gzip data
+type Gzip struct{}
+
+// Name implements Decoder
+func (Gzip) Name() string { return "Gzip" }
+
+// Sniff implements Decoder
+func (Gzip) Sniff(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// Decode implements Decoder
+func (Gzip) Decode(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r)
+}
+
+// Zlib decompresses zlib (deflate with a zlib header) data
+type Zlib struct{}
+
+// Name implements Decoder
+func (Zlib) Name() string { return "Zlib" }
+
+// Sniff implements Decoder, checking the deflate method and header checksum
+func (Zlib) Sniff(data []byte) bool {
+	return len(data) >= 2 && data[0]&0x0f == 8 && (uint16(data[0])<<8|uint16(data[1]))%31 == 0
+}
+
+// Decode implements Decoder
+func (Zlib) Decode(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return readLimited(r)
+}
+
+func readLimited(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxOutputSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxOutputSize {
+		return nil, fmt.Errorf("decompressed value is larger than %d MB", maxOutputSize>>20)
+	}
+	return data, nil
+}
@@ -0,0 +1,247 @@
+package decode
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// maxNesting bounds how deeply nested arrays and maps may be in decoded documents
+const maxNesting = 100
+
+var errTruncated = errors.New("unexpected end of data")
+
+// jsonFloat is f as encoding/json can write it: JSON has no NaN or infinities,
+// so those become the strings "NaN", "+Inf" and "-Inf"
+func jsonFloat[F float32 | float64](f F) interface{} {
+	switch v := float64(f); {
+	case math.IsNaN(v):
+		return "NaN"
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return f
+}
+
+// MessagePack converts MessagePack data to indented JSON. Binary values become
+// base64 strings and extension values become {"ext": type, "data": base64}.
+type MessagePack struct{}
+
+// Name implements Decoder
+func (MessagePack) Name() string { return "MessagePack" }
+
+// Sniff implements Decoder. Only documents starting with a map or array are
+// considered, since almost any byte is a valid MessagePack scalar.
+func (m MessagePack) Sniff(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+	b := data[0]
+	if !(b >= 0x80 && b <= 0x9f) && b != 0xdc && b != 0xdd && b != 0xde && b != 0xdf {
+		return false
+	}
+	_, err := m.Decode(data)
+	return err == nil
+}
+
+// Decode implements Decoder
+func (MessagePack) Decode(data []byte) ([]byte, error) {
+	r := &msgpackReader{data: data}
+	value, err := r.value(0)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("%d bytes left over after the MessagePack value", len(r.data)-r.pos)
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
+
+type msgpackReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || r.pos+n > len(r.data) {
+		return nil, errTruncated
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b, nil
+}
+
+func (r *msgpackReader) uint(n int) (uint64, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, x := range b {
+		v = v<<8 | uint64(x)
+	}
+	return v, nil
+}
+
+func (r *msgpackReader) value(depth int) (interface{}, error) {
+	if depth > maxNesting {
+		return nil, fmt.Errorf("MessagePack value is nested too deeply")
+	}
+	head, err := r.next(1)
+	if err != nil {
+		return nil, err
+	}
+	b := head[0]
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), nil
+	case b >= 0xe0:
+		return int64(int8(b)), nil
+	case b >= 0x80 && b <= 0x8f:
+		return r.mapValue(int(b&0x0f), depth)
+	case b >= 0x90 && b <= 0x9f:
+		return r.array(int(b&0x0f), depth)
+	case b >= 0xa0 && b <= 0xbf:
+		return r.str(int(b & 0x1f))
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := r.uint(1 << (b - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		data, err := r.next(int(n))
+		if err != nil {
+			return nil, err
+		}
+		return base64.StdEncoding.EncodeToString(data), nil
+	case 0xc7, 0xc8, 0xc9:
+		n, err := r.uint(1 << (b - 0xc7))
+		if err != nil {
+			return nil, err
+		}
+		return r.ext(int(n))
+	case 0xca:
+		v, err := r.uint(4)
+		return jsonFloat(float64(math.Float32frombits(uint32(v)))), err
+	case 0xcb:
+		v, err := r.uint(8)
+		return jsonFloat(math.Float64frombits(v)), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return r.uint(1 << (b - 0xcc))
+	case 0xd0:
+		v, err := r.uint(1)
+		return int64(int8(v)), err
+	case 0xd1:
+		v, err := r.uint(2)
+		return int64(int16(v)), err
+	case 0xd2:
+		v, err := r.uint(4)
+		return int64(int32(v)), err
+	case 0xd3:
+		v, err := r.uint(8)
+		return int64(v), err
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8:
+		return r.ext(1 << (b - 0xd4))
+	case 0xd9, 0xda, 0xdb:
+		n, err := r.uint(1 << (b - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return r.str(int(n))
+	case 0xdc, 0xdd:
+		n, err := r.uint(2 << (b - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return r.array(int(n), depth)
+	case 0xde, 0xdf:
+		n, err := r.uint(2 << (b - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return r.mapValue(int(n), depth)
+	}
+	return nil, fmt.Errorf("invalid MessagePack type byte 0x%02x", b)
+}
+
+func (r *msgpackReader) str(n int) (interface{}, error) {
+	b, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (r *msgpackReader) ext(n int) (interface{}, error) {
+	typ, err := r.uint(1)
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.next(n)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"ext":  int8(typ),
+		"data": base64.StdEncoding.EncodeToString(data),
+	}, nil
+}
+
+func (r *msgpackReader) array(n int, depth int) (interface{}, error) {
+	// Every element takes at least one byte, so larger counts are corrupt
+	if n > len(r.data)-r.pos {
+		return nil, errTruncated
+	}
+	items := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		item, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *msgpackReader) mapValue(n int, depth int) (interface{}, error) {
+	if n > (len(r.data)-r.pos)/2 {
+		return nil, errTruncated
+	}
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		key, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		m[mapKey(key)] = value
+	}
+	return m, nil
+}
+
+// mapKey turns a non-string map key into a JSON object key
+func mapKey(key interface{}) string {
+	if s, ok := key.(string); ok {
+		return s
+	}
+	if data, err := json.Marshal(key); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(key)
+}
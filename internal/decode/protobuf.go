@@ -0,0 +1,119 @@
+package decode
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Protobuf wire types
+const (
+	pbVarint  = 0
+	pbFixed64 = 1
+	pbBytes   = 2
+	pbFixed32 = 5
+)
+
+// Protobuf decodes protobuf wire format without a schema into indented JSON,
+// keyed by field number. Length-delimited fields are shown as a nested message
+// when they parse as one, otherwise as text or base64. Fixed-width fields may be
+// integers or floats, so both readings are shown. Repeated fields become arrays.
+type Protobuf struct{}
+
+// Name implements Decoder
+func (Protobuf) Name() string { return "Protobuf (raw)" }
+
+// Sniff implements Decoder. Random bytes parse as protobuf too often for
+// auto-detection, so this decoder is only applied when chosen explicitly.
+func (Protobuf) Sniff([]byte) bool { return false }
+
+// Decode implements Decoder
+func (Protobuf) Decode(data []byte) ([]byte, error) {
+	message, err := decodeProtoMessage(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(message, "", "  ")
+}
+
+func decodeProtoMessage(data []byte, depth int) (map[string]interface{}, error) {
+	if depth > maxNesting {
+		return nil, fmt.Errorf("protobuf message is nested too deeply")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("empty message")
+	}
+
+	message := make(map[string]interface{})
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, fmt.Errorf("invalid field tag")
+		}
+		data = data[n:]
+		field := tag >> 3
+		if field == 0 {
+			return nil, fmt.Errorf("invalid field number 0")
+		}
+
+		var value interface{}
+		switch tag & 7 {
+		case pbVarint:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, fmt.Errorf("field %d: invalid varint", field)
+			}
+			data = data[n:]
+			value = v
+		case pbFixed64:
+			if len(data) < 8 {
+				return nil, errTruncated
+			}
+			v := binary.LittleEndian.Uint64(data)
+			value = map[string]interface{}{"fixed64": v, "double": jsonFloat(math.Float64frombits(v))}
+			data = data[8:]
+		case pbFixed32:
+			if len(data) < 4 {
+				return nil, errTruncated
+			}
+			v := binary.LittleEndian.Uint32(data)
+			value = map[string]interface{}{"fixed32": v, "float": jsonFloat(math.Float32frombits(v))}
+			data = data[4:]
+		case pbBytes:
+			size, n := binary.Uvarint(data)
+			if n <= 0 || size > uint64(len(data)-n) {
+				return nil, fmt.Errorf("field %d: invalid length", field)
+			}
+			payload := data[n : n+int(size)]
+			data = data[n+int(size):]
+			value = protoBytesValue(payload, depth)
+		default:
+			return nil, fmt.Errorf("field %d: unsupported wire type %d", field, tag&7)
+		}
+
+		key := strconv.FormatUint(field, 10)
+		switch existing := message[key].(type) {
+		case nil:
+			message[key] = value
+		case []interface{}:
+			message[key] = append(existing, value)
+		default:
+			message[key] = []interface{}{existing, value}
+		}
+	}
+	return message, nil
+}
+
+// protoBytesValue guesses what a length-delimited field holds
+func protoBytesValue(payload []byte, depth int) interface{} {
+	if utf8.Valid(payload) && isPrintable(string(payload)) {
+		return string(payload)
+	}
+	if nested, err := decodeProtoMessage(payload, depth+1); err == nil {
+		return nested
+	}
+	return payload // encoding/json writes []byte as base64
+}
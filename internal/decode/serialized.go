@@ -0,0 +1,292 @@
+package decode
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// PHPSerialized converts PHP serialize() output to indented JSON. Objects become
+// {"__class": name, ...properties}; arrays with keys 0..n-1 become JSON arrays.
+type PHPSerialized struct{}
+
+// Name implements Decoder
+func (PHPSerialized) Name() string { return "PHP serialized" }
+
+// Sniff implements Decoder
+func (p PHPSerialized) Sniff(data []byte) bool {
+	if len(data) < 2 || data[1] != ':' && !(data[0] == 'N' && data[1] == ';') {
+		return false
+	}
+	if !strings.ContainsRune("abdiNOs", rune(data[0])) {
+		return false
+	}
+	_, err := p.Decode(data)
+	return err == nil
+}
+
+// Decode implements Decoder
+func (PHPSerialized) Decode(data []byte) ([]byte, error) {
+	r := &phpReader{data: data}
+	value, err := r.value(0)
+	if err != nil {
+		return nil, fmt.Errorf("at offset %d: %w", r.pos, err)
+	}
+	if r.pos != len(r.data) {
+		return nil, fmt.Errorf("%d bytes left over after the serialized value", len(r.data)-r.pos)
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
+
+type phpReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *phpReader) expect(b byte) error {
+	if r.pos >= len(r.data) || r.data[r.pos] != b {
+		return fmt.Errorf("expected %q", b)
+	}
+	r.pos++
+	return nil
+}
+
+// until returns the text up to the next occurrence of end and skips past it
+func (r *phpReader) until(end byte) (string, error) {
+	i := bytes.IndexByte(r.data[r.pos:], end)
+	if i < 0 {
+		return "", errTruncated
+	}
+	text := string(r.data[r.pos : r.pos+i])
+	r.pos += i + 1
+	return text, nil
+}
+
+func (r *phpReader) length(end byte) (int, error) {
+	text, err := r.until(end)
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid length %q", text)
+	}
+	return n, nil
+}
+
+// quoted reads a "..." string of n bytes
+func (r *phpReader) quoted(n int) (string, error) {
+	if err := r.expect('"'); err != nil {
+		return "", err
+	}
+	if r.pos+n > len(r.data) {
+		return "", errTruncated
+	}
+	s := string(r.data[r.pos : r.pos+n])
+	r.pos += n
+	return s, r.expect('"')
+}
+
+func (r *phpReader) value(depth int) (interface{}, error) {
+	if depth > maxNesting {
+		return nil, fmt.Errorf("serialized value is nested too deeply")
+	}
+	if r.pos+1 >= len(r.data) {
+		return nil, errTruncated
+	}
+	kind := r.data[r.pos]
+	r.pos++
+	if kind == 'N' {
+		return nil, r.expect(';')
+	}
+	if err := r.expect(':'); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case 'b':
+		text, err := r.until(';')
+		return text == "1", err
+	case 'i':
+		text, err := r.until(';')
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseInt(text, 10, 64)
+	case 'd':
+		text, err := r.until(';')
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(text, 64)
+	case 's':
+		n, err := r.length(':')
+		if err != nil {
+			return nil, err
+		}
+		s, err := r.quoted(n)
+		if err != nil {
+			return nil, err
+		}
+		return s, r.expect(';')
+	case 'a':
+		return r.array(depth)
+	case 'O':
+		n, err := r.length(':')
+		if err != nil {
+			return nil, err
+		}
+		class, err := r.quoted(n)
+		if err != nil {
+			return nil, err
+		}
+		if err := r.expect(':'); err != nil {
+			return nil, err
+		}
+		props, err := r.array(depth)
+		if err != nil {
+			return nil, err
+		}
+		object := map[string]interface{}{"__class": class}
+		switch props := props.(type) {
+		case map[string]interface{}:
+			for k, v := range props {
+				object[k] = v
+			}
+		case []interface{}:
+			for i, v := range props {
+				object[strconv.Itoa(i)] = v
+			}
+		}
+		return object, nil
+	}
+	return nil, fmt.Errorf("unknown type %q", kind)
+}
+
+// array reads "n:{key;value...}", returning a slice when the keys are 0..n-1
+func (r *phpReader) array(depth int) (interface{}, error) {
+	n, err := r.length(':')
+	if err != nil {
+		return nil, err
+	}
+	if err := r.expect('{'); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, n)
+	values := make([]interface{}, 0, n)
+	sequential := true
+	for i := 0; i < n; i++ {
+		key, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		value, err := r.value(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if k, ok := key.(int64); !ok || k != int64(i) {
+			sequential = false
+		}
+		keys = append(keys, mapKey(key))
+		values = append(values, value)
+	}
+	if err := r.expect('}'); err != nil {
+		return nil, err
+	}
+
+	if sequential {
+		return values, nil
+	}
+	m := make(map[string]interface{}, n)
+	for i, key := range keys {
+		m[key] = values[i]
+	}
+	return m, nil
+}
+
+// Java serialization stream constants
+const (
+	javaStreamMagic   = 0xaced
+	javaTCClassDesc   = 0x72
+	javaTCString      = 0x74
+	javaMinStringSize = 3
+)
+
+// JavaSerialized summarizes a Java serialization stream. Fully decoding one
+// needs the original classes, so this lists the class names and strings found.
+type JavaSerialized struct{}
+
+// Name implements Decoder
+func (JavaSerialized) Name() string { return "Java serialized" }
+
+// Sniff implements Decoder
+func (JavaSerialized) Sniff(data []byte) bool {
+	magic, ok := uint16At(data, 0)
+	return ok && magic == javaStreamMagic
+}
+
+// Decode implements Decoder
+func (j JavaSerialized) Decode(data []byte) ([]byte, error) {
+	if !j.Sniff(data) {
+		return nil, fmt.Errorf("not a Java serialization stream")
+	}
+
+	var classes, strs []string
+	seen := make(map[string]bool)
+	for pos := 4; pos < len(data); pos++ {
+		if data[pos] != javaTCClassDesc && data[pos] != javaTCString {
+			continue
+		}
+		n, ok := uint16At(data, pos+1)
+		if !ok || pos+3+int(n) > len(data) {
+			continue
+		}
+		text := string(data[pos+3 : pos+3+int(n)])
+		if int(n) < javaMinStringSize || !isPrintable(text) || seen[text] {
+			continue
+		}
+		seen[text] = true
+		if data[pos] == javaTCClassDesc {
+			classes = append(classes, text)
+		} else {
+			strs = append(strs, text)
+		}
+		pos += 2 + int(n)
+	}
+
+	var b strings.Builder
+	b.WriteString("Java serialized object\n")
+	b.WriteString("\nClasses:\n")
+	for _, class := range classes {
+		b.WriteString("  " + class + "\n")
+	}
+	if len(strs) > 0 {
+		b.WriteString("\nStrings:\n")
+		for _, s := range strs {
+			b.WriteString("  " + strconv.Quote(s) + "\n")
+		}
+	}
+	return []byte(b.String()), nil
+}
+
+// uint16At reads a big-endian uint16, reporting false if data is too short
+func uint16At(data []byte, pos int) (uint16, bool) {
+	if pos < 0 || pos+2 > len(data) {
+		return 0, false
+	}
+	return binary.BigEndian.Uint16(data[pos:]), true
+}
+
+func isPrintable(s string) bool {
+	for _, r := range s {
+		if r < 0x20 && r != '\n' && r != '\r' && r != '\t' || r == 0x7f || r == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
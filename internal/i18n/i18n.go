@@ -0,0 +1,89 @@
+// Package i18n translates the user interface. Texts are looked up by their
+// English wording, so code reads as before and a text without a translation
+// shows in English.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync/atomic"
+
+	"fyne.io/fyne/v2/lang"
+)
+
+// English is the language the user interface is written in
+const English = "en"
+
+// Language is a language the user interface can be shown in
+type Language struct {
+	Code string // ISO 639-1 code, also the name of its file in locales
+	Name string // Name in the language itself
+}
+
+// languages lists English and the languages with a file in locales
+var languages = []Language{
+	{Code: English, Name: "English"},
+	{Code: "de", Name: "Deutsch"},
+}
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// translations maps English texts to the current language's
+var translations atomic.Pointer[map[string]string]
+
+// Languages returns the languages the user interface can be shown in
+func Languages() []Language {
+	return languages
+}
+
+// SetLanguage shows texts in the language with the given code from now on.
+// An empty code follows the system's language, falling back to English.
+func SetLanguage(code string) error {
+	if code == "" {
+		code = systemLanguage()
+	}
+	if code == English {
+		translations.Store(nil)
+		return nil
+	}
+	data, err := locales.ReadFile(path.Join("locales", code+".json"))
+	if err != nil {
+		return fmt.Errorf("unsupported language %q", code)
+	}
+	table := make(map[string]string)
+	if err := json.Unmarshal(data, &table); err != nil {
+		return fmt.Errorf("failed to read %s translations: %w", code, err)
+	}
+	translations.Store(&table)
+	return nil
+}
+
+// systemLanguage is the code of the system's language if it is one of
+// Languages, or English
+func systemLanguage() string {
+	code, _, _ := strings.Cut(lang.SystemLocale().String(), "-")
+	for _, l := range languages {
+		if l.Code == code {
+			return code
+		}
+	}
+	return English
+}
+
+// T translates an English text into the current language. With args, the
+// text is a fmt format, translated before the args are filled in.
+func T(text string, args ...any) string {
+	if table := translations.Load(); table != nil {
+		if translated, ok := (*table)[text]; ok && translated != "" {
+			text = translated
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(text, args...)
+	}
+	return text
+}
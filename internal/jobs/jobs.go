@@ -0,0 +1,241 @@
+// Package jobs tracks long-running background operations such as key scans,
+// bulk deletes, exports and analyses. Each job runs in its own goroutine with
+// a cancellable context, reports its progress, and is kept in a Manager so the
+// UI can list running and recently finished jobs and cancel them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// keepFinished is how many finished jobs a Manager keeps listing
+const keepFinished = 20
+
+// State is where a job is in its life
+type State int
+
+// Job states
+const (
+	Running State = iota
+	Done
+	Failed
+	Cancelled
+)
+
+func (s State) String() string {
+	switch s {
+	case Running:
+		return "Running"
+	case Done:
+		return "Done"
+	case Failed:
+		return "Failed"
+	case Cancelled:
+		return "Cancelled"
+	}
+	return "Unknown"
+}
+
+// Status is a consistent copy of a job's state at one moment
+type Status struct {
+	ID      int
+	Title   string
+	Detail  string
+	Done    int64
+	Total   int64 // 0 while the amount of work is unknown
+	State   State
+	Err     error
+	Started time.Time
+	Ended   time.Time
+}
+
+// Fraction is the share of the job done, or -1 while the total is unknown
+func (s Status) Fraction() float64 {
+	if s.Total <= 0 {
+		return -1
+	}
+	return min(float64(s.Done)/float64(s.Total), 1)
+}
+
+// Job is a background operation started by a Manager. Its methods may be
+// called from any goroutine.
+type Job struct {
+	manager *Manager
+	cancel  context.CancelFunc
+
+	mu     sync.Mutex
+	status Status
+}
+
+// Update reports how much of the work is done; total 0 means unknown
+func (j *Job) Update(done, total int64) {
+	j.mu.Lock()
+	j.status.Done, j.status.Total = done, total
+	j.mu.Unlock()
+	j.manager.changed()
+}
+
+// SetDetail describes what the job is doing now, e.g. "1,204 keys scanned"
+func (j *Job) SetDetail(detail string) {
+	j.mu.Lock()
+	j.status.Detail = detail
+	j.mu.Unlock()
+	j.manager.changed()
+}
+
+// Cancel asks the job to stop by cancelling its context
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+// Status returns a copy of the job's state
+func (j *Job) Status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.status
+}
+
+// finish records how the job ended
+func (j *Job) finish(err error) {
+	j.mu.Lock()
+	j.status.Ended = time.Now()
+	j.status.Err = err
+	switch {
+	case err == nil:
+		j.status.State = Done
+	case errors.Is(err, context.Canceled):
+		j.status.State = Cancelled
+	default:
+		j.status.State = Failed
+	}
+	j.mu.Unlock()
+}
+
+// Manager starts jobs and keeps the running ones and the last few finished
+// ones. Its methods may be called from any goroutine.
+type Manager struct {
+	mu      sync.Mutex
+	jobs    []*Job // in start order
+	nextID  int
+	changes chan struct{}
+}
+
+// NewManager creates a Manager with no jobs
+func NewManager() *Manager {
+	return &Manager{changes: make(chan struct{}, 1)}
+}
+
+// Start runs work as a job in a new goroutine. Its context is derived from
+// parent and cancelled by the job's Cancel. finish (if set) is called from
+// that goroutine with work's error once the job's end is recorded.
+func (m *Manager) Start(parent context.Context, title string, work func(ctx context.Context, job *Job) error, finish func(err error)) *Job {
+	ctx, cancel := context.WithCancel(parent)
+
+	m.mu.Lock()
+	m.nextID++
+	job := &Job{
+		manager: m,
+		cancel:  cancel,
+		status:  Status{ID: m.nextID, Title: title, State: Running, Started: time.Now()},
+	}
+	m.jobs = append(m.jobs, job)
+	m.mu.Unlock()
+	m.changed()
+
+	go func() {
+		err := work(ctx, job)
+		cancel()
+		job.finish(err)
+		m.prune()
+		m.changed()
+		if finish != nil {
+			finish(err)
+		}
+	}()
+	return job
+}
+
+// Jobs returns the running jobs in start order, then the finished ones,
+// most recently ended first
+func (m *Manager) Jobs() []Status {
+	m.mu.Lock()
+	jobs := append([]*Job(nil), m.jobs...)
+	m.mu.Unlock()
+
+	var running, finished []Status
+	for _, job := range jobs {
+		if status := job.Status(); status.State == Running {
+			running = append(running, status)
+		} else {
+			finished = append(finished, status)
+		}
+	}
+	// Jobs finish in any order, so start order isn't end order
+	sort.SliceStable(finished, func(i, j int) bool {
+		return finished[i].Ended.After(finished[j].Ended)
+	})
+	return append(running, finished...)
+}
+
+// Cancel cancels the job with the given ID, if it is still running
+func (m *Manager) Cancel(id int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, job := range m.jobs {
+		if job.status.ID == id {
+			job.cancel()
+		}
+	}
+}
+
+// ClearFinished forgets every finished job
+func (m *Manager) ClearFinished() {
+	m.mu.Lock()
+	kept := m.jobs[:0]
+	for _, job := range m.jobs {
+		if job.Status().State == Running {
+			kept = append(kept, job)
+		}
+	}
+	m.jobs = kept
+	m.mu.Unlock()
+	m.changed()
+}
+
+// Changes receives a value after jobs start, report progress or finish.
+// Changes in quick succession are coalesced into one.
+func (m *Manager) Changes() <-chan struct{} {
+	return m.changes
+}
+
+func (m *Manager) changed() {
+	select {
+	case m.changes <- struct{}{}:
+	default:
+	}
+}
+
+// prune drops the oldest finished jobs beyond keepFinished
+func (m *Manager) prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	finished := 0
+	for _, job := range m.jobs {
+		if job.Status().State != Running {
+			finished++
+		}
+	}
+	kept := m.jobs[:0]
+	for _, job := range m.jobs {
+		if finished > keepFinished && job.Status().State != Running {
+			finished--
+			continue
+		}
+		kept = append(kept, job)
+	}
+	m.jobs = kept
+}
@@ -0,0 +1,156 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// startJob starts a job that runs until it is released or cancelled, ending
+// with the error sent to release. The returned channel is closed once the job
+// has finished.
+func startJob(m *Manager, title string) (job *Job, release chan<- error, finished <-chan struct{}) {
+	results := make(chan error, 1)
+	done := make(chan struct{})
+	job = m.Start(context.Background(), title, func(ctx context.Context, _ *Job) error {
+		select {
+		case err := <-results:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}, func(error) { close(done) })
+	return job, results, done
+}
+
+// runJob runs a job to completion with the given error
+func runJob(m *Manager, title string, err error) {
+	_, release, finished := startJob(m, title)
+	release <- err
+	<-finished
+}
+
+func titles(statuses []Status) []string {
+	out := make([]string, len(statuses))
+	for i, s := range statuses {
+		out[i] = s.Title
+	}
+	return out
+}
+
+func TestJobStates(t *testing.T) {
+	m := NewManager()
+	failure := errors.New("boom")
+
+	succeeded, release, finished := startJob(m, "succeeds")
+	if got := succeeded.Status().State; got != Running {
+		t.Fatalf("state before finishing = %v, want Running", got)
+	}
+	release <- nil
+	<-finished
+
+	failed, release, finished := startJob(m, "fails")
+	release <- failure
+	<-finished
+
+	cancelled, _, finished := startJob(m, "cancelled")
+	m.Cancel(cancelled.Status().ID)
+	<-finished
+
+	tests := []struct {
+		job   *Job
+		state State
+		err   error
+	}{
+		{succeeded, Done, nil},
+		{failed, Failed, failure},
+		{cancelled, Cancelled, context.Canceled},
+	}
+	for _, tt := range tests {
+		status := tt.job.Status()
+		if status.State != tt.state {
+			t.Errorf("%s: state = %v, want %v", status.Title, status.State, tt.state)
+		}
+		if !errors.Is(status.Err, tt.err) || (tt.err == nil && status.Err != nil) {
+			t.Errorf("%s: err = %v, want %v", status.Title, status.Err, tt.err)
+		}
+		if status.Ended.IsZero() {
+			t.Errorf("%s: no end time recorded", status.Title)
+		}
+	}
+}
+
+func TestJobsOrder(t *testing.T) {
+	m := NewManager()
+	_, releaseFirst, firstFinished := startJob(m, "first")
+	_, releaseSecond, secondFinished := startJob(m, "second")
+	_, releaseThird, thirdFinished := startJob(m, "third")
+	startJob(m, "running")
+	defer func() {
+		for _, s := range m.Jobs() {
+			m.Cancel(s.ID)
+		}
+	}()
+
+	// Finish out of start order
+	releaseSecond <- nil
+	<-secondFinished
+	releaseFirst <- nil
+	<-firstFinished
+	releaseThird <- errors.New("boom")
+	<-thirdFinished
+
+	want := []string{"running", "third", "first", "second"}
+	if got := titles(m.Jobs()); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Jobs() = %v, want %v", got, want)
+	}
+}
+
+func TestKeepFinished(t *testing.T) {
+	m := NewManager()
+	_, release, finished := startJob(m, "running")
+	for i := range keepFinished + 5 {
+		runJob(m, fmt.Sprintf("job %d", i), nil)
+	}
+
+	jobs := m.Jobs()
+	if len(jobs) != keepFinished+1 {
+		t.Fatalf("%d jobs kept, want %d finished and the running one", len(jobs), keepFinished)
+	}
+	if jobs[0].Title != "running" {
+		t.Errorf("first job = %q, want the running one", jobs[0].Title)
+	}
+	// The oldest finished jobs are the ones dropped
+	if got, want := jobs[len(jobs)-1].Title, "job 5"; got != want {
+		t.Errorf("oldest kept job = %q, want %q", got, want)
+	}
+
+	release <- nil
+	<-finished
+	if got := len(m.Jobs()); got != keepFinished {
+		t.Errorf("%d jobs kept after the last one finished, want %d", got, keepFinished)
+	}
+}
+
+func TestClearFinished(t *testing.T) {
+	m := NewManager()
+	runJob(m, "done", nil)
+	runJob(m, "failed", errors.New("boom"))
+	running, release, finished := startJob(m, "running")
+
+	m.ClearFinished()
+	if got := titles(m.Jobs()); len(got) != 1 || got[0] != "running" {
+		t.Fatalf("Jobs() after ClearFinished = %v, want only the running job", got)
+	}
+
+	release <- nil
+	<-finished
+	if got := running.Status().State; got != Done {
+		t.Errorf("running job state = %v, want Done", got)
+	}
+	m.ClearFinished()
+	if got := m.Jobs(); len(got) != 0 {
+		t.Errorf("Jobs() = %v, want none", titles(got))
+	}
+}
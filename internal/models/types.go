@@ -1,14 +1,196 @@
 package models
 
+import (
+	"strings"
+	"time"
+)
+
 // ServerConnection represents a Redis server connection configuration
 type ServerConnection struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	Host     string `json:"host"`
-	Port     int    `json:"port"`
-	Password string `json:"password,omitempty"`
-	Database int    `json:"database"`
-	UseTLS   bool   `json:"use_tls"`
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	Host     string    `json:"host"`
+	Port     int       `json:"port"`
+	Username string    `json:"username,omitempty"` // ACL user, Redis 6+; empty for default
+	Password string    `json:"password,omitempty"`
+	Database int       `json:"database"`
+	UseTLS   bool      `json:"use_tls"`
+	SSH      SSHTunnel `json:"ssh"`
+	Sentinel Sentinel  `json:"sentinel"`
+	Group    string    `json:"group,omitempty"` // sidebar group; empty for ungrouped
+
+	// Protocol is the RESP version, 2 or 3; 0 uses RESP3 where the server has
+	// HELLO and RESP2 otherwise
+	Protocol int `json:"protocol,omitempty"`
+
+	// Delimiter separates key namespaces in the tree view; empty detects it from the keys
+	Delimiter string `json:"delimiter,omitempty"`
+
+	// KeyScanCount and AutoRefreshSecs override the settings of the same name
+	// when set; KeyView, when set, shows the keys as a tree or a list on connecting
+	KeyScanCount    *int   `json:"key_scan_count,omitempty"`
+	AutoRefreshSecs *int   `json:"auto_refresh_secs,omitempty"`
+	KeyView         string `json:"key_view,omitempty"`
+
+	// KeySort is the key list column the keys are sorted by, "name" when empty
+	KeySort     string `json:"key_sort,omitempty"`
+	KeySortDesc bool   `json:"key_sort_desc,omitempty"`
+
+	// ReadOnly refuses write commands and disables editing; Production asks for
+	// ConfirmPhrase (the connection name when empty) before destructive actions
+	ReadOnly      bool   `json:"read_only,omitempty"`
+	Production    bool   `json:"production,omitempty"`
+	ConfirmPhrase string `json:"confirm_phrase,omitempty"`
+
+	// CredentialRef points to the passwords in the OS keychain or encrypted
+	// secrets file; the password fields are never written to config.json
+	CredentialRef string `json:"credential_ref,omitempty"`
+
+	Backup BackupSettings `json:"backup"`
+
+	// Watches are polled by the watch panel while connected
+	Watches []Watch `json:"watches,omitempty"`
+}
+
+// Watch is something the watch panel polls: a key, shown as its value if it
+// is a string and its length otherwise, or a command that only reads, shown
+// as its reply
+type Watch struct {
+	Expression string   `json:"expression"` // key name, or command line when Command is set
+	Command    bool     `json:"command,omitempty"`
+	Above      *float64 `json:"above,omitempty"` // alert while the value is above this
+	Below      *float64 `json:"below,omitempty"` // alert while the value is below this
+}
+
+// Alerting reports whether value crosses one of the watch's thresholds
+func (w Watch) Alerting(value float64) bool {
+	return (w.Above != nil && value > *w.Above) || (w.Below != nil && value < *w.Below)
+}
+
+// WatchReading is what one poll of a watch read
+type WatchReading struct {
+	Text    string
+	Value   float64
+	Numeric bool // Value holds the reading as a number
+}
+
+// Key views of a connection
+const (
+	KeyViewTree = "tree"
+	KeyViewList = "list"
+)
+
+// Backup methods
+const (
+	BackupBGSave = "bgsave" // RDB snapshot written by the server
+	BackupExport = "export" // DUMP payload export written by the app
+)
+
+// BackupSettings is how a connection is backed up. With EveryHours set the
+// backup repeats while the app is connected to it.
+type BackupSettings struct {
+	Method     string `json:"method,omitempty"` // BackupBGSave or BackupExport
+	Dir        string `json:"dir,omitempty"`    // where exports are written
+	EveryHours int    `json:"every_hours,omitempty"`
+}
+
+// GuardPhrase returns the phrase to type before destructive actions on the
+// connection, or "" when it isn't tagged as production
+func (c ServerConnection) GuardPhrase() string {
+	if !c.Production {
+		return ""
+	}
+	if c.ConfirmPhrase != "" {
+		return c.ConfirmPhrase
+	}
+	return c.Name
+}
+
+// ScanCount returns the connection's key scan count, or def when it uses the setting
+func (c ServerConnection) ScanCount(def int) int {
+	if c.KeyScanCount != nil {
+		return *c.KeyScanCount
+	}
+	return def
+}
+
+// RefreshSecs returns the connection's auto refresh interval, 0 for none, or
+// def when it uses the setting
+func (c ServerConnection) RefreshSecs(def int) int {
+	if c.AutoRefreshSecs != nil {
+		return *c.AutoRefreshSecs
+	}
+	return def
+}
+
+// TemporaryConnectionID is the ID of a connection given on the command line or
+// in REDIS_URL, used for the session without being saved
+const TemporaryConnectionID = "temporary"
+
+// Temporary reports whether the connection is used without being saved
+func (c ServerConnection) Temporary() bool {
+	return c.ID == TemporaryConnectionID
+}
+
+// Favorite is a starred key, or with Prefix a starred tree folder, in one
+// database of a saved connection
+type Favorite struct {
+	ConnectionID string `json:"connection_id"`
+	Database     int    `json:"database"`
+	Key          string `json:"key"`
+	Prefix       bool   `json:"prefix,omitempty"`
+}
+
+// SavedScript is a Lua script kept in the script library, with the KEYS and
+// ARGV it was last run with as console-style argument lines
+type SavedScript struct {
+	Name string `json:"name"`
+	Body string `json:"body"`
+	Keys string `json:"keys,omitempty"`
+	Args string `json:"args,omitempty"`
+}
+
+// KeyTemplate is the shape of a key created again and again, such as a session
+// or cache entry. Pattern, the values and the TTL may hold {placeholders},
+// asked for when a key is created from the template; {uuid} and {timestamp}
+// are filled in without asking.
+type KeyTemplate struct {
+	Name    string     `json:"name"`
+	Pattern string     `json:"pattern"` // key name, e.g. "session:{user}"
+	Type    string     `json:"type"`
+	Value   string     `json:"value,omitempty"`  // string
+	Items   []string   `json:"items,omitempty"`  // list (in order) and set
+	Fields  []KeyValue `json:"fields,omitempty"` // hash fields, or zset members with their scores as Value
+	TTL     string     `json:"ttl,omitempty"`    // as typed in the TTL box, e.g. "30m"; empty for no expiry
+}
+
+// ConnectionGroup is a named, collapsible group of connections in the sidebar.
+// Connections belong to a group through their Group field and keep their order
+// in the connection list.
+type ConnectionGroup struct {
+	Name      string `json:"name"`
+	Color     string `json:"color,omitempty"` // color tag name, e.g. "red"
+	Collapsed bool   `json:"collapsed,omitempty"`
+}
+
+// Sentinel holds the settings for a Sentinel-managed master; when enabled,
+// Host and Port are ignored and the master is discovered through the sentinels
+type Sentinel struct {
+	Enabled    bool     `json:"enabled"`
+	MasterName string   `json:"master_name,omitempty"`
+	Addrs      []string `json:"addrs,omitempty"` // host:port of each sentinel
+	Password   string   `json:"password,omitempty"`
+}
+
+// SSHTunnel holds the settings for reaching Redis through an SSH bastion host
+type SSHTunnel struct {
+	Enabled       bool   `json:"enabled"`
+	Host          string `json:"host,omitempty"`
+	Port          int    `json:"port,omitempty"`
+	User          string `json:"user,omitempty"`
+	Password      string `json:"password,omitempty"`
+	KeyFile       string `json:"key_file,omitempty"`
+	KeyPassphrase string `json:"key_passphrase,omitempty"`
 }
 
 // RedisKey represents a key in Redis with its metadata
@@ -18,16 +200,292 @@ type RedisKey struct {
 	TTL  int64 // -1 for no expiry, -2 for key doesn't exist
 }
 
+// Expiry is a change to a key's TTL: either a relative TTL in seconds (EXPIRE)
+// or an absolute time (EXPIREAT). The zero value removes the expiry.
+type Expiry struct {
+	Seconds int64
+	At      time.Time
+}
+
+// Persist reports whether the expiry removes the TTL
+func (e Expiry) Persist() bool {
+	return e.At.IsZero() && e.Seconds <= 0
+}
+
 // KeyValue represents a generic key-value pair
 type KeyValue struct {
-	Key   string
-	Value string
+	Key   string `json:"key"`
+	Value string `json:"value"`
 }
 
 // ScoredValue represents a value with score for sorted sets
 type ScoredValue struct {
-	Score  float64
-	Member string
+	Score  float64 `json:"score"`
+	Member string  `json:"member"`
+}
+
+// SortedSetRange picks the members of a sorted set to show: the ranks Min to
+// Max, negative ones counting from the end, or with ByScore the scores Min to
+// Max, where "(" makes a bound exclusive and -inf/+inf leave it open. Reverse
+// orders members, and counts ranks, from the highest score down.
+type SortedSetRange struct {
+	ByScore bool
+	Min     string
+	Max     string
+	Reverse bool
+}
+
+// GeoMember is a member of a geo set with its position. Distance is set for
+// search results, in the unit of the search.
+type GeoMember struct {
+	Member    string
+	Longitude float64
+	Latitude  float64
+	Distance  float64
+}
+
+// GeoSearchRequest describes a GEOSEARCH BYRADIUS query, centered on a member
+// when FromMember is set and on Longitude/Latitude otherwise
+type GeoSearchRequest struct {
+	FromMember string
+	Longitude  float64
+	Latitude   float64
+	Radius     float64
+	Unit       string // m, km, mi or ft
+	Count      int    // 0 for no limit
+}
+
+// StreamEntry represents a single entry in a Redis stream
+type StreamEntry struct {
+	ID     string     `json:"id"`
+	Fields []KeyValue `json:"fields"` // sorted by field name
+}
+
+// StreamGroup represents a consumer group attached to a stream
+type StreamGroup struct {
+	Name            string
+	Consumers       int64
+	Pending         int64
+	LastDeliveredID string
+	Lag             int64 // -1 when unknown
+}
+
+// StreamConsumer is a consumer of a stream group from XINFO CONSUMERS.
+// Inactive is negative for a consumer that never read anything, and zero on
+// servers before 7.2, which don't report it.
+type StreamConsumer struct {
+	Name     string
+	Pending  int64
+	Idle     time.Duration // since its last read or claim
+	Inactive time.Duration // since its last successful read
+}
+
+// StreamPending is an entry delivered to a consumer but not yet acknowledged,
+// from XPENDING
+type StreamPending struct {
+	ID         string
+	Consumer   string
+	Idle       time.Duration // since it was last delivered
+	Deliveries int64
+}
+
+// ACLUser is a user from ACL LIST
+type ACLUser struct {
+	Name    string
+	Rules   string // the user's rules as ACL LIST prints them
+	Enabled bool
+}
+
+// Set combination operations for sets and sorted sets
+const (
+	CombineUnion = "union"
+	CombineInter = "inter"
+	CombineDiff  = "diff" // sets only: the first key's members in none of the others
+)
+
+// CombineRequest describes a set/zset combination stored into a destination key
+type CombineRequest struct {
+	Op          string    // CombineUnion, CombineInter or CombineDiff
+	Keys        []string  // source keys, the edited key first
+	Weights     []float64 // zset only; one per key, or empty for all 1
+	Aggregate   string    // zset only; SUM, MIN or MAX
+	Destination string
+}
+
+// ListEnds is the length of a list and up to a few elements at either end:
+// Head from index 0 onwards, Tail ending with the last element
+type ListEnds struct {
+	Length int64
+	Head   []string
+	Tail   []string
+}
+
+// ValueMatch is a key whose value contains a searched-for term: where in the
+// value it was first found, e.g. "field 'name'", and the text around it
+type ValueMatch struct {
+	Key     RedisKey
+	Where   string
+	Snippet string
+}
+
+// SaveStatus is the RDB persistence state from INFO persistence. The key counts
+// are only reported by Redis 7 and later, during a save.
+type SaveStatus struct {
+	InProgress    bool
+	LastSave      time.Time
+	LastOK        bool // whether the last BGSAVE succeeded
+	KeysProcessed int64
+	KeysTotal     int64
+}
+
+// PersistenceStatus is the RDB and AOF state from INFO persistence. The AOF
+// fields other than AOFEnabled are only meaningful while AOF is on.
+type PersistenceStatus struct {
+	SaveStatus
+	ChangesSinceSave    int64
+	AOFEnabled          bool
+	AOFRewriting        bool
+	AOFRewriteScheduled bool
+	AOFLastRewriteOK    bool
+	AOFLastWriteOK      bool
+	AOFSize             int64
+}
+
+// KeyDetails is the object metadata of a key. Counters the server doesn't
+// report are -1: OBJECT FREQ needs an LFU maxmemory-policy, OBJECT IDLETIME
+// works only without one, and DEBUG OBJECT is often disabled.
+type KeyDetails struct {
+	Encoding         string // e.g. listpack, hashtable, embstr
+	RefCount         int64
+	IdleSeconds      int64
+	Frequency        int64 // logarithmic LFU access counter
+	SerializedLength int64 // bytes, from DEBUG OBJECT
+}
+
+// KeySnapshot is a key as DUMP serialized it, kept so that deleting or
+// overwriting it can be undone
+type KeySnapshot struct {
+	Key      string
+	Payload  string
+	ExpireAt time.Time // zero for no expiry
+}
+
+// KeyDump is a key with its complete value, as written by export. Only the
+// field matching Type is set.
+type KeyDump struct {
+	Key     string            `json:"key"`
+	Type    string            `json:"type"`
+	TTL     int64             `json:"ttl"`               // seconds, -1 for no expiry
+	Value   string            `json:"value,omitempty"`   // string
+	Items   []string          `json:"items,omitempty"`   // list (in order) and set
+	Fields  map[string]string `json:"fields,omitempty"`  // hash
+	Members []ScoredValue     `json:"members,omitempty"` // zset
+	Entries []StreamEntry     `json:"entries,omitempty"` // stream, oldest first
+}
+
+// Export formats
+const (
+	ExportJSON     = "json"
+	ExportCSV      = "csv"
+	ExportCommands = "txt"  // redis-cli commands, one per line
+	ExportPayloads = "dump" // DUMP payloads for RESTORE
+)
+
+// KeyPayload is a key as DUMP serialized it, as written by a payload export.
+// The payload keeps the exact encoding of any type, including module types,
+// but only servers of the same or a newer RDB version can RESTORE it.
+type KeyPayload struct {
+	Key     string `json:"key"`
+	PTTL    int64  `json:"pttl"`    // milliseconds, -1 for no expiry
+	Payload []byte `json:"payload"` // base64 in the file
+}
+
+// ExportRequest describes which keys to export and how
+type ExportRequest struct {
+	Keys    []string // explicit keys; when empty, every key matching Pattern
+	Pattern string
+	Format  string // ExportJSON, ExportCSV, ExportCommands or ExportPayloads
+}
+
+// Import conflict policies, for keys that already exist
+const (
+	ImportSkip      = "skip"
+	ImportOverwrite = "overwrite"
+	ImportPrompt    = "prompt"
+)
+
+// MigrationRequest describes which keys to copy to another database and how
+type MigrationRequest struct {
+	Pattern    string
+	Target     ServerConnection // the destination, with the database to copy into
+	Policy     string           // ImportSkip or ImportOverwrite, for keys that already exist
+	KeepTTL    bool             // copies expire with the originals; otherwise they don't expire
+	UseMigrate bool             // the source server sends the keys with MIGRATE, not through the app
+	BatchSize  int              // keys copied per round trip
+	Pause      time.Duration    // wait between batches, to go easy on busy servers
+}
+
+// MigrationFailure is a key that couldn't be copied, and why
+type MigrationFailure struct {
+	Key   string
+	Error string
+}
+
+// MigrationReport is what a migration copied
+type MigrationReport struct {
+	Copied   int
+	Skipped  int // already in the destination, or gone from the source
+	Failures []MigrationFailure
+}
+
+// GenerateRequest describes test keys to write: Count keys named by Pattern
+// with {n} replaced by Start, Start+1 and so on
+type GenerateRequest struct {
+	Pattern   string
+	Count     int
+	Start     int
+	Type      string // string, list, set, hash or zset
+	Elements  int    // items, members or fields of each collection
+	ValueSize int    // bytes of each value, member or field value
+	Random    bool   // random letters and digits; otherwise values follow the key numbers
+	TTL       int64  // seconds until the keys expire, 0 for no expiry
+}
+
+// CompareReport is how the keys matching Pattern differ between two databases
+type CompareReport struct {
+	Pattern   string
+	OnlyA     []string
+	OnlyB     []string
+	Differing []KeyDifference // in both, with a different type, value or TTL
+	Same      int             // in both and alike
+}
+
+// KeyDifference is a key found in both compared databases that differs
+type KeyDifference struct {
+	Key          string
+	TypeA, TypeB string
+	TTLA, TTLB   int64 // seconds, -1 for no expiry
+	Value        bool  // the type or value differs
+	TTL          bool  // the TTL differs, or only one copy expires
+}
+
+// ServerHello is what HELLO reports about the server and the connection
+type ServerHello struct {
+	Server   string
+	Version  string
+	Protocol int // the RESP version the connection speaks
+	ID       int64
+	Mode     string
+	Role     string
+	Modules  []string // name and version of each loaded module
+}
+
+// ClusterNode is a master of a Redis Cluster and the hash slots it serves
+type ClusterNode struct {
+	ID       string
+	Addr     string   // host:port as the cluster announces it
+	Slots    [][2]int // inclusive ranges of slots
+	Replicas []string // IDs of its replicas
 }
 
 // ServerInfo holds Redis server information
@@ -44,6 +502,162 @@ type ServerInfo struct {
 	ExpiredKeys      int64
 	KeyspaceHits     int64
 	KeyspaceMisses   int64
+	OpsPerSec        int64
+	Keyspace         map[int]int64 // keys in each non-empty database
+	Persistence      PersistenceStatus
+}
+
+// InfoSection is one "# Section" of the INFO reply with its fields in reply order
+type InfoSection struct {
+	Name   string
+	Fields []KeyValue
+}
+
+// LatencyEvent is an event class of the server's latency monitor, e.g.
+// "command" or "fork", with its latest and worst recorded spike
+type LatencyEvent struct {
+	Name   string
+	Time   time.Time // of the latest spike
+	Latest time.Duration
+	Max    time.Duration
+}
+
+// LatencySample is one recorded spike of a latency event
+type LatencySample struct {
+	Time    time.Time
+	Latency time.Duration
+}
+
+// CommandStat is a command's counters from INFO commandstats since the server
+// started or CONFIG RESETSTAT. Subcommands are named like "config|get".
+type CommandStat struct {
+	Name     string
+	Calls    int64
+	Usec     int64 // total time spent running it
+	Rejected int64 // refused before running, e.g. by ACLs; Redis 6.2 and later
+	Failed   int64 // ran and replied with an error; Redis 6.2 and later
+}
+
+// ImpactPreview summarizes the keys a destructive operation would remove
+type ImpactPreview struct {
+	Pattern      string
+	TotalKeys    int64
+	Scanned      int64
+	ByType       map[string]int64
+	SampleKeys   []string
+	ApproxMemory int64
+	Estimated    bool // true when counts are extrapolated from a partial scan
+}
+
+// ScanCost estimates the commands sent by an operation that reads every key of
+// a database
+type ScanCost struct {
+	Keys     int64 // keys in the database
+	Scans    int64 // SCAN calls to walk them
+	Commands int64 // SCAN calls plus the per-key commands if every key matches
+}
+
+// MemoryReport sums MEMORY USAGE of the keys matching Pattern by key prefix
+type MemoryReport struct {
+	Pattern    string
+	Scanned    int64
+	TotalBytes int64
+	Prefixes   []PrefixMemory // largest first
+}
+
+// PrefixMemory is the key count and memory of one prefix in a MemoryReport
+type PrefixMemory struct {
+	Prefix string
+	Keys   int64
+	Bytes  int64
+}
+
+// TTL buckets of a KeyspaceReport, in display order
+const (
+	TTLNone   = "No TTL"
+	TTLHour   = "< 1 hour"
+	TTLDay    = "< 1 day"
+	TTLLonger = "Longer"
+)
+
+// TTLBuckets lists the TTL buckets in display order
+var TTLBuckets = []string{TTLNone, TTLHour, TTLDay, TTLLonger}
+
+// KeyspaceReport holds database-wide statistics for the keys matching Pattern
+type KeyspaceReport struct {
+	Pattern         string
+	Scanned         int64
+	TotalBytes      int64
+	ByType          map[string]int64
+	ByTTL           map[string]int64 // keyed by the TTL bucket constants
+	ByPrefix        []PrefixMemory   // top-level prefixes, most keys first
+	LargestByLength []KeySize
+	LargestByMemory []KeySize
+}
+
+// KeySize is a key's element count (bytes for strings) and MEMORY USAGE
+type KeySize struct {
+	Key    string
+	Type   string
+	Length int64
+	Memory int64
+}
+
+// ReplyKind identifies the shape of a raw command reply
+type ReplyKind int
+
+const (
+	ReplyNil ReplyKind = iota
+	ReplyString
+	ReplyInteger
+	ReplyFloat
+	ReplyBool
+	ReplyArray
+	ReplyMap // Items alternate key, value
+	ReplyError
+	ReplyBigNumber // RESP3 big number, in Text
+	ReplyVerbatim  // text shown as it is, e.g. the RESP3 verbatim string of INFO
+	ReplyPush      // RESP3 push message sent outside a reply, e.g. a tracking invalidation
+)
+
+// CommandReply is the typed reply to a raw command run from the console
+type CommandReply struct {
+	Kind    ReplyKind
+	Text    string // string, float, bool and error replies
+	Integer int64
+	Items   []CommandReply
+}
+
+// MonitorEntry is a single command reported by MONITOR
+type MonitorEntry struct {
+	Time    time.Time
+	DB      int
+	Client  string // client address, or "lua" for commands run by scripts
+	Command string
+	Args    []string
+}
+
+// WindowLayout is how the main window was arranged, restored at the next start
+type WindowLayout struct {
+	SidebarOffset float64 `json:"sidebar_offset"` // position of the sidebar divider, 0 to 1
+	BrowserOffset float64 `json:"browser_offset"` // position of the key browser divider, 0 to 1
+	ActiveTab     string  `json:"active_tab,omitempty"`
+	TreeView      bool    `json:"tree_view,omitempty"`
+}
+
+// Appearance is how text and values are shown
+type Appearance struct {
+	TextSize        float32 `json:"text_size"`
+	MonospaceValues bool    `json:"monospace_values"`
+	WrapValues      bool    `json:"wrap_values"`
+}
+
+// Notifications choose the conditions that raise desktop notifications
+type Notifications struct {
+	ConnectionLost bool   `json:"connection_lost,omitempty"`
+	MemoryAboveMB  int    `json:"memory_above_mb,omitempty"` // 0 turns the memory alert off
+	KeyExpired     bool   `json:"key_expired,omitempty"`
+	ExpiredPattern string `json:"expired_pattern,omitempty"` // keys whose expiry notifies, every key if empty
 }
 
 // ThemeName represents available theme options
@@ -55,6 +669,8 @@ const (
 	ThemeNord      ThemeName = "nord"
 	ThemeDracula   ThemeName = "dracula"
 	ThemeSolarized ThemeName = "solarized"
+	// ThemeAuto follows the system's light or dark preference
+	ThemeAuto ThemeName = "auto"
 )
 
 // AllThemes returns all available theme names
@@ -75,7 +691,39 @@ func (t ThemeName) DisplayName() string {
 		return "Dracula"
 	case ThemeSolarized:
 		return "Solarized"
-	default:
-		return string(t)
+	case ThemeAuto:
+		return "Auto (System)"
 	}
+	if name, ok := t.CustomName(); ok {
+		return name
+	}
+	return string(t)
+}
+
+// AutoTheme is the pair of themes the Auto (System) theme switches between
+type AutoTheme struct {
+	Light ThemeName `json:"light"`
+	Dark  ThemeName `json:"dark"`
+}
+
+// customThemePrefix marks the theme names of user-defined themes
+const customThemePrefix = "custom:"
+
+// CustomThemeName is the theme name selecting the custom theme called name
+func CustomThemeName(name string) ThemeName {
+	return ThemeName(customThemePrefix + name)
+}
+
+// CustomName returns the name of the custom theme t selects, if it selects one
+func (t ThemeName) CustomName() (string, bool) {
+	return strings.CutPrefix(string(t), customThemePrefix)
+}
+
+// CustomTheme is a user-defined theme: a built-in theme with some of its
+// colors replaced. Colors maps color names such as "background" or "primary"
+// to hex values like "#1e1e1e" or "#1e1e1e80".
+type CustomTheme struct {
+	Name   string            `json:"name"`
+	Base   ThemeName         `json:"base"`
+	Colors map[string]string `json:"colors,omitempty"`
 }
@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+// ACLUsers lists the server's ACL users with their rules, sorted by name.
+// Redis before 6.0 has no ACLs.
+func (c *Client) ACLUsers() ([]models.ACLUser, error) {
+	if !c.Supports(FeatureACL) {
+		return nil, c.unsupported(FeatureACL)
+	}
+	lines, err := c.rdb.ACLList(c.ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]models.ACLUser, 0, len(lines))
+	for _, line := range lines {
+		// Each line reads "user <name> <rules...>"
+		fields := strings.SplitN(line, " ", 3)
+		if len(fields) < 2 || fields[0] != "user" {
+			continue
+		}
+		user := models.ACLUser{Name: fields[1]}
+		if len(fields) == 3 {
+			user.Rules = fields[2]
+		}
+		for _, rule := range strings.Fields(user.Rules) {
+			switch rule {
+			case "on":
+				user.Enabled = true
+			case "off":
+				user.Enabled = false
+			}
+		}
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].Name < users[j].Name })
+	return users, nil
+}
+
+// ACLGetUser returns the ACL GETUSER fields of a user in the order the server
+// sends them
+func (c *Client) ACLGetUser(name string) ([]models.KeyValue, error) {
+	reply, err := c.rdb.Do(c.ctx, "ACL", "GETUSER", name).Result()
+	if err == redis.Nil {
+		return nil, fmt.Errorf("no ACL user '%s'", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []models.KeyValue
+	switch r := reply.(type) {
+	case map[interface{}]interface{}: // RESP3
+		for k, v := range r {
+			fields = append(fields, models.KeyValue{Key: fmt.Sprint(k), Value: aclValue(v)})
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+	case []interface{}: // RESP2: flat name/value pairs
+		for i := 0; i+1 < len(r); i += 2 {
+			fields = append(fields, models.KeyValue{Key: fmt.Sprint(r[i]), Value: aclValue(r[i+1])})
+		}
+	}
+	return fields, nil
+}
+
+// aclValue renders an ACL GETUSER value: a string, a list of strings, or for
+// selectors a list of nested field lists
+func aclValue(v interface{}) string {
+	switch v := v.(type) {
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = aclValue(item)
+			if _, nested := item.([]interface{}); nested {
+				parts[i] = "(" + parts[i] + ")"
+			}
+		}
+		return strings.Join(parts, " ")
+	case map[interface{}]interface{}:
+		parts := make([]string, 0, len(v))
+		for k, item := range v {
+			parts = append(parts, fmt.Sprintf("%v %s", k, aclValue(item)))
+		}
+		sort.Strings(parts)
+		return "(" + strings.Join(parts, " ") + ")"
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// ACLSetUser creates a user or applies rules to an existing one with ACL SETUSER.
+// Rules add to what the user already has unless they start with "reset".
+func (c *Client) ACLSetUser(name string, rules []string) error {
+	return c.rdb.ACLSetUser(c.ctx, name, rules...).Err()
+}
+
+// ACLDeleteUser removes a user, disconnecting its clients
+func (c *Client) ACLDeleteUser(name string) error {
+	return c.rdb.ACLDelUser(c.ctx, name).Err()
+}
+
+// ACLWhoAmI returns the user the connection is authenticated as
+func (c *Client) ACLWhoAmI() (string, error) {
+	return c.rdb.ACLWhoAmI(c.ctx).Result()
+}
@@ -0,0 +1,118 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Feature is a command or data type the app uses that older servers lack
+type Feature struct {
+	Name  string // as shown to the user, e.g. "UNLINK"
+	Since string // the Redis version that added it
+}
+
+// The features whose absence the app works around or explains
+var (
+	FeatureUnlink      = Feature{Name: "UNLINK", Since: "4.0"}
+	FeatureObjectFreq  = Feature{Name: "OBJECT FREQ", Since: "4.0"}
+	FeatureMemoryUsage = Feature{Name: "MEMORY USAGE", Since: "4.0"}
+	FeatureStreams     = Feature{Name: "Streams", Since: "5.0"}
+	FeatureACL         = Feature{Name: "ACL", Since: "6.0"}
+	FeatureListFind    = Feature{Name: "LPOS", Since: "6.0.6"}
+	FeatureRandomField = Feature{Name: "HRANDFIELD and ZRANDMEMBER", Since: "6.2"}
+)
+
+// capabilities are what the server was found to support on connecting
+type capabilities struct {
+	version string   // redis_version, empty when INFO didn't report it
+	modules []string // from MODULE LIST, nil when it couldn't be run
+	cluster bool     // redis_mode is cluster
+}
+
+// detectCapabilities reads the server version and modules. A server that hides
+// either, e.g. with renamed commands or ACLs, is assumed to have the features
+// rather than to lack them.
+func (c *Client) detectCapabilities() *capabilities {
+	caps := &capabilities{}
+	if info, err := c.rdb.Info(c.ctx, "server").Result(); err == nil {
+		for _, line := range strings.Split(info, "\n") {
+			line = strings.TrimSpace(line)
+			if version, ok := strings.CutPrefix(line, "redis_version:"); ok {
+				caps.version = version
+			}
+			if line == "redis_mode:cluster" {
+				caps.cluster = true
+			}
+		}
+	}
+	if modules, err := c.Modules(); err == nil {
+		caps.modules = modules
+	}
+	return caps
+}
+
+// ServerVersion is the Redis version the server reported on connecting, empty
+// if it didn't
+func (c *Client) ServerVersion() string {
+	if c.caps == nil {
+		return ""
+	}
+	return c.caps.version
+}
+
+// Supports reports whether the server has f. It is assumed to when the
+// server's version is unknown.
+func (c *Client) Supports(f Feature) bool {
+	if c.caps == nil || c.caps.version == "" {
+		return true
+	}
+	return !versionBefore(c.caps.version, f.Since)
+}
+
+// HasModule reports whether a module of one of the names is loaded, or ok
+// false when MODULE LIST couldn't be read on connecting
+func (c *Client) HasModule(names ...string) (loaded, ok bool) {
+	if c.caps == nil || c.caps.modules == nil {
+		return false, false
+	}
+	for _, module := range c.caps.modules {
+		for _, name := range names {
+			if strings.EqualFold(module, name) {
+				return true, true
+			}
+		}
+	}
+	return false, true
+}
+
+// unsupported is the error for using f on a server that lacks it
+func (c *Client) unsupported(f Feature) error {
+	return fmt.Errorf("%s needs Redis %s or later, and this server runs %s", f.Name, f.Since, c.caps.version)
+}
+
+// versionBefore reports whether version a is older than b. Missing parts
+// count as 0, and suffixes like "-rc1" are ignored.
+func versionBefore(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < max(len(as), len(bs)); i++ {
+		x, y := versionPart(as, i), versionPart(bs, i)
+		if x != y {
+			return x < y
+		}
+	}
+	return false
+}
+
+// versionPart is the number at part i of a split version, 0 if there is none
+func versionPart(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	digits := parts[i]
+	if end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		digits = digits[:end]
+	}
+	n, _ := strconv.Atoi(digits)
+	return n
+}
@@ -3,65 +3,267 @@ package redis
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math/big"
+	"net"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/crypto/ssh"
 	"redis-explorer/internal/models"
 )
 
+// ErrKeyNotFound is returned when an operation needs a key that doesn't exist
+var ErrKeyNotFound = errors.New("key does not exist")
+
+// ErrSnapshotTooLarge is returned when keys are too big to keep a snapshot of
+var ErrSnapshotTooLarge = errors.New("the keys are too large to snapshot")
+
+// ErrJSONModuleMissing is returned for RedisJSON keys on a server without the module
+var ErrJSONModuleMissing = errors.New("the RedisJSON module is not loaded on this server")
+
+// JSONType is the TYPE reply for RedisJSON documents
+const JSONType = "ReJSON-RL"
+
 // Client wraps the Redis client with additional functionality
 type Client struct {
 	rdb        *redis.Client
 	connection *models.ServerConnection
 	ctx        context.Context
+	throttle   *scanThrottle
+	timeouts   Timeouts
+	tunnel     *ssh.Client
+	pushes     *pushRelay // nil over RESP2
+	caps       *capabilities
+	cluster    *clusterMap // nil outside a cluster
+}
+
+// Timeouts bound how long connecting and each socket read or write may take.
+// Commands also stop as soon as the context of the client they run on is done.
+type Timeouts struct {
+	Dial  time.Duration
+	Read  time.Duration
+	Write time.Duration
+}
+
+// DefaultTimeouts returns the timeouts used unless SetTimeouts is called
+func DefaultTimeouts() Timeouts {
+	return Timeouts{Dial: 5 * time.Second, Read: 3 * time.Second, Write: 3 * time.Second}
+}
+
+// ScanThrottle limits the load that key scans put on the server
+type ScanThrottle struct {
+	Count      int64         // COUNT hint sent with each SCAN page
+	PageDelay  time.Duration // pause between SCAN pages
+	MaxLookups int           // concurrent TYPE/TTL/MEMORY lookups across the client (0 = unlimited)
+	BatchSize  int           // keys per pipelined TYPE/TTL round trip (0 = a whole SCAN page)
+}
+
+// DefaultScanCount is the SCAN COUNT hint used when no throttle is configured
+const DefaultScanCount = 100
+
+// GentleScanThrottle returns the throttle used by the "gentle scan" setting
+// for browsing busy production servers
+func GentleScanThrottle(count int64) ScanThrottle {
+	if count <= 0 || count > 20 {
+		count = 20
+	}
+	return ScanThrottle{Count: count, PageDelay: 100 * time.Millisecond, MaxLookups: 1}
+}
+
+// scanThrottle is the active throttle plus the semaphore enforcing MaxLookups.
+// It is shared by all copies made through WithContext.
+type scanThrottle struct {
+	ScanThrottle
+	lookups chan struct{}
 }
 
 // New creates a new Redis client from a server connection
 func New(conn *models.ServerConnection) *Client {
-	return &Client{
+	c := &Client{
 		connection: conn,
 		ctx:        context.Background(),
+		timeouts:   DefaultTimeouts(),
+	}
+	c.SetScanThrottle(ScanThrottle{Count: DefaultScanCount})
+	return c
+}
+
+// SetScanThrottle sets how aggressively key scans may hit the server
+func (c *Client) SetScanThrottle(t ScanThrottle) {
+	if t.Count <= 0 {
+		t.Count = DefaultScanCount
+	}
+	throttle := &scanThrottle{ScanThrottle: t}
+	if t.MaxLookups > 0 {
+		throttle.lookups = make(chan struct{}, t.MaxLookups)
+	}
+	c.throttle = throttle
+}
+
+// SetTimeouts sets the dial, read and write timeouts used by Connect
+func (c *Client) SetTimeouts(t Timeouts) {
+	c.timeouts = t
+}
+
+// acquireLookup blocks until a metadata lookup slot is free or the context is done
+func (c *Client) acquireLookup() error {
+	if c.throttle.lookups == nil {
+		return nil
+	}
+	select {
+	case c.throttle.lookups <- struct{}{}:
+		return nil
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+func (c *Client) releaseLookup() {
+	if c.throttle.lookups != nil {
+		<-c.throttle.lookups
+	}
+}
+
+// pauseBetweenPages waits for the throttle's page delay, returning early if the context is done
+func (c *Client) pauseBetweenPages() error {
+	if c.throttle.PageDelay <= 0 {
+		return nil
 	}
+	timer := time.NewTimer(c.throttle.PageDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-c.ctx.Done():
+		return c.ctx.Err()
+	}
+}
+
+// WithContext returns a copy of the client whose commands run under ctx,
+// so callers can cancel individual operations. The underlying connection is shared.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	clone := *c
+	clone.ctx = ctx
+	return &clone
 }
 
 // Connect establishes a connection to the Redis server
 func (c *Client) Connect() error {
-	opts := &redis.Options{
-		Addr:     fmt.Sprintf("%s:%d", c.connection.Host, c.connection.Port),
-		Password: c.connection.Password,
-		DB:       c.connection.Database,
+	var tlsConfig *tls.Config
+	if c.connection.UseTLS {
+		tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if !c.connection.Sentinel.Enabled {
+			tlsConfig.ServerName = c.connection.Host // Required for SNI verification
+		}
 	}
 
-	if c.connection.UseTLS {
-		opts.TLSConfig = &tls.Config{
-			MinVersion: tls.VersionTLS12,
-			ServerName: c.connection.Host, // Required for SNI verification
+	// Dial Redis through the bastion host; the address is resolved on the SSH side
+	var dialer func(ctx context.Context, network, addr string) (net.Conn, error)
+	if c.connection.SSH.Enabled {
+		tunnel, err := dialSSH(c.ctx, c.connection.SSH)
+		if err != nil {
+			return err
+		}
+		c.tunnel = tunnel
+		dialer = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return tunnel.Dial(network, addr)
 		}
 	}
 
-	c.rdb = redis.NewClient(opts)
+	target := fmt.Sprintf("%s:%d", c.connection.Host, c.connection.Port)
+	if sentinel := c.connection.Sentinel; sentinel.Enabled {
+		// The failover client follows the master across failovers on its own
+		target = fmt.Sprintf("master %q via sentinels %s", sentinel.MasterName, strings.Join(sentinel.Addrs, ", "))
+		c.rdb = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       sentinel.MasterName,
+			SentinelAddrs:    sentinel.Addrs,
+			SentinelPassword: sentinel.Password,
+			Username:         c.connection.Username,
+			Password:         c.connection.Password,
+			DB:               c.connection.Database,
+			Protocol:         c.protocol(),
+			TLSConfig:        tlsConfig,
+			Dialer:           dialer,
+
+			DialTimeout:           c.timeouts.Dial,
+			ReadTimeout:           c.timeouts.Read,
+			WriteTimeout:          c.timeouts.Write,
+			ContextTimeoutEnabled: true,
+		})
+	} else {
+		c.rdb = redis.NewClient(&redis.Options{
+			Addr:      target,
+			Username:  c.connection.Username,
+			Password:  c.connection.Password,
+			DB:        c.connection.Database,
+			Protocol:  c.protocol(),
+			TLSConfig: tlsConfig,
+			Dialer:    dialer,
+
+			DialTimeout:           c.timeouts.Dial,
+			ReadTimeout:           c.timeouts.Read,
+			WriteTimeout:          c.timeouts.Write,
+			ContextTimeoutEnabled: true,
+		})
+	}
+
+	if c.connection.ReadOnly {
+		c.rdb.AddHook(readOnlyHook{})
+	}
+	if c.protocol() == 3 {
+		c.pushes = &pushRelay{}
+		for _, name := range pushNames {
+			if err := c.rdb.RegisterPushNotificationHandler(name, c.pushes, false); err != nil {
+				log.Printf("warning: failed to handle %s pushes: %v", name, err)
+			}
+		}
+	}
 
 	// Test connection
-	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(c.ctx, c.timeouts.Dial+c.timeouts.Read)
 	defer cancel()
 
 	_, err := c.rdb.Ping(ctx).Result()
 	if err != nil {
-		return fmt.Errorf("failed to connect to Redis at %s:%d: %w", c.connection.Host, c.connection.Port, err)
+		c.Disconnect()
+		return fmt.Errorf("failed to connect to Redis at %s: %w", target, err)
+	}
+	// go-redis quietly uses RESP2 when HELLO fails, which is only wanted when
+	// the protocol is left to the server
+	if c.connection.Protocol == 3 {
+		if _, err := c.WithContext(ctx).Hello(); err != nil {
+			c.Disconnect()
+			return fmt.Errorf("%s doesn't speak RESP3, which needs Redis 6 or later: %w", target, err)
+		}
+	}
+	c.caps = c.WithContext(ctx).detectCapabilities()
+	if c.caps.cluster {
+		c.rdb.AddHook(movedHook{})
+		if c.cluster, err = c.WithContext(ctx).loadClusterMap(); err != nil {
+			log.Printf("warning: cluster slots unavailable: %v", err)
+		}
 	}
 	return nil
 }
 
 // Disconnect closes the Redis connection
 func (c *Client) Disconnect() error {
+	var err error
 	if c.rdb != nil {
-		return c.rdb.Close()
+		err = c.rdb.Close()
 	}
-	return nil
+	if c.tunnel != nil {
+		c.tunnel.Close()
+		c.tunnel = nil
+	}
+	return err
 }
 
 // IsConnected checks if the client is connected
@@ -75,9 +277,9 @@ func (c *Client) IsConnected() bool {
 	return err == nil
 }
 
-// SelectDatabase changes the current database
-func (c *Client) SelectDatabase(db int) error {
-	return c.rdb.Do(c.ctx, "SELECT", db).Err()
+// Connection returns the connection settings, with Database set to the database connected to
+func (c *Client) Connection() models.ServerConnection {
+	return *c.connection
 }
 
 // ScanKeys returns keys matching the pattern with pagination
@@ -99,8 +301,8 @@ func (c *Client) GetAllKeys(pattern string, maxKeys int) ([]models.RedisKey, err
 	var cursor uint64
 
 	// Optimize scan count based on maxKeys
-	scanCount := int64(100)
-	if maxKeys > 0 && maxKeys < 100 {
+	scanCount := c.throttle.Count
+	if maxKeys > 0 && int64(maxKeys) < scanCount {
 		scanCount = int64(maxKeys)
 	}
 
@@ -110,36 +312,257 @@ func (c *Client) GetAllKeys(pattern string, maxKeys int) ([]models.RedisKey, err
 			return nil, fmt.Errorf("failed to scan keys: %w", err)
 		}
 
-		for _, key := range result {
-			keyType, err := c.rdb.Type(c.ctx, key).Result()
-			if err != nil {
-				log.Printf("warning: failed to get type for key %s: %v", key, err)
-				keyType = "unknown"
-			}
+		// Stop promptly when the operation is cancelled
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
 
-			ttl, err := c.rdb.TTL(c.ctx, key).Result()
-			if err != nil {
-				log.Printf("warning: failed to get TTL for key %s: %v", key, err)
-				ttl = -2 * time.Second
-			}
+		if maxKeys > 0 && len(keys)+len(result) > maxKeys {
+			result = result[:maxKeys-len(keys)]
+		}
 
-			keys = append(keys, models.RedisKey{
-				Key:  key,
-				Type: keyType,
-				TTL:  int64(ttl.Seconds()),
-			})
+		page, err := c.lookupKeys(result)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
 
-			if maxKeys > 0 && len(keys) >= maxKeys {
-				return keys, nil
-			}
+		if maxKeys > 0 && len(keys) >= maxKeys {
+			return keys, nil
 		}
 
 		cursor = nextCursor
 		if cursor == 0 {
 			break
 		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, err
+		}
+	}
+
+	return keys, nil
+}
+
+// MatchPattern turns a search term into a SCAN MATCH glob. Terms that already
+// contain glob syntax are used as-is; anything else matches keys containing the term.
+func MatchPattern(term string) string {
+	if term == "" {
+		return "*"
+	}
+	if strings.ContainsAny(term, "*?[") {
+		return term
+	}
+	return "*" + strings.ReplaceAll(term, `\`, `\\`) + "*"
+}
+
+// PrefixPattern returns a SCAN MATCH glob for every key starting with prefix,
+// escaping any glob syntax in the prefix itself
+func PrefixPattern(prefix string) string {
+	return escapeGlob(prefix) + "*"
+}
+
+// ContainsPattern returns a MATCH glob for every name containing text
+func ContainsPattern(text string) string {
+	return "*" + escapeGlob(text) + "*"
+}
+
+func escapeGlob(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ScanKeysPage scans from cursor until at least pageSize keys matching the pattern
+// are found or the scan completes, returning the keys with their type and TTL plus
+// the cursor to continue from (0 once the whole keyspace has been scanned).
+// A page may hold slightly more than pageSize keys, since SCAN batches are never split.
+func (c *Client) ScanKeysPage(pattern string, cursor uint64, pageSize int) ([]models.RedisKey, uint64, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var keys []models.RedisKey
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan keys: %w", err)
+		}
+
+		// Stop promptly when the operation is cancelled
+		if err := c.ctx.Err(); err != nil {
+			return nil, 0, err
+		}
+
+		page, err := c.lookupKeys(result)
+		if err != nil {
+			return nil, 0, err
+		}
+		keys = append(keys, page...)
+
+		cursor = nextCursor
+		if cursor == 0 || len(keys) >= pageSize {
+			return keys, cursor, nil
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// MatchingKeys returns the names of every key matching the pattern
+func (c *Client) MatchingKeys(pattern string) ([]string, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var keys []string
+	var cursor uint64
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		keys = append(keys, result...)
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return keys, nil
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// CountMatching counts the keys matching the pattern without keeping their names
+func (c *Client) CountMatching(pattern string) (int64, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var count int64
+	var cursor uint64
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return count, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		count += int64(len(result))
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return count, nil
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return count, err
+		}
+	}
+}
+
+// SetTTLMatching sets the same expiry on every key matching the pattern one SCAN
+// batch at a time, reporting the running count after each batch, and returns how
+// many keys were updated
+func (c *Client) SetTTLMatching(pattern string, exp models.Expiry, progress func(updated int64)) (int64, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	var updated int64
+	var cursor uint64
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return updated, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if err := c.SetTTLs(result, exp); err != nil {
+			return updated, err
+		}
+		updated += int64(len(result))
+		if progress != nil {
+			progress(updated)
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			return updated, nil
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return updated, err
+		}
+	}
+}
+
+// lookupKeys fetches TYPE and TTL for a page of keys, pipelined in batches of
+// the throttle's BatchSize
+func (c *Client) lookupKeys(names []string) ([]models.RedisKey, error) {
+	batch := c.throttle.BatchSize
+	if batch <= 0 || batch > len(names) {
+		batch = len(names)
+	}
+
+	var keys []models.RedisKey
+	for start := 0; start < len(names); start += batch {
+		end := start + batch
+		if end > len(names) {
+			end = len(names)
+		}
+		page, err := c.lookupBatch(names[start:end])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
+	}
+	return keys, nil
+}
+
+// lookupBatch fetches TYPE and TTL for a batch of keys in a single pipelined round trip
+func (c *Client) lookupBatch(names []string) ([]models.RedisKey, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if err := c.acquireLookup(); err != nil {
+		return nil, err
+	}
+	defer c.releaseLookup()
+
+	pipe := c.rdb.Pipeline()
+	typeCmds := make([]*redis.StatusCmd, len(names))
+	ttlCmds := make([]*redis.DurationCmd, len(names))
+	for i, key := range names {
+		typeCmds[i] = pipe.Type(c.ctx, key)
+		ttlCmds[i] = pipe.TTL(c.ctx, key)
+	}
+	// Per-key failures are reported through the individual commands below
+	if _, err := pipe.Exec(c.ctx); err != nil && c.ctx.Err() != nil {
+		return nil, c.ctx.Err()
 	}
 
+	keys := make([]models.RedisKey, len(names))
+	for i, key := range names {
+		keyType, err := typeCmds[i].Result()
+		if err != nil {
+			log.Printf("warning: failed to get type for key %s: %v", key, err)
+			keyType = "unknown"
+		}
+
+		ttl, err := ttlCmds[i].Result()
+		if err != nil {
+			log.Printf("warning: failed to get TTL for key %s: %v", key, err)
+			ttl = -2
+		}
+
+		keys[i] = models.RedisKey{
+			Key:  key,
+			Type: keyType,
+			TTL:  ttlSeconds(ttl),
+		}
+	}
 	return keys, nil
 }
 
@@ -154,27 +577,142 @@ func (c *Client) GetTTL(key string) (int64, error) {
 	if err != nil {
 		return -2, err
 	}
-	return int64(ttl.Seconds()), nil
+	return ttlSeconds(ttl), nil
+}
+
+// ttlSeconds converts a TTL reply to seconds. go-redis passes the -1 (no expiry)
+// and -2 (no such key) replies through as nanoseconds rather than seconds.
+func ttlSeconds(ttl time.Duration) int64 {
+	if ttl < 0 {
+		return int64(ttl)
+	}
+	return int64(ttl.Seconds())
+}
+
+// SetTTL sets or removes the expiry of a key
+func (c *Client) SetTTL(key string, exp models.Expiry) error {
+	return c.expire(c.rdb, key, exp).Err()
 }
 
-// SetTTL sets the TTL for a key
-func (c *Client) SetTTL(key string, seconds int64) error {
-	if seconds <= 0 {
-		return c.rdb.Persist(c.ctx, key).Err()
+// expire queues the EXPIRE, EXPIREAT or PERSIST for exp on cmd, which may be a pipeline
+func (c *Client) expire(cmd redis.Cmdable, key string, exp models.Expiry) *redis.BoolCmd {
+	switch {
+	case !exp.At.IsZero():
+		return cmd.ExpireAt(c.ctx, key, exp.At)
+	case exp.Seconds > 0:
+		return cmd.Expire(c.ctx, key, time.Duration(exp.Seconds)*time.Second)
 	}
-	return c.rdb.Expire(c.ctx, key, time.Duration(seconds)*time.Second).Err()
+	return cmd.Persist(c.ctx, key)
 }
 
 // DeleteKey deletes a key
 func (c *Client) DeleteKey(key string) error {
-	return c.rdb.Del(c.ctx, key).Err()
+	return c.del(c.rdb, key).Err()
+}
+
+// del deletes keys with UNLINK, which frees large values in the background,
+// or with DEL on servers before UNLINK, on cmd
+func (c *Client) del(cmd redis.Cmdable, keys ...string) *redis.IntCmd {
+	if c.Supports(FeatureUnlink) {
+		return cmd.Unlink(c.ctx, keys...)
+	}
+	return cmd.Del(c.ctx, keys...)
+}
+
+// delBatch deletes keys in one round trip, with a command per hash slot on a
+// cluster, and returns how many were removed
+func (c *Client) delBatch(keys []string) (int64, error) {
+	groups := c.bySlot(keys)
+	if len(groups) == 1 {
+		return c.del(c.rdb, groups[0]...).Result()
+	}
+	var cmds []*redis.IntCmd
+	_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for _, group := range groups {
+			cmds = append(cmds, c.del(pipe, group...))
+		}
+		return nil
+	})
+	var deleted int64
+	for _, cmd := range cmds {
+		deleted += cmd.Val()
+	}
+	return deleted, err
+}
+
+// batchSize is how many keys multi-key commands touch per round trip
+const batchSize = 500
+
+// DeleteKeys deletes keys in batches and returns how many were removed
+func (c *Client) DeleteKeys(keys []string) (int64, error) {
+	var deleted int64
+	for start := 0; start < len(keys); start += batchSize {
+		end := min(start+batchSize, len(keys))
+		n, err := c.delBatch(keys[start:end])
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+	}
+	return deleted, nil
+}
+
+// DeleteKeysWithProgress deletes keys in batches like DeleteKeys, reporting the
+// running count after each batch, and returns how many were removed. Gentle
+// scan mode pauses between batches.
+func (c *Client) DeleteKeysWithProgress(keys []string, progress func(deleted int64)) (int64, error) {
+	var deleted int64
+	for start := 0; start < len(keys); start += batchSize {
+		if start > 0 {
+			if err := c.pauseBetweenPages(); err != nil {
+				return deleted, err
+			}
+		}
+		end := min(start+batchSize, len(keys))
+		n, err := c.delBatch(keys[start:end])
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+		progress(deleted)
+	}
+	return deleted, nil
+}
+
+// SetTTLs sets the same expiry on every key in one pipelined round trip per
+// batch; an Expiry without a TTL or time removes the keys' expiry (PERSIST)
+func (c *Client) SetTTLs(keys []string, exp models.Expiry) error {
+	for start := 0; start < len(keys); start += batchSize {
+		end := min(start+batchSize, len(keys))
+		_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range keys[start:end] {
+				c.expire(pipe, key, exp)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // RenameKey renames a key
 func (c *Client) RenameKey(oldKey, newKey string) error {
+	if err := c.CrossSlot(oldKey, newKey); err != nil {
+		return err
+	}
 	return c.rdb.Rename(c.ctx, oldKey, newKey).Err()
 }
 
+// RenameKeyNX renames a key only if newKey doesn't exist yet, reporting whether it was renamed
+func (c *Client) RenameKeyNX(oldKey, newKey string) (bool, error) {
+	if err := c.CrossSlot(oldKey, newKey); err != nil {
+		return false, err
+	}
+	return c.rdb.RenameNX(c.ctx, oldKey, newKey).Result()
+}
+
 // String operations
 
 // GetString gets a string value
@@ -187,13 +725,93 @@ func (c *Client) SetString(key, value string) error {
 	return c.rdb.Set(c.ctx, key, value, 0).Err()
 }
 
+// SetStringRange overwrites a string's bytes from offset on with value using
+// SETRANGE, which leaves the bytes after them in place, and returns the new length
+func (c *Client) SetStringRange(key string, offset int64, value string) (int64, error) {
+	return c.rdb.SetRange(c.ctx, key, offset, value).Result()
+}
+
+// AppendString adds value to the end of a string with APPEND and returns the
+// new length
+func (c *Client) AppendString(key, value string) (int64, error) {
+	return c.rdb.Append(c.ctx, key, value).Result()
+}
+
+// Increment adds delta to an integer string with INCR, DECR or INCRBY, which
+// the server applies atomically, and returns the new value. A missing key
+// counts as 0.
+func (c *Client) Increment(key string, delta int64) (int64, error) {
+	switch delta {
+	case 1:
+		return c.rdb.Incr(c.ctx, key).Result()
+	case -1:
+		return c.rdb.Decr(c.ctx, key).Result()
+	}
+	return c.rdb.IncrBy(c.ctx, key, delta).Result()
+}
+
+// Bitmap operations
+
+// BitCount returns how many bits are set in a string
+func (c *Client) BitCount(key string) (int64, error) {
+	return c.rdb.BitCount(c.ctx, key, nil).Result()
+}
+
+// StringLength returns the length of a string in bytes
+func (c *Client) StringLength(key string) (int64, error) {
+	return c.rdb.StrLen(c.ctx, key).Result()
+}
+
+// GetBit returns the bit at offset
+func (c *Client) GetBit(key string, offset int64) (int64, error) {
+	return c.rdb.GetBit(c.ctx, key, offset).Result()
+}
+
+// SetBit sets the bit at offset to value (0 or 1) and returns its previous value
+func (c *Client) SetBit(key string, offset int64, value int) (int64, error) {
+	return c.rdb.SetBit(c.ctx, key, offset, value).Result()
+}
+
+// BitPos returns the offset of the first bit set to bit within the byte range
+// start..end (end -1 for the end of the string), or -1 if there is none
+func (c *Client) BitPos(key string, bit int64, start, end int64) (int64, error) {
+	return c.rdb.BitPos(c.ctx, key, bit, start, end).Result()
+}
+
+// GetBytes returns the bytes start..end (inclusive) of a string
+func (c *Client) GetBytes(key string, start, end int64) ([]byte, error) {
+	value, err := c.rdb.GetRange(c.ctx, key, start, end).Result()
+	return []byte(value), err
+}
+
 // List operations
 
+// CollectionLength returns the element count of a list, set, hash or sorted set
+// using LLEN, SCARD, HLEN or ZCARD
+func (c *Client) CollectionLength(key, keyType string) (int64, error) {
+	switch keyType {
+	case "list":
+		return c.rdb.LLen(c.ctx, key).Result()
+	case "set":
+		return c.rdb.SCard(c.ctx, key).Result()
+	case "hash":
+		return c.rdb.HLen(c.ctx, key).Result()
+	case "zset":
+		return c.rdb.ZCard(c.ctx, key).Result()
+	}
+	return 0, fmt.Errorf("unsupported collection type: %s", keyType)
+}
+
 // GetList returns all elements in a list
 func (c *Client) GetList(key string) ([]string, error) {
 	return c.rdb.LRange(c.ctx, key, 0, -1).Result()
 }
 
+// GetListRange returns up to count elements of a list starting at index start
+func (c *Client) GetListRange(key string, start, count int64) ([]string, error) {
+	return c.rdb.LRange(c.ctx, key, start, start+count-1).Result()
+}
+
 // ListPush adds an element to a list
 func (c *Client) ListPush(key, value string, left bool) error {
 	if left {
@@ -207,26 +825,152 @@ func (c *Client) ListSet(key string, index int64, value string) error {
 	return c.rdb.LSet(c.ctx, key, index, value).Err()
 }
 
-// ListRemove removes elements from a list
-func (c *Client) ListRemove(key string, count int64, value string) error {
-	return c.rdb.LRem(c.ctx, key, count, value).Err()
+// ListRemove removes elements equal to value from a list with LREM: the
+// first count from the head, the last -count from the tail when negative, or
+// all of them when 0. It returns how many were removed.
+func (c *Client) ListRemove(key string, count int64, value string) (int64, error) {
+	return c.rdb.LRem(c.ctx, key, count, value).Result()
 }
 
-// Set operations
-
-// GetSet returns all members of a set
-func (c *Client) GetSet(key string) ([]string, error) {
-	return c.rdb.SMembers(c.ctx, key).Result()
+// ListInsert inserts value before or after the first element equal to pivot
+func (c *Client) ListInsert(key string, before bool, pivot, value string) error {
+	var n int64
+	var err error
+	if before {
+		n, err = c.rdb.LInsertBefore(c.ctx, key, pivot, value).Result()
+	} else {
+		n, err = c.rdb.LInsertAfter(c.ctx, key, pivot, value).Result()
+	}
+	if err == nil && n <= 0 {
+		return fmt.Errorf("the list no longer holds %q", pivot)
+	}
+	return err
 }
 
-// SetAdd adds a member to a set
-func (c *Client) SetAdd(key, member string) error {
-	return c.rdb.SAdd(c.ctx, key, member).Err()
+// ListFind returns the indexes of elements equal to value using LPOS, up to limit matches
+func (c *Client) ListFind(key, value string, limit int64) ([]int64, error) {
+	if !c.Supports(FeatureListFind) {
+		return nil, c.unsupported(FeatureListFind)
+	}
+	return c.rdb.LPosCount(c.ctx, key, value, limit, redis.LPosArgs{}).Result()
 }
 
-// SetRemove removes a member from a set
-func (c *Client) SetRemove(key, member string) error {
-	return c.rdb.SRem(c.ctx, key, member).Err()
+// ListEnds reads a list's length and its first and last n elements in one
+// round trip, as the queue view polls them
+func (c *Client) ListEnds(key string, n int64) (models.ListEnds, error) {
+	pipe := c.rdb.Pipeline()
+	length := pipe.LLen(c.ctx, key)
+	head := pipe.LRange(c.ctx, key, 0, n-1)
+	tail := pipe.LRange(c.ctx, key, -n, -1)
+	if _, err := pipe.Exec(c.ctx); err != nil {
+		return models.ListEnds{}, err
+	}
+	return models.ListEnds{Length: length.Val(), Head: head.Val(), Tail: tail.Val()}, nil
+}
+
+// ListPop removes and returns the first (left) or last element of a list,
+// or ErrKeyNotFound when the list is empty
+func (c *Client) ListPop(key string, left bool) (string, error) {
+	var value string
+	var err error
+	if left {
+		value, err = c.rdb.LPop(c.ctx, key).Result()
+	} else {
+		value, err = c.rdb.RPop(c.ctx, key).Result()
+	}
+	if err == redis.Nil {
+		return "", ErrKeyNotFound
+	}
+	return value, err
+}
+
+// ListMove moves the first (fromLeft) or last element of src onto the head
+// (toLeft) or tail of dst with LMOVE, returning it, or ErrKeyNotFound when
+// src is empty
+func (c *Client) ListMove(src, dst string, fromLeft, toLeft bool) (string, error) {
+	side := func(left bool) string {
+		if left {
+			return "LEFT"
+		}
+		return "RIGHT"
+	}
+	if err := c.CrossSlot(src, dst); err != nil {
+		return "", err
+	}
+	value, err := c.rdb.LMove(c.ctx, src, dst, side(fromLeft), side(toLeft)).Result()
+	if err == redis.Nil {
+		return "", ErrKeyNotFound
+	}
+	return value, err
+}
+
+// Set operations
+
+// GetSet returns all members of a set
+func (c *Client) GetSet(key string) ([]string, error) {
+	return c.rdb.SMembers(c.ctx, key).Result()
+}
+
+// ScanSet continues an SSCAN from cursor until at least count members matching
+// the MATCH pattern ("" for all) are returned or the scan completes, returning the
+// members and the next cursor (0 once the whole set has been seen). SSCAN may
+// return a member more than once.
+func (c *Client) ScanSet(key string, cursor uint64, match string, count int64) ([]string, uint64, error) {
+	var members []string
+	for {
+		batch, next, err := c.rdb.SScan(c.ctx, key, cursor, match, count).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		members = append(members, batch...)
+		cursor = next
+		if cursor == 0 || int64(len(members)) >= count {
+			return members, cursor, nil
+		}
+	}
+}
+
+// SetAdd adds a member to a set
+func (c *Client) SetAdd(key, member string) error {
+	return c.rdb.SAdd(c.ctx, key, member).Err()
+}
+
+// SetRemove removes a member from a set
+func (c *Client) SetRemove(key, member string) error {
+	return c.rdb.SRem(c.ctx, key, member).Err()
+}
+
+// SetCombine returns the members of the union, intersection or difference of the sets
+func (c *Client) SetCombine(req models.CombineRequest) ([]string, error) {
+	if err := c.CrossSlot(req.Keys...); err != nil {
+		return nil, err
+	}
+	switch req.Op {
+	case models.CombineInter:
+		return c.rdb.SInter(c.ctx, req.Keys...).Result()
+	case models.CombineUnion:
+		return c.rdb.SUnion(c.ctx, req.Keys...).Result()
+	case models.CombineDiff:
+		return c.rdb.SDiff(c.ctx, req.Keys...).Result()
+	}
+	return nil, fmt.Errorf("unsupported set operation: %s", req.Op)
+}
+
+// SetCombineStore stores the union, intersection or difference of the sets at the
+// destination key, returning the number of members stored
+func (c *Client) SetCombineStore(req models.CombineRequest) (int64, error) {
+	if err := c.CrossSlot(append([]string{req.Destination}, req.Keys...)...); err != nil {
+		return 0, err
+	}
+	switch req.Op {
+	case models.CombineInter:
+		return c.rdb.SInterStore(c.ctx, req.Destination, req.Keys...).Result()
+	case models.CombineUnion:
+		return c.rdb.SUnionStore(c.ctx, req.Destination, req.Keys...).Result()
+	case models.CombineDiff:
+		return c.rdb.SDiffStore(c.ctx, req.Destination, req.Keys...).Result()
+	}
+	return 0, fmt.Errorf("unsupported set operation: %s", req.Op)
 }
 
 // Hash operations
@@ -236,43 +980,1188 @@ func (c *Client) GetHash(key string) (map[string]string, error) {
 	return c.rdb.HGetAll(c.ctx, key).Result()
 }
 
+// ScanHash continues an HSCAN from cursor until at least count fields whose
+// names match the MATCH pattern ("" for all) are returned or the scan completes,
+// returning the fields and the next cursor (0 once the whole hash has been seen)
+func (c *Client) ScanHash(key string, cursor uint64, match string, count int64) (map[string]string, uint64, error) {
+	fields := make(map[string]string)
+	for {
+		batch, next, err := c.rdb.HScan(c.ctx, key, cursor, match, count).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		// HSCAN replies with a flat field, value, field, value... list
+		for i := 0; i+1 < len(batch); i += 2 {
+			fields[batch[i]] = batch[i+1]
+		}
+		cursor = next
+		if cursor == 0 || int64(len(fields)) >= count {
+			return fields, cursor, nil
+		}
+	}
+}
+
 // HashSet sets a field in a hash
 func (c *Client) HashSet(key, field, value string) error {
 	return c.rdb.HSet(c.ctx, key, field, value).Err()
 }
 
+// HashApply sets and deletes fields of a hash in one MULTI/EXEC round trip
+func (c *Client) HashApply(key string, set map[string]string, del []string) error {
+	_, err := c.rdb.TxPipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		if len(set) > 0 {
+			pipe.HSet(c.ctx, key, set)
+		}
+		if len(del) > 0 {
+			pipe.HDel(c.ctx, key, del...)
+		}
+		return nil
+	})
+	return err
+}
+
 // HashDelete deletes a field from a hash
 func (c *Client) HashDelete(key, field string) error {
 	return c.rdb.HDel(c.ctx, key, field).Err()
 }
 
-// Sorted Set operations
+// Sorted Set operations
+
+// GetSortedSet returns all members with scores in a sorted set
+func (c *Client) GetSortedSet(key string) ([]models.ScoredValue, error) {
+	return c.GetSortedSetRange(key, 0, -1)
+}
+
+// GetSortedSetRange returns up to count members with scores in score order,
+// starting at rank start. A negative count returns every member from start on.
+func (c *Client) GetSortedSetRange(key string, start, count int64) ([]models.ScoredValue, error) {
+	stop := int64(-1)
+	if count >= 0 {
+		stop = start + count - 1
+	}
+	result, err := c.rdb.ZRangeWithScores(c.ctx, key, start, stop).Result()
+	if err != nil {
+		return nil, err
+	}
+	return scoredValues(result), nil
+}
+
+// QuerySortedSet returns up to count members of a range with their scores,
+// skipping the first offset, in the range's order. Score ranges use
+// ZRANGEBYSCORE and ZREVRANGEBYSCORE, which servers before 6.2 know too.
+func (c *Client) QuerySortedSet(key string, r models.SortedSetRange, offset, count int64) ([]models.ScoredValue, error) {
+	var result []redis.Z
+	var err error
+	if r.ByScore {
+		by := &redis.ZRangeBy{Min: r.Min, Max: r.Max, Offset: offset, Count: count}
+		if r.Reverse {
+			result, err = c.rdb.ZRevRangeByScoreWithScores(c.ctx, key, by).Result()
+		} else {
+			result, err = c.rdb.ZRangeByScoreWithScores(c.ctx, key, by).Result()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return scoredValues(result), nil
+	}
+
+	start, stop, err := c.rankBounds(key, r)
+	if err != nil {
+		return nil, err
+	}
+	start += offset
+	stop = min(stop, start+count-1)
+	if start > stop {
+		return nil, nil
+	}
+	if r.Reverse {
+		result, err = c.rdb.ZRevRangeWithScores(c.ctx, key, start, stop).Result()
+	} else {
+		result, err = c.rdb.ZRangeWithScores(c.ctx, key, start, stop).Result()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return scoredValues(result), nil
+}
+
+// CountSortedSetRange returns how many members a range holds
+func (c *Client) CountSortedSetRange(key string, r models.SortedSetRange) (int64, error) {
+	if r.ByScore {
+		return c.rdb.ZCount(c.ctx, key, r.Min, r.Max).Result()
+	}
+	start, stop, err := c.rankBounds(key, r)
+	if err != nil {
+		return 0, err
+	}
+	return max(stop-start+1, 0), nil
+}
+
+// rankBounds resolves the ranks of a range against the size of the sorted
+// set, so negative ranks and ones past the end become ranks of members
+func (c *Client) rankBounds(key string, r models.SortedSetRange) (start, stop int64, err error) {
+	if start, err = strconv.ParseInt(strings.TrimSpace(r.Min), 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("rank %q is not a whole number", r.Min)
+	}
+	if stop, err = strconv.ParseInt(strings.TrimSpace(r.Max), 10, 64); err != nil {
+		return 0, 0, fmt.Errorf("rank %q is not a whole number", r.Max)
+	}
+	card, err := c.rdb.ZCard(c.ctx, key).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	if start < 0 {
+		start += card
+	}
+	if stop < 0 {
+		stop += card
+	}
+	return max(start, 0), min(stop, card-1), nil
+}
+
+// scoredValues converts go-redis members with scores
+func scoredValues(result []redis.Z) []models.ScoredValue {
+	var values []models.ScoredValue
+	for _, z := range result {
+		values = append(values, models.ScoredValue{
+			Score:  z.Score,
+			Member: z.Member.(string),
+		})
+	}
+	return values
+}
+
+// ScanSortedSet continues a ZSCAN from cursor until at least count members
+// matching the MATCH pattern ("" for all) are returned or the scan completes,
+// returning them in scan order with the next cursor (0 once the whole sorted
+// set has been seen)
+func (c *Client) ScanSortedSet(key string, cursor uint64, match string, count int64) ([]models.ScoredValue, uint64, error) {
+	var members []models.ScoredValue
+	for {
+		batch, next, err := c.rdb.ZScan(c.ctx, key, cursor, match, count).Result()
+		if err != nil {
+			return nil, 0, err
+		}
+		// ZSCAN replies with a flat member, score, member, score... list
+		for i := 0; i+1 < len(batch); i += 2 {
+			score, err := strconv.ParseFloat(batch[i+1], 64)
+			if err != nil {
+				return nil, 0, fmt.Errorf("invalid score %q for member %q", batch[i+1], batch[i])
+			}
+			members = append(members, models.ScoredValue{Member: batch[i], Score: score})
+		}
+		cursor = next
+		if cursor == 0 || int64(len(members)) >= count {
+			return members, cursor, nil
+		}
+	}
+}
+
+// SortedSetAdd adds a member with score to a sorted set
+func (c *Client) SortedSetAdd(key string, score float64, member string) error {
+	return c.rdb.ZAdd(c.ctx, key, redis.Z{Score: score, Member: member}).Err()
+}
+
+// ZAddFlags holds the ZADD condition options
+type ZAddFlags struct {
+	NX bool // only add new members
+	XX bool // only update existing members
+	GT bool // only update when the new score is greater
+	LT bool // only update when the new score is less
+	CH bool // count changed members, not just added ones
+}
+
+// SortedSetAddWithFlags adds or updates a member using ZADD condition flags and
+// returns the number of members added (or changed, with CH)
+func (c *Client) SortedSetAddWithFlags(key string, score float64, member string, flags ZAddFlags) (int64, error) {
+	return c.rdb.ZAddArgs(c.ctx, key, redis.ZAddArgs{
+		NX:      flags.NX,
+		XX:      flags.XX,
+		GT:      flags.GT,
+		LT:      flags.LT,
+		Ch:      flags.CH,
+		Members: []redis.Z{{Score: score, Member: member}},
+	}).Result()
+}
+
+// renameMemberScript moves a member's score to a new member name in one atomic step
+var renameMemberScript = redis.NewScript(`
+local score = redis.call('ZSCORE', KEYS[1], ARGV[1])
+if not score then
+	return 0
+end
+redis.call('ZREM', KEYS[1], ARGV[1])
+redis.call('ZADD', KEYS[1], score, ARGV[2])
+return 1
+`)
+
+// SortedSetRenameMember atomically renames a member, keeping its score
+func (c *Client) SortedSetRenameMember(key, oldMember, newMember string) error {
+	renamed, err := renameMemberScript.Run(c.ctx, c.rdb, []string{key}, oldMember, newMember).Int()
+	if err != nil {
+		return err
+	}
+	if renamed == 0 {
+		return fmt.Errorf("member %q no longer exists", oldMember)
+	}
+	return nil
+}
+
+// SortedSetIncrement adds delta to a member's score with ZINCRBY, adding the
+// member with a score of delta if it is missing, and returns the new score
+func (c *Client) SortedSetIncrement(key, member string, delta float64) (float64, error) {
+	return c.rdb.ZIncrBy(c.ctx, key, delta, member).Result()
+}
+
+// SortedSetRemove removes a member from a sorted set
+func (c *Client) SortedSetRemove(key, member string) error {
+	return c.rdb.ZRem(c.ctx, key, member).Err()
+}
+
+// SortedSetCombine returns the members of the union or intersection of the sorted sets
+func (c *Client) SortedSetCombine(req models.CombineRequest) ([]string, error) {
+	if err := c.CrossSlot(req.Keys...); err != nil {
+		return nil, err
+	}
+	store := redis.ZStore{Keys: req.Keys}
+	switch req.Op {
+	case models.CombineInter:
+		return c.rdb.ZInter(c.ctx, &store).Result()
+	case models.CombineUnion:
+		return c.rdb.ZUnion(c.ctx, store).Result()
+	}
+	return nil, fmt.Errorf("unsupported sorted set operation: %s", req.Op)
+}
+
+// SortedSetCombineStore stores the weighted union or intersection of the sorted sets
+// at the destination key, returning the number of members stored
+func (c *Client) SortedSetCombineStore(req models.CombineRequest) (int64, error) {
+	if err := c.CrossSlot(append([]string{req.Destination}, req.Keys...)...); err != nil {
+		return 0, err
+	}
+	store := &redis.ZStore{Keys: req.Keys, Weights: req.Weights, Aggregate: req.Aggregate}
+	switch req.Op {
+	case models.CombineInter:
+		return c.rdb.ZInterStore(c.ctx, req.Destination, store).Result()
+	case models.CombineUnion:
+		return c.rdb.ZUnionStore(c.ctx, req.Destination, store).Result()
+	}
+	return 0, fmt.Errorf("unsupported sorted set operation: %s", req.Op)
+}
+
+// DumpKey returns a key's type, TTL and complete value
+func (c *Client) DumpKey(key string) (*models.KeyDump, error) {
+	keyType, err := c.GetKeyType(key)
+	if err != nil {
+		return nil, err
+	}
+	if keyType == "none" {
+		return nil, fmt.Errorf("key '%s': %w", key, ErrKeyNotFound)
+	}
+
+	ttl, err := c.GetTTL(key)
+	if err != nil {
+		return nil, err
+	}
+
+	dump := &models.KeyDump{Key: key, Type: keyType, TTL: ttl}
+	switch keyType {
+	case "string":
+		dump.Value, err = c.GetString(key)
+	case "list":
+		dump.Items, err = c.GetList(key)
+	case "set":
+		dump.Items, err = c.GetSet(key)
+	case "hash":
+		dump.Fields, err = c.GetHash(key)
+	case "zset":
+		dump.Members, err = c.GetSortedSet(key)
+	case "stream":
+		dump.Entries, err = c.GetStreamAll(key)
+	case JSONType:
+		dump.Value, err = c.JSONGet(key, "$")
+	default:
+		err = fmt.Errorf("unsupported key type: %s", keyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dump, nil
+}
+
+// KeyExists reports whether a key exists
+func (c *Client) KeyExists(key string) (bool, error) {
+	n, err := c.rdb.Exists(c.ctx, key).Result()
+	return n > 0, err
+}
+
+// CreateKey creates a key from a dump, failing if the key already exists
+func (c *Client) CreateKey(dump *models.KeyDump) error {
+	exists, err := c.KeyExists(dump.Key)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("key '%s' already exists", dump.Key)
+	}
+	return c.RestoreKey(dump, false)
+}
+
+// RestoreKey recreates a key from a dump in a single transaction, deleting any
+// existing value first when replace is set
+func (c *Client) RestoreKey(dump *models.KeyDump, replace bool) error {
+	_, err := c.rdb.TxPipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		if replace {
+			pipe.Del(c.ctx, dump.Key)
+		}
+		return c.queueDump(pipe, dump)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore '%s': %w", dump.Key, err)
+	}
+	return nil
+}
+
+// queueDump queues the commands writing a dump's value and TTL on pipe
+func (c *Client) queueDump(pipe redis.Pipeliner, dump *models.KeyDump) error {
+	switch dump.Type {
+	case "string":
+		pipe.Set(c.ctx, dump.Key, dump.Value, 0)
+	case "list":
+		if len(dump.Items) > 0 {
+			pipe.RPush(c.ctx, dump.Key, toInterfaces(dump.Items)...)
+		}
+	case "set":
+		if len(dump.Items) > 0 {
+			pipe.SAdd(c.ctx, dump.Key, toInterfaces(dump.Items)...)
+		}
+	case "hash":
+		if len(dump.Fields) > 0 {
+			pipe.HSet(c.ctx, dump.Key, dump.Fields)
+		}
+	case "zset":
+		members := make([]redis.Z, len(dump.Members))
+		for i, m := range dump.Members {
+			members[i] = redis.Z{Score: m.Score, Member: m.Member}
+		}
+		if len(members) > 0 {
+			pipe.ZAdd(c.ctx, dump.Key, members...)
+		}
+	case "stream":
+		if !c.Supports(FeatureStreams) {
+			return fmt.Errorf("can't restore '%s': %w", dump.Key, c.unsupported(FeatureStreams))
+		}
+		for _, entry := range dump.Entries {
+			values := make([]interface{}, 0, len(entry.Fields)*2)
+			for _, f := range entry.Fields {
+				values = append(values, f.Key, f.Value)
+			}
+			pipe.XAdd(c.ctx, &redis.XAddArgs{Stream: dump.Key, ID: entry.ID, Values: values})
+		}
+	case JSONType:
+		pipe.JSONSet(c.ctx, dump.Key, "$", dump.Value)
+	default:
+		return fmt.Errorf("unsupported key type for '%s': %s", dump.Key, dump.Type)
+	}
+
+	if dump.TTL > 0 {
+		pipe.Expire(c.ctx, dump.Key, time.Duration(dump.TTL)*time.Second)
+	}
+	return nil
+}
+
+func toInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
+	}
+	return result
+}
+
+// CopyKeyTo copies a key with its TTL to newKey on target, which may be another
+// database or server. DUMP/RESTORE is used when possible, falling back to reading
+// and rewriting the value when the payload isn't accepted (e.g. across Redis versions).
+func (c *Client) CopyKeyTo(key string, target *Client, newKey string, replace bool) error {
+	if !replace {
+		exists, err := target.KeyExists(newKey)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("key '%s' already exists in the destination", newKey)
+		}
+	}
+
+	payload, err := c.rdb.Dump(c.ctx, key).Result()
+	if err == redis.Nil {
+		return fmt.Errorf("key '%s': %w", key, ErrKeyNotFound)
+	}
+	if err == nil {
+		var pttl time.Duration
+		pttl, err = c.rdb.PTTL(c.ctx, key).Result()
+		if err != nil {
+			return err
+		}
+		if pttl < 0 {
+			pttl = 0 // RESTORE treats 0 as no expiry
+		}
+		if replace {
+			err = target.rdb.RestoreReplace(target.ctx, newKey, pttl, payload).Err()
+		} else {
+			err = target.rdb.Restore(target.ctx, newKey, pttl, payload).Err()
+		}
+		if err == nil {
+			return nil
+		}
+	}
+	if c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+	log.Printf("DUMP/RESTORE of key %s failed, copying its value instead: %v", key, err)
+
+	dump, err := c.DumpKey(key)
+	if err != nil {
+		return err
+	}
+	dump.Key = newKey
+	return target.RestoreKey(dump, replace)
+}
+
+// CopyKeyToConnection copies a key to the database of another connection (or of
+// this one), opening a temporary connection to the destination
+func (c *Client) CopyKeyToConnection(key string, conn models.ServerConnection, newKey string, replace bool) error {
+	target, err := c.Dial(conn)
+	if err != nil {
+		return err
+	}
+	defer target.Disconnect()
+	return c.CopyKeyTo(key, target, newKey, replace)
+}
+
+// Dial opens a temporary connection to conn with this client's context and
+// timeouts. The caller disconnects it when done.
+func (c *Client) Dial(conn models.ServerConnection) (*Client, error) {
+	other := New(&conn).WithContext(c.ctx)
+	other.SetTimeouts(c.timeouts)
+	if err := other.Connect(); err != nil {
+		return nil, err
+	}
+	return other, nil
+}
+
+// DumpPayload returns a key's DUMP payload and TTL
+func (c *Client) DumpPayload(key string) (models.KeyPayload, error) {
+	var dump *redis.StringCmd
+	var pttl *redis.DurationCmd
+	_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		dump = pipe.Dump(c.ctx, key)
+		pttl = pipe.PTTL(c.ctx, key)
+		return nil
+	})
+	if err == redis.Nil {
+		return models.KeyPayload{}, fmt.Errorf("key '%s': %w", key, ErrKeyNotFound)
+	}
+	if err != nil {
+		return models.KeyPayload{}, err
+	}
+	payload := models.KeyPayload{Key: key, PTTL: -1, Payload: []byte(dump.Val())}
+	if ttl := pttl.Val(); ttl > 0 {
+		payload.PTTL = ttl.Milliseconds()
+	}
+	return payload, nil
+}
+
+// RestorePayload recreates a key from its DUMP payload with RESTORE, replacing
+// any existing value when replace is set
+func (c *Client) RestorePayload(payload models.KeyPayload, replace bool) error {
+	ttl := time.Duration(0) // no expiry
+	if payload.PTTL > 0 {
+		ttl = time.Duration(payload.PTTL) * time.Millisecond
+	}
+	data := string(payload.Payload)
+	if replace {
+		return c.rdb.RestoreReplace(c.ctx, payload.Key, ttl, data).Err()
+	}
+	return c.rdb.Restore(c.ctx, payload.Key, ttl, data).Err()
+}
+
+// SnapshotKeys DUMPs keys with their expiry so they can be put back with
+// RestoreSnapshots, giving up with ErrSnapshotTooLarge if the payloads would
+// pass maxBytes. Keys that don't exist are left out.
+func (c *Client) SnapshotKeys(keys []string, maxBytes int) ([]models.KeySnapshot, error) {
+	// MEMORY USAGE is cheap and saves transferring a huge key only to drop it
+	if usage, err := c.MemoryUsages(keys); err == nil {
+		var total int64
+		for _, n := range usage {
+			total += n
+		}
+		if total > int64(maxBytes) {
+			return nil, ErrSnapshotTooLarge
+		}
+	}
+
+	var snapshots []models.KeySnapshot
+	size := 0
+	now := time.Now()
+	for start := 0; start < len(keys); start += batchSize {
+		end := min(start+batchSize, len(keys))
+		dumps := make([]*redis.StringCmd, end-start)
+		pttls := make([]*redis.DurationCmd, end-start)
+		_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+			for i, key := range keys[start:end] {
+				dumps[i] = pipe.Dump(c.ctx, key)
+				pttls[i] = pipe.PTTL(c.ctx, key)
+			}
+			return nil
+		})
+		// DUMP of a missing key replies nil, which is checked per key below
+		if err != nil && err != redis.Nil {
+			return nil, err
+		}
+		for i, key := range keys[start:end] {
+			payload, err := dumps[i].Result()
+			if err == redis.Nil {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if size += len(payload); size > maxBytes {
+				return nil, ErrSnapshotTooLarge
+			}
+			snapshot := models.KeySnapshot{Key: key, Payload: payload}
+			if pttl := pttls[i].Val(); pttl > 0 {
+				snapshot.ExpireAt = now.Add(pttl)
+			}
+			snapshots = append(snapshots, snapshot)
+		}
+	}
+	return snapshots, nil
+}
+
+// RestoreSnapshots puts snapshotted keys back as they were, replacing their
+// current values. Keys whose expiry has passed since are deleted instead.
+func (c *Client) RestoreSnapshots(snapshots []models.KeySnapshot) error {
+	for start := 0; start < len(snapshots); start += batchSize {
+		end := min(start+batchSize, len(snapshots))
+		_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+			for _, s := range snapshots[start:end] {
+				var ttl time.Duration // 0 restores without an expiry
+				if !s.ExpireAt.IsZero() {
+					if ttl = time.Until(s.ExpireAt); ttl <= 0 {
+						pipe.Del(c.ctx, s.Key)
+						continue
+					}
+				}
+				pipe.RestoreReplace(c.ctx, s.Key, ttl, s.Payload)
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore keys: %w", err)
+		}
+	}
+	return nil
+}
+
+// Geo operations
+
+// GeoPositions returns the positions of members of a geo set, skipping members
+// that have none
+func (c *Client) GeoPositions(key string, members []string) ([]models.GeoMember, error) {
+	var result []models.GeoMember
+	for start := 0; start < len(members); start += batchSize {
+		end := min(start+batchSize, len(members))
+		positions, err := c.rdb.GeoPos(c.ctx, key, members[start:end]...).Result()
+		if err != nil {
+			return nil, err
+		}
+		for i, pos := range positions {
+			if pos != nil {
+				result = append(result, models.GeoMember{
+					Member:    members[start+i],
+					Longitude: pos.Longitude,
+					Latitude:  pos.Latitude,
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+// GeoAdd adds or moves a member of a geo set
+func (c *Client) GeoAdd(key string, longitude, latitude float64, member string) error {
+	return c.rdb.GeoAdd(c.ctx, key, &redis.GeoLocation{
+		Name:      member,
+		Longitude: longitude,
+		Latitude:  latitude,
+	}).Err()
+}
+
+// GeoSearch returns the members within a radius, nearest first
+func (c *Client) GeoSearch(key string, req models.GeoSearchRequest) ([]models.GeoMember, error) {
+	query := redis.GeoSearchQuery{
+		Radius:     req.Radius,
+		RadiusUnit: req.Unit,
+		Sort:       "ASC",
+		Count:      req.Count,
+	}
+	if req.FromMember != "" {
+		query.Member = req.FromMember
+	} else {
+		query.Longitude = req.Longitude
+		query.Latitude = req.Latitude
+	}
+
+	locations, err := c.rdb.GeoSearchLocation(c.ctx, key, &redis.GeoSearchLocationQuery{
+		GeoSearchQuery: query,
+		WithCoord:      true,
+		WithDist:       true,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.GeoMember, len(locations))
+	for i, loc := range locations {
+		result[i] = models.GeoMember{
+			Member:    loc.Name,
+			Longitude: loc.Longitude,
+			Latitude:  loc.Latitude,
+			Distance:  loc.Dist,
+		}
+	}
+	return result, nil
+}
+
+// RedisJSON operations
+
+// Modules returns the names of the modules loaded on the server (MODULE LIST)
+func (c *Client) Modules() ([]string, error) {
+	reply, err := c.rdb.Do(c.ctx, "MODULE", "LIST").Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, module := range reply {
+		switch m := module.(type) {
+		case map[interface{}]interface{}: // RESP3
+			if name, ok := m["name"].(string); ok {
+				names = append(names, name)
+			}
+		case []interface{}: // RESP2: flat name/value pairs
+			for i := 0; i+1 < len(m); i += 2 {
+				if field, _ := m[i].(string); field == "name" {
+					if name, ok := m[i+1].(string); ok {
+						names = append(names, name)
+					}
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// HasJSONModule reports whether RedisJSON is loaded, as found on connecting
+// when MODULE LIST could be read then
+func (c *Client) HasJSONModule() (bool, error) {
+	if loaded, ok := c.HasModule("ReJSON", "json"); ok {
+		return loaded, nil
+	}
+	modules, err := c.Modules()
+	if err != nil {
+		return false, err
+	}
+	for _, name := range modules {
+		if strings.EqualFold(name, "ReJSON") || strings.EqualFold(name, "json") {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// JSONGet returns the JSON at a JSONPath in a document, e.g. "$" for the whole
+// document. Only the first match is returned when the path matches several values.
+func (c *Client) JSONGet(key, path string) (string, error) {
+	raw, err := c.rdb.JSONGet(c.ctx, key, path).Result()
+	if err != nil {
+		return "", err
+	}
+	var matches []json.RawMessage
+	if err := json.Unmarshal([]byte(raw), &matches); err != nil {
+		return "", fmt.Errorf("unexpected JSON.GET reply: %w", err)
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("path %s does not exist", path)
+	}
+	return string(matches[0]), nil
+}
+
+// JSONSet sets the value at a JSONPath; value must be valid JSON
+func (c *Client) JSONSet(key, path, value string) error {
+	return c.rdb.JSONSet(c.ctx, key, path, value).Err()
+}
+
+// JSONDelete deletes the values at a JSONPath
+func (c *Client) JSONDelete(key, path string) error {
+	return c.rdb.JSONDel(c.ctx, key, path).Err()
+}
+
+// JSONArrayAppend appends a JSON value to the arrays at a JSONPath
+func (c *Client) JSONArrayAppend(key, path, value string) error {
+	return c.rdb.JSONArrAppend(c.ctx, key, path, value).Err()
+}
+
+// Stream operations
+
+// GetStream returns the newest count entries of a stream, newest first
+func (c *Client) GetStream(key string, count int64) ([]models.StreamEntry, error) {
+	messages, err := c.rdb.XRevRangeN(c.ctx, key, "+", "-", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	return toStreamEntries(messages), nil
+}
+
+// GetStreamAll returns every entry of a stream, oldest first
+func (c *Client) GetStreamAll(key string) ([]models.StreamEntry, error) {
+	messages, err := c.rdb.XRange(c.ctx, key, "-", "+").Result()
+	if err != nil {
+		return nil, err
+	}
+	return toStreamEntries(messages), nil
+}
+
+// toStreamEntries converts go-redis stream messages, sorting each entry's fields by name
+func toStreamEntries(messages []redis.XMessage) []models.StreamEntry {
+	entries := make([]models.StreamEntry, 0, len(messages))
+	for _, msg := range messages {
+		entry := models.StreamEntry{ID: msg.ID}
+		for field, value := range msg.Values {
+			entry.Fields = append(entry.Fields, models.KeyValue{Key: field, Value: fmt.Sprint(value)})
+		}
+		sort.Slice(entry.Fields, func(i, j int) bool {
+			return entry.Fields[i].Key < entry.Fields[j].Key
+		})
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// StreamLength returns the number of entries in a stream
+func (c *Client) StreamLength(key string) (int64, error) {
+	return c.rdb.XLen(c.ctx, key).Result()
+}
+
+// StreamAdd appends an entry with an auto-generated ID and returns the new ID
+func (c *Client) StreamAdd(key string, fields []models.KeyValue) (string, error) {
+	values := make([]interface{}, 0, len(fields)*2)
+	for _, f := range fields {
+		values = append(values, f.Key, f.Value)
+	}
+	return c.rdb.XAdd(c.ctx, &redis.XAddArgs{Stream: key, Values: values}).Result()
+}
+
+// StreamDelete removes an entry from a stream
+func (c *Client) StreamDelete(key, id string) error {
+	return c.rdb.XDel(c.ctx, key, id).Err()
+}
+
+// StreamTrim trims a stream to at most maxLen entries, returning how many were removed
+func (c *Client) StreamTrim(key string, maxLen int64) (int64, error) {
+	return c.rdb.XTrimMaxLen(c.ctx, key, maxLen).Result()
+}
+
+// GetStreamGroups returns the consumer groups of a stream via XINFO GROUPS
+func (c *Client) GetStreamGroups(key string) ([]models.StreamGroup, error) {
+	infos, err := c.rdb.XInfoGroups(c.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]models.StreamGroup, 0, len(infos))
+	for _, info := range infos {
+		groups = append(groups, models.StreamGroup{
+			Name:            info.Name,
+			Consumers:       info.Consumers,
+			Pending:         info.Pending,
+			LastDeliveredID: info.LastDeliveredID,
+			Lag:             info.Lag,
+		})
+	}
+	return groups, nil
+}
+
+// GetStreamConsumers returns the consumers of a stream group via XINFO CONSUMERS
+func (c *Client) GetStreamConsumers(key, group string) ([]models.StreamConsumer, error) {
+	infos, err := c.rdb.XInfoConsumers(c.ctx, key, group).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	consumers := make([]models.StreamConsumer, 0, len(infos))
+	for _, info := range infos {
+		consumers = append(consumers, models.StreamConsumer{
+			Name:     info.Name,
+			Pending:  info.Pending,
+			Idle:     info.Idle,
+			Inactive: info.Inactive,
+		})
+	}
+	return consumers, nil
+}
+
+// GetStreamPending returns up to count entries of a group's pending entries
+// list, oldest first, that have been idle for at least minIdle. consumer ""
+// lists the entries of every consumer.
+func (c *Client) GetStreamPending(key, group, consumer string, minIdle time.Duration, count int64) ([]models.StreamPending, error) {
+	infos, err := c.rdb.XPendingExt(c.ctx, &redis.XPendingExtArgs{
+		Stream:   key,
+		Group:    group,
+		Idle:     minIdle,
+		Start:    "-",
+		End:      "+",
+		Count:    count,
+		Consumer: consumer,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]models.StreamPending, 0, len(infos))
+	for _, info := range infos {
+		pending = append(pending, models.StreamPending{
+			ID:         info.ID,
+			Consumer:   info.Consumer,
+			Idle:       info.Idle,
+			Deliveries: info.RetryCount,
+		})
+	}
+	return pending, nil
+}
+
+// StreamAck acknowledges pending entries of a group with XACK, returning how
+// many were still pending
+func (c *Client) StreamAck(key, group string, ids []string) (int64, error) {
+	return c.rdb.XAck(c.ctx, key, group, ids...).Result()
+}
+
+// StreamClaim hands pending entries to consumer with XCLAIM, skipping those
+// idle for less than minIdle (e.g. claimed by someone else meanwhile), and
+// returns the IDs it claimed
+func (c *Client) StreamClaim(key, group, consumer string, minIdle time.Duration, ids []string) ([]string, error) {
+	return c.rdb.XClaimJustID(c.ctx, &redis.XClaimArgs{
+		Stream:   key,
+		Group:    group,
+		Consumer: consumer,
+		MinIdle:  minIdle,
+		Messages: ids,
+	}).Result()
+}
+
+// StreamAutoClaim hands every pending entry of a group idle for at least
+// minIdle to consumer, running XAUTOCLAIM through the whole pending entries
+// list, and returns how many it claimed
+func (c *Client) StreamAutoClaim(key, group, consumer string, minIdle time.Duration) (int, error) {
+	claimed := 0
+	start := "0-0"
+	for {
+		ids, next, err := c.rdb.XAutoClaimJustID(c.ctx, &redis.XAutoClaimArgs{
+			Stream:   key,
+			Group:    group,
+			Consumer: consumer,
+			MinIdle:  minIdle,
+			Start:    start,
+			Count:    100,
+		}).Result()
+		if err != nil {
+			return claimed, err
+		}
+		claimed += len(ids)
+		if next == "0-0" {
+			return claimed, nil
+		}
+		start = next
+	}
+}
+
+// Raw command execution
+
+// unsupportedCommands hold the connection open for pushed replies and can't be run as one-shot commands
+var unsupportedCommands = map[string]bool{
+	"MONITOR":    true,
+	"SUBSCRIBE":  true,
+	"PSUBSCRIBE": true,
+	"SSUBSCRIBE": true,
+}
+
+// Do runs an arbitrary command and returns the raw go-redis reply
+func (c *Client) Do(args ...interface{}) (interface{}, error) {
+	return c.rdb.Do(c.ctx, args...).Result()
+}
+
+// Execute runs an arbitrary command, e.g. from the console, and returns its typed reply.
+// Errors returned by the server become ReplyError replies; err is only set when the
+// command could not be run at all (connection failure, cancellation, unsupported command).
+func (c *Client) Execute(args []string) (models.CommandReply, error) {
+	if len(args) == 0 {
+		return models.CommandReply{}, fmt.Errorf("no command given")
+	}
+	name := strings.ToUpper(args[0])
+	if unsupportedCommands[name] {
+		return models.CommandReply{}, fmt.Errorf("%s is not supported in the console", name)
+	}
+
+	cmdArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		cmdArgs[i] = arg
+	}
+
+	// The pool's connections must all speak the protocol go-redis was set up for
+	if name == "HELLO" && len(args) > 1 && args[1] != strconv.Itoa(c.protocol()) {
+		return models.CommandReply{}, fmt.Errorf("HELLO %s would switch one pooled connection to another protocol; change the connection's protocol instead", args[1])
+	}
+
+	val, err := c.Do(cmdArgs...)
+	if err == redis.Nil {
+		return models.CommandReply{Kind: models.ReplyNil}, nil
+	}
+	if err != nil {
+		var redisErr redis.Error
+		if errors.As(err, &redisErr) {
+			return models.CommandReply{Kind: models.ReplyError, Text: redisErr.Error()}, nil
+		}
+		return models.CommandReply{}, err
+	}
+	reply := toReply(val)
+	if reply.Kind == models.ReplyString && isVerbatim(args) {
+		reply.Kind = models.ReplyVerbatim
+	}
+	return reply, nil
+}
+
+// toReply converts a raw go-redis reply into a typed CommandReply
+func toReply(val interface{}) models.CommandReply {
+	switch v := val.(type) {
+	case nil:
+		return models.CommandReply{Kind: models.ReplyNil}
+	case string:
+		return models.CommandReply{Kind: models.ReplyString, Text: v}
+	case int64:
+		return models.CommandReply{Kind: models.ReplyInteger, Integer: v}
+	case float64:
+		return models.CommandReply{Kind: models.ReplyFloat, Text: strconv.FormatFloat(v, 'g', -1, 64)}
+	case bool:
+		return models.CommandReply{Kind: models.ReplyBool, Text: strconv.FormatBool(v)}
+	case *big.Int:
+		return models.CommandReply{Kind: models.ReplyBigNumber, Text: v.String()}
+	case error:
+		return models.CommandReply{Kind: models.ReplyError, Text: v.Error()}
+	case []interface{}:
+		items := make([]models.CommandReply, len(v))
+		for i, item := range v {
+			items[i] = toReply(item)
+		}
+		return models.CommandReply{Kind: models.ReplyArray, Items: items}
+	case map[interface{}]interface{}:
+		items := make([]models.CommandReply, 0, len(v)*2)
+		for key, item := range v {
+			items = append(items, toReply(key), toReply(item))
+		}
+		return models.CommandReply{Kind: models.ReplyMap, Items: items}
+	default:
+		return models.CommandReply{Kind: models.ReplyString, Text: fmt.Sprint(v)}
+	}
+}
+
+// ParseCommandLine splits a console line into arguments the way redis-cli does:
+// whitespace separates arguments, and double or single quotes group them.
+// Double-quoted arguments support \n, \r, \t, \" and \xHH escapes.
+func ParseCommandLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case ch == '"' || ch == '\'':
+			quote := ch
+			closed := false
+			for i++; i < len(line); i++ {
+				ch = line[i]
+				if ch == quote {
+					closed = true
+					break
+				}
+				if ch == '\\' && quote == '"' && i+1 < len(line) {
+					i++
+					switch line[i] {
+					case 'n':
+						current.WriteByte('\n')
+					case 'r':
+						current.WriteByte('\r')
+					case 't':
+						current.WriteByte('\t')
+					case 'x':
+						if i+2 < len(line) {
+							if b, err := strconv.ParseUint(line[i+1:i+3], 16, 8); err == nil {
+								current.WriteByte(byte(b))
+								i += 2
+								continue
+							}
+						}
+						current.WriteByte('x')
+					default:
+						current.WriteByte(line[i])
+					}
+					continue
+				}
+				if ch == '\\' && quote == '\'' && i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					current.WriteByte('\'')
+					continue
+				}
+				current.WriteByte(ch)
+			}
+			if !closed {
+				return nil, fmt.Errorf("unbalanced quotes in command")
+			}
+			inArg = true
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteByte(ch)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// QuoteArg writes an argument so that ParseCommandLine, redis-cli and the
+// server's inline command parser all read it back unchanged: plain arguments
+// as they are, anything else double-quoted with escapes
+func QuoteArg(arg string) string {
+	plain := arg != ""
+	for i := 0; i < len(arg) && plain; i++ {
+		ch := arg[i]
+		plain = ch > ' ' && ch < 0x7f && ch != '"' && ch != '\'' && ch != '\\'
+	}
+	if plain {
+		return arg
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(arg); i++ {
+		switch ch := arg[i]; {
+		case ch == '"' || ch == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(ch)
+		case ch == '\n':
+			b.WriteString(`\n`)
+		case ch == '\r':
+			b.WriteString(`\r`)
+		case ch == '\t':
+			b.WriteString(`\t`)
+		case ch < ' ' || ch >= 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, ch)
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// Monitoring
+
+// monitorBuffer is how many MONITOR lines may queue up before the reader waits
+const monitorBuffer = 1024
+
+// Monitor runs MONITOR on a dedicated connection and calls onEntry for every
+// command the server reports, until the client's context is cancelled.
+// onEntry is called from the monitoring goroutine.
+func (c *Client) Monitor(onEntry func(models.MonitorEntry)) error {
+	// MONITOR takes over its connection, so it must not share the pool
+	opts := *c.rdb.Options()
+	opts.PoolSize = 1
+	opts.MinIdleConns = 0
+	opts.MaxIdleConns = 0
+	mon := redis.NewClient(&opts)
+	defer mon.Close()
+
+	lines := make(chan string, monitorBuffer)
+	cmd := mon.Monitor(c.ctx, lines)
+	if err := cmd.Err(); err != nil {
+		return fmt.Errorf("failed to start MONITOR: %w", err)
+	}
+	cmd.Start()
+	defer func() {
+		cmd.Stop()
+		// The reader may be blocked handing over one last line; let it finish
+		go func() {
+			for {
+				select {
+				case <-lines:
+				case <-time.After(5 * time.Second):
+					return
+				}
+			}
+		}()
+	}()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		case line := <-lines:
+			if entry, ok := ParseMonitorLine(line); ok {
+				onEntry(entry)
+			}
+		}
+	}
+}
+
+// ParseMonitorLine parses a MONITOR line such as
+// 1339518083.107412 [0 127.0.0.1:60866] "set" "key" "value"
+func ParseMonitorLine(line string) (models.MonitorEntry, bool) {
+	var entry models.MonitorEntry
 
-// GetSortedSet returns all members with scores in a sorted set
-func (c *Client) GetSortedSet(key string) ([]models.ScoredValue, error) {
-	result, err := c.rdb.ZRangeWithScores(c.ctx, key, 0, -1).Result()
-	if err != nil {
-		return nil, err
+	stamp, rest, ok := strings.Cut(line, " [")
+	if !ok {
+		return entry, false
+	}
+	source, command, ok := strings.Cut(rest, "] ")
+	if !ok {
+		return entry, false
 	}
 
-	var values []models.ScoredValue
-	for _, z := range result {
-		values = append(values, models.ScoredValue{
-			Score:  z.Score,
-			Member: z.Member.(string),
-		})
+	seconds, err := strconv.ParseFloat(stamp, 64)
+	if err != nil {
+		return entry, false
 	}
-	return values, nil
-}
+	entry.Time = time.Unix(0, int64(seconds*float64(time.Second)))
 
-// SortedSetAdd adds a member with score to a sorted set
-func (c *Client) SortedSetAdd(key string, score float64, member string) error {
-	return c.rdb.ZAdd(c.ctx, key, redis.Z{Score: score, Member: member}).Err()
-}
+	db, client, _ := strings.Cut(source, " ")
+	entry.DB, _ = strconv.Atoi(db)
+	entry.Client = client
 
-// SortedSetRemove removes a member from a sorted set
-func (c *Client) SortedSetRemove(key, member string) error {
-	return c.rdb.ZRem(c.ctx, key, member).Err()
+	args, err := ParseCommandLine(command)
+	if err != nil || len(args) == 0 {
+		return entry, false
+	}
+	entry.Command = strings.ToUpper(args[0])
+	entry.Args = args[1:]
+	return entry, true
 }
 
 // Server information
@@ -284,7 +2173,7 @@ func (c *Client) GetServerInfo() (*models.ServerInfo, error) {
 		return nil, err
 	}
 
-	serverInfo := &models.ServerInfo{}
+	serverInfo := &models.ServerInfo{Keyspace: make(map[int]int64)}
 	lines := strings.Split(info, "\n")
 
 	for _, line := range lines {
@@ -323,6 +2212,15 @@ func (c *Client) GetServerInfo() (*models.ServerInfo, error) {
 			serverInfo.KeyspaceHits, _ = strconv.ParseInt(value, 10, 64)
 		case "keyspace_misses":
 			serverInfo.KeyspaceMisses, _ = strconv.ParseInt(value, 10, 64)
+		case "instantaneous_ops_per_sec":
+			serverInfo.OpsPerSec, _ = strconv.ParseInt(value, 10, 64)
+		default:
+			if parsePersistence(key, value, &serverInfo.Persistence) {
+				continue
+			}
+			if db, keys, ok := parseKeyspace(key, value); ok {
+				serverInfo.Keyspace[db] = keys
+			}
 		}
 	}
 
@@ -335,6 +2233,83 @@ func (c *Client) GetServerInfo() (*models.ServerInfo, error) {
 	return serverInfo, nil
 }
 
+// UsedMemory returns the server's used_memory, reading just INFO memory
+func (c *Client) UsedMemory() (int64, error) {
+	info, err := c.rdb.Info(c.ctx, "memory").Result()
+	if err != nil {
+		return 0, err
+	}
+	for _, line := range strings.Split(info, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), "used_memory:"); ok {
+			return strconv.ParseInt(value, 10, 64)
+		}
+	}
+	return 0, fmt.Errorf("INFO memory has no used_memory")
+}
+
+// InfoSections returns the complete INFO reply split into its sections.
+// "INFO everything" adds module sections, but servers before 6.2 only know
+// "all", which is tried when it is refused.
+func (c *Client) InfoSections() ([]models.InfoSection, error) {
+	info, err := c.rdb.Info(c.ctx, "everything").Result()
+	if err != nil {
+		if info, err = c.rdb.Info(c.ctx, "all").Result(); err != nil {
+			return nil, err
+		}
+	}
+
+	var sections []models.InfoSection
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if name, ok := strings.CutPrefix(line, "#"); ok {
+			sections = append(sections, models.InfoSection{Name: strings.TrimSpace(name)})
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || len(sections) == 0 {
+			continue
+		}
+		last := &sections[len(sections)-1]
+		last.Fields = append(last.Fields, models.KeyValue{Key: key, Value: value})
+	}
+	return sections, nil
+}
+
+// CommandStats returns the per-command counters of INFO commandstats, whose
+// lines read "cmdstat_get:calls=10,usec=25,usec_per_call=2.50,..."
+func (c *Client) CommandStats() ([]models.CommandStat, error) {
+	info, err := c.rdb.Info(c.ctx, "commandstats").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	var stats []models.CommandStat
+	for _, line := range strings.Split(info, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		name, isStat := strings.CutPrefix(key, "cmdstat_")
+		if !ok || !isStat {
+			continue
+		}
+		stat := models.CommandStat{Name: name}
+		for _, field := range strings.Split(value, ",") {
+			k, v, _ := strings.Cut(field, "=")
+			n, _ := strconv.ParseInt(v, 10, 64)
+			switch k {
+			case "calls":
+				stat.Calls = n
+			case "usec":
+				stat.Usec = n
+			case "rejected_calls":
+				stat.Rejected = n
+			case "failed_calls":
+				stat.Failed = n
+			}
+		}
+		stats = append(stats, stat)
+	}
+	return stats, nil
+}
+
 // GetDatabaseCount returns the number of databases
 func (c *Client) GetDatabaseCount() int {
 	// Try to get from server config
@@ -355,7 +2330,557 @@ func (c *Client) FlushDB() error {
 	return c.rdb.FlushDB(c.ctx).Err()
 }
 
+// FlushAll removes every key from every database on the server
+func (c *Client) FlushAll() error {
+	return c.rdb.FlushAll(c.ctx).Err()
+}
+
+// KeyspaceSizes returns the number of keys in each non-empty database from the
+// Keyspace section of INFO, e.g. "db0:keys=12,expires=3,avg_ttl=0"
+func (c *Client) KeyspaceSizes() (map[int]int64, error) {
+	info, err := c.rdb.Info(c.ctx, "keyspace").Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make(map[int]int64)
+	for _, line := range strings.Split(info, "\n") {
+		name, fields, _ := strings.Cut(strings.TrimSpace(line), ":")
+		if db, keys, ok := parseKeyspace(name, fields); ok {
+			sizes[db] = keys
+		}
+	}
+	return sizes, nil
+}
+
+// parseKeyspace reads the database number and key count from a Keyspace line
+// split at its colon, e.g. "db0" and "keys=12,expires=3,avg_ttl=0"
+func parseKeyspace(name, fields string) (db int, keys int64, ok bool) {
+	number, found := strings.CutPrefix(name, "db")
+	if !found {
+		return 0, 0, false
+	}
+	db, err := strconv.Atoi(number)
+	if err != nil {
+		return 0, 0, false
+	}
+	for _, field := range strings.Split(fields, ",") {
+		if value, found := strings.CutPrefix(field, "keys="); found {
+			keys, err = strconv.ParseInt(value, 10, 64)
+			return db, keys, err == nil
+		}
+	}
+	return 0, 0, false
+}
+
 // GetKeyCount returns the number of keys in the current database
 func (c *Client) GetKeyCount() (int64, error) {
 	return c.rdb.DBSize(c.ctx).Result()
 }
+
+// EstimateScan works out what reading every key of the database costs at the
+// scan throttle's count, with perKey commands sent for each key. A pattern
+// doesn't lower the SCAN calls, only the keys perKey applies to.
+func (c *Client) EstimateScan(perKey int) (models.ScanCost, error) {
+	keys, err := c.rdb.DBSize(c.ctx).Result()
+	if err != nil {
+		return models.ScanCost{}, err
+	}
+	count := c.throttle.Count
+	if count <= 0 {
+		count = 10 // the server's default
+	}
+	cost := models.ScanCost{Keys: keys, Scans: (keys + count - 1) / count}
+	cost.Commands = cost.Scans + keys*int64(perKey)
+	return cost, nil
+}
+
+// PreviewImpact scans keys matching the pattern and summarizes what deleting them
+// would remove. At most maxScan keys are inspected; beyond that the totals are
+// extrapolated from the scanned portion and the preview is marked as estimated.
+func (c *Client) PreviewImpact(pattern string, maxScan int, sampleSize int) (*models.ImpactPreview, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	preview := &models.ImpactPreview{
+		Pattern: pattern,
+		ByType:  make(map[string]int64),
+	}
+
+	var cursor uint64
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if len(result) > 0 {
+			if err := c.addToPreview(preview, result, sampleSize); err != nil {
+				return nil, err
+			}
+			preview.Scanned += int64(len(result))
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+		if maxScan > 0 && preview.Scanned >= int64(maxScan) {
+			preview.Estimated = true
+			break
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, err
+		}
+	}
+
+	preview.TotalKeys = preview.Scanned
+	if !preview.Estimated {
+		return preview, nil
+	}
+
+	// Only a full-keyspace pattern has a cheap exact total; otherwise report what was seen
+	if pattern != "*" {
+		return preview, nil
+	}
+	dbSize, err := c.rdb.DBSize(c.ctx).Result()
+	if err != nil || dbSize <= preview.Scanned || preview.Scanned == 0 {
+		return preview, nil
+	}
+	ratio := float64(dbSize) / float64(preview.Scanned)
+	for t, n := range preview.ByType {
+		preview.ByType[t] = int64(float64(n) * ratio)
+	}
+	preview.ApproxMemory = int64(float64(preview.ApproxMemory) * ratio)
+	preview.TotalKeys = dbSize
+
+	return preview, nil
+}
+
+// PreviewKeys summarizes what deleting the given keys would remove, as
+// PreviewImpact does for a pattern. Keys that no longer exist are left out.
+func (c *Client) PreviewKeys(keys []string, sampleSize int) (*models.ImpactPreview, error) {
+	preview := &models.ImpactPreview{ByType: make(map[string]int64)}
+	for start := 0; start < len(keys); start += batchSize {
+		end := min(start+batchSize, len(keys))
+		if err := c.addToPreview(preview, keys[start:end], sampleSize); err != nil {
+			return nil, err
+		}
+	}
+	delete(preview.ByType, "none")
+	for _, n := range preview.ByType {
+		preview.TotalKeys += n
+	}
+	preview.Scanned = int64(len(keys))
+	return preview, nil
+}
+
+// addToPreview adds the types and memory of keys to preview in one pipelined
+// round trip, listing them as samples until it holds sampleSize
+func (c *Client) addToPreview(preview *models.ImpactPreview, keys []string, sampleSize int) error {
+	if err := c.acquireLookup(); err != nil {
+		return err
+	}
+	pipe := c.rdb.Pipeline()
+	typeCmds := make([]*redis.StatusCmd, len(keys))
+	memCmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		typeCmds[i] = pipe.Type(c.ctx, key)
+		memCmds[i] = pipe.MemoryUsage(c.ctx, key)
+	}
+	// Individual command errors (e.g. MEMORY USAGE on old servers) are checked per key below
+	pipe.Exec(c.ctx)
+	c.releaseLookup()
+	if err := c.ctx.Err(); err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		keyType, err := typeCmds[i].Result()
+		if err != nil {
+			keyType = "unknown"
+		}
+		preview.ByType[keyType]++
+		if keyType == "none" {
+			continue
+		}
+		if mem, err := memCmds[i].Result(); err == nil {
+			preview.ApproxMemory += mem
+		}
+		if len(preview.SampleKeys) < sampleSize {
+			preview.SampleKeys = append(preview.SampleKeys, key)
+		}
+	}
+	return nil
+}
+
+// Memory analysis
+
+// MemoryUsage returns the approximate bytes a key and its value use (MEMORY USAGE)
+func (c *Client) MemoryUsage(key string) (int64, error) {
+	return c.rdb.MemoryUsage(c.ctx, key).Result()
+}
+
+// KeyDetails reads a key's OBJECT ENCODING, REFCOUNT, IDLETIME and FREQ, and its
+// serialized length from DEBUG OBJECT where the server allows it. None of them
+// count as an access to the key.
+func (c *Client) KeyDetails(key string) (models.KeyDetails, error) {
+	details := models.KeyDetails{IdleSeconds: -1, Frequency: -1, SerializedLength: -1}
+
+	pipe := c.rdb.Pipeline()
+	encoding := pipe.ObjectEncoding(c.ctx, key)
+	refCount := pipe.ObjectRefCount(c.ctx, key)
+	idle := pipe.ObjectIdleTime(c.ctx, key)
+	freq := pipe.ObjectFreq(c.ctx, key)
+	// Either IDLETIME or FREQ fails, depending on the maxmemory-policy
+	_, _ = pipe.Exec(c.ctx)
+	if err := encoding.Err(); err != nil {
+		if err == redis.Nil {
+			return details, fmt.Errorf("key '%s' does not exist", key)
+		}
+		return details, err
+	}
+	details.Encoding = encoding.Val()
+	details.RefCount = refCount.Val()
+	if idle.Err() == nil {
+		details.IdleSeconds = int64(idle.Val().Seconds())
+	}
+	if freq.Err() == nil {
+		details.Frequency = freq.Val()
+	}
+
+	// DEBUG is disabled by default since Redis 7 and refused on read-only
+	// connections; the reply reads "Value at:0x... refcount:1 encoding:... serializedlength:5 ..."
+	if info, err := c.rdb.DebugObject(c.ctx, key).Result(); err == nil {
+		for _, field := range strings.Fields(info) {
+			if n, ok := strings.CutPrefix(field, "serializedlength:"); ok {
+				if length, err := strconv.ParseInt(n, 10, 64); err == nil {
+					details.SerializedLength = length
+				}
+			}
+		}
+	}
+	return details, nil
+}
+
+// MemoryUsages returns MEMORY USAGE for each key, pipelined in batches. Keys that
+// no longer exist or can't be measured are left out of the result.
+func (c *Client) MemoryUsages(keys []string) (map[string]int64, error) {
+	if !c.Supports(FeatureMemoryUsage) {
+		return nil, c.unsupported(FeatureMemoryUsage)
+	}
+	usage := make(map[string]int64, len(keys))
+	for start := 0; start < len(keys); start += batchSize {
+		end := min(start+batchSize, len(keys))
+		if err := c.measureBatch(keys[start:end], usage); err != nil {
+			return nil, err
+		}
+	}
+	return usage, nil
+}
+
+// measureBatch adds MEMORY USAGE of each key to usage in one pipelined round trip
+func (c *Client) measureBatch(keys []string, usage map[string]int64) error {
+	if err := c.acquireLookup(); err != nil {
+		return err
+	}
+	defer c.releaseLookup()
+
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = pipe.MemoryUsage(c.ctx, key)
+	}
+	// Per-key failures (e.g. a key that just expired) are checked below
+	if _, err := pipe.Exec(c.ctx); err != nil && c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+	for i, key := range keys {
+		if mem, err := cmds[i].Result(); err == nil {
+			usage[key] = mem
+		}
+	}
+	return nil
+}
+
+// DefaultDelimiter is the key namespace delimiter used when none is detected
+const DefaultDelimiter = ":"
+
+// CommonDelimiters are the namespace delimiters DetectDelimiter chooses from,
+// preferred in this order when they are equally common
+var CommonDelimiters = []string{":", "/", ".", "|", "#"}
+
+// delimiterSample is how many keys DetectDelimiter looks at
+const delimiterSample = 1000
+
+// DetectDelimiter guesses the namespace delimiter of keys: the common delimiter
+// found between two non-empty segments in the most keys, or DefaultDelimiter
+func DetectDelimiter(keys []models.RedisKey) string {
+	if len(keys) > delimiterSample {
+		keys = keys[:delimiterSample]
+	}
+	best, bestCount := DefaultDelimiter, 0
+	for _, delimiter := range CommonDelimiters {
+		count := 0
+		for _, key := range keys {
+			if i := strings.Index(key.Key, delimiter); i > 0 && i < len(key.Key)-len(delimiter) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = delimiter, count
+		}
+	}
+	return best
+}
+
+// PrefixName returns the first depth delimiter-separated segments of key, with
+// a trailing delimiter when the key is longer, e.g. "user:42:" for depth 2
+func PrefixName(key, delimiter string, depth int) string {
+	if delimiter == "" || depth <= 0 {
+		return key
+	}
+	pos := 0
+	for i := 0; i < depth; i++ {
+		next := strings.Index(key[pos:], delimiter)
+		if next < 0 {
+			return key
+		}
+		pos += next + len(delimiter)
+	}
+	return key[:pos]
+}
+
+// AnalyzeMemory scans the keys matching pattern and sums their MEMORY USAGE by
+// prefix (see PrefixName), largest first
+func (c *Client) AnalyzeMemory(pattern, delimiter string, depth int) (*models.MemoryReport, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	report := &models.MemoryReport{Pattern: pattern}
+	byPrefix := make(map[string]*models.PrefixMemory)
+	var cursor uint64
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		usage, err := c.MemoryUsages(result)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range result {
+			mem, ok := usage[key]
+			if !ok {
+				continue
+			}
+			prefix := PrefixName(key, delimiter, depth)
+			entry := byPrefix[prefix]
+			if entry == nil {
+				entry = &models.PrefixMemory{Prefix: prefix}
+				byPrefix[prefix] = entry
+			}
+			entry.Keys++
+			entry.Bytes += mem
+			report.TotalBytes += mem
+		}
+		report.Scanned += int64(len(result))
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range byPrefix {
+		report.Prefixes = append(report.Prefixes, *entry)
+	}
+	sort.Slice(report.Prefixes, func(i, j int) bool {
+		if report.Prefixes[i].Bytes != report.Prefixes[j].Bytes {
+			return report.Prefixes[i].Bytes > report.Prefixes[j].Bytes
+		}
+		return report.Prefixes[i].Prefix < report.Prefixes[j].Prefix
+	})
+	return report, nil
+}
+
+// AnalyzeKeyspace scans the keys matching pattern and reports counts by type, TTL
+// bucket and top-level prefix, plus the top largest keys by length and by memory.
+// progress, if set, is called with the number of keys scanned after each page.
+func (c *Client) AnalyzeKeyspace(pattern, delimiter string, top int, progress func(scanned int64)) (*models.KeyspaceReport, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+
+	report := &models.KeyspaceReport{
+		Pattern: pattern,
+		ByType:  make(map[string]int64),
+		ByTTL:   make(map[string]int64),
+	}
+	byPrefix := make(map[string]*models.PrefixMemory)
+	var cursor uint64
+	for {
+		result, nextCursor, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if err := c.ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for start := 0; start < len(result); start += batchSize {
+			end := min(start+batchSize, len(result))
+			sizes, ttls, err := c.sizeBatch(result[start:end])
+			if err != nil {
+				return nil, err
+			}
+			for i, size := range sizes {
+				if size.Type == "none" {
+					// Deleted or expired since it was scanned
+					continue
+				}
+				report.ByType[size.Type]++
+				report.ByTTL[ttlBucket(ttls[i])]++
+				report.TotalBytes += size.Memory
+
+				prefix := PrefixName(size.Key, delimiter, 1)
+				entry := byPrefix[prefix]
+				if entry == nil {
+					entry = &models.PrefixMemory{Prefix: prefix}
+					byPrefix[prefix] = entry
+				}
+				entry.Keys++
+				entry.Bytes += size.Memory
+
+				report.LargestByLength = append(report.LargestByLength, size)
+				report.LargestByMemory = append(report.LargestByMemory, size)
+			}
+			report.LargestByLength = largestKeys(report.LargestByLength, top, func(k models.KeySize) int64 { return k.Length })
+			report.LargestByMemory = largestKeys(report.LargestByMemory, top, func(k models.KeySize) int64 { return k.Memory })
+		}
+		report.Scanned += int64(len(result))
+		if progress != nil {
+			progress(report.Scanned)
+		}
+
+		cursor = nextCursor
+		if cursor == 0 {
+			break
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range byPrefix {
+		report.ByPrefix = append(report.ByPrefix, *entry)
+	}
+	sort.Slice(report.ByPrefix, func(i, j int) bool {
+		if report.ByPrefix[i].Keys != report.ByPrefix[j].Keys {
+			return report.ByPrefix[i].Keys > report.ByPrefix[j].Keys
+		}
+		return report.ByPrefix[i].Prefix < report.ByPrefix[j].Prefix
+	})
+	if len(report.ByPrefix) > top {
+		report.ByPrefix = report.ByPrefix[:top]
+	}
+	return report, nil
+}
+
+// sizeBatch fetches TYPE, TTL, MEMORY USAGE and the length of each key in two
+// pipelined round trips: one for the type, one for the type's length command
+func (c *Client) sizeBatch(keys []string) ([]models.KeySize, []time.Duration, error) {
+	if err := c.acquireLookup(); err != nil {
+		return nil, nil, err
+	}
+	defer c.releaseLookup()
+
+	pipe := c.rdb.Pipeline()
+	typeCmds := make([]*redis.StatusCmd, len(keys))
+	ttlCmds := make([]*redis.DurationCmd, len(keys))
+	memCmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		typeCmds[i] = pipe.Type(c.ctx, key)
+		ttlCmds[i] = pipe.TTL(c.ctx, key)
+		memCmds[i] = pipe.MemoryUsage(c.ctx, key)
+	}
+	// Per-key failures (e.g. MEMORY USAGE denied by ACLs) are checked below
+	if _, err := pipe.Exec(c.ctx); err != nil && c.ctx.Err() != nil {
+		return nil, nil, c.ctx.Err()
+	}
+
+	sizes := make([]models.KeySize, len(keys))
+	ttls := make([]time.Duration, len(keys))
+	lenPipe := c.rdb.Pipeline()
+	lenCmds := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		keyType, err := typeCmds[i].Result()
+		if err != nil {
+			keyType = "unknown"
+		}
+		sizes[i] = models.KeySize{Key: key, Type: keyType}
+		sizes[i].Memory, _ = memCmds[i].Result()
+		ttls[i], _ = ttlCmds[i].Result()
+
+		switch keyType {
+		case "string":
+			lenCmds[i] = lenPipe.StrLen(c.ctx, key)
+		case "list":
+			lenCmds[i] = lenPipe.LLen(c.ctx, key)
+		case "set":
+			lenCmds[i] = lenPipe.SCard(c.ctx, key)
+		case "hash":
+			lenCmds[i] = lenPipe.HLen(c.ctx, key)
+		case "zset":
+			lenCmds[i] = lenPipe.ZCard(c.ctx, key)
+		case "stream":
+			lenCmds[i] = lenPipe.XLen(c.ctx, key)
+		}
+	}
+	if lenPipe.Len() > 0 {
+		if _, err := lenPipe.Exec(c.ctx); err != nil && c.ctx.Err() != nil {
+			return nil, nil, c.ctx.Err()
+		}
+	}
+	for i, cmd := range lenCmds {
+		if cmd != nil {
+			sizes[i].Length, _ = cmd.Result()
+		}
+	}
+	return sizes, ttls, nil
+}
+
+// ttlBucket sorts a TTL reply into one of the KeyspaceReport buckets
+func ttlBucket(ttl time.Duration) string {
+	switch {
+	case ttl < 0:
+		return models.TTLNone
+	case ttl < time.Hour:
+		return models.TTLHour
+	case ttl < 24*time.Hour:
+		return models.TTLDay
+	}
+	return models.TTLLonger
+}
+
+// largestKeys keeps the n keys with the largest size, largest first
+func largestKeys(keys []models.KeySize, n int, size func(models.KeySize) int64) []models.KeySize {
+	sort.SliceStable(keys, func(i, j int) bool { return size(keys[i]) > size(keys[j]) })
+	if len(keys) > n {
+		keys = keys[:n]
+	}
+	return keys
+}
@@ -0,0 +1,244 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+// ClusterSlots is how many hash slots a Redis Cluster splits its keys over
+const ClusterSlots = 16384
+
+// clusterMap is which master serves each hash slot, read from CLUSTER SLOTS on
+// connecting. A connection talks to one node, whose keys are the only ones SCAN
+// sees; the map tells where the others are.
+type clusterMap struct {
+	nodes  []models.ClusterNode
+	owners [ClusterSlots]int16 // index into nodes, -1 while a slot is unassigned
+	self   string              // ID of the master whose keys this node holds
+}
+
+// loadClusterMap reads the slot map and which master the connected node is, or
+// replicates
+func (c *Client) loadClusterMap() (*clusterMap, error) {
+	slots, err := c.rdb.ClusterSlots(c.ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("CLUSTER SLOTS failed: %w", err)
+	}
+	myID, err := c.rdb.ClusterMyID(c.ctx).Result()
+	if err != nil {
+		return nil, fmt.Errorf("CLUSTER MYID failed: %w", err)
+	}
+
+	m := &clusterMap{}
+	index := map[string]int{}
+	for _, slot := range slots {
+		if len(slot.Nodes) == 0 {
+			continue
+		}
+		master := slot.Nodes[0]
+		i, ok := index[master.ID]
+		if !ok {
+			i = len(m.nodes)
+			index[master.ID] = i
+			m.nodes = append(m.nodes, models.ClusterNode{ID: master.ID, Addr: master.Addr})
+		}
+		node := &m.nodes[i]
+		node.Slots = append(node.Slots, [2]int{slot.Start, slot.End})
+		for _, replica := range slot.Nodes[1:] {
+			if !slices.Contains(node.Replicas, replica.ID) {
+				node.Replicas = append(node.Replicas, replica.ID)
+			}
+			if replica.ID == myID {
+				m.self = master.ID
+			}
+		}
+		if master.ID == myID {
+			m.self = master.ID
+		}
+	}
+	slices.SortFunc(m.nodes, func(a, b models.ClusterNode) int { return a.Slots[0][0] - b.Slots[0][0] })
+	for i := range m.owners {
+		m.owners[i] = -1
+	}
+	for i, node := range m.nodes {
+		for _, r := range node.Slots {
+			for s := max(r[0], 0); s <= min(r[1], ClusterSlots-1); s++ {
+				m.owners[s] = int16(i)
+			}
+		}
+	}
+	return m, nil
+}
+
+// IsCluster reports whether the server is a cluster node whose slot map could be read
+func (c *Client) IsCluster() bool {
+	return c.cluster != nil
+}
+
+// ClusterNodes are the masters of the cluster in slot order, nil outside one
+func (c *Client) ClusterNodes() []models.ClusterNode {
+	if c.cluster == nil {
+		return nil
+	}
+	return c.cluster.nodes
+}
+
+// ClusterNodeID is the ID of the master whose keys the connected node holds:
+// itself, or the master it replicates
+func (c *Client) ClusterNodeID() string {
+	if c.cluster == nil {
+		return ""
+	}
+	return c.cluster.self
+}
+
+// KeyNode is the address of the master serving key's slot, empty outside a
+// cluster or while the slot is unassigned
+func (c *Client) KeyNode(key string) string {
+	if c.cluster == nil {
+		return ""
+	}
+	if i := c.cluster.owners[KeySlot(key)]; i >= 0 {
+		return c.cluster.nodes[i].Addr
+	}
+	return ""
+}
+
+// NodeConnection is the connection's settings pointed at another master of the
+// cluster, to browse the keys it holds
+func (c *Client) NodeConnection(id string) (models.ServerConnection, error) {
+	conn := *c.connection
+	for _, node := range c.ClusterNodes() {
+		if node.ID != id {
+			continue
+		}
+		host, port, err := net.SplitHostPort(node.Addr)
+		if err != nil {
+			return conn, fmt.Errorf("node %s has no usable address '%s': %w", id, node.Addr, err)
+		}
+		// A node that doesn't know its own address announces only its port
+		if host != "" {
+			conn.Host = host
+		}
+		conn.Port, _ = strconv.Atoi(port)
+		conn.Database = 0
+		return conn, nil
+	}
+	return conn, fmt.Errorf("node %s is not a master of this cluster", id)
+}
+
+// CrossSlot returns an error naming two of the keys that hash to different
+// slots, which a cluster refuses to use in one command, or nil when they share
+// a slot or the server isn't a cluster
+func (c *Client) CrossSlot(keys ...string) error {
+	if c.cluster == nil || len(keys) < 2 {
+		return nil
+	}
+	first := KeySlot(keys[0])
+	for _, key := range keys[1:] {
+		if slot := KeySlot(key); slot != first {
+			return fmt.Errorf("'%s' (slot %d) and '%s' (slot %d) are in different hash slots, which a cluster can't use in one command; put the same {hash tag} in their names to keep them together",
+				keys[0], first, key, slot)
+		}
+	}
+	return nil
+}
+
+// bySlot groups keys by hash slot, keeping their order, so each group can go
+// in one multi-key command on a cluster. Outside one all keys are a group.
+func (c *Client) bySlot(keys []string) [][]string {
+	if c.cluster == nil {
+		return [][]string{keys}
+	}
+	index := map[int]int{}
+	var groups [][]string
+	for _, key := range keys {
+		slot := KeySlot(key)
+		i, ok := index[slot]
+		if !ok {
+			i = len(groups)
+			index[slot] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], key)
+	}
+	return groups
+}
+
+// KeySlot is the hash slot of key, as CLUSTER KEYSLOT computes it: the CRC16
+// of the key, or of the part inside its first non-empty {hash tag}, modulo 16384
+func KeySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % ClusterSlots)
+}
+
+// crc16 is the CRC-16/XMODEM checksum Redis Cluster hashes keys with
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for range 8 {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// movedHook explains the MOVED and ASK redirects a cluster node answers for keys
+// it doesn't hold. The connection stays on its node rather than following them.
+type movedHook struct{}
+
+func (movedHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (movedHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		err := next(ctx, cmd)
+		if explained := explainMoved(err); explained != err {
+			cmd.SetErr(explained)
+			return explained
+		}
+		return err
+	}
+}
+
+func (movedHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		err := next(ctx, cmds)
+		for _, cmd := range cmds {
+			if explained := explainMoved(cmd.Err()); explained != cmd.Err() {
+				cmd.SetErr(explained)
+			}
+		}
+		return explainMoved(err)
+	}
+}
+
+// explainMoved turns a "MOVED <slot> <addr>" or "ASK <slot> <addr>" error into
+// one saying which node to browse instead, leaving other errors as they are
+func explainMoved(err error) error {
+	if err == nil {
+		return nil
+	}
+	fields := strings.Fields(err.Error())
+	if len(fields) != 3 || fields[0] != "MOVED" && fields[0] != "ASK" {
+		return err
+	}
+	return fmt.Errorf("slot %s is served by node %s, not the one connected; choose that node in the key browser to work with its keys (%w)", fields[1], fields[2], err)
+}
@@ -0,0 +1,44 @@
+package redis
+
+import "testing"
+
+func TestKeySlot(t *testing.T) {
+	tests := []struct {
+		key  string
+		slot int
+	}{
+		// Test vectors from the Redis Cluster specification
+		{"123456789", 12739},
+		{"foo", 12182},
+		// The hash tag alone is hashed
+		{"{user1000}.following", KeySlot("user1000")},
+		{"{user1000}.followers", KeySlot("user1000")},
+		{"foo{bar}{zap}", KeySlot("bar")},
+		// An empty tag hashes the whole key
+		{"foo{}{bar}", int(crc16("foo{}{bar}") % ClusterSlots)},
+		{"{}", int(crc16("{}") % ClusterSlots)},
+		// An unclosed tag too
+		{"foo{bar", int(crc16("foo{bar") % ClusterSlots)},
+	}
+	for _, tt := range tests {
+		if got := KeySlot(tt.key); got != tt.slot {
+			t.Errorf("KeySlot(%q) = %d, want %d", tt.key, got, tt.slot)
+		}
+	}
+}
+
+func TestKeySlotSameTag(t *testing.T) {
+	if a, b := KeySlot("{user1000}.following"), KeySlot("{user1000}.followers"); a != b {
+		t.Errorf("keys with the tag {user1000} map to slots %d and %d", a, b)
+	}
+	if KeySlot("foo{}{bar}") == KeySlot("bar") {
+		t.Error("foo{}{bar} hashed its second tag instead of the whole key")
+	}
+}
+
+func TestCRC16(t *testing.T) {
+	// The check value of CRC-16/XMODEM
+	if got := crc16("123456789"); got != 0x31c3 {
+		t.Errorf("crc16(\"123456789\") = %#x, want 0x31c3", got)
+	}
+}
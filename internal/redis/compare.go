@@ -0,0 +1,164 @@
+package redis
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+// compareTTLSlack is how far the TTLs of two copies of a key may drift apart,
+// from being read a moment apart, and still count as the same
+const compareTTLSlack = 2 * time.Second
+
+// Compare scans this client's database and other's for the keys matching
+// pattern and reports the keys only in one of them, and the keys in both
+// whose type, value or TTL differ. progress, if set, is called with the keys
+// compared after each batch.
+func (c *Client) Compare(other *Client, pattern string, progress func(done, total int)) (*models.CompareReport, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	namesA, err := c.MatchingKeys(pattern)
+	if err != nil {
+		return nil, err
+	}
+	namesB, err := other.MatchingKeys(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.CompareReport{Pattern: pattern}
+	inB := make(map[string]bool, len(namesB))
+	for _, name := range namesB {
+		inB[name] = true
+	}
+	var both []string
+	for _, name := range namesA {
+		if inB[name] {
+			both = append(both, name)
+			delete(inB, name)
+		} else {
+			report.OnlyA = append(report.OnlyA, name)
+		}
+	}
+	for name := range inB {
+		report.OnlyB = append(report.OnlyB, name)
+	}
+	slices.Sort(report.OnlyA)
+	slices.Sort(report.OnlyB)
+
+	for start := 0; start < len(both); start += batchSize {
+		end := min(start+batchSize, len(both))
+		if err := c.compareBatch(other, both[start:end], report); err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(end, len(both))
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return nil, err
+		}
+	}
+	slices.SortFunc(report.Differing, func(a, b models.KeyDifference) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+	return report, nil
+}
+
+// keyState is a key's type, TTL and DUMP payload, read in one pipeline
+type keyState struct {
+	typ     *redis.StatusCmd
+	pttl    *redis.DurationCmd
+	payload *redis.StringCmd
+}
+
+// readStates reads the state of each key in one pipelined round trip
+func (c *Client) readStates(keys []string) ([]keyState, error) {
+	states := make([]keyState, len(keys))
+	_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			states[i] = keyState{pipe.Type(c.ctx, key), pipe.PTTL(c.ctx, key), pipe.Dump(c.ctx, key)}
+		}
+		return nil
+	})
+	// DUMP of a key that expired in between replies nil, which reads as gone
+	if err != nil && err != redis.Nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+// compareBatch compares keys found in both databases and adds those that
+// differ to report
+func (c *Client) compareBatch(other *Client, keys []string, report *models.CompareReport) error {
+	statesA, err := c.readStates(keys)
+	if err != nil {
+		return err
+	}
+	statesB, err := other.readStates(keys)
+	if err != nil {
+		return err
+	}
+
+	for i, key := range keys {
+		a, b := statesA[i], statesB[i]
+		diff := models.KeyDifference{
+			Key:   key,
+			TypeA: a.typ.Val(),
+			TypeB: b.typ.Val(),
+			TTLA:  ttlSeconds(a.pttl.Val()),
+			TTLB:  ttlSeconds(b.pttl.Val()),
+		}
+		diff.TTL = ttlDiffers(a.pttl.Val(), b.pttl.Val())
+		if diff.TypeA != diff.TypeB {
+			diff.Value = true
+		} else if a.payload.Val() != b.payload.Val() {
+			// The same value can be encoded differently, e.g. by servers with
+			// other listpack limits, so differing payloads are read and compared
+			diff.Value, err = c.valueDiffers(other, key)
+			if err != nil {
+				return err
+			}
+		}
+		if diff.Value || diff.TTL {
+			report.Differing = append(report.Differing, diff)
+		} else {
+			report.Same++
+		}
+	}
+	return nil
+}
+
+// valueDiffers reads a key from both databases and compares the values. A key
+// that can't be read, e.g. of a module type or gone since the scan, counts as
+// differing.
+func (c *Client) valueDiffers(other *Client, key string) (bool, error) {
+	a, errA := c.DumpKey(key)
+	b, errB := other.DumpKey(key)
+	if errA != nil || errB != nil {
+		if err := c.ctx.Err(); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if a.Type == "set" {
+		// SMEMBERS replies in no particular order
+		slices.Sort(a.Items)
+		slices.Sort(b.Items)
+	}
+	a.TTL, b.TTL = 0, 0
+	return !reflect.DeepEqual(a, b), nil
+}
+
+// ttlDiffers reports whether two PTTL replies differ by more than
+// compareTTLSlack, or only one of the keys expires
+func ttlDiffers(a, b time.Duration) bool {
+	if (a > 0) != (b > 0) {
+		return true
+	}
+	return a > 0 && (a-b > compareTTLSlack || b-a > compareTTLSlack)
+}
@@ -0,0 +1,208 @@
+package redis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+// ErrChangedSinceRead is returned by ConvertKey when the key no longer holds
+// the value its conversion was previewed from
+var ErrChangedSinceRead = errors.New("the key changed since the conversion was previewed; convert it again to see the new value")
+
+// convertTargets lists the types each key type can be converted to
+var convertTargets = map[string][]string{
+	"string": {"hash", "list", "set"},
+	"list":   {"set", "string"},
+	"set":    {"list", "string"},
+	"hash":   {"string"},
+	"zset":   {"list", "set", "hash", "string"},
+}
+
+// ConvertTargets returns the types a key of keyType can be converted to, none
+// when it can't be converted
+func ConvertTargets(keyType string) []string {
+	return convertTargets[keyType]
+}
+
+// ConvertDump converts a key's value to another type, keeping its key and TTL:
+//   - a string holding a JSON object becomes a hash of its top-level keys, and
+//     one holding a JSON array a list or set of its elements; JSON strings are
+//     stored unquoted and other JSON values as their JSON text
+//   - a list becomes a set of its distinct elements, and a set a list of its
+//     members in sorted order
+//   - a sorted set becomes a list or set of its members in score order, or a
+//     hash of each member's score
+//   - a list or set becomes a string holding a JSON array, a hash one holding a
+//     JSON object, and a sorted set one holding an object of member scores
+func ConvertDump(dump *models.KeyDump, target string) (*models.KeyDump, error) {
+	if !slices.Contains(convertTargets[dump.Type], target) {
+		return nil, fmt.Errorf("a %s can't be converted to a %s", dump.Type, target)
+	}
+	out := &models.KeyDump{Key: dump.Key, Type: target, TTL: dump.TTL}
+	var err error
+	switch dump.Type {
+	case "string":
+		err = convertJSONString(dump.Value, out)
+	case "list", "set", "zset":
+		items := dump.Items
+		if dump.Type == "set" {
+			items = slices.Sorted(slices.Values(items))
+		}
+		if dump.Type == "zset" {
+			items = make([]string, len(dump.Members))
+			for i, m := range dump.Members {
+				items[i] = m.Member
+			}
+		}
+		switch target {
+		case "list":
+			out.Items = items
+		case "set":
+			out.Items = distinct(items)
+		case "hash":
+			out.Fields = make(map[string]string, len(dump.Members))
+			for _, m := range dump.Members {
+				out.Fields[m.Member] = strconv.FormatFloat(m.Score, 'f', -1, 64)
+			}
+		case "string":
+			var contents any = items
+			if dump.Type == "zset" {
+				scores := make(map[string]float64, len(dump.Members))
+				for _, m := range dump.Members {
+					scores[m.Member] = m.Score
+				}
+				contents = scores
+			}
+			out.Value, err = marshalValue(contents)
+		}
+	case "hash":
+		out.Value, err = marshalValue(dump.Fields)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if target != "string" && len(out.Items) == 0 && len(out.Fields) == 0 {
+		return nil, fmt.Errorf("the %s would be empty, and Redis doesn't keep empty collections", target)
+	}
+	return out, nil
+}
+
+// convertJSONString fills out, a hash, list or set, from a string holding a
+// JSON object or array
+func convertJSONString(value string, out *models.KeyDump) error {
+	if out.Type == "hash" {
+		var object map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(value), &object); err != nil {
+			return fmt.Errorf("the string isn't a JSON object, so it has no fields for a hash")
+		}
+		out.Fields = make(map[string]string, len(object))
+		for name, raw := range object {
+			out.Fields[name] = jsonElement(raw)
+		}
+		return nil
+	}
+	var array []json.RawMessage
+	if err := json.Unmarshal([]byte(value), &array); err != nil {
+		return fmt.Errorf("the string isn't a JSON array, so it has no elements for a %s", out.Type)
+	}
+	for _, raw := range array {
+		out.Items = append(out.Items, jsonElement(raw))
+	}
+	if out.Type == "set" {
+		out.Items = distinct(out.Items)
+	}
+	return nil
+}
+
+// jsonElement is the text stored for a JSON value taken out of an object or
+// array: a string unquoted, anything else as its JSON
+func jsonElement(raw json.RawMessage) string {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		return text
+	}
+	return string(raw)
+}
+
+// distinct returns items without repeats, in the order each first appears
+func distinct(items []string) []string {
+	seen := make(map[string]bool, len(items))
+	var unique []string
+	for _, item := range items {
+		if !seen[item] {
+			seen[item] = true
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+func marshalValue(contents any) (string, error) {
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ConvertKey writes the conversion of a key's value to target at dest, which
+// replaces the key itself when it is the key's name. The key is read again
+// under WATCH and the write fails with ErrChangedSinceRead unless its value
+// is still preview's, the value the conversion was previewed from, so a
+// change made meanwhile isn't lost. Unless replace is set, an existing dest
+// other than the key fails the conversion.
+func (c *Client) ConvertKey(preview *models.KeyDump, target, dest string, replace bool) error {
+	key := preview.Key
+	err := c.rdb.Watch(c.ctx, func(tx *redis.Tx) error {
+		current, err := c.DumpKey(key)
+		if err != nil {
+			return err
+		}
+		if !sameValue(current, preview) {
+			return ErrChangedSinceRead
+		}
+		converted, err := ConvertDump(current, target)
+		if err != nil {
+			return err
+		}
+		converted.Key = dest
+		if dest != key && !replace {
+			if exists, err := c.KeyExists(dest); err != nil {
+				return err
+			} else if exists {
+				return fmt.Errorf("key '%s' already exists", dest)
+			}
+		}
+		_, err = tx.TxPipelined(c.ctx, func(pipe redis.Pipeliner) error {
+			pipe.Del(c.ctx, dest)
+			return c.queueDump(pipe, converted)
+		})
+		return err
+	}, key, dest)
+	if errors.Is(err, redis.TxFailedErr) {
+		return ErrChangedSinceRead
+	}
+	return err
+}
+
+// sameValue reports whether two dumps of a key of a type ConvertDump takes hold
+// the same value, whatever their TTLs and the order SMEMBERS returned a set's
+// members in
+func sameValue(a, b *models.KeyDump) bool {
+	if a.Type != b.Type || a.Value != b.Value || !maps.Equal(a.Fields, b.Fields) {
+		return false
+	}
+	itemsA, itemsB := a.Items, b.Items
+	if a.Type == "set" {
+		itemsA = slices.Sorted(slices.Values(itemsA))
+		itemsB = slices.Sorted(slices.Values(itemsB))
+	}
+	return slices.Equal(itemsA, itemsB) && slices.Equal(a.Members, b.Members)
+}
@@ -0,0 +1,113 @@
+package redis
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// expiredChannel is the keyevent channel of expired keys in every database
+const expiredChannel = "__keyevent@*__:expired"
+
+// WatchExpired calls onExpired with each key matching pattern that expires in
+// the client's current database, until the client's context is done. The
+// server must publish expired key events, which notify-keyspace-events "Ex"
+// turns on; this is checked when the server lets CONFIG GET be run.
+func (c *Client) WatchExpired(pattern string, onExpired func(key string)) error {
+	match, err := globRegexp(pattern)
+	if err != nil {
+		return err
+	}
+	if values, err := c.rdb.ConfigGet(c.ctx, "notify-keyspace-events").Result(); err == nil {
+		flags := values["notify-keyspace-events"]
+		if !strings.Contains(flags, "E") || !strings.ContainsAny(flags, "xA") {
+			return fmt.Errorf("the server doesn't publish expired key events; CONFIG SET notify-keyspace-events Ex turns them on")
+		}
+	}
+
+	pubsub := c.rdb.PSubscribe(c.ctx, expiredChannel)
+	defer pubsub.Close()
+	// Wait for the subscription, so a refused one fails here
+	if _, err := pubsub.Receive(c.ctx); err != nil {
+		return err
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			if eventDatabase(msg.Channel) == c.connection.Database && match.MatchString(msg.Payload) {
+				onExpired(msg.Payload)
+			}
+		case <-c.ctx.Done():
+			return c.ctx.Err()
+		}
+	}
+}
+
+// eventDatabase is the database of a __keyevent@<db>__ channel, -1 if the
+// channel has none
+func eventDatabase(channel string) int {
+	rest, ok := strings.CutPrefix(channel, "__keyevent@")
+	if !ok {
+		return -1
+	}
+	db, _, ok := strings.Cut(rest, "__:")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.Atoi(db)
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// globRegexp compiles a Redis glob pattern, matching every key when empty,
+// into the equivalent regular expression
+func globRegexp(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = "*"
+	}
+	var b strings.Builder
+	b.WriteString("(?s)^")
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		case '\\':
+			if i+1 < len(pattern) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if negated, ok := strings.CutPrefix(class, "^"); ok {
+				class = "^" + strings.ReplaceAll(negated, `\`, `\\`)
+			} else {
+				class = strings.ReplaceAll(class, `\`, `\\`)
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+	return re, nil
+}
@@ -0,0 +1,129 @@
+package redis
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+const (
+	// GeneratePlaceholder is replaced by each key's number in a generate pattern
+	GeneratePlaceholder = "{n}"
+	// generateBatchBytes is about how much value data one pipelined round trip sends
+	generateBatchBytes = 1 << 20
+	// generateBatchKeys caps the keys written per round trip, however small
+	generateBatchKeys = 1000
+	// generateChunk is how many elements one command adds to a collection
+	generateChunk = 1000
+)
+
+// generateAlphabet is what random values are made of
+const generateAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// GeneratedKey is the name of key number n of a generate pattern
+func GeneratedKey(pattern string, n int) string {
+	return strings.ReplaceAll(pattern, GeneratePlaceholder, strconv.Itoa(n))
+}
+
+// Generate writes the test keys req describes in pipelined batches, replacing
+// any keys of the same names, and returns how many were written. progress, if
+// set, is called with the keys done after each batch.
+func (c *Client) Generate(req models.GenerateRequest, progress func(done int)) (int, error) {
+	if !strings.Contains(req.Pattern, GeneratePlaceholder) {
+		return 0, fmt.Errorf("the pattern needs %s for the key number, or every key would have the same name", GeneratePlaceholder)
+	}
+	elements := 1
+	if req.Type != "string" {
+		elements = max(req.Elements, 1)
+	}
+	batch := min(max(generateBatchBytes/max(elements*req.ValueSize, 1), 1), generateBatchKeys)
+	ttl := time.Duration(req.TTL) * time.Second
+
+	for done := 0; done < req.Count; {
+		if err := c.ctx.Err(); err != nil {
+			return done, err
+		}
+		end := min(done+batch, req.Count)
+		_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+			for i := done; i < end; i++ {
+				n := req.Start + i
+				key := GeneratedKey(req.Pattern, n)
+				if req.Type == "string" {
+					pipe.Set(c.ctx, key, generateValue(req, strconv.Itoa(n)), ttl)
+					continue
+				}
+				pipe.Del(c.ctx, key)
+				c.queueElements(pipe, req, key, n, elements)
+				if ttl > 0 {
+					pipe.Expire(c.ctx, key, ttl)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return done, err
+		}
+		done = end
+		if progress != nil {
+			progress(done)
+		}
+	}
+	return req.Count, nil
+}
+
+// queueElements adds the elements of collection key number n, a chunk per command
+func (c *Client) queueElements(pipe redis.Pipeliner, req models.GenerateRequest, key string, n, elements int) {
+	for from := 0; from < elements; from += generateChunk {
+		to := min(from+generateChunk, elements)
+		switch req.Type {
+		case "list", "set":
+			values := make([]any, 0, to-from)
+			for j := from; j < to; j++ {
+				values = append(values, generateValue(req, fmt.Sprintf("%d-%d", n, j)))
+			}
+			if req.Type == "list" {
+				pipe.RPush(c.ctx, key, values...)
+			} else {
+				pipe.SAdd(c.ctx, key, values...)
+			}
+		case "hash":
+			pairs := make([]any, 0, 2*(to-from))
+			for j := from; j < to; j++ {
+				pairs = append(pairs, "field:"+strconv.Itoa(j), generateValue(req, fmt.Sprintf("%d-%d", n, j)))
+			}
+			pipe.HSet(c.ctx, key, pairs...)
+		case "zset":
+			members := make([]redis.Z, 0, to-from)
+			for j := from; j < to; j++ {
+				score := float64(j)
+				if req.Random {
+					score = rand.Float64() * float64(elements)
+				}
+				members = append(members, redis.Z{Score: score, Member: generateValue(req, fmt.Sprintf("%d-%d", n, j))})
+			}
+			pipe.ZAdd(c.ctx, key, members...)
+		}
+	}
+}
+
+// generateValue is a value of req.ValueSize bytes: random letters and digits,
+// or seq padded with leading zeros. A seq longer than the size is kept whole,
+// so sequential set members and sorted set members stay distinct.
+func generateValue(req models.GenerateRequest, seq string) string {
+	if !req.Random {
+		if len(seq) >= req.ValueSize {
+			return seq
+		}
+		return strings.Repeat("0", req.ValueSize-len(seq)) + seq
+	}
+	b := make([]byte, req.ValueSize)
+	for i := range b {
+		b[i] = generateAlphabet[rand.IntN(len(generateAlphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,98 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"redis-explorer/internal/models"
+)
+
+// LatencyLatest returns the latest spike of every event the latency monitor
+// has recorded (LATENCY LATEST)
+func (c *Client) LatencyLatest() ([]models.LatencyEvent, error) {
+	reply, err := c.rdb.Do(c.ctx, "LATENCY", "LATEST").Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	// Each event reads [name, unix time, latest ms, max ms]
+	events := make([]models.LatencyEvent, 0, len(reply))
+	for _, item := range reply {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) < 4 {
+			continue
+		}
+		events = append(events, models.LatencyEvent{
+			Name:   fmt.Sprint(fields[0]),
+			Time:   time.Unix(replyInt(fields[1]), 0),
+			Latest: time.Duration(replyInt(fields[2])) * time.Millisecond,
+			Max:    time.Duration(replyInt(fields[3])) * time.Millisecond,
+		})
+	}
+	return events, nil
+}
+
+// LatencyHistory returns the recorded spikes of an event, oldest first
+// (LATENCY HISTORY keeps the last 160)
+func (c *Client) LatencyHistory(event string) ([]models.LatencySample, error) {
+	reply, err := c.rdb.Do(c.ctx, "LATENCY", "HISTORY", event).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	samples := make([]models.LatencySample, 0, len(reply))
+	for _, item := range reply {
+		fields, ok := item.([]interface{})
+		if !ok || len(fields) < 2 {
+			continue
+		}
+		samples = append(samples, models.LatencySample{
+			Time:    time.Unix(replyInt(fields[0]), 0),
+			Latency: time.Duration(replyInt(fields[1])) * time.Millisecond,
+		})
+	}
+	return samples, nil
+}
+
+// LatencyDoctor returns the latency monitor's human-readable analysis
+func (c *Client) LatencyDoctor() (string, error) {
+	return c.rdb.Do(c.ctx, "LATENCY", "DOCTOR").Text()
+}
+
+// LatencyThreshold returns latency-monitor-threshold: events at least this
+// long are recorded, and 0 means the monitor is off
+func (c *Client) LatencyThreshold() (time.Duration, error) {
+	values, err := c.rdb.ConfigGet(c.ctx, "latency-monitor-threshold").Result()
+	if err != nil {
+		return 0, err
+	}
+	ms, err := strconv.ParseInt(values["latency-monitor-threshold"], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unexpected latency-monitor-threshold %q", values["latency-monitor-threshold"])
+	}
+	return time.Duration(ms) * time.Millisecond, nil
+}
+
+// PingLatency measures the round trip of a PING from the app to the server,
+// network included
+func (c *Client) PingLatency() (time.Duration, error) {
+	start := time.Now()
+	if err := c.rdb.Ping(c.ctx).Err(); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// replyInt reads an integer from a generic reply, which RESP2 and RESP3 may
+// send as an integer or a string
+func replyInt(v interface{}) int64 {
+	switch v := v.(type) {
+	case int64:
+		return v
+	case string:
+		n, _ := strconv.ParseInt(v, 10, 64)
+		return n
+	}
+	return 0
+}
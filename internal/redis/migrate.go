@@ -0,0 +1,207 @@
+package redis
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+// migrateTimeout is how long the source server waits on the destination for
+// each key sent with MIGRATE
+const migrateTimeout = 10 * time.Second
+
+// Migrate copies the keys matching req.Pattern into the database of
+// req.Target, batch by batch, leaving the originals in place. Keys already in
+// the destination are skipped or replaced by req.Policy; a key that fails is
+// recorded in the report and the rest are still copied. progress, if set, is
+// called with the keys done after each batch.
+func (c *Client) Migrate(req models.MigrationRequest, progress func(done, total int)) (*models.MigrationReport, error) {
+	if req.UseMigrate && (req.Target.SSH.Enabled || req.Target.Sentinel.Enabled) {
+		return nil, fmt.Errorf("MIGRATE needs a destination the source server reaches directly, not through an SSH tunnel or Sentinel")
+	}
+
+	keys, err := c.MatchingKeys(req.Pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := c.Dial(req.Target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the destination: %w", err)
+	}
+	defer target.Disconnect()
+
+	report := &models.MigrationReport{}
+	batch := max(req.BatchSize, 1)
+	for start := 0; start < len(keys); start += batch {
+		if start > 0 && req.Pause > 0 {
+			timer := time.NewTimer(req.Pause)
+			select {
+			case <-timer.C:
+			case <-c.ctx.Done():
+				timer.Stop()
+				return report, c.ctx.Err()
+			}
+		}
+
+		end := min(start+batch, len(keys))
+		if req.UseMigrate {
+			err = c.migrateBatch(keys[start:end], target, req, report)
+		} else {
+			err = c.restoreBatch(keys[start:end], target, req, report)
+		}
+		if err != nil {
+			return report, err
+		}
+		if progress != nil {
+			progress(end, len(keys))
+		}
+	}
+	return report, nil
+}
+
+// restoreBatch copies keys through the app with DUMP and RESTORE, in one
+// pipelined round trip to each server. A payload the destination can't read,
+// e.g. from a newer Redis version, is copied by reading and rewriting the value.
+func (c *Client) restoreBatch(keys []string, target *Client, req models.MigrationRequest, report *models.MigrationReport) error {
+	dumps := make([]*redis.StringCmd, len(keys))
+	pttls := make([]*redis.DurationCmd, len(keys))
+	_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			dumps[i] = pipe.Dump(c.ctx, key)
+			pttls[i] = pipe.PTTL(c.ctx, key)
+		}
+		return nil
+	})
+	// Per-key failures, like DUMP of a key that just expired, are checked below
+	if err != nil && c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+
+	replace := req.Policy == models.ImportOverwrite
+	restores := make([]*redis.StatusCmd, len(keys))
+	_, err = target.rdb.Pipelined(target.ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			payload, err := dumps[i].Result()
+			if err != nil {
+				continue
+			}
+			ttl := time.Duration(0) // no expiry
+			if pttl := pttls[i].Val(); req.KeepTTL && pttl > 0 {
+				ttl = pttl
+			}
+			if replace {
+				restores[i] = pipe.RestoreReplace(target.ctx, key, ttl, payload)
+			} else {
+				restores[i] = pipe.Restore(target.ctx, key, ttl, payload)
+			}
+		}
+		return nil
+	})
+	if err != nil && target.ctx.Err() != nil {
+		return target.ctx.Err()
+	}
+
+	for i, key := range keys {
+		if err := dumps[i].Err(); err != nil {
+			if err == redis.Nil {
+				report.Skipped++
+			} else {
+				report.Failures = append(report.Failures, models.MigrationFailure{Key: key, Error: err.Error()})
+			}
+			continue
+		}
+		err := restores[i].Err()
+		if err != nil && !isBusyKey(err) {
+			err = c.rewriteKey(key, target, req)
+		}
+		switch {
+		case err == nil:
+			report.Copied++
+		case isBusyKey(err):
+			report.Skipped++
+		default:
+			report.Failures = append(report.Failures, models.MigrationFailure{Key: key, Error: err.Error()})
+		}
+	}
+	return nil
+}
+
+// rewriteKey copies a key by reading its value and writing it to target
+func (c *Client) rewriteKey(key string, target *Client, req models.MigrationRequest) error {
+	dump, err := c.DumpKey(key)
+	if err != nil {
+		return err
+	}
+	if !req.KeepTTL {
+		dump.TTL = -1
+	}
+	return target.RestoreKey(dump, req.Policy == models.ImportOverwrite)
+}
+
+// migrateBatch has the source server send keys to the destination itself with
+// MIGRATE ... COPY, in one pipelined round trip. MIGRATE always keeps the TTL,
+// so without KeepTTL the copies are made persistent afterwards.
+func (c *Client) migrateBatch(keys []string, target *Client, req models.MigrationRequest, report *models.MigrationReport) error {
+	dst := req.Target
+	args := []any{"MIGRATE", dst.Host, strconv.Itoa(dst.Port), "", dst.Database, migrateTimeout.Milliseconds(), "COPY"}
+	if req.Policy == models.ImportOverwrite {
+		args = append(args, "REPLACE")
+	}
+	switch {
+	case dst.Username != "":
+		args = append(args, "AUTH2", dst.Username, dst.Password)
+	case dst.Password != "":
+		args = append(args, "AUTH", dst.Password)
+	}
+
+	cmds := make([]*redis.Cmd, len(keys))
+	_, err := c.rdb.Pipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			// The key goes where the empty key name is in the single-key form
+			keyArgs := append([]any{}, args...)
+			keyArgs[3] = key
+			cmds[i] = pipe.Do(c.ctx, keyArgs...)
+		}
+		return nil
+	})
+	if err != nil && c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+
+	var copied []string
+	for i, key := range keys {
+		reply, err := cmds[i].Text()
+		switch {
+		case err == nil && reply == "NOKEY":
+			report.Skipped++ // gone from the source
+		case err == nil:
+			copied = append(copied, key)
+		case isBusyKey(err):
+			report.Skipped++
+		default:
+			report.Failures = append(report.Failures, models.MigrationFailure{Key: key, Error: err.Error()})
+		}
+	}
+	report.Copied += len(copied)
+
+	if req.KeepTTL || len(copied) == 0 {
+		return nil
+	}
+	_, err = target.rdb.Pipelined(target.ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range copied {
+			pipe.Persist(target.ctx, key)
+		}
+		return nil
+	})
+	return err
+}
+
+// isBusyKey reports whether RESTORE or MIGRATE refused to replace an existing key
+func isBusyKey(err error) bool {
+	return strings.HasPrefix(err.Error(), "BUSYKEY")
+}
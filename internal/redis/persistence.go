@@ -0,0 +1,81 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"redis-explorer/internal/models"
+)
+
+// BGSave starts a background RDB save on the server. It fails if a save is
+// already running.
+func (c *Client) BGSave() error {
+	return c.rdb.BgSave(c.ctx).Err()
+}
+
+// BGRewriteAOF starts rewriting the append-only file in the background; with
+// AOF off it writes a one-off file. While an RDB save runs, the rewrite is
+// scheduled to follow it.
+func (c *Client) BGRewriteAOF() error {
+	return c.rdb.BgRewriteAOF(c.ctx).Err()
+}
+
+// SaveStatus reads the RDB save state from INFO persistence
+func (c *Client) SaveStatus() (models.SaveStatus, error) {
+	status, err := c.PersistenceStatus()
+	return status.SaveStatus, err
+}
+
+// PersistenceStatus reads the RDB and AOF state from INFO persistence
+func (c *Client) PersistenceStatus() (models.PersistenceStatus, error) {
+	info, err := c.rdb.Info(c.ctx, "persistence").Result()
+	if err != nil {
+		return models.PersistenceStatus{}, err
+	}
+
+	var status models.PersistenceStatus
+	for _, line := range strings.Split(info, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if ok {
+			parsePersistence(key, value, &status)
+		}
+	}
+	return status, nil
+}
+
+// parsePersistence reads an INFO persistence field into status, reporting
+// whether it was one
+func parsePersistence(key, value string, status *models.PersistenceStatus) bool {
+	switch key {
+	case "rdb_bgsave_in_progress":
+		status.InProgress = value == "1"
+	case "rdb_last_save_time":
+		if secs, err := strconv.ParseInt(value, 10, 64); err == nil {
+			status.LastSave = time.Unix(secs, 0)
+		}
+	case "rdb_last_bgsave_status":
+		status.LastOK = value == "ok"
+	case "current_save_keys_processed":
+		status.KeysProcessed, _ = strconv.ParseInt(value, 10, 64)
+	case "current_save_keys_total":
+		status.KeysTotal, _ = strconv.ParseInt(value, 10, 64)
+	case "rdb_changes_since_last_save":
+		status.ChangesSinceSave, _ = strconv.ParseInt(value, 10, 64)
+	case "aof_enabled":
+		status.AOFEnabled = value == "1"
+	case "aof_rewrite_in_progress":
+		status.AOFRewriting = value == "1"
+	case "aof_rewrite_scheduled":
+		status.AOFRewriteScheduled = value == "1"
+	case "aof_last_bgrewrite_status":
+		status.AOFLastRewriteOK = value == "ok"
+	case "aof_last_write_status":
+		status.AOFLastWriteOK = value == "ok"
+	case "aof_current_size":
+		status.AOFSize, _ = strconv.ParseInt(value, 10, 64)
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,132 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrReadOnly is returned for write commands on a read-only connection
+var ErrReadOnly = errors.New("the connection is read-only")
+
+// readCommands are the commands a read-only connection may send. Commands
+// listed with subcommands only allow those; anything unknown is refused.
+var readCommands = map[string][]string{
+	// Connection and server
+	"PING": nil, "ECHO": nil, "AUTH": nil, "HELLO": nil, "SELECT": nil, "QUIT": nil,
+	"RESET": nil, "READONLY": nil, "INFO": nil, "DBSIZE": nil, "TIME": nil,
+	"LASTSAVE": nil, "ROLE": nil, "LOLWUT": nil, "MONITOR": nil, "COMMAND": nil,
+	"CLIENT":   {"LIST", "INFO", "GETNAME", "ID", "SETNAME", "SETINFO", "TRACKINGINFO", "GETREDIR", "MAINT_NOTIFICATIONS"},
+	"CONFIG":   {"GET"},
+	"ACL":      {"LIST", "GETUSER", "WHOAMI", "CAT", "USERS", "LOG", "DRYRUN"},
+	"MEMORY":   {"USAGE", "STATS", "DOCTOR", "MALLOC-STATS"},
+	"LATENCY":  {"LATEST", "HISTORY", "DOCTOR", "GRAPH", "HISTOGRAM"},
+	"SLOWLOG":  {"GET", "LEN"},
+	"MODULE":   {"LIST"},
+	"SCRIPT":   {"EXISTS"},
+	"FUNCTION": {"LIST", "DUMP", "STATS"},
+	"CLUSTER":  {"INFO", "NODES", "SLOTS", "SHARDS", "MYID", "KEYSLOT", "COUNTKEYSINSLOT", "GETKEYSINSLOT"},
+	"OBJECT":   nil, "XINFO": nil, "PUBSUB": nil,
+	"SUBSCRIBE": nil, "PSUBSCRIBE": nil, "SSUBSCRIBE": nil,
+	"UNSUBSCRIBE": nil, "PUNSUBSCRIBE": nil, "SUNSUBSCRIBE": nil,
+	"MULTI": nil, "EXEC": nil, "DISCARD": nil, "WATCH": nil, "UNWATCH": nil,
+
+	// Keys
+	"EXISTS": nil, "TYPE": nil, "TTL": nil, "PTTL": nil, "EXPIRETIME": nil, "PEXPIRETIME": nil,
+	"KEYS": nil, "SCAN": nil, "RANDOMKEY": nil, "DUMP": nil, "TOUCH": nil, "SORT_RO": nil,
+
+	// Strings and bitmaps
+	"GET": nil, "MGET": nil, "STRLEN": nil, "GETRANGE": nil, "SUBSTR": nil, "LCS": nil,
+	"GETBIT": nil, "BITCOUNT": nil, "BITPOS": nil, "BITFIELD_RO": nil,
+
+	// Lists, sets and hashes
+	"LLEN": nil, "LRANGE": nil, "LINDEX": nil, "LPOS": nil,
+	"SCARD": nil, "SMEMBERS": nil, "SISMEMBER": nil, "SMISMEMBER": nil, "SRANDMEMBER": nil,
+	"SSCAN": nil, "SINTER": nil, "SUNION": nil, "SDIFF": nil, "SINTERCARD": nil,
+	"HGET": nil, "HMGET": nil, "HGETALL": nil, "HKEYS": nil, "HVALS": nil, "HLEN": nil,
+	"HEXISTS": nil, "HSTRLEN": nil, "HSCAN": nil, "HRANDFIELD": nil,
+	"HTTL": nil, "HPTTL": nil, "HEXPIRETIME": nil, "HPEXPIRETIME": nil,
+
+	// Sorted sets and geo
+	"ZCARD": nil, "ZSCORE": nil, "ZMSCORE": nil, "ZRANK": nil, "ZREVRANK": nil,
+	"ZRANGE": nil, "ZREVRANGE": nil, "ZRANGEBYSCORE": nil, "ZREVRANGEBYSCORE": nil,
+	"ZRANGEBYLEX": nil, "ZREVRANGEBYLEX": nil, "ZCOUNT": nil, "ZLEXCOUNT": nil,
+	"ZSCAN": nil, "ZRANDMEMBER": nil, "ZINTER": nil, "ZUNION": nil, "ZDIFF": nil, "ZINTERCARD": nil,
+	"GEOPOS": nil, "GEODIST": nil, "GEOHASH": nil, "GEOSEARCH": nil,
+	"GEORADIUS_RO": nil, "GEORADIUSBYMEMBER_RO": nil,
+
+	// Streams and HyperLogLog
+	"XRANGE": nil, "XREVRANGE": nil, "XREAD": nil, "XLEN": nil, "XPENDING": nil, "PFCOUNT": nil,
+
+	// Scripts that declare themselves read-only
+	"EVAL_RO": nil, "EVALSHA_RO": nil, "FCALL_RO": nil,
+
+	// RedisJSON
+	"JSON.GET": nil, "JSON.MGET": nil, "JSON.TYPE": nil, "JSON.STRLEN": nil, "JSON.ARRLEN": nil,
+	"JSON.ARRINDEX": nil, "JSON.OBJKEYS": nil, "JSON.OBJLEN": nil, "JSON.RESP": nil, "JSON.DEBUG": nil,
+}
+
+// checkReadOnly refuses commands that aren't known to only read
+func checkReadOnly(args []interface{}) error {
+	if len(args) == 0 {
+		return nil
+	}
+	name := strings.ToUpper(fmt.Sprint(args[0]))
+	subcommands, ok := readCommands[name]
+	if !ok {
+		return fmt.Errorf("%w: %s may modify data", ErrReadOnly, name)
+	}
+	if subcommands == nil {
+		return nil
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("%w: %s needs a subcommand", ErrReadOnly, name)
+	}
+	sub := strings.ToUpper(fmt.Sprint(args[1]))
+	for _, allowed := range subcommands {
+		if sub == allowed {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s %s may modify data", ErrReadOnly, name, sub)
+}
+
+// readOnlyHook fails write commands before they are sent
+type readOnlyHook struct{}
+
+func (readOnlyHook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+func (readOnlyHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if err := checkReadOnly(cmd.Args()); err != nil {
+			cmd.SetErr(err)
+			return err
+		}
+		return next(ctx, cmd)
+	}
+}
+
+func (readOnlyHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		for _, cmd := range cmds {
+			if err := checkReadOnly(cmd.Args()); err != nil {
+				// Nothing in the pipeline runs if any of it would write
+				for _, c := range cmds {
+					c.SetErr(err)
+				}
+				return err
+			}
+		}
+		return next(ctx, cmds)
+	}
+}
+
+// ReadOnly reports whether the connection refuses write commands
+func (c *Client) ReadOnly() bool {
+	return c.connection.ReadOnly
+}
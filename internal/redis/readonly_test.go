@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestCheckReadOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []interface{}
+		refused bool
+	}{
+		{"get", []interface{}{"GET", "k"}, false},
+		{"scan", []interface{}{"scan", 0, "match", "*"}, false},
+		{"config get", []interface{}{"CONFIG", "GET", "maxmemory"}, false},
+		{"object encoding", []interface{}{"OBJECT", "ENCODING", "k"}, false},
+		{"set", []interface{}{"SET", "k", "v"}, true},
+		{"del", []interface{}{"DEL", "k"}, true},
+		{"flushall", []interface{}{"FLUSHALL"}, true},
+		{"eval", []interface{}{"EVAL", "return 1", 0}, true},
+		{"config set", []interface{}{"config", "set", "maxmemory", "0"}, true},
+		{"config without subcommand", []interface{}{"CONFIG"}, true},
+		{"unknown command", []interface{}{"NOSUCHCOMMAND"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkReadOnly(tt.args)
+			if tt.refused && !errors.Is(err, ErrReadOnly) {
+				t.Errorf("checkReadOnly(%v) = %v, want ErrReadOnly", tt.args, err)
+			}
+			if !tt.refused && err != nil {
+				t.Errorf("checkReadOnly(%v) = %v, want nil", tt.args, err)
+			}
+		})
+	}
+}
+
+func TestReadOnlyHookProcess(t *testing.T) {
+	ctx := context.Background()
+	sent := 0
+	process := readOnlyHook{}.ProcessHook(func(context.Context, redis.Cmder) error {
+		sent++
+		return nil
+	})
+
+	if err := process(ctx, redis.NewStringCmd(ctx, "GET", "k")); err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	set := redis.NewStatusCmd(ctx, "SET", "k", "v")
+	if err := process(ctx, set); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("SET: got %v, want ErrReadOnly", err)
+	}
+	if !errors.Is(set.Err(), ErrReadOnly) {
+		t.Errorf("SET command error = %v, want ErrReadOnly", set.Err())
+	}
+	if sent != 1 {
+		t.Errorf("%d commands sent, want only GET", sent)
+	}
+}
+
+func TestReadOnlyHookPipeline(t *testing.T) {
+	ctx := context.Background()
+	sent := 0
+	process := readOnlyHook{}.ProcessPipelineHook(func(_ context.Context, cmds []redis.Cmder) error {
+		sent += len(cmds)
+		return nil
+	})
+
+	reads := []redis.Cmder{
+		redis.NewStatusCmd(ctx, "TYPE", "k"),
+		redis.NewIntCmd(ctx, "MEMORY", "USAGE", "k"),
+	}
+	if err := process(ctx, reads); err != nil {
+		t.Fatalf("read pipeline: %v", err)
+	}
+	if sent != len(reads) {
+		t.Fatalf("%d commands sent, want %d", sent, len(reads))
+	}
+
+	sent = 0
+	mixed := []redis.Cmder{
+		redis.NewStringCmd(ctx, "GET", "k"),
+		redis.NewIntCmd(ctx, "DEL", "k"),
+	}
+	if err := process(ctx, mixed); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("pipeline with DEL: got %v, want ErrReadOnly", err)
+	}
+	if sent != 0 {
+		t.Errorf("%d commands sent, want none", sent)
+	}
+	for _, cmd := range mixed {
+		if !errors.Is(cmd.Err(), ErrReadOnly) {
+			t.Errorf("%v error = %v, want ErrReadOnly", cmd.Args(), cmd.Err())
+		}
+	}
+}
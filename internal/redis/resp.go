@@ -0,0 +1,115 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9/push"
+	"redis-explorer/internal/models"
+)
+
+// verbatimCommands reply with text meant to be read as it is, sent as a
+// verbatim string over RESP3. go-redis drops the verbatim marker, so they are
+// known by name, as redis-cli does.
+var verbatimCommands = map[string]bool{
+	"INFO":                true,
+	"LOLWUT":              true,
+	"CLIENT INFO":         true,
+	"CLIENT LIST":         true,
+	"CLUSTER INFO":        true,
+	"CLUSTER NODES":       true,
+	"LATENCY DOCTOR":      true,
+	"MEMORY DOCTOR":       true,
+	"MEMORY MALLOC-STATS": true,
+}
+
+// isVerbatim reports whether the command args replies with verbatim text
+func isVerbatim(args []string) bool {
+	name := strings.ToUpper(args[0])
+	if len(args) > 1 && verbatimCommands[name+" "+strings.ToUpper(args[1])] {
+		return true
+	}
+	return verbatimCommands[name]
+}
+
+// pushNames are the RESP3 pushes passed to the SetOnPush handler. Pub/Sub
+// messages are pushes too, but are read by the Pub/Sub connections.
+var pushNames = []string{"invalidate", "tracking-redir-broken"}
+
+// pushRelay passes pushes to the handler set with SetOnPush. It is shared by
+// the copies made through WithContext.
+type pushRelay struct {
+	mu     sync.Mutex
+	onPush func(reply models.CommandReply)
+}
+
+// HandlePushNotification implements push.NotificationHandler
+func (r *pushRelay) HandlePushNotification(_ context.Context, _ push.NotificationHandlerContext, notification []interface{}) error {
+	r.mu.Lock()
+	onPush := r.onPush
+	r.mu.Unlock()
+	if onPush != nil {
+		onPush(models.CommandReply{Kind: models.ReplyPush, Items: toReply(notification).Items})
+	}
+	return nil
+}
+
+// protocol is the RESP version the client asks the server for
+func (c *Client) protocol() int {
+	if c.connection.Protocol == 2 {
+		return 2
+	}
+	return 3
+}
+
+// SetOnPush sets where RESP3 pushes sent outside replies go, such as the key
+// invalidations of CLIENT TRACKING. A push is read with the next reply on its
+// connection, and onPush is called from the goroutine reading it. RESP2
+// connections have no such pushes.
+func (c *Client) SetOnPush(onPush func(reply models.CommandReply)) {
+	if c.pushes == nil {
+		return
+	}
+	c.pushes.mu.Lock()
+	c.pushes.onPush = onPush
+	c.pushes.mu.Unlock()
+}
+
+// Hello reports the server and connection details HELLO returns, asking for
+// the protocol the connection already speaks so it isn't switched. Servers
+// before Redis 6 have no HELLO and only speak RESP2.
+func (c *Client) Hello() (*models.ServerHello, error) {
+	val, err := c.rdb.Do(c.ctx, "HELLO", c.protocol()).Result()
+	if err != nil {
+		return nil, fmt.Errorf("HELLO failed: %w", err)
+	}
+	fields := map[string]models.CommandReply{}
+	items := toReply(val).Items
+	for i := 0; i+1 < len(items); i += 2 {
+		fields[items[i].Text] = items[i+1]
+	}
+
+	hello := &models.ServerHello{
+		Server:   fields["server"].Text,
+		Version:  fields["version"].Text,
+		Protocol: int(fields["proto"].Integer),
+		ID:       fields["id"].Integer,
+		Mode:     fields["mode"].Text,
+		Role:     fields["role"].Text,
+	}
+	for _, module := range fields["modules"].Items {
+		var name, version string
+		for i := 0; i+1 < len(module.Items); i += 2 {
+			switch module.Items[i].Text {
+			case "name":
+				name = module.Items[i+1].Text
+			case "ver":
+				version = fmt.Sprint(module.Items[i+1].Integer)
+			}
+		}
+		hello.Modules = append(hello.Modules, strings.TrimSpace(name+" "+version))
+	}
+	return hello, nil
+}
@@ -0,0 +1,106 @@
+package redis
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+// SampleCollection picks up to count distinct elements of a set, hash, sorted
+// set or list at random without reading the rest of it. Each sample's Key is
+// the hash field, sorted set score or list index of its Value, and empty for a
+// set member.
+func (c *Client) SampleCollection(key, keyType string, count int) ([]models.KeyValue, error) {
+	if (keyType == "hash" || keyType == "zset") && !c.Supports(FeatureRandomField) {
+		return nil, c.unsupported(FeatureRandomField)
+	}
+	var samples []models.KeyValue
+	switch keyType {
+	case "set":
+		// A positive count makes SRANDMEMBER, HRANDFIELD and ZRANDMEMBER pick
+		// distinct elements
+		members, err := c.rdb.SRandMemberN(c.ctx, key, int64(count)).Result()
+		if err != nil {
+			return nil, err
+		}
+		slices.Sort(members)
+		for _, m := range members {
+			samples = append(samples, models.KeyValue{Value: m})
+		}
+	case "hash":
+		fields, err := c.rdb.HRandFieldWithValues(c.ctx, key, count).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			samples = append(samples, models.KeyValue{Key: f.Key, Value: f.Value})
+		}
+		slices.SortFunc(samples, func(a, b models.KeyValue) int { return strings.Compare(a.Key, b.Key) })
+	case "zset":
+		members, err := c.rdb.ZRandMemberWithScores(c.ctx, key, count).Result()
+		if err != nil {
+			return nil, err
+		}
+		slices.SortFunc(members, func(a, b redis.Z) int {
+			return cmp.Or(cmp.Compare(a.Score, b.Score), strings.Compare(a.Member.(string), b.Member.(string)))
+		})
+		for _, m := range members {
+			samples = append(samples, models.KeyValue{
+				Key:   strconv.FormatFloat(m.Score, 'f', -1, 64),
+				Value: m.Member.(string),
+			})
+		}
+	case "list":
+		return c.sampleList(key, count)
+	default:
+		return nil, fmt.Errorf("unsupported collection type: %s", keyType)
+	}
+	return samples, nil
+}
+
+// sampleList reads the elements at count distinct random indexes of a list
+// with LINDEX, in index order
+func (c *Client) sampleList(key string, count int) ([]models.KeyValue, error) {
+	length, err := c.rdb.LLen(c.ctx, key).Result()
+	if err != nil {
+		return nil, err
+	}
+	var indexes []int64
+	if length <= int64(count) {
+		for i := range length {
+			indexes = append(indexes, i)
+		}
+	} else {
+		picked := make(map[int64]bool, count)
+		for len(indexes) < count {
+			if i := rand.Int64N(length); !picked[i] {
+				picked[i] = true
+				indexes = append(indexes, i)
+			}
+		}
+		slices.Sort(indexes)
+	}
+
+	pipe := c.rdb.Pipeline()
+	cmds := make([]*redis.StringCmd, len(indexes))
+	for i, index := range indexes {
+		cmds[i] = pipe.LIndex(c.ctx, key, index)
+	}
+	// An index past the end of a list that shrank since LLEN replies nil
+	if _, err := pipe.Exec(c.ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+	var samples []models.KeyValue
+	for i, cmd := range cmds {
+		if value, err := cmd.Result(); err == nil {
+			samples = append(samples, models.KeyValue{Key: strconv.FormatInt(indexes[i], 10), Value: value})
+		}
+	}
+	return samples, nil
+}
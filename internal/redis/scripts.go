@@ -0,0 +1,71 @@
+package redis
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"strconv"
+
+	"redis-explorer/internal/models"
+)
+
+// ScriptSHA returns the SHA1 digest Redis caches a script under, as SCRIPT LOAD
+// would report it
+func ScriptSHA(body string) string {
+	sum := sha1.Sum([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// EvalScript runs a Lua script with EVAL, or EVAL_RO when readOnly is set, and
+// returns its typed reply the way Execute does
+func (c *Client) EvalScript(body string, keys, args []string, readOnly bool) (models.CommandReply, error) {
+	name := "EVAL"
+	if readOnly {
+		name = "EVAL_RO"
+	}
+	return c.Execute(scriptArgs(name, body, keys, args))
+}
+
+// EvalScriptSHA runs a cached script by its SHA1 with EVALSHA, or EVALSHA_RO
+// when readOnly is set. A script missing from the cache gets a NOSCRIPT error
+// reply.
+func (c *Client) EvalScriptSHA(sha string, keys, args []string, readOnly bool) (models.CommandReply, error) {
+	name := "EVALSHA"
+	if readOnly {
+		name = "EVALSHA_RO"
+	}
+	return c.Execute(scriptArgs(name, sha, keys, args))
+}
+
+func scriptArgs(name, script string, keys, args []string) []string {
+	cmd := make([]string, 0, 3+len(keys)+len(args))
+	cmd = append(cmd, name, script, strconv.Itoa(len(keys)))
+	cmd = append(cmd, keys...)
+	return append(cmd, args...)
+}
+
+// LoadScript adds a script to the server's script cache and returns its SHA1
+func (c *Client) LoadScript(body string) (string, error) {
+	return c.rdb.ScriptLoad(c.ctx, body).Result()
+}
+
+// ScriptsCached reports which of the SHA1s are in the server's script cache.
+// Redis can't list the cache, only check for given scripts.
+func (c *Client) ScriptsCached(shas []string) (map[string]bool, error) {
+	cached := make(map[string]bool, len(shas))
+	if len(shas) == 0 {
+		return cached, nil
+	}
+	exists, err := c.rdb.ScriptExists(c.ctx, shas...).Result()
+	if err != nil {
+		return nil, err
+	}
+	for i, sha := range shas {
+		cached[sha] = i < len(exists) && exists[i]
+	}
+	return cached, nil
+}
+
+// FlushScripts empties the server's script cache
+func (c *Client) FlushScripts() error {
+	return c.rdb.ScriptFlush(c.ctx).Err()
+}
@@ -0,0 +1,222 @@
+package redis
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+	"redis-explorer/internal/models"
+)
+
+const (
+	// searchValueBytes is how much of a string value is searched
+	searchValueBytes = 1 << 20
+	// searchBatch is how many collection elements are read per command
+	searchBatch = 500
+	// searchContext is how many characters of context a snippet keeps on each side
+	searchContext = 40
+)
+
+// SearchValues scans the keys matching pattern for values containing term:
+// string contents (the first MiB), list items, set and sorted set members, and
+// hash fields and values. Each matching key is reported once, with where its
+// first match is, through onMatch; onProgress gets the number of keys searched
+// so far after every SCAN batch. Other types are skipped.
+func (c *Client) SearchValues(pattern, term string, caseSensitive bool, onMatch func(models.ValueMatch), onProgress func(searched int64)) error {
+	if pattern == "" {
+		pattern = "*"
+	}
+	m := newMatcher(term, caseSensitive)
+
+	var searched int64
+	var cursor uint64
+	for {
+		names, next, err := c.rdb.Scan(c.ctx, cursor, pattern, c.throttle.Count).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan keys: %w", err)
+		}
+		keys, err := c.lookupKeys(names)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if err := c.ctx.Err(); err != nil {
+				return err
+			}
+			match, found, err := c.searchKey(key, m)
+			if err != nil {
+				return err
+			}
+			if found {
+				onMatch(match)
+			}
+			searched++
+		}
+		onProgress(searched)
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+		if err := c.pauseBetweenPages(); err != nil {
+			return err
+		}
+	}
+}
+
+// searchKey looks through one key's value for the first match
+func (c *Client) searchKey(key models.RedisKey, m matcher) (models.ValueMatch, bool, error) {
+	result := models.ValueMatch{Key: key}
+	found := func(where, text string) (models.ValueMatch, bool, error) {
+		result.Where, result.Snippet = where, m.snippet(text)
+		return result, true, nil
+	}
+
+	switch key.Type {
+	case "string":
+		value, err := c.rdb.GetRange(c.ctx, key.Key, 0, searchValueBytes-1).Result()
+		if err != nil {
+			return result, false, ignoreGone(err)
+		}
+		if m.match(value) {
+			return found("value", value)
+		}
+
+	case "list":
+		for start := int64(0); ; start += searchBatch {
+			items, err := c.rdb.LRange(c.ctx, key.Key, start, start+searchBatch-1).Result()
+			if err != nil {
+				return result, false, ignoreGone(err)
+			}
+			for i, item := range items {
+				if m.match(item) {
+					return found(fmt.Sprintf("item %d", start+int64(i)), item)
+				}
+			}
+			if len(items) < searchBatch {
+				break
+			}
+		}
+
+	case "set":
+		for cursor := uint64(0); ; {
+			members, next, err := c.rdb.SScan(c.ctx, key.Key, cursor, "", searchBatch).Result()
+			if err != nil {
+				return result, false, ignoreGone(err)
+			}
+			for _, member := range members {
+				if m.match(member) {
+					return found("member", member)
+				}
+			}
+			if cursor = next; cursor == 0 {
+				break
+			}
+		}
+
+	case "zset":
+		for cursor := uint64(0); ; {
+			// Members and scores alternate
+			values, next, err := c.rdb.ZScan(c.ctx, key.Key, cursor, "", searchBatch).Result()
+			if err != nil {
+				return result, false, ignoreGone(err)
+			}
+			for i := 0; i+1 < len(values); i += 2 {
+				if m.match(values[i]) {
+					return found("member (score "+values[i+1]+")", values[i])
+				}
+			}
+			if cursor = next; cursor == 0 {
+				break
+			}
+		}
+
+	case "hash":
+		for cursor := uint64(0); ; {
+			// Fields and values alternate
+			values, next, err := c.rdb.HScan(c.ctx, key.Key, cursor, "", searchBatch).Result()
+			if err != nil {
+				return result, false, ignoreGone(err)
+			}
+			for i := 0; i+1 < len(values); i += 2 {
+				field, value := values[i], values[i+1]
+				if m.match(field) {
+					return found("field name", field)
+				}
+				if m.match(value) {
+					return found(fmt.Sprintf("field '%s'", field), value)
+				}
+			}
+			if cursor = next; cursor == 0 {
+				break
+			}
+		}
+	}
+	return result, false, nil
+}
+
+// ignoreGone treats a key that expired or changed type mid-search as not
+// matching, so one key can't fail the whole search
+func ignoreGone(err error) error {
+	if err == redis.Nil || strings.HasPrefix(err.Error(), "WRONGTYPE") {
+		return nil
+	}
+	return err
+}
+
+// matcher finds a term in text, optionally ignoring case
+type matcher struct {
+	term string
+	fold bool
+}
+
+func newMatcher(term string, caseSensitive bool) matcher {
+	if !caseSensitive {
+		term = strings.ToLower(term)
+	}
+	return matcher{term: term, fold: !caseSensitive}
+}
+
+func (m matcher) index(text string) int {
+	if !m.fold {
+		return strings.Index(text, m.term)
+	}
+	lower := strings.ToLower(text)
+	i := strings.Index(lower, m.term)
+	if i >= 0 && len(lower) != len(text) {
+		// Lowercasing changed byte offsets; the snippet starts at the beginning
+		return 0
+	}
+	return i
+}
+
+func (m matcher) match(text string) bool {
+	return m.index(text) >= 0
+}
+
+// snippet returns the text around the first match on one line, with an
+// ellipsis where it was cut
+func (m matcher) snippet(text string) string {
+	i := max(m.index(text), 0)
+	start := i
+	for n := 0; start > 0 && n < searchContext; n++ {
+		_, size := utf8.DecodeLastRuneInString(text[:start])
+		start -= size
+	}
+	end := i + len(m.term)
+	for n := 0; end < len(text) && n < searchContext; n++ {
+		_, size := utf8.DecodeRuneInString(text[end:])
+		end += size
+	}
+	end = min(end, len(text))
+
+	snippet := strings.Join(strings.Fields(text[start:end]), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
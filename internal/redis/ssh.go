@@ -0,0 +1,139 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"redis-explorer/internal/models"
+)
+
+// DefaultSSHPort is used when a tunnel has no port configured
+const DefaultSSHPort = 22
+
+// sshDialTimeout bounds connecting and authenticating to the bastion host
+const sshDialTimeout = 10 * time.Second
+
+// dialSSH connects and authenticates to the tunnel's bastion host
+func dialSSH(ctx context.Context, tunnel models.SSHTunnel) (*ssh.Client, error) {
+	auth, err := sshAuthMethods(tunnel)
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := sshHostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	port := tunnel.Port
+	if port == 0 {
+		port = DefaultSSHPort
+	}
+	addr := net.JoinHostPort(tunnel.Host, fmt.Sprint(port))
+
+	config := &ssh.ClientConfig{
+		User:            tunnel.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshDialTimeout,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sshDialTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach SSH host %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("SSH handshake with %s failed: %w", addr, err)
+	}
+	conn.SetDeadline(time.Time{})
+
+	return ssh.NewClient(sshConn, chans, reqs), nil
+}
+
+// sshAuthMethods builds the key and/or password authentication for a tunnel
+func sshAuthMethods(tunnel models.SSHTunnel) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if tunnel.KeyFile != "" {
+		signer, err := loadSSHKey(tunnel.KeyFile, tunnel.KeyPassphrase)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	if tunnel.Password != "" {
+		methods = append(methods, ssh.Password(tunnel.Password))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("SSH tunnel needs a key file or a password")
+	}
+	return methods, nil
+}
+
+// loadSSHKey reads and parses a private key file, expanding a leading ~
+func loadSSHKey(path, passphrase string) (ssh.Signer, error) {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SSH key: %w", err)
+	}
+
+	var signer ssh.Signer
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(data)
+	}
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, fmt.Errorf("SSH key %s is encrypted; enter its passphrase", path)
+		}
+		return nil, fmt.Errorf("failed to parse SSH key: %w", err)
+	}
+	return signer, nil
+}
+
+// sshHostKeyCallback verifies host keys against ~/.ssh/known_hosts when it exists.
+// Without a known_hosts file any host key is accepted, like a first ssh connection.
+func sshHostKeyCallback() (ssh.HostKeyCallback, error) {
+	home, err := os.UserHomeDir()
+	if err == nil {
+		path := filepath.Join(home, ".ssh", "known_hosts")
+		if _, statErr := os.Stat(path); statErr == nil {
+			callback, err := knownhosts.New(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", path, err)
+			}
+			return callback, nil
+		}
+	}
+
+	log.Printf("No known_hosts file found; SSH host keys will not be verified")
+	return ssh.InsecureIgnoreHostKey(), nil
+}
@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"redis-explorer/internal/models"
+)
+
+// watchTextBytes is how much of a string value a watch reads
+const watchTextBytes = 256
+
+// ReadWatch polls a watch once. A string key reads as its value, any other key
+// as its length and a missing key as nil; a command runs only if it is known
+// to just read, since it repeats on every poll.
+func (c *Client) ReadWatch(w models.Watch) (models.WatchReading, error) {
+	if w.Command {
+		return c.readWatchCommand(w.Expression)
+	}
+
+	key := w.Expression
+	keyType, err := c.GetKeyType(key)
+	if err != nil {
+		return models.WatchReading{}, err
+	}
+	var length int64
+	switch keyType {
+	case "none":
+		return models.WatchReading{Text: "(nil)"}, nil
+	case "string":
+		value, err := c.rdb.GetRange(c.ctx, key, 0, watchTextBytes-1).Result()
+		if err != nil {
+			return models.WatchReading{}, err
+		}
+		return textReading(value), nil
+	case "stream":
+		length, err = c.StreamLength(key)
+	case JSONType:
+		return models.WatchReading{Text: keyType}, nil
+	default:
+		length, err = c.CollectionLength(key, keyType)
+	}
+	if err != nil {
+		return models.WatchReading{}, err
+	}
+	return models.WatchReading{Text: strconv.FormatInt(length, 10), Value: float64(length), Numeric: true}, nil
+}
+
+// readWatchCommand runs a watch's command and reads its reply
+func (c *Client) readWatchCommand(line string) (models.WatchReading, error) {
+	args, err := ParseCommandLine(line)
+	if err != nil {
+		return models.WatchReading{}, err
+	}
+	if len(args) == 0 {
+		return models.WatchReading{}, fmt.Errorf("no command given")
+	}
+	cmdArgs := make([]interface{}, len(args))
+	for i, arg := range args {
+		cmdArgs[i] = arg
+	}
+	if checkReadOnly(cmdArgs) != nil {
+		return models.WatchReading{}, fmt.Errorf("watches only run commands that just read, and %s may write", strings.ToUpper(args[0]))
+	}
+
+	reply, err := c.Execute(args)
+	if err != nil {
+		return models.WatchReading{}, err
+	}
+	switch reply.Kind {
+	case models.ReplyNil:
+		return models.WatchReading{Text: "(nil)"}, nil
+	case models.ReplyInteger:
+		return models.WatchReading{Text: strconv.FormatInt(reply.Integer, 10), Value: float64(reply.Integer), Numeric: true}, nil
+	case models.ReplyError:
+		return models.WatchReading{}, errors.New(reply.Text)
+	case models.ReplyArray, models.ReplyMap:
+		return models.WatchReading{Text: fmt.Sprintf("(%d items)", len(reply.Items))}, nil
+	}
+	return textReading(reply.Text), nil
+}
+
+// textReading is a text reading, numeric if the text is a number
+func textReading(text string) models.WatchReading {
+	value, err := strconv.ParseFloat(text, 64)
+	return models.WatchReading{Text: text, Value: value, Numeric: err == nil}
+}
@@ -0,0 +1,137 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileStore keeps secrets as an AES-GCM encrypted JSON object. The key is a random
+// file readable only by the user, so the secrets stay out of config.json and
+// anything it is copied into, but are only as safe as the user's config directory.
+type fileStore struct {
+	mu      sync.Mutex
+	keyPath string
+	path    string
+}
+
+func newFileStore(dir string) *fileStore {
+	return &fileStore{
+		keyPath: filepath.Join(dir, "secrets.key"),
+		path:    filepath.Join(dir, "secrets.enc"),
+	}
+}
+
+func (f *fileStore) Get(name string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secrets, err := f.read()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return secret, nil
+}
+
+func (f *fileStore) Set(name, secret string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	secrets[name] = secret
+	return f.write(secrets)
+}
+
+func (f *fileStore) Delete(name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secrets, err := f.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[name]; !ok {
+		return ErrNotFound
+	}
+	delete(secrets, name)
+	return f.write(secrets)
+}
+
+// read decrypts every stored secret; a missing file holds none
+func (f *fileStore) read() (map[string]string, error) {
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := f.cipher()
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("%s is corrupt", f.path)
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt %s: %w", f.path, err)
+	}
+	if err := json.Unmarshal(plain, &secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func (f *fileStore) write(secrets map[string]string) error {
+	plain, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	gcm, err := f.cipher()
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	return os.WriteFile(f.path, gcm.Seal(nonce, nonce, plain, nil), 0600)
+}
+
+// cipher loads the file key, creating it the first time
+func (f *fileStore) cipher() (cipher.AEAD, error) {
+	key, err := os.ReadFile(f.keyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(f.keyPath, key, 0600); err != nil {
+			return nil, err
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("%s is not a valid key file", f.keyPath)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
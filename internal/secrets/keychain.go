@@ -0,0 +1,33 @@
+package secrets
+
+import (
+	"errors"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keychainService is the service name secrets are filed under in the OS credential store
+const keychainService = "redis-explorer"
+
+// keychainStore keeps secrets in the OS credential store
+type keychainStore struct{}
+
+func (keychainStore) Get(name string) (string, error) {
+	secret, err := keyring.Get(keychainService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return "", ErrNotFound
+	}
+	return secret, err
+}
+
+func (keychainStore) Set(name, secret string) error {
+	return keyring.Set(keychainService, name, secret)
+}
+
+func (keychainStore) Delete(name string) error {
+	err := keyring.Delete(keychainService, name)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
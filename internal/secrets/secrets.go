@@ -0,0 +1,104 @@
+// Package secrets keeps connection passwords out of config.json. Each secret is
+// stored under a reference, either in the OS credential store (Keychain, Windows
+// Credential Manager, Secret Service) or in an encrypted file next to the config.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Backends a secret can be stored in
+const (
+	Keychain = "keychain"
+	File     = "file"
+)
+
+// ErrNotFound is returned for a reference with no stored secret
+var ErrNotFound = errors.New("secret not found")
+
+// Store saves secrets by name
+type Store interface {
+	Get(name string) (string, error)
+	Set(name, secret string) error
+	Delete(name string) error
+}
+
+// Stores holds one Store per backend
+type Stores struct {
+	keychain Store
+	file     Store
+	// keychainErr is set once the OS credential store fails, after which
+	// secrets go straight to the file
+	keychainErr error
+}
+
+// NewStores opens the backends, keeping the encrypted file in dir
+func NewStores(dir string) *Stores {
+	return &Stores{
+		keychain: keychainStore{},
+		file:     newFileStore(dir),
+	}
+}
+
+// Set stores secret under name in the preferred backend, falling back to the
+// encrypted file when the OS credential store is unavailable, and returns the
+// reference to read it back with
+func (s *Stores) Set(preferred, name, secret string) (string, error) {
+	if preferred != File && s.keychainErr == nil {
+		err := s.keychain.Set(name, secret)
+		if err == nil {
+			return Keychain + ":" + name, nil
+		}
+		s.keychainErr = err
+	}
+	if err := s.file.Set(name, secret); err != nil {
+		if s.keychainErr != nil && preferred != File {
+			return "", fmt.Errorf("keychain: %v; encrypted file: %w", s.keychainErr, err)
+		}
+		return "", err
+	}
+	return File + ":" + name, nil
+}
+
+// Get reads the secret a reference from Set points to
+func (s *Stores) Get(ref string) (string, error) {
+	store, name, err := s.resolve(ref)
+	if err != nil {
+		return "", err
+	}
+	return store.Get(name)
+}
+
+// Delete removes the secret a reference points to; a missing secret is not an error
+func (s *Stores) Delete(ref string) error {
+	store, name, err := s.resolve(ref)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(name); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+// Backend returns which backend a reference points to
+func Backend(ref string) string {
+	backend, _, _ := strings.Cut(ref, ":")
+	return backend
+}
+
+func (s *Stores) resolve(ref string) (Store, string, error) {
+	backend, name, ok := strings.Cut(ref, ":")
+	if !ok || name == "" {
+		return nil, "", fmt.Errorf("invalid credential reference '%s'", ref)
+	}
+	switch backend {
+	case Keychain:
+		return s.keychain, name, nil
+	case File:
+		return s.file, name, nil
+	}
+	return nil, "", fmt.Errorf("unknown credential store '%s'", backend)
+}
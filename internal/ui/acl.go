@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"context"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// ACLPanel lists the server's ACL users and creates, edits and deletes them
+type ACLPanel struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	worker    *Worker
+	window    fyne.Window
+
+	users    []models.ACLUser
+	selected int
+
+	statusLabel *widget.Label
+	userList    *widget.List
+	nameLabel   *widget.Label
+	rulesLabel  *widget.Label
+	detailsGrid *fyne.Container
+	newBtn      *widget.Button
+	newTip      *tipLayer
+	editBtn     *widget.Button
+	deleteBtn   *widget.Button
+	toggleBtn   *widget.Button
+}
+
+// NewACLPanel creates a new ACL management panel
+func NewACLPanel(window fyne.Window, worker *Worker) *ACLPanel {
+	p := &ACLPanel{
+		window:   window,
+		worker:   worker,
+		selected: -1,
+	}
+	p.ExtendBaseWidget(p)
+	p.buildUI()
+	return p
+}
+
+func (p *ACLPanel) buildUI() {
+	p.statusLabel = widget.NewLabel("")
+
+	p.userList = widget.NewList(
+		func() int { return len(p.users) },
+		func() fyne.CanvasObject {
+			return container.NewBorder(nil, nil, nil, widget.NewLabel("off"), widget.NewLabel("user"))
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			row := o.(*fyne.Container)
+			user := p.users[id]
+			row.Objects[0].(*widget.Label).SetText(user.Name)
+			state := "on"
+			if !user.Enabled {
+				state = "off"
+			}
+			row.Objects[1].(*widget.Label).SetText(state)
+		},
+	)
+	p.userList.OnSelected = func(id widget.ListItemID) {
+		p.selected = id
+		p.showUser()
+	}
+
+	p.nameLabel = widget.NewLabelWithStyle(i18n.T("Select a user"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	p.rulesLabel = widget.NewLabel("")
+	p.rulesLabel.Wrapping = fyne.TextWrapWord
+	p.rulesLabel.TextStyle = fyne.TextStyle{Monospace: true}
+	p.detailsGrid = container.NewGridWithColumns(2)
+
+	refreshBtn := widget.NewButtonWithIcon(i18n.T("Refresh"), theme.ViewRefreshIcon(), p.Refresh)
+	p.newBtn = widget.NewButtonWithIcon(i18n.T("New User"), theme.ContentAddIcon(), func() {
+		if p.client == nil {
+			return
+		}
+		ShowACLUserDialog(p.window, "", "on >password ~* &* +@all", p.setUser)
+	})
+	p.editBtn = widget.NewButtonWithIcon(i18n.T("Edit Rules"), theme.DocumentCreateIcon(), func() {
+		if user := p.selectedUser(); user != nil {
+			ShowACLUserDialog(p.window, user.Name, user.Rules, p.setUser)
+		}
+	})
+	p.toggleBtn = widget.NewButtonWithIcon(i18n.T("Disable"), theme.CancelIcon(), func() {
+		if user := p.selectedUser(); user != nil {
+			rule := "off"
+			if !user.Enabled {
+				rule = "on"
+			}
+			p.setUser(user.Name, []string{rule})
+		}
+	})
+	p.deleteBtn = widget.NewButtonWithIcon(i18n.T("Delete"), theme.DeleteIcon(), func() {
+		user := p.selectedUser()
+		if user == nil {
+			return
+		}
+		name := user.Name
+		confirmDestructive(p.window, "Delete User",
+			i18n.T("Delete ACL user '%s'? Clients authenticated as it are disconnected.", name), func() {
+				p.worker.Do(p.window, p.client, func(c *redis.Client) error {
+					return c.ACLDeleteUser(name)
+				}, p.Refresh)
+			})
+	})
+	p.deleteBtn.Importance = widget.DangerImportance
+
+	details := container.NewVBox(
+		p.nameLabel,
+		container.NewHBox(p.editBtn, p.toggleBtn, p.deleteBtn),
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle(i18n.T("Rules"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		p.rulesLabel,
+		widget.NewSeparator(),
+		widget.NewLabelWithStyle("ACL GETUSER", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		p.detailsGrid,
+	)
+
+	split := container.NewHSplit(p.userList, container.NewVScroll(details))
+	split.SetOffset(0.3)
+
+	var newBox fyne.CanvasObject
+	newBox, p.newTip = withTip(p.newBtn)
+	toolbar := container.NewBorder(nil, nil, nil, container.NewHBox(newBox, refreshBtn), p.statusLabel)
+	p.container = container.NewBorder(toolbar, nil, nil, nil, split)
+	p.showUser()
+}
+
+// CreateRenderer implements fyne.Widget
+func (p *ACLPanel) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.container)
+}
+
+// SetClient sets the Redis client
+func (p *ACLPanel) SetClient(client *redis.Client) {
+	p.client = client
+	setWritable(client, p.newBtn)
+	if tip := unsupportedTip(client, redis.FeatureACL); tip != "" {
+		p.newBtn.Disable()
+		p.newTip.SetTip(tip)
+	} else {
+		p.newTip.SetTip("")
+	}
+}
+
+// Clear removes the listed users
+func (p *ACLPanel) Clear() {
+	p.users = nil
+	p.selected = -1
+	p.userList.UnselectAll()
+	p.userList.Refresh()
+	p.statusLabel.SetText("")
+	p.showUser()
+}
+
+// Refresh reloads the users with ACL LIST, keeping the selected user selected
+func (p *ACLPanel) Refresh() {
+	if p.client == nil {
+		return
+	}
+
+	selected := ""
+	if user := p.selectedUser(); user != nil {
+		selected = user.Name
+	}
+
+	client := p.client
+	var users []models.ACLUser
+	var whoami string
+	p.worker.Go(func(ctx context.Context) error {
+		c := client.WithContext(ctx)
+		var err error
+		if users, err = c.ACLUsers(); err != nil {
+			return err
+		}
+		whoami, err = c.ACLWhoAmI()
+		return err
+	}, func(err error) {
+		if p.client != client {
+			return
+		}
+		if err != nil {
+			p.Clear()
+			p.statusLabel.SetText(i18n.T("ACL users are unavailable: %s", err))
+			return
+		}
+
+		p.users = users
+		p.selected = -1
+		p.userList.UnselectAll()
+		p.userList.Refresh()
+		p.statusLabel.SetText(i18n.T("%d users, connected as '%s'", len(users), whoami))
+		for i, user := range users {
+			if user.Name == selected {
+				p.userList.Select(i) // shows the user through OnSelected
+			}
+		}
+		if p.selected < 0 {
+			p.showUser()
+		}
+	})
+}
+
+func (p *ACLPanel) selectedUser() *models.ACLUser {
+	if p.selected < 0 || p.selected >= len(p.users) {
+		return nil
+	}
+	return &p.users[p.selected]
+}
+
+// showUser shows the rules of the selected user and loads its ACL GETUSER details
+func (p *ACLPanel) showUser() {
+	p.detailsGrid.RemoveAll()
+	user := p.selectedUser()
+	if user == nil {
+		p.nameLabel.SetText(i18n.T("Select a user"))
+		p.rulesLabel.SetText("")
+		p.editBtn.Disable()
+		p.toggleBtn.Disable()
+		p.deleteBtn.Disable()
+		return
+	}
+
+	p.nameLabel.SetText(user.Name)
+	p.rulesLabel.SetText(user.Rules)
+	setWritable(p.client, p.editBtn, p.toggleBtn, p.deleteBtn)
+	if user.Enabled {
+		p.toggleBtn.SetText(i18n.T("Disable"))
+	} else {
+		p.toggleBtn.SetText(i18n.T("Enable"))
+	}
+
+	name := user.Name
+	client := p.client
+	var fields []models.KeyValue
+	p.worker.Go(func(ctx context.Context) (err error) {
+		fields, err = client.WithContext(ctx).ACLGetUser(name)
+		return
+	}, func(err error) {
+		// Another user was selected while the details loaded
+		if current := p.selectedUser(); current == nil || current.Name != name {
+			return
+		}
+		if err != nil {
+			p.detailsGrid.Add(widget.NewLabel(i18n.T("Error: %s", err)))
+			return
+		}
+		for _, f := range fields {
+			p.detailsGrid.Add(widget.NewLabelWithStyle(f.Key, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			value := widget.NewLabel(f.Value)
+			value.Wrapping = fyne.TextWrapWord
+			p.detailsGrid.Add(value)
+		}
+	})
+}
+
+// setUser applies rules to a user with ACL SETUSER and reloads the list
+func (p *ACLPanel) setUser(name string, rules []string) {
+	p.worker.Do(p.window, p.client, func(c *redis.Client) error {
+		return c.ACLSetUser(name, rules)
+	}, p.Refresh)
+}
@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// analysisTopKeys is how many prefixes and largest keys the analysis lists
+const analysisTopKeys = 20
+
+// Analysis is a panel that scans the keyspace in the background and charts
+// key counts by type, TTL and prefix along with the largest keys
+type Analysis struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	worker    *Worker
+	window    fyne.Window
+	delimiter string // groups keys into prefixes, as in the key browser
+
+	patternEntry *widget.Entry
+	statusLabel  *widget.Label
+	runBtn       *widget.Button
+	cancelBtn    *widget.Button
+	results      *fyne.Container
+	cancel       context.CancelFunc
+}
+
+// NewAnalysis creates a new keyspace analysis panel
+func NewAnalysis(window fyne.Window, worker *Worker) *Analysis {
+	a := &Analysis{
+		window:    window,
+		worker:    worker,
+		delimiter: redis.DefaultDelimiter,
+	}
+	a.ExtendBaseWidget(a)
+	a.buildUI()
+	return a
+}
+
+func (a *Analysis) buildUI() {
+	a.patternEntry = widget.NewEntry()
+	a.patternEntry.SetPlaceHolder(i18n.T("Pattern, e.g. user:* (empty for every key)"))
+	a.patternEntry.OnSubmitted = func(string) { a.run() }
+
+	a.statusLabel = widget.NewLabel(i18n.T("Scan the database to see how its keys are distributed."))
+
+	a.runBtn = widget.NewButtonWithIcon(i18n.T("Analyze"), theme.MediaPlayIcon(), a.run)
+	a.cancelBtn = widget.NewButtonWithIcon(i18n.T("Cancel"), theme.CancelIcon(), func() {
+		if a.cancel != nil {
+			a.cancel()
+		}
+	})
+	a.cancelBtn.Hide()
+
+	a.results = container.NewVBox()
+
+	toolbar := container.NewVBox(
+		container.NewBorder(nil, nil, nil, container.NewHBox(a.runBtn, a.cancelBtn), a.patternEntry),
+		a.statusLabel,
+		widget.NewSeparator(),
+	)
+
+	a.container = container.NewBorder(toolbar, nil, nil, nil, container.NewVScroll(a.results))
+}
+
+// CreateRenderer implements fyne.Widget
+func (a *Analysis) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(a.container)
+}
+
+// SetClient sets the Redis client
+func (a *Analysis) SetClient(client *redis.Client) {
+	a.client = client
+}
+
+// SetDelimiter sets the delimiter that ends key prefixes
+func (a *Analysis) SetDelimiter(delimiter string) {
+	a.delimiter = delimiter
+}
+
+// Clear cancels a running analysis and removes the last report
+func (a *Analysis) Clear() {
+	if a.cancel != nil {
+		a.cancel()
+	}
+	a.results.RemoveAll()
+	a.results.Refresh()
+	a.statusLabel.SetText(i18n.T("Scan the database to see how its keys are distributed."))
+}
+
+// run scans the keys matching the pattern, once confirmed on a large database
+func (a *Analysis) run() {
+	if a.client == nil || a.cancel != nil {
+		return
+	}
+	pattern := strings.TrimSpace(a.patternEntry.Text)
+	if pattern == "" {
+		pattern = "*"
+	}
+	confirmHeavyScan(a.window, a.worker, a.client, "Keyspace Analysis", pattern, analysisCommandsPerKey, a.analyze)
+}
+
+// analyze scans the keys matching pattern as a cancellable background job
+func (a *Analysis) analyze(pattern string) {
+	if a.client == nil || a.cancel != nil {
+		return
+	}
+	a.patternEntry.SetText(pattern)
+
+	a.runBtn.Disable()
+	a.cancelBtn.Show()
+	a.statusLabel.SetText(i18n.T("Scanning..."))
+
+	client := a.client
+	delimiter := a.delimiter
+	var report *models.KeyspaceReport
+	a.cancel = a.worker.Job(i18n.T("Analyze keys matching '%s'", pattern), func(ctx context.Context, job *jobs.Job) error {
+		var err error
+		report, err = client.WithContext(ctx).AnalyzeKeyspace(pattern, delimiter, analysisTopKeys, func(scanned int64) {
+			job.SetDetail(i18n.T("%d keys scanned", scanned))
+			fyne.Do(func() {
+				if a.cancel != nil {
+					a.statusLabel.SetText(i18n.T("Scanning... %d keys so far", scanned))
+				}
+			})
+		})
+		return err
+	}, func(err error) {
+		a.cancel = nil
+		a.runBtn.Enable()
+		a.cancelBtn.Hide()
+		if errors.Is(err, context.Canceled) {
+			a.statusLabel.SetText(i18n.T("Analysis cancelled"))
+			return
+		}
+		if err != nil {
+			a.statusLabel.SetText(i18n.T("Analysis failed"))
+			ShowErrorDialog(a.window, "Analysis Error", err)
+			return
+		}
+		// A report for a connection that has since changed isn't shown
+		if a.client != client {
+			return
+		}
+		a.showReport(report)
+	})
+}
+
+func (a *Analysis) showReport(report *models.KeyspaceReport) {
+	a.statusLabel.SetText(i18n.T("%d keys matching '%s', %s in total",
+		report.Scanned, report.Pattern, formatBytes(report.TotalBytes)))
+
+	types := make([]string, 0, len(report.ByType))
+	for t := range report.ByType {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if report.ByType[types[i]] != report.ByType[types[j]] {
+			return report.ByType[types[i]] > report.ByType[types[j]]
+		}
+		return types[i] < types[j]
+	})
+	typeCounts := make([]int64, len(types))
+	for i, t := range types {
+		typeCounts[i] = report.ByType[t]
+	}
+
+	ttlCounts := make([]int64, len(models.TTLBuckets))
+	for i, bucket := range models.TTLBuckets {
+		ttlCounts[i] = report.ByTTL[bucket]
+	}
+
+	prefixes := make([]string, len(report.ByPrefix))
+	prefixCounts := make([]int64, len(report.ByPrefix))
+	for i, p := range report.ByPrefix {
+		prefixes[i] = p.Prefix
+		prefixCounts[i] = p.Keys
+	}
+
+	a.results.Objects = []fyne.CanvasObject{
+		analysisSection("Keys by Type", barChart(types, typeCounts, report.Scanned)),
+		analysisSection("Keys by TTL", barChart(models.TTLBuckets, ttlCounts, report.Scanned)),
+		analysisSection(i18n.T("Top %d Prefixes", analysisTopKeys), barChart(prefixes, prefixCounts, report.Scanned)),
+		analysisSection("Largest Keys by Length", keySizeTable(report.LargestByLength)),
+		analysisSection("Largest Keys by Memory", keySizeTable(report.LargestByMemory)),
+	}
+	a.results.Refresh()
+}
+
+func analysisSection(title string, content fyne.CanvasObject) fyne.CanvasObject {
+	return container.NewVBox(
+		widget.NewLabelWithStyle(i18n.T(title), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		content,
+		widget.NewSeparator(),
+	)
+}
+
+// barChart draws one horizontal bar per label, sized by its share of total
+func barChart(labels []string, counts []int64, total int64) fyne.CanvasObject {
+	if len(labels) == 0 {
+		return widget.NewLabel(i18n.T("No keys"))
+	}
+	rows := container.NewGridWithColumns(2)
+	for i, label := range labels {
+		count := counts[i]
+		bar := widget.NewProgressBar()
+		if total > 0 {
+			bar.SetValue(float64(count) / float64(total))
+		}
+		bar.TextFormatter = func() string {
+			return fmt.Sprintf("%d (%.1f%%)", count, bar.Value*100)
+		}
+		rows.Add(widget.NewLabel(label))
+		rows.Add(bar)
+	}
+	return rows
+}
+
+// keySizeTable lists keys with their type, length and memory
+func keySizeTable(keys []models.KeySize) fyne.CanvasObject {
+	if len(keys) == 0 {
+		return widget.NewLabel(i18n.T("No keys"))
+	}
+	grid := container.NewGridWithColumns(4,
+		widget.NewLabelWithStyle(i18n.T("Key"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Type"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Length"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Memory"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+	)
+	for _, k := range keys {
+		name := widget.NewLabel(k.Key)
+		name.Truncation = fyne.TextTruncateEllipsis
+		grid.Add(name)
+		grid.Add(widget.NewLabel(k.Type))
+		grid.Add(widget.NewLabel(strconv.FormatInt(k.Length, 10)))
+		grid.Add(widget.NewLabel(formatBytes(k.Memory)))
+	}
+	return grid
+}
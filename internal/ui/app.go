@@ -1,15 +1,22 @@
 package ui
 
 import (
+	"context"
+	"log"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
 	"redis-explorer/internal/models"
 	"redis-explorer/internal/redis"
 )
@@ -22,19 +29,55 @@ type App struct {
 	keyBrowser    *KeyBrowser
 	editor        *ValueEditor
 	serverInfo    *ServerInfo
+	console       *Console
+	scripts       *ScriptPanel
+	valueSearch   *ValueSearch
+	monitor       *Monitor
+	watches       *WatchPanel
+	analysis      *Analysis
+	acl           *ACLPanel
+	tabs          *container.AppTabs
+	editorTab     *container.TabItem
+	editorWindow  fyne.Window // the detached editor's window, nil while docked
+	fullSplit     *container.Split
+	mainSplit     *container.Split
+	searchTab     *container.TabItem
+	worker        *Worker
+	jobQueue      *JobQueue
+	statusBar     *StatusBar
+	undo          *UndoStack
 	client        *redis.Client
 	connected     bool
 	currentDB     int
 	appIcon       fyne.Resource
 	refreshTicker *time.Ticker
 	stopRefresh   chan struct{}
+	currentConn   models.ServerConnection
+	stopKeepAlive chan struct{}
+	stopBackup    chan struct{}
+	stopAlerting  context.CancelFunc
+	stale         bool
+	startupURL    string
 }
 
+const (
+	// keepAliveInterval is how often the active connection is pinged in the background
+	keepAliveInterval = 15 * time.Second
+	// keepAliveMaxFailures is how many consecutive failed pings mark the connection stale
+	keepAliveMaxFailures = 3
+)
+
 // NewApp creates a new application instance
 func NewApp() *App {
 	return &App{}
 }
 
+// SetStartupURL makes Run connect to a redis:// or rediss:// URL once the
+// window is up, through a temporary connection that isn't saved
+func (a *App) SetStartupURL(url string) {
+	a.startupURL = url
+}
+
 // Run starts the application
 func (a *App) Run() {
 	// Load config
@@ -43,9 +86,13 @@ func (a *App) Run() {
 		panic(err)
 	}
 
+	if err := i18n.SetLanguage(cfg.Language); err != nil {
+		log.Printf("Language: %v", err)
+	}
+
 	// Create Fyne app
 	a.fyneApp = app.NewWithID("com.redis-explorer")
-	a.fyneApp.Settings().SetTheme(GetTheme(cfg.Theme))
+	applyTheme(a.fyneApp)
 
 	// Load app icon
 	a.loadIcon()
@@ -67,18 +114,44 @@ func (a *App) Run() {
 		}
 		size := a.window.Canvas().Size()
 		config.SetWindowSize(size.Width, size.Height)
+		config.SetLayout(a.layout())
 	})
 
+	// A URL from the command line wins over the last used connection
+	if a.startupURL != "" {
+		a.fyneApp.Lifecycle().SetOnStarted(func() {
+			a.connectURL(a.startupURL)
+		})
+	} else if cfg.ConnectOnLaunch {
+		if conn := config.GetConnection(cfg.LastConnectionID); conn != nil {
+			a.fyneApp.Lifecycle().SetOnStarted(func() {
+				a.connect(*conn)
+			})
+		}
+	}
+
 	// Show and run
 	a.window.ShowAndRun()
 }
 
 func (a *App) createUI() {
 	// Create components
+	a.worker = NewWorker()
+	a.jobQueue = NewJobQueue(a.window, a.worker.Jobs())
+	a.statusBar = NewStatusBar(a.jobQueue)
+	a.worker.SetOnReport(a.statusBar.ShowResult)
 	a.sidebar = NewSidebar(a.window)
-	a.keyBrowser = NewKeyBrowser(a.window)
-	a.editor = NewValueEditor(a.window)
-	a.serverInfo = NewServerInfo(a.window)
+	a.undo = NewUndoStack(a.window, a.worker)
+	a.keyBrowser = NewKeyBrowser(a.window, a.worker, a.undo)
+	a.editor = NewValueEditor(a.window, a.worker, a.undo)
+	a.serverInfo = NewServerInfo(a.window, a.worker)
+	a.console = NewConsole(a.window, a.worker)
+	a.scripts = NewScriptPanel(a.window, a.worker)
+	a.valueSearch = NewValueSearch(a.window, a.worker)
+	a.monitor = NewMonitor(a.window)
+	a.watches = NewWatchPanel(a.window)
+	a.analysis = NewAnalysis(a.window, a.worker)
+	a.acl = NewACLPanel(a.window, a.worker)
 
 	// Set up callbacks
 	a.sidebar.SetOnConnect(func(conn models.ServerConnection) {
@@ -93,96 +166,271 @@ func (a *App) createUI() {
 		a.editor.LoadKey(key)
 	})
 
+	a.valueSearch.SetOnOpenKey(func(key models.RedisKey) {
+		a.keyBrowser.OpenKey(key)
+		a.tabs.Select(a.editorTab)
+	})
+
 	a.keyBrowser.SetOnKeyDeleted(func(key string) {
 		a.editor.Clear()
 	})
 
+	a.keyBrowser.SetOnKeysLoaded(a.refreshKeyCount)
+	a.keyBrowser.SetOnNodeSelected(a.connect)
+
 	a.editor.SetOnKeyUpdated(func() {
 		a.keyBrowser.LoadKeys()
 	})
 
+	a.editor.SetOnDetach(a.toggleEditorWindow)
+
+	a.undo.SetOnUndone(func() {
+		a.keyBrowser.LoadKeys()
+		a.editor.Reload()
+	})
+
+	a.keyBrowser.SetOnDelimiterChanged(func(setting, delimiter string) {
+		a.analysis.SetDelimiter(delimiter)
+		if a.connected && setting != a.currentConn.Delimiter {
+			a.currentConn.Delimiter = setting
+			config.SetConnectionDelimiter(a.currentConn.ID, setting)
+		}
+	})
+
 	a.serverInfo.SetOnDBChanged(func(db int) {
 		a.selectDatabase(db)
 	})
 
+	a.serverInfo.SetOnDBFlushed(func() {
+		a.editor.Clear()
+		a.analysis.Clear()
+		a.keyBrowser.LoadKeys()
+	})
+
 	// Create menu
 	menu := a.createMenu()
 	a.window.SetMainMenu(menu)
+	a.window.Canvas().SetOnTypedKey(a.typedKey)
+	// Entries handle Ctrl+Z themselves while focused
+	a.window.Canvas().AddShortcut(&fyne.ShortcutUndo{}, func(fyne.Shortcut) { a.undoLast() })
 
 	// Create tabs for right panel
+	a.editorTab = container.NewTabItemWithIcon(i18n.T("Editor"), theme.DocumentCreateIcon(), a.editor)
+	a.searchTab = container.NewTabItemWithIcon(i18n.T("Search"), theme.SearchIcon(), a.valueSearch)
 	tabs := container.NewAppTabs(
-		container.NewTabItemWithIcon("Editor", theme.DocumentCreateIcon(), a.editor),
-		container.NewTabItemWithIcon("Server Info", theme.InfoIcon(), a.serverInfo),
+		a.editorTab,
+		container.NewTabItemWithIcon(i18n.T("Server Info"), theme.InfoIcon(), a.serverInfo),
+		container.NewTabItemWithIcon(i18n.T("Console"), theme.ComputerIcon(), a.console),
+		container.NewTabItemWithIcon(i18n.T("Scripts"), theme.FileTextIcon(), a.scripts),
+		container.NewTabItemWithIcon(i18n.T("Monitor"), theme.VisibilityIcon(), a.monitor),
+		container.NewTabItemWithIcon(i18n.T("Watch"), theme.HistoryIcon(), a.watches),
+		a.searchTab,
+		container.NewTabItemWithIcon(i18n.T("Analysis"), theme.StorageIcon(), a.analysis),
+		container.NewTabItemWithIcon("ACL", theme.AccountIcon(), a.acl),
 	)
 	tabs.SetTabLocation(container.TabLocationTop)
+	a.tabs = tabs
 
 	// Main content: keys browser | editor/info tabs
-	mainSplit := container.NewHSplit(a.keyBrowser, tabs)
-	mainSplit.SetOffset(0.35)
+	a.mainSplit = container.NewHSplit(a.keyBrowser, tabs)
 
 	// Full layout: sidebar | main content
-	fullSplit := container.NewHSplit(a.sidebar, mainSplit)
-	fullSplit.SetOffset(0.18)
+	a.fullSplit = container.NewHSplit(a.sidebar, a.mainSplit)
+	a.restoreLayout(config.Get().Layout)
 
-	a.window.SetContent(fullSplit)
+	a.window.SetContent(container.NewBorder(nil, container.NewVBox(a.worker.Indicator(), a.statusBar), nil, nil, a.fullSplit))
 }
 
 func (a *App) createMenu() *fyne.MainMenu {
+	// Marked as the quit item so Fyne doesn't add its own when the label is
+	// translated differently from Fyne's
+	quitItem := fyne.NewMenuItem(i18n.T("Quit"), func() {
+		a.fyneApp.Quit()
+	})
+	quitItem.IsQuit = true
+
 	// File menu
-	fileMenu := fyne.NewMenu("File",
-		fyne.NewMenuItem("Settings", func() {
+	fileMenu := fyne.NewMenu(i18n.T("File"),
+		fyne.NewMenuItem(i18n.T("Settings"), func() {
 			ShowSettingsDialog(a.window, func() {
-				// Restart auto-refresh with new settings
+				a.applyAppearance()
+				// Rebuild the menus in the language chosen
+				a.window.SetMainMenu(a.createMenu())
+				// Restart auto-refresh and re-apply scan throttling with new settings
 				if a.connected {
+					a.applyScanThrottle()
 					a.stopAutoRefresh()
 					a.startAutoRefresh()
+					a.serverInfo.StartMetrics(a.currentConn.ID)
+					a.stopAlerts()
+					a.startAlerts()
 				}
 			})
 		}),
+		fyne.NewMenuItem(i18n.T("Export Keys..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowExport()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Import Keys..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowImport()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Set TTL on Pattern..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowBulkTTL()
+			}
+		}),
 		fyne.NewMenuItemSeparator(),
-		fyne.NewMenuItem("Quit", func() {
-			a.fyneApp.Quit()
+		fyne.NewMenuItem(i18n.T("Export Configuration..."), func() {
+			ShowConfigExportDialog(a.window)
+		}),
+		fyne.NewMenuItem(i18n.T("Import Configuration..."), func() {
+			ShowConfigImportDialog(a.window, a.configImported)
 		}),
+		fyne.NewMenuItemSeparator(),
+		quitItem,
 	)
 
 	// View menu
-	viewMenu := fyne.NewMenu("View",
-		fyne.NewMenuItem("Theme", func() {
+	viewMenu := fyne.NewMenu(i18n.T("View"),
+		fyne.NewMenuItem(i18n.T("Theme"), func() {
 			cfg := config.Get()
 			ShowThemeDialog(a.window, cfg.Theme, func(theme models.ThemeName) {
 				config.SetTheme(theme)
-				a.fyneApp.Settings().SetTheme(GetTheme(theme))
+				applyTheme(a.fyneApp)
 			})
 		}),
-		fyne.NewMenuItem("Refresh Keys", func() {
+		shortcutItem("Refresh Keys", fyne.KeyR, func() {
 			if a.connected {
 				a.keyBrowser.LoadKeys()
 			}
 		}),
+		fyne.NewMenuItem(i18n.T("Memory Analysis..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowMemoryAnalysis()
+			}
+		}),
+		fyne.NewMenuItemSeparator(),
+		shortcutItem("Larger Text", fyne.KeyEqual, func() { a.setTextSize(config.Get().Appearance.TextSize + 1) }),
+		shortcutItem("Smaller Text", fyne.KeyMinus, func() { a.setTextSize(config.Get().Appearance.TextSize - 1) }),
+		shortcutItem("Reset Text Size", fyne.Key0, func() { a.setTextSize(defaultTextSize) }),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(i18n.T("Background Jobs..."), a.jobQueue.ShowJobs),
+		fyne.NewMenuItem(i18n.T("Editor in Separate Window"), a.toggleEditorWindow),
+		fyne.NewMenuItemSeparator(),
+		shortcutItem("Command Palette...", fyne.KeyK, a.showCommandPalette),
+	)
+
+	// Key menu; Delete has no menu shortcut so it can't fire while typing, the
+	// canvas handles it when nothing has focus
+	keyMenu := fyne.NewMenu(i18n.T("Key"),
+		fyne.NewMenuItem(i18n.T("Undo"), a.undoLast),
+		fyne.NewMenuItemSeparator(),
+		shortcutItem("Find Key", fyne.KeyF, a.keyBrowser.FocusSearch),
+		shortcutItem("New Key...", fyne.KeyN, func() {
+			if a.connected {
+				a.keyBrowser.ShowNewKey()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("New Key from Clipboard..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowNewKeyFromClipboard()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("New Key from Template..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowNewKeyFromTemplate()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Search in Values..."), func() {
+			a.tabs.Select(a.searchTab)
+			a.valueSearch.Focus()
+		}),
+		fyne.NewMenuItem(i18n.T("Copy Key Name"), a.keyBrowser.CopySelectedName),
+		fyne.NewMenuItem(i18n.T("Delete Key"), func() {
+			if a.connected {
+				a.keyBrowser.DeleteSelectedKey()
+			}
+		}),
 	)
 
 	// Connection menu
-	connMenu := fyne.NewMenu("Connection",
-		fyne.NewMenuItem("New Connection", func() {
+	connMenu := fyne.NewMenu(i18n.T("Connection"),
+		fyne.NewMenuItem(i18n.T("New Connection"), func() {
 			ShowConnectionDialog(a.window, nil, func(conn models.ServerConnection) {
 				config.AddConnection(conn)
 				a.sidebar.RefreshConnections()
 			})
 		}),
 		fyne.NewMenuItemSeparator(),
-		fyne.NewMenuItem("Disconnect", func() {
+		fyne.NewMenuItem(i18n.T("Back Up..."), a.showBackup),
+		fyne.NewMenuItem(i18n.T("Migrate Keys..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowMigration()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Compare Databases..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowCompare()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Generate Test Data..."), func() {
+			if a.connected {
+				a.keyBrowser.ShowGenerate()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Flush Current Database..."), func() {
+			if a.connected {
+				a.serverInfo.ShowFlushDB()
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Flush All Databases..."), func() {
+			if a.connected {
+				a.serverInfo.ShowFlushAll()
+			}
+		}),
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(i18n.T("Disconnect"), func() {
 			a.disconnect()
 		}),
 	)
 
 	// Help menu
-	helpMenu := fyne.NewMenu("Help",
-		fyne.NewMenuItem("About", func() {
+	helpMenu := fyne.NewMenu(i18n.T("Help"),
+		fyne.NewMenuItem(i18n.T("About"), func() {
 			ShowAboutDialog(a.window, a.appIcon)
 		}),
 	)
 
-	return fyne.NewMainMenu(fileMenu, viewMenu, connMenu, helpMenu)
+	return fyne.NewMainMenu(fileMenu, viewMenu, keyMenu, connMenu, helpMenu)
+}
+
+// shortcutItem creates a menu item triggered by key with Ctrl (Cmd on macOS)
+func shortcutItem(label string, key fyne.KeyName, action func()) *fyne.MenuItem {
+	item := fyne.NewMenuItem(i18n.T(label), action)
+	item.Shortcut = &desktop.CustomShortcut{KeyName: key, Modifier: fyne.KeyModifierShortcutDefault}
+	return item
+}
+
+// showCommandPalette searches the menu actions and loaded keys
+func (a *App) showCommandPalette() {
+	ShowCommandPalette(a.window, a.window.MainMenu(), a.keyBrowser.Keys(), a.keyBrowser.OpenKey)
+}
+
+// undoLast undoes the latest delete or overwrite
+func (a *App) undoLast() {
+	if a.connected {
+		a.undo.Undo(a.client)
+	}
+}
+
+// typedKey handles keys pressed while no widget has focus
+func (a *App) typedKey(ev *fyne.KeyEvent) {
+	if ev.Name == fyne.KeyDelete && a.connected {
+		a.keyBrowser.DeleteSelectedKey()
+	}
 }
 
 func (a *App) connect(conn models.ServerConnection) {
@@ -191,32 +439,82 @@ func (a *App) connect(conn models.ServerConnection) {
 		a.disconnect()
 	}
 
-	// Create new client
-	a.client = redis.New(&conn)
-	err := a.client.Connect()
+	// Connect in the background so a slow or unreachable server doesn't freeze the window
+	cfg := config.Get()
+	client := redis.New(&conn)
+	client.SetTimeouts(redis.Timeouts{
+		Dial:  time.Duration(cfg.DialTimeoutSecs) * time.Second,
+		Read:  time.Duration(cfg.ReadTimeoutSecs) * time.Second,
+		Write: time.Duration(cfg.WriteTimeoutSecs) * time.Second,
+	})
+	a.sidebar.SetConnecting(conn.Name)
+	a.worker.Go(func(ctx context.Context) error {
+		return client.Connect()
+	}, func(err error) {
+		if err != nil {
+			a.sidebar.SetConnected(false, "")
+			ShowErrorDialog(a.window, "Connection Error", err)
+			return
+		}
+		a.onConnected(client, conn)
+	})
+}
+
+// connectURL connects to a redis:// or rediss:// URL through a temporary
+// connection named after its address
+func (a *App) connectURL(url string) {
+	conn, err := redis.ParseURL(url)
 	if err != nil {
 		ShowErrorDialog(a.window, "Connection Error", err)
 		return
 	}
+	conn.ID = models.TemporaryConnectionID
+	conn.Name = net.JoinHostPort(conn.Host, strconv.Itoa(conn.Port))
+	a.connect(conn)
+}
 
+func (a *App) onConnected(client *redis.Client, conn models.ServerConnection) {
+	a.client = client
 	a.connected = true
+	a.currentConn = conn
 	a.currentDB = conn.Database
+	a.applyScanThrottle()
 
 	// Update UI
-	a.sidebar.SetConnected(true, conn.Name)
+	a.sidebar.SetConnected(true, a.connectionLabel())
+	a.statusBar.SetConnection(a.connectionLabel(), a.currentDB)
+	SetGuardPhrase(conn.GuardPhrase())
 	a.keyBrowser.SetClient(a.client)
+	a.keyBrowser.SetDelimiter(conn.Delimiter)
+	if conn.KeyView != "" {
+		a.keyBrowser.SetTreeView(conn.KeyView == models.KeyViewTree)
+	}
 	a.editor.SetClient(a.client)
 	a.serverInfo.SetClient(a.client)
+	a.console.SetClient(a.client)
+	a.scripts.SetClient(a.client)
+	a.monitor.SetClient(a.client)
+	a.watches.SetClient(a.client)
+	a.analysis.SetClient(a.client)
+	a.valueSearch.SetClient(a.client)
+	a.acl.SetClient(a.client)
 
 	// Load data
 	a.keyBrowser.LoadKeys()
+	a.serverInfo.StartMetrics(conn.ID)
 	a.serverInfo.Refresh()
+	a.acl.Refresh()
 
 	// Start auto-refresh if configured
 	a.startAutoRefresh()
+	a.startKeepAlive()
+	a.startBackups()
+	a.startAlerts()
 
 	// Save last connection
-	config.SetLastConnection(conn.ID)
+	if !conn.Temporary() {
+		config.SetLastConnection(conn.ID)
+	}
 }
 
 func (a *App) disconnect() {
@@ -224,8 +522,12 @@ func (a *App) disconnect() {
 		return
 	}
 
-	// Stop auto-refresh
+	// Stop background tickers and abandon in-flight operations
 	a.stopAutoRefresh()
+	a.stopKeepAliveLoop()
+	a.stopBackups()
+	a.stopAlerts()
+	a.worker.CancelAll()
 
 	if a.client != nil {
 		a.client.Disconnect()
@@ -236,28 +538,162 @@ func (a *App) disconnect() {
 
 	// Clear UI
 	a.sidebar.SetConnected(false, "")
+	a.statusBar.Clear()
+	SetGuardPhrase("")
 	a.keyBrowser.SetClient(nil)
 	a.keyBrowser.Clear()
 	a.editor.SetClient(nil)
 	a.editor.Clear()
 	a.serverInfo.SetClient(nil)
 	a.serverInfo.Clear()
+	a.console.SetClient(nil)
+	a.scripts.SetClient(nil)
+	a.monitor.SetClient(nil)
+	a.watches.SetClient(nil)
+	a.analysis.SetClient(nil)
+	a.analysis.Clear()
+	a.valueSearch.SetClient(nil)
+	a.valueSearch.Clear()
+	a.acl.SetClient(nil)
+	a.acl.Clear()
 }
 
+// selectDatabase switches to database db by reconnecting with it. SELECT would
+// only switch the pooled connection it ran on, leaving the key loads, refreshes
+// and watches on the others in the old database.
 func (a *App) selectDatabase(db int) {
 	if !a.connected || a.client == nil {
 		return
 	}
+	conn := a.currentConn
+	conn.Database = db
+	a.connect(conn)
+}
 
-	err := a.client.SelectDatabase(db)
-	if err != nil {
-		ShowErrorDialog(a.window, "Error", err)
+// setTextSize changes the text size, within minTextSize and maxTextSize
+func (a *App) setTextSize(size float32) {
+	appearance := config.Get().Appearance
+	appearance.TextSize = min(max(size, minTextSize), maxTextSize)
+	if err := config.SetAppearance(appearance); err != nil {
+		ShowErrorDialog(a.window, "Appearance", err)
+	}
+	applyTheme(a.fyneApp)
+}
+
+// configImported shows the connections and settings of an imported
+// configuration
+func (a *App) configImported() {
+	a.sidebar.RefreshConnections()
+	if err := i18n.SetLanguage(config.Get().Language); err != nil {
+		log.Printf("Language: %v", err)
+	}
+	a.window.SetMainMenu(a.createMenu())
+	a.applyAppearance()
+}
+
+// applyAppearance shows the appearance settings: the text size through the
+// theme, the value font and wrapping by reloading the open key
+func (a *App) applyAppearance() {
+	applyTheme(a.fyneApp)
+	a.editor.Reload()
+}
+
+// restoreLayout arranges the window as it was when it was last closed
+func (a *App) restoreLayout(layout models.WindowLayout) {
+	a.fullSplit.SetOffset(layout.SidebarOffset)
+	a.mainSplit.SetOffset(layout.BrowserOffset)
+	for _, tab := range a.tabs.Items {
+		if tab.Text == layout.ActiveTab {
+			a.tabs.Select(tab)
+		}
+	}
+	a.keyBrowser.SetTreeView(layout.TreeView)
+}
+
+// layout describes the window's current arrangement
+func (a *App) layout() models.WindowLayout {
+	layout := models.WindowLayout{
+		SidebarOffset: a.fullSplit.Offset,
+		BrowserOffset: a.mainSplit.Offset,
+		TreeView:      a.keyBrowser.TreeView(),
+	}
+	if tab := a.tabs.Selected(); tab != nil {
+		layout.ActiveTab = tab.Text
+	}
+	return layout
+}
+
+// toggleEditorWindow moves the value editor into a window of its own, leaving
+// a placeholder in its tab, or docks it back when it is detached. Closing the
+// editor's window docks it too.
+func (a *App) toggleEditorWindow() {
+	if a.editorWindow != nil {
+		a.editorWindow.Close()
 		return
 	}
 
-	a.currentDB = db
-	a.keyBrowser.LoadKeys()
-	a.editor.Clear()
+	w := a.fyneApp.NewWindow(i18n.T("Value Editor - %s", AppName))
+	if a.appIcon != nil {
+		w.SetIcon(a.appIcon)
+	}
+	dockBtn := widget.NewButtonWithIcon(i18n.T("Dock Editor"), theme.ViewRestoreIcon(), w.Close)
+	a.editorTab.Content = container.NewCenter(container.NewVBox(
+		widget.NewLabel(i18n.T("The value editor is open in its own window.")),
+		container.NewCenter(dockBtn),
+	))
+	a.tabs.Refresh()
+
+	w.SetContent(a.editor)
+	w.SetOnClosed(func() {
+		a.editorWindow = nil
+		a.editorTab.Content = a.editor
+		a.tabs.Refresh()
+		a.editor.SetWindow(a.window, false)
+	})
+	w.Resize(fyne.NewSize(800, 600))
+	a.editorWindow = w
+	a.editor.SetWindow(w, true)
+	w.Show()
+}
+
+// refreshKeyCount shows the number of keys in the current database in the
+// status bar
+func (a *App) refreshKeyCount() {
+	client := a.client
+	if client == nil {
+		return
+	}
+	var count int64
+	a.worker.Go(func(ctx context.Context) (err error) {
+		count, err = client.WithContext(ctx).GetKeyCount()
+		return err
+	}, func(err error) {
+		if err == nil && a.client == client {
+			a.statusBar.SetKeyCount(count)
+		}
+	})
+}
+
+// connectionLabel names the active connection in the sidebar status, noting
+// when it is read-only
+func (a *App) connectionLabel() string {
+	if a.currentConn.ReadOnly {
+		return a.currentConn.Name + " (read-only)"
+	}
+	return a.currentConn.Name
+}
+
+// applyScanThrottle configures how hard key scans may hit the server from
+// settings and the connection's overrides
+func (a *App) applyScanThrottle() {
+	cfg := config.Get()
+	count := int64(a.currentConn.ScanCount(cfg.KeyScanCount))
+	throttle := redis.ScanThrottle{Count: count}
+	if cfg.GentleScan {
+		throttle = redis.GentleScanThrottle(count)
+	}
+	throttle.BatchSize = cfg.LookupBatchSize
+	a.client.SetScanThrottle(throttle)
 }
 
 func (a *App) loadIcon() {
@@ -281,28 +717,30 @@ func (a *App) loadIcon() {
 	}
 }
 
-// startAutoRefresh starts the auto-refresh ticker if configured
+// startAutoRefresh starts the auto-refresh ticker if configured, for the
+// connection or in settings
 func (a *App) startAutoRefresh() {
-	cfg := config.Get()
-	if cfg.AutoRefreshSecs <= 0 {
+	secs := a.currentConn.RefreshSecs(config.Get().AutoRefreshSecs)
+	if secs <= 0 {
 		return
 	}
 
-	a.stopRefresh = make(chan struct{})
-	a.refreshTicker = time.NewTicker(time.Duration(cfg.AutoRefreshSecs) * time.Second)
+	stop := make(chan struct{})
+	ticker := time.NewTicker(time.Duration(secs) * time.Second)
+	a.stopRefresh, a.refreshTicker = stop, ticker
 
 	go func() {
 		for {
 			select {
-			case <-a.refreshTicker.C:
-				if a.connected {
-					// Update UI on main thread (silent to avoid loading bar)
-					fyne.Do(func() {
+			case <-ticker.C:
+				// Update UI on main thread (silent to avoid loading bar)
+				fyne.Do(func() {
+					if a.connected {
 						a.keyBrowser.LoadKeysSilent()
 						a.serverInfo.Refresh()
-					})
-				}
-			case <-a.stopRefresh:
+					}
+				})
+			case <-stop:
 				return
 			}
 		}
@@ -320,3 +758,129 @@ func (a *App) stopAutoRefresh() {
 		a.stopRefresh = nil
 	}
 }
+
+// showBackup edits the connection's backup settings and runs a backup on demand
+func (a *App) showBackup() {
+	if !a.connected {
+		return
+	}
+	ShowBackupDialog(a.window, a.currentConn, func(backup models.BackupSettings, now bool) {
+		a.currentConn.Backup = backup
+		if err := config.SetConnectionBackup(a.currentConn.ID, backup); err != nil {
+			ShowErrorDialog(a.window, "Backup", err)
+		}
+		a.stopBackups()
+		a.startBackups()
+		if now {
+			runBackup(a.window, a.worker, a.client, backup, false)
+		}
+	})
+}
+
+// startBackups repeats the connection's backup on its schedule while connected
+func (a *App) startBackups() {
+	backup := a.currentConn.Backup
+	if backup.EveryHours <= 0 {
+		return
+	}
+	stop := make(chan struct{})
+	a.stopBackup = stop
+	client := a.client
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(backup.EveryHours) * time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(func() {
+					if a.client == client {
+						runBackup(a.window, a.worker, client, backup, true)
+					}
+				})
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopBackups stops the backup schedule
+func (a *App) stopBackups() {
+	if a.stopBackup != nil {
+		close(a.stopBackup)
+		a.stopBackup = nil
+	}
+}
+
+// startKeepAlive pings the active connection in the background and marks the
+// session stale after repeated failures, offering to reconnect
+func (a *App) startKeepAlive() {
+	stop := make(chan struct{})
+	a.stopKeepAlive = stop
+	a.stale = false
+	client := a.client
+
+	go func() {
+		ticker := time.NewTicker(keepAliveInterval)
+		defer ticker.Stop()
+		failures := 0
+		for {
+			select {
+			case <-ticker.C:
+				if client.IsConnected() {
+					if failures >= keepAliveMaxFailures {
+						fyne.Do(func() {
+							a.onConnectionRecovered(client)
+						})
+					}
+					failures = 0
+					continue
+				}
+				failures++
+				if failures == keepAliveMaxFailures {
+					fyne.Do(func() {
+						a.onConnectionStale(client)
+					})
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopKeepAliveLoop stops the keep-alive pinger
+func (a *App) stopKeepAliveLoop() {
+	if a.stopKeepAlive != nil {
+		close(a.stopKeepAlive)
+		a.stopKeepAlive = nil
+	}
+}
+
+func (a *App) onConnectionStale(client *redis.Client) {
+	if a.client != client || a.stale {
+		return
+	}
+	a.stale = true
+	conn := a.currentConn
+	a.sidebar.SetStale(conn.Name)
+	a.statusBar.SetStale()
+	if config.Get().Notifications.ConnectionLost {
+		notify(i18n.T("Connection Lost"), i18n.T("'%s' has stopped responding", conn.Name))
+	}
+	ShowConfirmDialog(a.window, "Connection Lost",
+		i18n.T("'%s' has stopped responding. Reconnect now?", conn.Name),
+		func() {
+			a.connect(conn)
+		})
+}
+
+func (a *App) onConnectionRecovered(client *redis.Client) {
+	if a.client != client || !a.stale {
+		return
+	}
+	a.stale = false
+	a.sidebar.SetConnected(true, a.connectionLabel())
+	a.statusBar.SetRecovered()
+}
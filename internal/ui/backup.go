@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// backupSchedules are the repeat intervals offered for backups
+var backupSchedules = []struct {
+	label string
+	hours int
+}{
+	{"Off", 0},
+	{"Every hour", 1},
+	{"Every 6 hours", 6},
+	{"Every 12 hours", 12},
+	{"Daily", 24},
+}
+
+// ShowBackupDialog edits how a connection is backed up. onSave receives the
+// settings and whether to back up right away.
+func ShowBackupDialog(window fyne.Window, conn models.ServerConnection, onSave func(backup models.BackupSettings, now bool)) {
+	dirEntry := widget.NewEntry()
+	dirEntry.SetText(conn.Backup.Dir)
+	dirEntry.SetPlaceHolder(i18n.T("Folder for the export files"))
+	browseBtn := widget.NewButtonWithIcon("", theme.FolderOpenIcon(), func() {
+		d := dialog.NewFolderOpen(func(dir fyne.ListableURI, err error) {
+			if err == nil && dir != nil {
+				dirEntry.SetText(dir.Path())
+			}
+		}, window)
+		if conn.Backup.Dir != "" {
+			if uri, err := storage.ListerForURI(storage.NewFileURI(conn.Backup.Dir)); err == nil {
+				d.SetLocation(uri)
+			}
+		}
+		d.Show()
+	})
+	dirRow := container.NewBorder(nil, nil, nil, browseBtn, dirEntry)
+
+	backupMethodBGSave := i18n.T("Server snapshot (BGSAVE)")
+	backupMethodExport := i18n.T("Export every key to a file (DUMP payloads)")
+	methodRadio := widget.NewRadioGroup([]string{backupMethodBGSave, backupMethodExport}, func(method string) {
+		if method == backupMethodExport {
+			dirEntry.Enable()
+			browseBtn.Enable()
+		} else {
+			dirEntry.Disable()
+			browseBtn.Disable()
+		}
+	})
+	methodRadio.Required = true
+	if conn.Backup.Method == models.BackupExport {
+		methodRadio.SetSelected(backupMethodExport)
+	} else {
+		methodRadio.SetSelected(backupMethodBGSave)
+	}
+
+	labels := make([]string, len(backupSchedules))
+	selected := 0
+	for i, s := range backupSchedules {
+		labels[i] = i18n.T(s.label)
+		if s.hours == conn.Backup.EveryHours {
+			selected = i
+		}
+	}
+	scheduleSelect := widget.NewSelect(labels, nil)
+	scheduleSelect.SetSelectedIndex(selected)
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Method"), methodRadio),
+		&widget.FormItem{Text: i18n.T("Folder"), Widget: dirRow, HintText: i18n.T("Files are named after the connection, database and time")},
+		&widget.FormItem{Text: i18n.T("Repeat"), Widget: scheduleSelect, HintText: i18n.T("Scheduled backups run while the app is connected")},
+	)
+
+	var d *dialog.CustomDialog
+	save := func(now bool) {
+		backup := models.BackupSettings{Method: models.BackupBGSave, Dir: strings.TrimSpace(dirEntry.Text)}
+		if methodRadio.Selected == backupMethodExport {
+			backup.Method = models.BackupExport
+			if backup.Dir == "" {
+				dialog.ShowError(fmt.Errorf("choose a folder for the export files"), window)
+				return
+			}
+		}
+		backup.EveryHours = backupSchedules[scheduleSelect.SelectedIndex()].hours
+		d.Hide()
+		onSave(backup, now)
+	}
+
+	cancelBtn := widget.NewButton(i18n.T("Cancel"), func() { d.Hide() })
+	saveBtn := widget.NewButton(i18n.T("Save"), func() { save(false) })
+	nowBtn := widget.NewButtonWithIcon(i18n.T("Back Up Now"), theme.DocumentSaveIcon(), func() { save(true) })
+	nowBtn.Importance = widget.HighImportance
+
+	d = dialog.NewCustomWithoutButtons(i18n.T("Back Up %s", conn.Name), form, window)
+	d.SetButtons([]fyne.CanvasObject{cancelBtn, saveBtn, nowBtn})
+	d.Resize(fyne.NewSize(520, 300))
+	d.Show()
+}
+
+// runBackup backs up the database client is using. A scheduled backup runs
+// without a progress dialog and only reports failures.
+func runBackup(window fyne.Window, worker *Worker, client *redis.Client, backup models.BackupSettings, scheduled bool) {
+	if backup.Method == models.BackupExport {
+		backupExport(window, worker, client, backup.Dir, scheduled)
+	} else {
+		backupBGSave(window, worker, client, scheduled)
+	}
+}
+
+// backupProgress shows a progress dialog for an on-demand backup; a scheduled
+// one gets functions that do nothing
+func backupProgress(window fyne.Window, scheduled bool, cancel context.CancelFunc) (update func(done, total int), hide func()) {
+	if scheduled {
+		return func(int, int) {}, func() {}
+	}
+	return ShowProgressDialog(window, "Backing Up", cancel)
+}
+
+// backupFailed reports a backup that didn't finish
+func backupFailed(window fyne.Window, scheduled bool, err error) {
+	title := "Backup Failed"
+	if scheduled {
+		title = "Scheduled Backup Failed"
+	}
+	ShowErrorDialog(window, title, err)
+}
+
+// backupBGSave starts a BGSAVE and follows it through INFO persistence, which
+// reports the keys saved so far on Redis 7 and later
+func backupBGSave(window fyne.Window, worker *Worker, client *redis.Client, scheduled bool) {
+	if refuseReadOnly(window, client) {
+		return
+	}
+	var status models.SaveStatus
+	var update func(done, total int)
+	var hide func()
+	cancel := worker.GoCancellable(func(ctx context.Context) error {
+		c := client.WithContext(ctx)
+		before, err := c.SaveStatus()
+		if err != nil {
+			return err
+		}
+		if err := c.BGSave(); err != nil {
+			return err
+		}
+
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-ticker.C:
+			}
+			if status, err = c.SaveStatus(); err != nil {
+				return err
+			}
+			switch {
+			case status.InProgress:
+				if status.KeysTotal > 0 {
+					done, total := int(status.KeysProcessed), int(status.KeysTotal)
+					fyne.Do(func() { update(done, total) })
+				}
+			case !status.LastOK:
+				return fmt.Errorf("the server's background save failed; see the Redis log for why")
+			case status.LastSave.After(before.LastSave):
+				return nil
+			}
+		}
+	}, func(err error) {
+		hide()
+		switch {
+		case errors.Is(err, context.Canceled):
+			if !scheduled {
+				ShowInfoDialog(window, "Backup", "Stopped following the save; the server carries on with it.")
+			}
+		case err != nil:
+			backupFailed(window, scheduled, err)
+		case scheduled:
+			log.Printf("Scheduled BGSAVE of %s finished at %s", client.Connection().Name, status.LastSave.Format(time.TimeOnly))
+		default:
+			ShowToast(window, i18n.T("The server saved its RDB snapshot at %s", status.LastSave.Format(time.TimeOnly)))
+		}
+	})
+	update, hide = backupProgress(window, scheduled, cancel)
+}
+
+// backupExport writes every key of the database as DUMP payloads to a
+// timestamped file in dir, removing the file if the export doesn't finish
+func backupExport(window fyne.Window, worker *Worker, client *redis.Client, dir string, scheduled bool) {
+	conn := client.Connection()
+	path := filepath.Join(dir, backupFileName(conn, time.Now()))
+	var exported int
+	var update func(done, total int)
+	var hide func()
+	cancel := worker.GoCancellable(func(ctx context.Context) error {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		req := models.ExportRequest{Pattern: "*", Format: models.ExportPayloads}
+		exported, _, err = writeExport(client.WithContext(ctx), f, req, func(done, total int) {
+			fyne.Do(func() { update(done, total) })
+		})
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+		return err
+	}, func(err error) {
+		hide()
+		switch {
+		case errors.Is(err, context.Canceled):
+			if !scheduled {
+				ShowInfoDialog(window, "Backup Cancelled", "The backup was cancelled and its file removed.")
+			}
+		case err != nil:
+			backupFailed(window, scheduled, err)
+		case scheduled:
+			log.Printf("Scheduled backup of %s: %d keys written to %s", conn.Name, exported, path)
+		default:
+			ShowToast(window, i18n.T("Backup complete: wrote %d keys to %s", exported, path))
+		}
+	})
+	update, hide = backupProgress(window, scheduled, cancel)
+}
+
+// backupFileName names an export backup after the connection, database and
+// time, e.g. "cache-db0-20240102-150405.dump"
+func backupFileName(conn models.ServerConnection, at time.Time) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		}
+		return '_'
+	}, conn.Name)
+	return fmt.Sprintf("%s-db%d-%s.%s", name, conn.Database, at.Format("20060102-150405"), models.ExportPayloads)
+}
@@ -0,0 +1,188 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// bitmapPageBytes is how many bytes of a bitmap are rendered per page
+	bitmapPageBytes = 256
+	// bitmapRowBits is how many bits each rendered row shows
+	bitmapRowBits = 64
+)
+
+// buildBitmapEditor inspects a string key as a bitmap: BITCOUNT, a paged bit
+// grid, GETBIT/SETBIT at an offset and BITPOS searches
+func (ve *ValueEditor) buildBitmapEditor(key models.RedisKey) fyne.CanvasObject {
+	summary := widget.NewLabel(i18n.T("Loading..."))
+	pageLabel := widget.NewLabel("")
+	grid := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	var page, length int64
+
+	var showPage func(p int64)
+	showPage = func(p int64) {
+		var bits []byte
+		var count, size int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			if count, err = c.BitCount(key.Key); err != nil {
+				return err
+			}
+			if size, err = c.StringLength(key.Key); err != nil {
+				return err
+			}
+			start := p * bitmapPageBytes
+			bits, err = c.GetBytes(key.Key, start, start+bitmapPageBytes-1)
+			return err
+		}, func() {
+			page, length = p, size
+			summary.SetText(i18n.T("BITCOUNT %d of %d bits (%d bytes)", count, length*8, length))
+			first := page * bitmapPageBytes * 8
+			pageLabel.SetText(i18n.T("Bits %d-%d", first, first+int64(len(bits))*8-1))
+			if len(bits) == 0 {
+				pageLabel.SetText(i18n.T("No bits on this page"))
+			}
+			grid.SetText(renderBits(bits, first))
+		})
+	}
+
+	prevBtn := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), func() {
+		if page > 0 {
+			showPage(page - 1)
+		}
+	})
+	nextBtn := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), func() {
+		if (page+1)*bitmapPageBytes < length {
+			showPage(page + 1)
+		}
+	})
+
+	// GETBIT / SETBIT
+	offsetEntry := widget.NewEntry()
+	offsetEntry.SetPlaceHolder(i18n.T("Bit offset"))
+	bitResult := widget.NewLabel("")
+	parseOffset := func() (int64, bool) {
+		offset, err := strconv.ParseInt(strings.TrimSpace(offsetEntry.Text), 10, 64)
+		if err != nil || offset < 0 {
+			ShowErrorDialog(ve.window, "Invalid Offset", fmt.Errorf("offset must be a non-negative integer"))
+			return 0, false
+		}
+		return offset, true
+	}
+	getBtn := widget.NewButton("GETBIT", func() {
+		offset, ok := parseOffset()
+		if !ok {
+			return
+		}
+		var bit int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			bit, err = c.GetBit(key.Key, offset)
+			return
+		}, func() {
+			bitResult.SetText(i18n.T("Bit %d is %d", offset, bit))
+		})
+	})
+	setBit := func(value int) {
+		offset, ok := parseOffset()
+		if !ok {
+			return
+		}
+		var previous int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			previous, err = c.SetBit(key.Key, offset, value)
+			return
+		}, func() {
+			bitResult.SetText(i18n.T("Bit %d set to %d (was %d)", offset, value, previous))
+			showPage(offset / 8 / bitmapPageBytes)
+		})
+	}
+	setOneBtn := widget.NewButton("SETBIT 1", func() { setBit(1) })
+	setZeroBtn := widget.NewButton("SETBIT 0", func() { setBit(0) })
+
+	// BITPOS
+	bitSelect := widget.NewSelect([]string{"1", "0"}, nil)
+	bitSelect.SetSelected("1")
+	startEntry := widget.NewEntry()
+	startEntry.SetPlaceHolder(i18n.T("Start byte"))
+	endEntry := widget.NewEntry()
+	endEntry.SetPlaceHolder(i18n.T("End byte"))
+	posResult := widget.NewLabel("")
+	posBtn := widget.NewButton("BITPOS", func() {
+		start, end := int64(0), int64(-1)
+		var err error
+		if text := strings.TrimSpace(startEntry.Text); text != "" {
+			if start, err = strconv.ParseInt(text, 10, 64); err != nil {
+				ShowErrorDialog(ve.window, "Invalid Range", fmt.Errorf("start byte must be an integer"))
+				return
+			}
+		}
+		if text := strings.TrimSpace(endEntry.Text); text != "" {
+			if end, err = strconv.ParseInt(text, 10, 64); err != nil {
+				ShowErrorDialog(ve.window, "Invalid Range", fmt.Errorf("end byte must be an integer"))
+				return
+			}
+		}
+		bit, _ := strconv.ParseInt(bitSelect.Selected, 10, 64)
+
+		var pos int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			pos, err = c.BitPos(key.Key, bit, start, end)
+			return
+		}, func() {
+			if pos < 0 {
+				posResult.SetText(i18n.T("No %d bit in range", bit))
+				return
+			}
+			posResult.SetText(i18n.T("First %d bit at offset %d", bit, pos))
+			offsetEntry.SetText(strconv.FormatInt(pos, 10))
+			showPage(pos / 8 / bitmapPageBytes)
+		})
+	})
+
+	setWritable(ve.client, setOneBtn, setZeroBtn)
+
+	controls := container.NewVBox(
+		summary,
+		container.NewBorder(nil, nil, nil, container.NewHBox(getBtn, setOneBtn, setZeroBtn), offsetEntry),
+		bitResult,
+		container.NewBorder(nil, nil, bitSelect, posBtn, container.NewGridWithColumns(2, startEntry, endEntry)),
+		posResult,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil, prevBtn, nextBtn, pageLabel),
+	)
+
+	showPage(0)
+	return container.NewBorder(controls, nil, nil, nil, container.NewScroll(grid))
+}
+
+// renderBits draws bytes as rows of bitmapRowBits bits, most significant bit
+// first as Redis numbers them, prefixed with the offset of each row's first bit
+func renderBits(data []byte, firstBit int64) string {
+	var b strings.Builder
+	rowBytes := bitmapRowBits / 8
+	width := len(strconv.FormatInt(firstBit+int64(len(data))*8, 10))
+	for row := 0; row < len(data); row += rowBytes {
+		fmt.Fprintf(&b, "%*d  ", width, firstBit+int64(row)*8)
+		for i := row; i < row+rowBytes && i < len(data); i++ {
+			for bit := 7; bit >= 0; bit-- {
+				if data[i]&(1<<bit) != 0 {
+					b.WriteByte('1')
+				} else {
+					b.WriteByte('.')
+				}
+			}
+			b.WriteByte(' ')
+		}
+		b.WriteByte('\n')
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// setTTLMatching previews the keys matching pattern, then sets exp on all of them
+// in batches with progress and cancel. onDone runs once any keys were changed.
+func setTTLMatching(window fyne.Window, worker *Worker, client *redis.Client, pattern string, exp models.Expiry, onDone func()) {
+	var preview *models.ImpactPreview
+	var closeProgress func()
+	cancel := worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		preview, err = client.WithContext(ctx).PreviewImpact(pattern, impactScanLimit, impactSampleSize)
+		return err
+	}, func(err error) {
+		closeProgress()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Set TTL", err)
+			return
+		}
+		ShowImpactDialog(window, i18n.T("Set TTL on '%s'", pattern), preview, expiryEffect(exp), "Apply", "", func() {
+			applyTTLMatching(window, worker, client, pattern, exp, int(preview.TotalKeys), onDone)
+		})
+	})
+	closeProgress = ShowCancellableProgress(window, "Set TTL", i18n.T("Counting keys matching '%s'...", pattern), cancel)
+}
+
+// applyTTLMatching runs the batched EXPIRE/EXPIREAT/PERSIST, with expected as the
+// previewed key count for the progress bar
+func applyTTLMatching(window fyne.Window, worker *Worker, client *redis.Client, pattern string, exp models.Expiry, expected int, onDone func()) {
+	var updated int64
+	var update func(done, total int)
+	var hideProgress func()
+	cancel := worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		updated, err = client.WithContext(ctx).SetTTLMatching(pattern, exp, func(n int64) {
+			fyne.Do(func() { update(int(n), max(expected, int(n))) })
+		})
+		return err
+	}, func(err error) {
+		hideProgress()
+		if updated > 0 {
+			onDone()
+		}
+		if errors.Is(err, context.Canceled) {
+			ShowInfoDialog(window, "Set TTL Cancelled", i18n.T("Cancelled after updating %d keys.", updated))
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Set TTL", err)
+			return
+		}
+		worker.Report(i18n.T("Updated the TTL of %d keys matching '%s'", updated, pattern), nil)
+	})
+	update, hideProgress = ShowProgressDialog(window, "Setting TTL", cancel)
+}
+
+// expiryEffect describes what exp does to a key, completing "N keys ..."
+func expiryEffect(exp models.Expiry) string {
+	switch {
+	case !exp.At.IsZero():
+		return i18n.T("will expire at %s", exp.At.Format("2006-01-02 15:04:05"))
+	case exp.Persist():
+		return i18n.T("will have their expiry removed")
+	}
+	return i18n.T("will expire in %s", formatTTL(exp.Seconds))
+}
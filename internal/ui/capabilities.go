@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/redis"
+)
+
+// unsupportedTip explains that the server lacks f, empty when it has it
+func unsupportedTip(client *redis.Client, f redis.Feature) string {
+	if client == nil || client.Supports(f) {
+		return ""
+	}
+	return i18n.T("%s needs Redis %s or later; this server runs %s", f.Name, f.Since, client.ServerVersion())
+}
+
+// disableable is a control that can be turned off
+type disableable interface {
+	fyne.CanvasObject
+	fyne.Disableable
+}
+
+// requireFeature disables obj, with the reason laid over it, when the server
+// lacks f. It suits controls built for one connection, like those of the
+// value editor; longer lived ones keep a tipLayer to update instead.
+func requireFeature(client *redis.Client, f redis.Feature, obj disableable) fyne.CanvasObject {
+	tip := unsupportedTip(client, f)
+	if tip == "" {
+		return obj
+	}
+	obj.Disable()
+	wrapped, layer := withTip(obj)
+	layer.SetTip(tip)
+	return wrapped
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// SetOnNodeSelected sets the callback for when another cluster node is chosen
+// to browse, given the connection pointed at it
+func (kb *KeyBrowser) SetOnNodeSelected(f func(conn models.ServerConnection)) {
+	kb.onNodeSelect = f
+}
+
+// nodeLabel names a cluster master by its address and the slots it serves
+func nodeLabel(node models.ClusterNode) string {
+	ranges := make([]string, len(node.Slots))
+	for i, r := range node.Slots {
+		if r[0] == r[1] {
+			ranges[i] = fmt.Sprint(r[0])
+		} else {
+			ranges[i] = fmt.Sprintf("%d-%d", r[0], r[1])
+		}
+	}
+	return i18n.T("%s (slots %s)", node.Addr, strings.Join(ranges, ", "))
+}
+
+// showNodes lists the cluster's masters in the node picker, selecting the one
+// connected to, and shows the picker and the slot and node columns on a
+// cluster only. SCAN sees the keys of one node, so browsing another means
+// connecting to it.
+func (kb *KeyBrowser) showNodes() {
+	kb.nodeIDs = nil
+	var options []string
+	selected := ""
+	if kb.client != nil {
+		for _, node := range kb.client.ClusterNodes() {
+			kb.nodeIDs = append(kb.nodeIDs, node.ID)
+			options = append(options, nodeLabel(node))
+			if node.ID == kb.client.ClusterNodeID() {
+				selected = nodeLabel(node)
+			}
+		}
+	}
+	kb.nodeSelect.Options = options
+	kb.nodeSelect.Selected = selected
+	kb.nodeSelect.Refresh()
+	if len(options) > 0 {
+		kb.nodeSelect.Show()
+	} else {
+		kb.nodeSelect.Hide()
+	}
+	kb.keyTable.Refresh()
+	kb.tableBox.Refresh()
+}
+
+// selectNode connects to the cluster master picked in the node picker
+func (kb *KeyBrowser) selectNode(string) {
+	index := kb.nodeSelect.SelectedIndex()
+	if kb.client == nil || index < 0 || kb.nodeIDs[index] == kb.client.ClusterNodeID() {
+		return
+	}
+	conn, err := kb.client.NodeConnection(kb.nodeIDs[index])
+	if err != nil {
+		ShowErrorDialog(kb.window, "Cluster Node", err)
+		kb.showNodes()
+		return
+	}
+	if kb.onNodeSelect != nil {
+		kb.onNodeSelect(conn)
+	}
+}
@@ -0,0 +1,240 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// Command statistics columns, in display order
+const (
+	statColumnCommand = iota
+	statColumnCalls
+	statColumnUsec
+	statColumnPerCall
+	statColumnLoad
+	statColumnFailed
+)
+
+var statColumns = []struct {
+	title string
+	width float32
+}{
+	{"Command", 200},
+	{"Calls", 110},
+	{"Total µs", 130},
+	{"µs/Call", 100},
+	{"% of Time", 100},
+	{"Failed", 90},
+}
+
+// commandStatsView is a sortable table of INFO commandstats. In delta mode it
+// shows the counters since a baseline, taken when the tab is opened or reset,
+// so the commands dominating right now stand out.
+type commandStatsView struct {
+	content    fyne.CanvasObject
+	table      *widget.Table
+	deltaCheck *widget.Check
+	status     *widget.Label
+
+	latest   []models.CommandStat
+	baseline map[string]models.CommandStat
+	since    time.Time
+	rows     []models.CommandStat // shown, sorted
+	total    int64                // usec of the shown rows
+	sortCol  int
+	sortAsc  bool
+}
+
+func newCommandStatsView() *commandStatsView {
+	v := &commandStatsView{sortCol: statColumnUsec}
+
+	v.table = widget.NewTableWithHeaders(
+		func() (int, int) { return len(v.rows), len(statColumns) },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			stat := v.rows[id.Row]
+			label.Alignment = fyne.TextAlignTrailing
+			switch id.Col {
+			case statColumnCommand:
+				label.Alignment = fyne.TextAlignLeading
+				label.SetText(stat.Name)
+			case statColumnCalls:
+				label.SetText(formatCount(stat.Calls))
+			case statColumnUsec:
+				label.SetText(formatCount(stat.Usec))
+			case statColumnPerCall:
+				label.SetText(fmt.Sprintf("%.2f", perCall(stat)))
+			case statColumnLoad:
+				label.SetText(fmt.Sprintf("%.1f%%", v.load(stat)))
+			case statColumnFailed:
+				label.SetText(formatCount(stat.Failed + stat.Rejected))
+			}
+		},
+	)
+	v.table.ShowHeaderColumn = false
+	v.table.CreateHeader = func() fyne.CanvasObject {
+		btn := widget.NewButton("", nil)
+		btn.Importance = widget.LowImportance
+		btn.Alignment = widget.ButtonAlignLeading
+		return btn
+	}
+	v.table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		btn := o.(*widget.Button)
+		btn.SetText(i18n.T(statColumns[id.Col].title))
+		btn.SetIcon(nil)
+		if id.Col == v.sortCol {
+			if v.sortAsc {
+				btn.SetIcon(theme.MenuDropUpIcon())
+			} else {
+				btn.SetIcon(theme.MenuDropDownIcon())
+			}
+		}
+		col := id.Col
+		btn.OnTapped = func() { v.sortBy(col) }
+	}
+	for i, column := range statColumns {
+		v.table.SetColumnWidth(i, column.width)
+	}
+
+	v.status = widget.NewLabel("")
+	v.deltaCheck = widget.NewCheck(i18n.T("Since opened"), func(bool) { v.update() })
+	v.deltaCheck.SetChecked(true)
+	resetBtn := widget.NewButtonWithIcon(i18n.T("Reset"), theme.HistoryIcon(), v.resetBaseline)
+
+	top := container.NewBorder(nil, nil, nil, container.NewHBox(v.deltaCheck, resetBtn), v.status)
+	v.content = container.NewBorder(top, nil, nil, nil, v.table)
+	return v
+}
+
+// show displays new counters, taking them as the baseline if there is none
+func (v *commandStatsView) show(stats []models.CommandStat) {
+	v.latest = stats
+	if v.baseline == nil {
+		v.resetBaseline()
+		return
+	}
+	v.update()
+}
+
+// resetBaseline counts from the latest counters onwards
+func (v *commandStatsView) resetBaseline() {
+	v.baseline = make(map[string]models.CommandStat, len(v.latest))
+	for _, stat := range v.latest {
+		v.baseline[stat.Name] = stat
+	}
+	v.since = time.Now()
+	v.update()
+}
+
+// clear drops the counters and baseline, which the next show takes anew
+func (v *commandStatsView) clear() {
+	v.latest = nil
+	v.baseline = nil
+	v.update()
+}
+
+// update recomputes the shown rows: the counters, or in delta mode their
+// increase since the baseline. A counter below its baseline means the server
+// restarted or ran CONFIG RESETSTAT, so it is shown whole.
+func (v *commandStatsView) update() {
+	delta := v.deltaCheck.Checked
+	v.rows = v.rows[:0]
+	v.total = 0
+	for _, stat := range v.latest {
+		if base, ok := v.baseline[stat.Name]; delta && ok && stat.Calls >= base.Calls {
+			stat.Calls -= base.Calls
+			stat.Usec -= base.Usec
+			stat.Rejected -= base.Rejected
+			stat.Failed -= base.Failed
+		}
+		if delta && stat.Calls == 0 && stat.Rejected == 0 {
+			continue
+		}
+		v.rows = append(v.rows, stat)
+		v.total += stat.Usec
+	}
+	v.sortRows()
+	v.table.Refresh()
+
+	switch {
+	case v.latest == nil:
+		v.status.SetText("")
+	case delta:
+		v.status.SetText(i18n.T("%d commands called since %s", len(v.rows), v.since.Format(time.TimeOnly)))
+	default:
+		v.status.SetText(i18n.T("%d commands called since the server started or CONFIG RESETSTAT", len(v.rows)))
+	}
+}
+
+// sortBy sorts by a column, reversing the order if it is already sorted by it.
+// Numbers sort largest first, names alphabetically.
+func (v *commandStatsView) sortBy(col int) {
+	if col == v.sortCol {
+		v.sortAsc = !v.sortAsc
+	} else {
+		v.sortCol, v.sortAsc = col, col == statColumnCommand
+	}
+	v.sortRows()
+	v.table.Refresh()
+}
+
+func (v *commandStatsView) sortRows() {
+	value := func(stat models.CommandStat) float64 {
+		switch v.sortCol {
+		case statColumnCalls:
+			return float64(stat.Calls)
+		case statColumnPerCall:
+			return perCall(stat)
+		case statColumnFailed:
+			return float64(stat.Failed + stat.Rejected)
+		default: // time and share of time order alike
+			return float64(stat.Usec)
+		}
+	}
+	sort.SliceStable(v.rows, func(i, j int) bool {
+		a, b := v.rows[i], v.rows[j]
+		if v.sortCol == statColumnCommand {
+			if v.sortAsc {
+				return a.Name < b.Name
+			}
+			return a.Name > b.Name
+		}
+		if va, vb := value(a), value(b); va != vb {
+			if v.sortAsc {
+				return va < vb
+			}
+			return va > vb
+		}
+		return strings.Compare(a.Name, b.Name) < 0
+	})
+}
+
+// load is a row's share of the time spent in all shown commands
+func (v *commandStatsView) load(stat models.CommandStat) float64 {
+	if v.total == 0 {
+		return 0
+	}
+	return float64(stat.Usec) / float64(v.total) * 100
+}
+
+// perCall is the average time of a call in microseconds
+func perCall(stat models.CommandStat) float64 {
+	if stat.Calls == 0 {
+		return 0
+	}
+	return float64(stat.Usec) / float64(stat.Calls)
+}
@@ -0,0 +1,332 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// ShowCompareDialog asks for two databases, of one connection or two, and a
+// pattern, then compares their keys in the background and shows the report
+func ShowCompareDialog(window fyne.Window, worker *Worker, client *redis.Client, pattern string) {
+	current := client.Connection()
+	connections := config.Get().Connections
+	names := make([]string, len(connections))
+	for i, conn := range connections {
+		names[i] = conn.Name
+	}
+	picker := func(db int) (*widget.Select, *widget.Entry) {
+		connSelect := widget.NewSelect(names, nil)
+		for i, conn := range connections {
+			if conn.ID == current.ID {
+				connSelect.SetSelectedIndex(i)
+			}
+		}
+		if connSelect.SelectedIndex() < 0 && len(names) > 0 {
+			connSelect.SetSelectedIndex(0)
+		}
+		dbEntry := widget.NewEntry()
+		dbEntry.SetText(strconv.Itoa(db))
+		return connSelect, dbEntry
+	}
+	connA, dbA := picker(current.Database)
+	connB, dbB := picker(current.Database + 1)
+
+	patternEntry := widget.NewEntry()
+	patternEntry.SetText(pattern)
+	patternEntry.SetPlaceHolder("*")
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: i18n.T("Connection A"), Widget: connA},
+			{Text: i18n.T("Database A"), Widget: dbA},
+			{Text: i18n.T("Connection B"), Widget: connB},
+			{Text: i18n.T("Database B"), Widget: dbB},
+			{Text: i18n.T("Pattern"), Widget: patternEntry, HintText: i18n.T("Keys to compare, e.g. user:* (empty for every key)")},
+		},
+	}
+
+	// side reads a connection and database choice
+	side := func(connSelect *widget.Select, dbEntry *widget.Entry) (models.ServerConnection, error) {
+		index := connSelect.SelectedIndex()
+		if index < 0 {
+			return models.ServerConnection{}, fmt.Errorf("choose a connection for both sides")
+		}
+		conn := connections[index]
+		if conn.ID == current.ID {
+			// Use the settings the active connection was opened with
+			conn = current
+		}
+		db, err := strconv.Atoi(dbEntry.Text)
+		if err != nil || db < 0 {
+			return conn, fmt.Errorf("database must be a non-negative number")
+		}
+		conn.Database = db
+		return conn, nil
+	}
+
+	d := dialog.NewCustomConfirm(i18n.T("Compare Databases"), i18n.T("Compare"), i18n.T("Cancel"), form, func(ok bool) {
+		if !ok {
+			return
+		}
+		a, err := side(connA, dbA)
+		if err == nil {
+			var b models.ServerConnection
+			if b, err = side(connB, dbB); err == nil {
+				if a.ID == b.ID && a.Database == b.Database {
+					err = fmt.Errorf("choose two different databases")
+				} else {
+					pattern := strings.TrimSpace(patternEntry.Text)
+					if pattern == "" {
+						pattern = "*"
+					}
+					runCompare(window, worker, client, a, b, pattern)
+					return
+				}
+			}
+		}
+		dialog.ShowError(err, window)
+	}, window)
+	d.Resize(fyne.NewSize(460, 380))
+	d.Show()
+}
+
+// openDatabase returns client when conn is the database it is on, or a
+// temporary connection to conn. done disconnects what was opened.
+func openDatabase(client *redis.Client, conn models.ServerConnection) (c *redis.Client, done func(), err error) {
+	if current := client.Connection(); current.ID == conn.ID && current.Database == conn.Database {
+		return client, func() {}, nil
+	}
+	c, err = client.Dial(conn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s, DB %d: %w", conn.Name, conn.Database, err)
+	}
+	return c, func() { c.Disconnect() }, nil
+}
+
+// runCompare compares the keys matching pattern in a and b as a background job
+func runCompare(window fyne.Window, worker *Worker, client *redis.Client, a, b models.ServerConnection, pattern string) {
+	var report *models.CompareReport
+	title := i18n.T("Compare '%s' in %s and %s", pattern, compareSide(a), compareSide(b))
+	worker.Job(title, func(ctx context.Context, job *jobs.Job) error {
+		clientA, doneA, err := openDatabase(client.WithContext(ctx), a)
+		if err != nil {
+			return err
+		}
+		defer doneA()
+		clientB, doneB, err := openDatabase(client.WithContext(ctx), b)
+		if err != nil {
+			return err
+		}
+		defer doneB()
+
+		report, err = clientA.Compare(clientB, pattern, func(done, total int) {
+			job.Update(int64(done), int64(total))
+		})
+		return err
+	}, func(err error) {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Compare Error", err)
+			return
+		}
+		ShowCompareReport(window, worker, client, a, b, report)
+	})
+}
+
+// compareSide names one side of a comparison
+func compareSide(conn models.ServerConnection) string {
+	return i18n.T("%s, DB %d", conn.Name, conn.Database)
+}
+
+// ShowCompareReport lists the keys only in a, only in b, and in both with a
+// different value or TTL. Selecting a differing key shows both copies side by
+// side.
+func ShowCompareReport(window fyne.Window, worker *Worker, client *redis.Client, a, b models.ServerConnection, report *models.CompareReport) {
+	keyList := func(keys []string) fyne.CanvasObject {
+		if len(keys) == 0 {
+			return container.NewCenter(widget.NewLabel(i18n.T("No keys")))
+		}
+		return widget.NewList(
+			func() int { return len(keys) },
+			func() fyne.CanvasObject { return widget.NewLabel("") },
+			func(id widget.ListItemID, o fyne.CanvasObject) { o.(*widget.Label).SetText(keys[id]) })
+	}
+
+	var differing fyne.CanvasObject = container.NewCenter(widget.NewLabel(i18n.T("No keys")))
+	if len(report.Differing) > 0 {
+		list := widget.NewList(
+			func() int { return len(report.Differing) },
+			func() fyne.CanvasObject {
+				return container.NewBorder(nil, nil, nil, widget.NewLabel(""), widget.NewLabel(""))
+			},
+			func(id widget.ListItemID, o fyne.CanvasObject) {
+				diff := report.Differing[id]
+				row := o.(*fyne.Container)
+				row.Objects[0].(*widget.Label).SetText(diff.Key)
+				row.Objects[1].(*widget.Label).SetText(describeDifference(diff))
+			})
+		list.OnSelected = func(id widget.ListItemID) {
+			list.Unselect(id)
+			showKeyDiff(window, worker, client, a, b, report.Differing[id].Key)
+		}
+		differing = list
+	}
+
+	summary := widget.NewLabel(i18n.T("A is %s, B is %s. Of the keys matching '%s', %d are only in A, %d only in B, %d differ and %d are the same.",
+		compareSide(a), compareSide(b), report.Pattern, len(report.OnlyA), len(report.OnlyB), len(report.Differing), report.Same))
+	summary.Wrapping = fyne.TextWrapWord
+
+	tabs := container.NewAppTabs(
+		container.NewTabItem(i18n.T("Different (%d)", len(report.Differing)), differing),
+		container.NewTabItem(i18n.T("Only in A (%d)", len(report.OnlyA)), keyList(report.OnlyA)),
+		container.NewTabItem(i18n.T("Only in B (%d)", len(report.OnlyB)), keyList(report.OnlyB)),
+	)
+	d := dialog.NewCustom(i18n.T("Compare Report"), i18n.T("Close"), container.NewBorder(summary, nil, nil, nil, tabs), window)
+	d.Resize(fyne.NewSize(640, 520))
+	d.Show()
+}
+
+// describeDifference says how a key differs between the two sides
+func describeDifference(diff models.KeyDifference) string {
+	var parts []string
+	switch {
+	case diff.TypeA != diff.TypeB:
+		parts = append(parts, i18n.T("%s vs %s", diff.TypeA, diff.TypeB))
+	case diff.Value:
+		parts = append(parts, i18n.T("value differs"))
+	}
+	if diff.TTL {
+		parts = append(parts, i18n.T("TTL %s vs %s", formatTTL(diff.TTLA), formatTTL(diff.TTLB)))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// showKeyDiff reads a key from both sides and shows the copies side by side,
+// highlighting the lines only one of them has
+func showKeyDiff(window fyne.Window, worker *Worker, client *redis.Client, a, b models.ServerConnection, key string) {
+	var dumpA, dumpB *models.KeyDump
+	worker.Go(func(ctx context.Context) error {
+		read := func(conn models.ServerConnection) (*models.KeyDump, error) {
+			c, done, err := openDatabase(client.WithContext(ctx), conn)
+			if err != nil {
+				return nil, err
+			}
+			defer done()
+			dump, err := c.DumpKey(key)
+			if errors.Is(err, redis.ErrKeyNotFound) {
+				return nil, nil
+			}
+			return dump, err
+		}
+		var err error
+		if dumpA, err = read(a); err != nil {
+			return err
+		}
+		dumpB, err = read(b)
+		return err
+	}, func(err error) {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Compare Error", err)
+			return
+		}
+
+		linesA, linesB := diffLines(dumpA), diffLines(dumpB)
+		inA, inB := lineSet(linesA), lineSet(linesB)
+		sides := container.NewGridWithColumns(2,
+			diffSide(compareSide(a), dumpA, linesA, inB, theme.ColorNameError),
+			diffSide(compareSide(b), dumpB, linesB, inA, theme.ColorNameSuccess),
+		)
+		d := dialog.NewCustom(i18n.T("Compare %s", key), i18n.T("Close"), sides, window)
+		d.Resize(fyne.NewSize(900, 600))
+		d.Show()
+	})
+}
+
+// diffSide shows one copy of a key, with the lines missing from the other
+// copy in color
+func diffSide(title string, dump *models.KeyDump, lines []string, other map[string]bool, colorName fyne.ThemeColorName) fyne.CanvasObject {
+	header := widget.NewLabelWithStyle(title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	if dump == nil {
+		return container.NewBorder(header, nil, nil, nil, widget.NewLabel(i18n.T("The key doesn't exist here.")))
+	}
+	info := widget.NewLabel(i18n.T("%s, TTL %s", dump.Type, formatTTL(dump.TTL)))
+
+	grid := widget.NewTextGrid()
+	grid.SetText(strings.Join(lines, "\n"))
+	grid.ShowLineNumbers = true
+	highlight := &widget.CustomTextGridStyle{FGColor: theme.Color(colorName), TextStyle: fyne.TextStyle{Bold: true}}
+	for row, line := range lines {
+		if !other[line] {
+			grid.SetRowStyle(row, highlight)
+		}
+	}
+	return container.NewBorder(container.NewVBox(header, info), nil, nil, nil, container.NewScroll(grid))
+}
+
+// diffLines renders a key's value as lines to compare: JSON and text line by
+// line, lists in order, sets and hashes sorted, sorted sets by score and
+// streams entry by entry
+func diffLines(dump *models.KeyDump) []string {
+	if dump == nil {
+		return nil
+	}
+	var lines []string
+	switch dump.Type {
+	case "string", redis.JSONType:
+		value := dump.Value
+		if formatted, err := formatJSON(value); err == nil {
+			value = formatted
+		}
+		lines = strings.Split(value, "\n")
+	case "list":
+		lines = dump.Items
+	case "set":
+		lines = append([]string(nil), dump.Items...)
+		sort.Strings(lines)
+	case "hash":
+		for field, value := range dump.Fields {
+			lines = append(lines, field+": "+value)
+		}
+		sort.Strings(lines)
+	case "zset":
+		for _, m := range dump.Members {
+			lines = append(lines, strconv.FormatFloat(m.Score, 'g', -1, 64)+"  "+m.Member)
+		}
+	case "stream":
+		for _, entry := range dump.Entries {
+			fields, _ := json.Marshal(entry.Fields)
+			lines = append(lines, entry.ID+"  "+string(fields))
+		}
+	}
+	return lines
+}
+
+// lineSet is the set of lines of one copy
+func lineSet(lines []string) map[string]bool {
+	set := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		set[line] = true
+	}
+	return set
+}
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+)
+
+// ShowConfigExportDialog exports the settings and connections to a file for
+// importing on another machine, with the passwords left out or encrypted with
+// a passphrase
+func ShowConfigExportDialog(window fyne.Window) {
+	passphraseEntry := widget.NewPasswordEntry()
+	passphraseEntry.SetPlaceHolder(i18n.T("Passphrase"))
+	confirmEntry := widget.NewPasswordEntry()
+	confirmEntry.SetPlaceHolder(i18n.T("Repeat the passphrase"))
+	passphraseEntry.Disable()
+	confirmEntry.Disable()
+
+	includeCheck := widget.NewCheck(i18n.T("Include passwords, encrypted with a passphrase"), func(include bool) {
+		if include {
+			passphraseEntry.Enable()
+			confirmEntry.Enable()
+		} else {
+			passphraseEntry.Disable()
+			confirmEntry.Disable()
+		}
+	})
+
+	intro := widget.NewLabel(i18n.T("Exports every connection and setting to a JSON file. Importing it on another machine adds its connections and replaces those with the same ID."))
+	intro.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm(i18n.T("Export Configuration"), i18n.T("Export..."), i18n.T("Cancel"),
+		container.NewVBox(intro, includeCheck, passphraseEntry, confirmEntry),
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			passphrase := ""
+			if includeCheck.Checked {
+				passphrase = passphraseEntry.Text
+				if passphrase == "" {
+					ShowErrorDialog(window, "Export Configuration", fmt.Errorf("enter a passphrase to include the passwords"))
+					return
+				}
+				if passphrase != confirmEntry.Text {
+					ShowErrorDialog(window, "Export Configuration", fmt.Errorf("the passphrases don't match"))
+					return
+				}
+			}
+			saveConfigExport(window, passphrase)
+		}, window)
+	d.Resize(fyne.NewSize(460, 280))
+	d.Show()
+}
+
+// saveConfigExport asks where to write the export and writes it
+func saveConfigExport(window fyne.Window, passphrase string) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			ShowErrorDialog(window, "Export Error", err)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		data, err := config.Export(passphrase)
+		if err == nil {
+			_, err = writer.Write(data)
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Export Error", err)
+			return
+		}
+		ShowToast(window, i18n.T("Configuration exported to %s", writer.URI().Name()))
+	}, window)
+	d.SetFileName("redis-explorer-config.json")
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	d.Show()
+}
+
+// ShowConfigImportDialog imports a configuration export, asking for its
+// passphrase if it holds passwords. onImported runs after the import, to
+// show the new connections and settings.
+func ShowConfigImportDialog(window fyne.Window, onImported func()) {
+	d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			ShowErrorDialog(window, "Import Error", err)
+			return
+		}
+		if reader == nil {
+			return
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			ShowErrorDialog(window, "Import Error", fmt.Errorf("failed to read %s: %w", reader.URI().Name(), err))
+			return
+		}
+
+		hasPasswords, err := config.ExportHasPasswords(data)
+		if err != nil {
+			ShowErrorDialog(window, "Import Error", err)
+			return
+		}
+		if hasPasswords {
+			askImportPassphrase(window, data, onImported)
+		} else {
+			importConfig(window, data, "", onImported)
+		}
+	}, window)
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json"}))
+	d.Show()
+}
+
+// askImportPassphrase asks for the passphrase of an export's passwords,
+// offering to import it without them
+func askImportPassphrase(window fyne.Window, data []byte, onImported func()) {
+	passphraseEntry := widget.NewPasswordEntry()
+	message := widget.NewLabel(i18n.T("The export holds passwords encrypted with a passphrase. Leave it empty to import without the passwords."))
+	message.Wrapping = fyne.TextWrapWord
+
+	d := dialog.NewCustomConfirm(i18n.T("Import Configuration"), i18n.T("Import"), i18n.T("Cancel"),
+		container.NewVBox(message, passphraseEntry),
+		func(ok bool) {
+			if ok {
+				importConfig(window, data, passphraseEntry.Text, onImported)
+			}
+		}, window)
+	d.Resize(fyne.NewSize(420, 200))
+	d.Show()
+	window.Canvas().Focus(passphraseEntry)
+}
+
+// importConfig merges an export into the configuration and reports what changed
+func importConfig(window fyne.Window, data []byte, passphrase string, onImported func()) {
+	result, err := config.Import(data, passphrase)
+	if errors.Is(err, config.ErrWrongPassphrase) {
+		// Ask again once the error is dismissed
+		d := dialog.NewError(err, window)
+		d.SetOnClosed(func() { askImportPassphrase(window, data, onImported) })
+		d.Show()
+		return
+	}
+	if err != nil {
+		ShowErrorDialog(window, "Import Error", err)
+		return
+	}
+
+	message := i18n.T("Added %d and updated %d connections.", result.Added, result.Updated)
+	if result.Passwords {
+		message += " " + i18n.T("Their passwords were imported.")
+	}
+	ShowInfoDialog(window, "Import Configuration", message)
+	if onImported != nil {
+		onImported()
+	}
+}
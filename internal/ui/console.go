@@ -0,0 +1,273 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// consoleHistoryLimit is how many commands the console remembers for recall
+	consoleHistoryLimit = 200
+	// consoleOutputLimit is how many output lines are kept before the oldest are dropped
+	consoleOutputLimit = 5000
+)
+
+// historyEntry is a single-line entry that reports up/down arrow presses for history recall
+type historyEntry struct {
+	widget.Entry
+	onUp   func()
+	onDown func()
+}
+
+func newHistoryEntry() *historyEntry {
+	e := &historyEntry{}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// TypedKey implements fyne.Focusable
+func (e *historyEntry) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyUp:
+		if e.onUp != nil {
+			e.onUp()
+		}
+	case fyne.KeyDown:
+		if e.onDown != nil {
+			e.onDown()
+		}
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+// Console is a redis-cli style panel for running raw commands
+type Console struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	worker    *Worker
+	window    fyne.Window
+
+	input   *historyEntry
+	output  *widget.Label
+	scroll  *container.Scroll
+	lines   []string
+	history []string
+	recall  int // index into history while recalling, len(history) when not
+	running bool
+}
+
+// NewConsole creates a new console panel
+func NewConsole(window fyne.Window, worker *Worker) *Console {
+	c := &Console{
+		window: window,
+		worker: worker,
+	}
+	c.ExtendBaseWidget(c)
+	c.buildUI()
+	return c
+}
+
+func (c *Console) buildUI() {
+	c.output = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	c.output.Wrapping = fyne.TextWrapBreak
+	c.output.Selectable = true
+	c.scroll = container.NewVScroll(c.output)
+
+	c.input = newHistoryEntry()
+	c.input.SetPlaceHolder(i18n.T("Enter a command, e.g. GET mykey"))
+	c.input.TextStyle = fyne.TextStyle{Monospace: true}
+	c.input.OnSubmitted = func(line string) {
+		c.run(line)
+	}
+	c.input.onUp = c.recallPrevious
+	c.input.onDown = c.recallNext
+
+	runBtn := widget.NewButtonWithIcon(i18n.T("Run"), theme.MediaPlayIcon(), func() {
+		c.run(c.input.Text)
+	})
+
+	clearBtn := widget.NewButtonWithIcon(i18n.T("Clear"), theme.ContentClearIcon(), func() {
+		c.clearOutput()
+	})
+
+	inputBar := container.NewBorder(nil, nil, widget.NewLabel(">"), container.NewHBox(runBtn, clearBtn), c.input)
+
+	c.container = container.NewBorder(nil, inputBar, nil, nil, c.scroll)
+}
+
+// CreateRenderer implements fyne.Widget
+func (c *Console) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(c.container)
+}
+
+// SetClient sets the Redis client. RESP3 pushes, such as the invalidations of
+// CLIENT TRACKING, are shown as they arrive.
+func (c *Console) SetClient(client *redis.Client) {
+	c.client = client
+	c.running = false
+	if client != nil {
+		client.SetOnPush(func(reply models.CommandReply) {
+			fyne.Do(func() {
+				if c.client == client {
+					c.appendOutput(formatReply(reply, ""))
+				}
+			})
+		})
+	}
+}
+
+func (c *Console) run(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || c.running {
+		return
+	}
+	c.addHistory(line)
+	c.input.SetText("")
+
+	if strings.EqualFold(line, "clear") {
+		c.clearOutput()
+		return
+	}
+
+	c.appendOutput("> " + line)
+	if c.client == nil {
+		c.appendOutput("(error) Not connected")
+		return
+	}
+
+	args, err := redis.ParseCommandLine(line)
+	if err != nil {
+		c.appendOutput("(error) " + err.Error())
+		return
+	}
+
+	client := c.client
+	var reply models.CommandReply
+	c.running = true
+	c.worker.Go(func(ctx context.Context) error {
+		var err error
+		reply, err = client.WithContext(ctx).Execute(args)
+		return err
+	}, func(err error) {
+		c.running = false
+		if c.client != client {
+			return
+		}
+		if err != nil {
+			c.appendOutput("(error) " + err.Error())
+			return
+		}
+		c.appendOutput(formatReply(reply, ""))
+	})
+}
+
+func (c *Console) addHistory(line string) {
+	if len(c.history) == 0 || c.history[len(c.history)-1] != line {
+		c.history = append(c.history, line)
+		if len(c.history) > consoleHistoryLimit {
+			c.history = c.history[len(c.history)-consoleHistoryLimit:]
+		}
+	}
+	c.recall = len(c.history)
+}
+
+func (c *Console) recallPrevious() {
+	if c.recall > 0 {
+		c.recall--
+		c.setInput(c.history[c.recall])
+	}
+}
+
+func (c *Console) recallNext() {
+	if c.recall < len(c.history)-1 {
+		c.recall++
+		c.setInput(c.history[c.recall])
+		return
+	}
+	c.recall = len(c.history)
+	c.setInput("")
+}
+
+func (c *Console) setInput(text string) {
+	c.input.SetText(text)
+	c.input.CursorColumn = len([]rune(text))
+	c.input.Refresh()
+}
+
+func (c *Console) appendOutput(text string) {
+	c.lines = append(c.lines, strings.Split(text, "\n")...)
+	if len(c.lines) > consoleOutputLimit {
+		c.lines = c.lines[len(c.lines)-consoleOutputLimit:]
+	}
+	c.output.SetText(strings.Join(c.lines, "\n"))
+	c.scroll.ScrollToBottom()
+}
+
+func (c *Console) clearOutput() {
+	c.lines = nil
+	c.output.SetText("")
+}
+
+// formatReply renders a reply the way redis-cli does, with nested arrays
+// numbered and indented under their parent item
+func formatReply(reply models.CommandReply, indent string) string {
+	switch reply.Kind {
+	case models.ReplyNil:
+		return "(nil)"
+	case models.ReplyString:
+		return fmt.Sprintf("%q", reply.Text)
+	case models.ReplyInteger:
+		return fmt.Sprintf("(integer) %d", reply.Integer)
+	case models.ReplyFloat:
+		return "(double) " + reply.Text
+	case models.ReplyBool:
+		return "(boolean) " + reply.Text
+	case models.ReplyError:
+		return "(error) " + reply.Text
+	case models.ReplyBigNumber:
+		return "(big number) " + reply.Text
+	case models.ReplyVerbatim:
+		return reply.Text
+	case models.ReplyPush:
+		prefix := "(push) "
+		return prefix + formatReply(models.CommandReply{Kind: models.ReplyArray, Items: reply.Items}, indent+strings.Repeat(" ", len(prefix)))
+	case models.ReplyArray, models.ReplyMap:
+		if len(reply.Items) == 0 {
+			return "(empty array)"
+		}
+		var b strings.Builder
+		if reply.Kind == models.ReplyMap {
+			for i := 0; i+1 < len(reply.Items); i += 2 {
+				prefix := fmt.Sprintf("%d# ", i/2+1)
+				if i > 0 {
+					b.WriteString("\n" + indent)
+				}
+				b.WriteString(prefix + formatReply(reply.Items[i], indent+strings.Repeat(" ", len(prefix))) +
+					" => " + formatReply(reply.Items[i+1], indent+strings.Repeat(" ", len(prefix))))
+			}
+			return b.String()
+		}
+		width := len(fmt.Sprint(len(reply.Items)))
+		for i, item := range reply.Items {
+			prefix := fmt.Sprintf("%*d) ", width, i+1)
+			if i > 0 {
+				b.WriteString("\n" + indent)
+			}
+			b.WriteString(prefix + formatReply(item, indent+strings.Repeat(" ", len(prefix))))
+		}
+		return b.String()
+	default:
+		return reply.Text
+	}
+}
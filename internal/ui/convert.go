@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// convertPreviewChars caps how much of a converted value the preview shows
+const convertPreviewChars = 4000
+
+// convertKey converts a key's value to another type. The conversion is
+// previewed first and only written while the key still holds the previewed
+// value, either over the key or to a new one.
+func (kb *KeyBrowser) convertKey(key models.RedisKey) {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+	targets := redis.ConvertTargets(key.Type)
+	if len(targets) == 0 {
+		ShowInfoDialog(kb.window, "Convert Type", i18n.T("A %s key can't be converted to another type", key.Type))
+		return
+	}
+
+	client := kb.client
+	var preview, converted *models.KeyDump
+	previewText := widget.NewMultiLineEntry()
+	previewText.TextStyle = valueTextStyle()
+	previewText.Wrapping = fyne.TextWrapBreak
+	previewText.Disable()
+	summaryLabel := widget.NewLabel("")
+	summaryLabel.Wrapping = fyne.TextWrapWord
+
+	destEntry := widget.NewEntry()
+	destEntry.SetPlaceHolder(i18n.T("New key name"))
+	convertOverwrite, convertNewKey := i18n.T("Overwrite this key"), i18n.T("Write to a new key")
+	destRadio := widget.NewRadioGroup([]string{convertOverwrite, convertNewKey}, func(dest string) {
+		if dest == convertNewKey {
+			destEntry.Enable()
+		} else {
+			destEntry.Disable()
+		}
+	})
+	destRadio.Required = true
+	destRadio.SetSelected(convertOverwrite)
+
+	convertBtn := widget.NewButtonWithIcon(i18n.T("Convert"), theme.ConfirmIcon(), nil)
+	convertBtn.Importance = widget.HighImportance
+	convertBtn.Disable()
+
+	// The key is read and converted each time a target is picked, so Convert
+	// always writes what the preview shows
+	targetSelect := widget.NewSelect(targets, nil)
+	targetSelect.OnChanged = func(target string) {
+		convertBtn.Disable()
+		converted = nil
+		if destEntry.Text == "" || strings.HasPrefix(destEntry.Text, key.Key+":") {
+			destEntry.SetText(key.Key + ":" + target)
+		}
+		summaryLabel.SetText(i18n.T("Reading '%s'...", key.Key))
+		var current, out *models.KeyDump
+		kb.worker.Go(func(ctx context.Context) (err error) {
+			if current, err = client.WithContext(ctx).DumpKey(key.Key); err != nil {
+				return err
+			}
+			out, err = redis.ConvertDump(current, target)
+			return err
+		}, func(err error) {
+			if targetSelect.Selected != target {
+				return
+			}
+			if err != nil {
+				summaryLabel.SetText(i18n.T("Error: %s", err))
+				previewText.SetText("")
+				return
+			}
+			preview, converted = current, out
+			text, err := dumpValueText(out)
+			if err != nil {
+				summaryLabel.SetText(i18n.T("Error: %s", err))
+				return
+			}
+			if len(text) > convertPreviewChars {
+				text = text[:convertPreviewChars] + "..."
+			}
+			previewText.SetText(text)
+			summaryLabel.SetText(i18n.T("%s with %s becomes %s with %s", current.Type, describeSize(current), out.Type, describeSize(out)))
+			convertBtn.Enable()
+		})
+	}
+
+	form := widget.NewForm(
+		&widget.FormItem{Text: i18n.T("Key"), Widget: widget.NewLabel(key.Key)},
+		&widget.FormItem{Text: i18n.T("Convert to"), Widget: targetSelect},
+		&widget.FormItem{Text: i18n.T("Write"), Widget: destRadio},
+		&widget.FormItem{Text: "", Widget: destEntry, HintText: i18n.T("The new key gets the TTL of this one")},
+	)
+	content := container.NewBorder(
+		container.NewVBox(form, summaryLabel),
+		nil, nil, nil,
+		previewText,
+	)
+
+	var d *dialog.CustomDialog
+	convertBtn.OnTapped = func() {
+		dest := key.Key
+		if destRadio.Selected == convertNewKey {
+			if dest = strings.TrimSpace(destEntry.Text); dest == "" {
+				dialog.ShowError(fmt.Errorf("enter a name for the new key"), kb.window)
+				return
+			}
+		}
+		from, target := preview, converted.Type
+		d.Hide()
+		var snapshots []models.KeySnapshot
+		kb.worker.Do(kb.window, client, func(c *redis.Client) error {
+			// A new key didn't exist, so only overwriting can be undone
+			if dest == key.Key {
+				snapshots = snapshotKeys(c, []string{key.Key})
+			}
+			return c.ConvertKey(from, target, dest, false)
+		}, func() {
+			kb.undo.push(client, i18n.T("Converted '%s' to %s", key.Key, target), snapshots, true)
+			kb.selectedKey = dest
+			kb.LoadKeys()
+			if kb.onKeySelected != nil {
+				kb.onKeySelected(models.RedisKey{Key: dest, Type: target, TTL: key.TTL})
+			}
+		})
+	}
+	cancelBtn := widget.NewButton(i18n.T("Cancel"), func() { d.Hide() })
+
+	d = dialog.NewCustomWithoutButtons(i18n.T("Convert Type"), content, kb.window)
+	d.SetButtons([]fyne.CanvasObject{cancelBtn, convertBtn})
+	d.Resize(fyne.NewSize(560, 520))
+	d.Show()
+	targetSelect.SetSelectedIndex(0)
+}
+
+// describeSize names how much a dumped value holds, for the conversion summary
+func describeSize(dump *models.KeyDump) string {
+	switch dump.Type {
+	case "string":
+		return formatBytes(int64(len(dump.Value)))
+	case "hash":
+		return i18n.T("%d fields", len(dump.Fields))
+	case "zset":
+		return i18n.T("%d members", len(dump.Members))
+	case "set":
+		return i18n.T("%d members", len(dump.Items))
+	}
+	return i18n.T("%d elements", len(dump.Items))
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// counterSamples is how many values the counter view charts
+const counterSamples = 60
+
+// isCounter reports whether a string value is an integer INCR accepts
+func isCounter(value string) bool {
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil
+}
+
+// counterButtons builds the INCR, DECR and INCRBY controls of an integer
+// string. done is called with the new value after each change.
+func (ve *ValueEditor) counterButtons(key models.RedisKey, done func(value int64)) fyne.CanvasObject {
+	change := func(delta int64) {
+		if refuseReadOnly(ve.window, ve.client) {
+			return
+		}
+		client := ve.client
+		var value int64
+		var snapshots []models.KeySnapshot
+		ve.worker.Do(ve.window, client, func(c *redis.Client) (err error) {
+			snapshots = snapshotKeys(c, []string{key.Key})
+			value, err = c.Increment(key.Key, delta)
+			return err
+		}, func() {
+			ve.undo.push(client, i18n.T("Edited '%s'", key.Key), snapshots, false)
+			done(value)
+		})
+	}
+
+	decrBtn := widget.NewButtonWithIcon("DECR", theme.ContentRemoveIcon(), func() { change(-1) })
+	incrBtn := widget.NewButtonWithIcon("INCR", theme.ContentAddIcon(), func() { change(1) })
+	byEntry := widget.NewEntry()
+	byEntry.SetText("10")
+	incrByBtn := widget.NewButton("INCRBY", func() {
+		delta, err := strconv.ParseInt(strings.TrimSpace(byEntry.Text), 10, 64)
+		if err != nil {
+			ShowErrorDialog(ve.window, "Invalid Amount", fmt.Errorf("the amount must be a whole number, negative to decrease"))
+			return
+		}
+		change(delta)
+	})
+	setWritable(ve.client, decrBtn, incrBtn, incrByBtn)
+	return container.NewHBox(decrBtn, incrBtn, widget.NewSeparator(),
+		container.NewGridWrap(fyne.NewSize(120, byEntry.MinSize().Height), byEntry), incrByBtn)
+}
+
+// withCounterMode adds a "Counter mode" checkbox that swaps the string editor
+// for the counter view, stopping the view's polling when it is left
+func (ve *ValueEditor) withCounterMode(key models.RedisKey, editor fyne.CanvasObject) fyne.CanvasObject {
+	body := container.NewStack(editor)
+	toggle := widget.NewCheck(i18n.T("Counter mode"), func(on bool) {
+		ve.stopCounterPoll()
+		if on {
+			body.Objects = []fyne.CanvasObject{ve.buildCounterView(key)}
+		} else {
+			body.Objects = []fyne.CanvasObject{editor}
+		}
+		body.Refresh()
+	})
+	return container.NewBorder(toggle, nil, nil, nil, body)
+}
+
+// stopCounterPoll stops the counter view from re-reading its key
+func (ve *ValueEditor) stopCounterPoll() {
+	if ve.stopCounter != nil {
+		close(ve.stopCounter)
+		ve.stopCounter = nil
+	}
+}
+
+// buildCounterView shows an integer string as a counter: its value, re-read
+// every LiveRefreshSecs and charted, with buttons that change it atomically
+func (ve *ValueEditor) buildCounterView(key models.RedisKey) fyne.CanvasObject {
+	valueLabel := widget.NewLabelWithStyle("...", fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true})
+	valueLabel.SizeName = theme.SizeNameHeadingText
+	statusLabel := widget.NewLabelWithStyle("", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	chart := newSparkline()
+
+	var samples []float64
+	show := func(value int64) {
+		valueLabel.SetText(strconv.FormatInt(value, 10))
+		samples = append(samples, float64(value))
+		if len(samples) > counterSamples {
+			samples = samples[len(samples)-counterSamples:]
+		}
+		chart.SetValues(samples)
+	}
+
+	polling := false
+	poll := func() {
+		if polling || ve.client == nil || ve.currentKey == nil || ve.currentKey.Key != key.Key {
+			return
+		}
+		polling = true
+		client := ve.client
+		var text string
+		ve.worker.Go(func(ctx context.Context) (err error) {
+			text, err = client.WithContext(ctx).GetString(key.Key)
+			return err
+		}, func(err error) {
+			polling = false
+			if err != nil {
+				statusLabel.SetText(i18n.T("Error: %s", err))
+				return
+			}
+			value, err := strconv.ParseInt(text, 10, 64)
+			if err != nil {
+				statusLabel.SetText(i18n.T("The value is no longer an integer"))
+				return
+			}
+			statusLabel.SetText(i18n.T("Updated %s", time.Now().Format(time.TimeOnly)))
+			show(value)
+		})
+	}
+
+	buttons := ve.counterButtons(key, func(value int64) {
+		show(value)
+		if ve.onKeyUpdated != nil {
+			ve.onKeyUpdated()
+		}
+	})
+
+	interval := max(config.Get().LiveRefreshSecs, 1)
+	stop := make(chan struct{})
+	ve.stopCounter = stop
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(func() {
+					if ve.stopCounter == stop {
+						poll()
+					}
+				})
+			case <-stop:
+				return
+			}
+		}
+	}()
+	poll()
+
+	return container.NewVBox(
+		valueLabel,
+		statusLabel,
+		chart,
+		container.NewCenter(buttons),
+	)
+}
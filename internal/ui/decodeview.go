@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/decode"
+	"redis-explorer/internal/i18n"
+)
+
+// newDecodeView wraps a value editor with a "View as" bar that shows the value run
+// through a chain of decoders, e.g. Base64 then Gzip. Decoded output is read-only;
+// resetting the chain brings the editor back. detected is the chain suggested by
+// auto-detection, if any.
+func newDecodeView(window fyne.Window, value string, detected []string, editor fyne.CanvasObject) fyne.CanvasObject {
+	var chain []string
+
+	chainLabel := widget.NewLabel("")
+	output := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	output.Selectable = true
+	outputScroll := container.NewScroll(output)
+	body := container.NewStack(editor)
+
+	stepSelect := widget.NewSelect(decode.Names(), nil)
+	stepSelect.PlaceHolder = i18n.T("Add decoder...")
+	resetBtn := widget.NewButtonWithIcon(i18n.T("Reset"), theme.ContentUndoIcon(), nil)
+
+	var apply func()
+	apply = func() {
+		if len(chain) == 0 {
+			text := i18n.T("Stored value")
+			if len(detected) > 0 {
+				text = i18n.T("Stored value - looks like %s", strings.Join(detected, " → "))
+			}
+			chainLabel.SetText(text)
+			body.Objects = []fyne.CanvasObject{editor}
+			resetBtn.Disable()
+			body.Refresh()
+			return
+		}
+
+		decoded, err := decode.Chain([]byte(value), chain...)
+		if err != nil {
+			ShowErrorDialog(window, "Decode Error", err)
+			chain = chain[:len(chain)-1]
+			apply()
+			return
+		}
+		text := decode.Printable(decoded)
+		if looksLikeJSON(text) {
+			text, _ = formatJSON(text)
+		}
+		output.SetText(text)
+		outputScroll.ScrollToTop()
+		chainLabel.SetText(strings.Join(chain, " → ") + " (read-only)")
+		body.Objects = []fyne.CanvasObject{outputScroll}
+		resetBtn.Enable()
+		body.Refresh()
+	}
+
+	stepSelect.OnChanged = func(name string) {
+		if name == "" {
+			return
+		}
+		chain = append(chain, name)
+		stepSelect.ClearSelected()
+		apply()
+	}
+
+	autoBtn := widget.NewButtonWithIcon(i18n.T("Auto"), theme.SearchIcon(), func() {
+		if len(detected) == 0 {
+			ShowInfoDialog(window, "View As", "No known encoding was detected for this value.")
+			return
+		}
+		chain = append([]string(nil), detected...)
+		apply()
+	})
+
+	resetBtn.OnTapped = func() {
+		chain = nil
+		apply()
+	}
+
+	apply()
+
+	bar := container.NewBorder(nil, nil,
+		widget.NewLabel(i18n.T("View as:")),
+		container.NewHBox(stepSelect, autoBtn, resetBtn),
+		chainLabel,
+	)
+	return container.NewBorder(bar, nil, nil, nil, body)
+}
@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"slices"
+
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/redis"
+)
+
+// SetDelimiter sets the key namespace delimiter of the connection, or "" to
+// detect it from the loaded keys
+func (kb *KeyBrowser) SetDelimiter(setting string) {
+	kb.delimSetting = setting
+	if kb.updateDelimiter() {
+		// A scope is a prefix under the old delimiter; clearing it re-filters the keys
+		kb.clearScope()
+	}
+}
+
+// Delimiter returns the key namespace delimiter in use
+func (kb *KeyBrowser) Delimiter() string {
+	return kb.delimiter
+}
+
+// SetOnDelimiterChanged sets the callback for when the delimiter is changed from
+// the toolbar or a different one is detected; setting is "" for auto-detect
+func (kb *KeyBrowser) SetOnDelimiterChanged(f func(setting, delimiter string)) {
+	kb.onDelimChange = f
+}
+
+// selectDelimiter applies a delimiter picked from the toolbar dropdown, whose
+// first option is auto-detect and last one a custom delimiter
+func (kb *KeyBrowser) selectDelimiter(option string) {
+	switch kb.delimSelect.SelectedIndex() {
+	case 0:
+		kb.SetDelimiter("")
+	case len(kb.delimSelect.Options) - 1:
+		// Show the current delimiter again unless a new one is saved
+		kb.showDelimiter()
+		ShowDelimiterDialog(kb.window, kb.delimiter, kb.SetDelimiter)
+	default:
+		kb.SetDelimiter(option)
+	}
+}
+
+// updateDelimiter works out the delimiter from the setting, detecting it from
+// the loaded keys when the setting is empty, and reports whether it changed
+func (kb *KeyBrowser) updateDelimiter() bool {
+	delimiter := kb.delimSetting
+	if delimiter == "" {
+		delimiter = redis.DetectDelimiter(kb.keys)
+	}
+	changed := delimiter != kb.delimiter
+	kb.delimiter = delimiter
+	kb.showDelimiter()
+
+	if kb.onDelimChange != nil {
+		kb.onDelimChange(kb.delimSetting, kb.delimiter)
+	}
+	return changed
+}
+
+// showDelimiter lists the common delimiters in the toolbar dropdown, with the
+// detected one next to "Auto" while detecting, and selects the current setting
+func (kb *KeyBrowser) showDelimiter() {
+	auto := i18n.T("Auto (%s)", kb.delimiter)
+	if kb.delimSetting != "" {
+		auto = i18n.T("Auto")
+	}
+	options := append([]string{auto}, redis.CommonDelimiters...)
+	selected := auto
+	if kb.delimSetting != "" {
+		selected = kb.delimSetting
+		if !slices.Contains(redis.CommonDelimiters, selected) {
+			options = append(options, selected)
+		}
+	}
+	kb.delimSelect.Options = append(options, i18n.T("Custom..."))
+	kb.delimSelect.Selected = selected
+	kb.delimSelect.Refresh()
+}
@@ -1,9 +1,13 @@
 package ui
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
@@ -11,6 +15,9 @@ import (
 	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/decode"
+	"redis-explorer/internal/i18n"
 	"redis-explorer/internal/models"
 	"redis-explorer/internal/redis"
 )
@@ -53,9 +60,9 @@ func (el *EditableLabel) showEditDialog() {
 	entry.SetText(el.text)
 	entry.MultiLine = false
 
-	dialog.ShowForm(fmt.Sprintf("Edit %s", el.fieldName), "Save", "Cancel",
+	dialog.ShowForm(i18n.T("Edit %s", i18n.T(el.fieldName)), i18n.T("Save"), i18n.T("Cancel"),
 		[]*widget.FormItem{
-			{Text: el.fieldName, Widget: entry},
+			{Text: i18n.T(el.fieldName), Widget: entry},
 		},
 		func(save bool) {
 			if save && el.onEdit != nil {
@@ -80,17 +87,46 @@ type ValueEditor struct {
 	keyLabel     *widget.Label
 	typeLabel    *widget.Label
 	ttlLabel     *widget.Label
+	memoryLabel  *widget.Label
+	liveCheck    *widget.Check
+	ttlBtn       *widget.Button
+	renameBtn    *widget.Button
 	contentArea  *fyne.Container
 	client       *redis.Client
+	worker       *Worker
+	undo         *UndoStack
 	currentKey   *models.RedisKey
 	window       fyne.Window
 	onKeyUpdated func()
+	detachBtn    *widget.Button
+	onDetach     func()
+
+	// Live refresh re-reads the current key on an interval
+	stopLive  chan struct{}
+	reloading bool
+
+	// The TTL label counts down to expiresAt while it is set
+	expiresAt     time.Time
+	stopCountdown chan struct{}
+
+	// The collapsible Details section shows OBJECT metadata while open
+	detailsBtn   *widget.Button
+	detailsBox   *fyne.Container
+	detailLabels []*widget.Label
+	detailsOpen  bool
+
+	// The queue view of a list polls it until closed
+	stopQueue chan struct{}
+	// The counter view of an integer string polls it until closed
+	stopCounter chan struct{}
 }
 
 // NewValueEditor creates a new value editor panel
-func NewValueEditor(window fyne.Window) *ValueEditor {
+func NewValueEditor(window fyne.Window, worker *Worker, undo *UndoStack) *ValueEditor {
 	ve := &ValueEditor{
 		window: window,
+		worker: worker,
+		undo:   undo,
 	}
 	ve.ExtendBaseWidget(ve)
 	ve.buildUI()
@@ -98,45 +134,200 @@ func NewValueEditor(window fyne.Window) *ValueEditor {
 }
 
 func (ve *ValueEditor) buildUI() {
-	ve.keyLabel = widget.NewLabelWithStyle("No key selected", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	ve.keyLabel = widget.NewLabelWithStyle(i18n.T("No key selected"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
 	ve.typeLabel = widget.NewLabel("")
 	ve.ttlLabel = widget.NewLabel("")
+	ve.memoryLabel = widget.NewLabel("")
 
-	ttlBtn := widget.NewButtonWithIcon("Set TTL", theme.HistoryIcon(), func() {
+	ve.ttlBtn = widget.NewButtonWithIcon(i18n.T("Set TTL"), theme.HistoryIcon(), func() {
 		if ve.currentKey == nil || ve.client == nil {
 			return
 		}
-		ShowTTLDialog(ve.window, ve.currentKey.TTL, func(ttl int64) {
-			err := ve.client.SetTTL(ve.currentKey.Key, ttl)
-			if err != nil {
-				ShowErrorDialog(ve.window, "Error", err)
-				return
+		keyName := ve.currentKey.Key
+		ShowTTLDialog(ve.window, ve.remainingTTL(), func(exp models.Expiry) {
+			ve.worker.Do(ve.window, ve.client, func(c *redis.Client) error {
+				return c.SetTTL(keyName, exp)
+			}, func() {
+				ve.refreshTTL()
+			})
+		})
+	})
+
+	copyBtn := widget.NewButtonWithIcon(i18n.T("Copy To..."), theme.ContentCopyIcon(), func() {
+		ve.showCopyKey()
+	})
+
+	ve.renameBtn = widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), func() {
+		if ve.currentKey == nil {
+			return
+		}
+		renamed := *ve.currentKey
+		promptRenameKey(ve.window, ve.worker, ve.client, renamed.Key, func(newKey string) {
+			renamed.Key = newKey
+			ve.LoadKey(renamed)
+			if ve.onKeyUpdated != nil {
+				ve.onKeyUpdated()
 			}
-			ve.refreshTTL()
 		})
 	})
+	ve.renameBtn.Importance = widget.LowImportance
+
+	copyNameBtn := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() {
+		if ve.currentKey != nil {
+			fyne.CurrentApp().Clipboard().SetContent(ve.currentKey.Key)
+		}
+	})
+	copyNameBtn.Importance = widget.LowImportance
+
+	ve.liveCheck = widget.NewCheck(i18n.T("Live"), func(on bool) {
+		if on {
+			ve.startLive()
+		} else {
+			ve.stopLiveRefresh()
+		}
+	})
+
+	detailsBtn, details := ve.buildDetails()
+
+	ve.detachBtn = widget.NewButtonWithIcon("", theme.ViewFullScreenIcon(), func() {
+		if ve.onDetach != nil {
+			ve.onDetach()
+		}
+	})
+	ve.detachBtn.Importance = widget.LowImportance
 
 	header := container.NewVBox(
-		ve.keyLabel,
-		container.NewHBox(ve.typeLabel, ve.ttlLabel, ttlBtn),
+		container.NewBorder(nil, nil, nil, ve.detachBtn, container.NewHBox(ve.keyLabel, ve.renameBtn, copyNameBtn)),
+		container.NewHBox(ve.typeLabel, ve.ttlLabel, ve.memoryLabel, ve.ttlBtn, copyBtn, ve.liveCheck, detailsBtn),
+		details,
 		widget.NewSeparator(),
 	)
 
-	ve.contentArea = container.NewMax(widget.NewLabel("Select a key to view its value"))
+	ve.contentArea = container.NewMax(widget.NewLabel(i18n.T("Select a key to view its value")))
 
 	ve.container = container.NewBorder(header, nil, nil, nil, ve.contentArea)
 }
 
+// showCopyKey copies the current key to another database or saved connection
+func (ve *ValueEditor) showCopyKey() {
+	if ve.currentKey == nil || ve.client == nil {
+		return
+	}
+	key := ve.currentKey.Key
+	client := ve.client
+	current := client.Connection()
+
+	ShowCopyKeyDialog(ve.window, key, config.Get().Connections, current,
+		func(target models.ServerConnection, newKey string, replace bool) {
+			ve.worker.Do(ve.window, client, func(c *redis.Client) error {
+				return c.CopyKeyToConnection(key, target, newKey, replace)
+			}, func() {
+				// A copy into the database being browsed shows up in the key list
+				if target.ID == current.ID && target.Database == client.Connection().Database && ve.onKeyUpdated != nil {
+					ve.onKeyUpdated()
+				}
+				ve.worker.Report(i18n.T("Copied '%s' to '%s' in %s, DB %d", key, newKey, target.Name, target.Database), nil)
+			})
+		})
+}
+
 func (ve *ValueEditor) refreshTTL() {
 	if ve.currentKey == nil || ve.client == nil {
 		return
 	}
-	ttl, _ := ve.client.GetTTL(ve.currentKey.Key)
-	ve.currentKey.TTL = ttl
+	key := ve.currentKey
+	client := ve.client
+	var ttl int64
+	ve.worker.Go(func(ctx context.Context) error {
+		var err error
+		ttl, err = client.WithContext(ctx).GetTTL(key.Key)
+		return err
+	}, func(err error) {
+		if err != nil || ve.currentKey != key {
+			return
+		}
+		key.TTL = ttl
+		ve.setTTLLabel(ttl)
+	})
+}
+
+// refreshMemory shows the approximate size of the current key from MEMORY USAGE
+func (ve *ValueEditor) refreshMemory() {
+	ve.memoryLabel.SetText("")
+	if ve.currentKey == nil || ve.client == nil {
+		return
+	}
+	key := ve.currentKey
+	client := ve.client
+	var mem int64
+	ve.worker.Go(func(ctx context.Context) error {
+		var err error
+		mem, err = client.WithContext(ctx).MemoryUsage(key.Key)
+		return err
+	}, func(err error) {
+		// Servers before 4.0 and restrictive ACLs don't allow MEMORY USAGE
+		if err != nil || ve.currentKey != key {
+			return
+		}
+		ve.memoryLabel.SetText(i18n.T("Memory: %s", formatBytes(mem)))
+	})
+}
+
+// setTTLLabel shows the TTL of the current key, counting down once a second
+// while it has one
+func (ve *ValueEditor) setTTLLabel(ttl int64) {
+	ve.stopTTLCountdown()
 	if ttl < 0 {
-		ve.ttlLabel.SetText("TTL: No expiry")
-	} else {
-		ve.ttlLabel.SetText(fmt.Sprintf("TTL: %ds", ttl))
+		ve.ttlLabel.SetText(i18n.T("TTL: No expiry"))
+		return
+	}
+
+	ve.expiresAt = time.Now().Add(time.Duration(ttl) * time.Second)
+	ve.tickTTL()
+	stop := make(chan struct{})
+	ve.stopCountdown = stop
+	go func() {
+		ticker := time.NewTicker(time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(ve.tickTTL)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// tickTTL updates the TTL label from the time left until the key expires
+func (ve *ValueEditor) tickTTL() {
+	// A tick queued before the countdown stopped
+	if ve.expiresAt.IsZero() {
+		return
+	}
+	remaining := ve.remainingTTL()
+	if remaining <= 0 {
+		ve.stopTTLCountdown()
+		ve.ttlLabel.SetText(i18n.T("TTL: Expired"))
+		return
+	}
+	ve.ttlLabel.SetText(i18n.T("TTL: %s", formatTTL(remaining)))
+}
+
+// remainingTTL is the current key's TTL in seconds as of now, -1 without expiry
+func (ve *ValueEditor) remainingTTL() int64 {
+	if ve.expiresAt.IsZero() {
+		return -1
+	}
+	return max(int64(time.Until(ve.expiresAt).Round(time.Second).Seconds()), 0)
+}
+
+func (ve *ValueEditor) stopTTLCountdown() {
+	ve.expiresAt = time.Time{}
+	if ve.stopCountdown != nil {
+		close(ve.stopCountdown)
+		ve.stopCountdown = nil
 	}
 }
 
@@ -148,6 +339,7 @@ func (ve *ValueEditor) CreateRenderer() fyne.WidgetRenderer {
 // SetClient sets the Redis client
 func (ve *ValueEditor) SetClient(client *redis.Client) {
 	ve.client = client
+	setWritable(client, ve.ttlBtn, ve.renameBtn)
 }
 
 // SetOnKeyUpdated sets the callback for when a key is updated
@@ -155,357 +347,1171 @@ func (ve *ValueEditor) SetOnKeyUpdated(f func()) {
 	ve.onKeyUpdated = f
 }
 
+// SetOnDetach sets the callback for the button moving the editor into its own
+// window and back
+func (ve *ValueEditor) SetOnDetach(f func()) {
+	ve.onDetach = f
+}
+
+// SetWindow moves the editor's dialogs to the window now showing it. The open
+// key is reloaded so its views use the new window.
+func (ve *ValueEditor) SetWindow(window fyne.Window, detached bool) {
+	ve.window = window
+	if detached {
+		ve.detachBtn.SetIcon(theme.ViewRestoreIcon())
+	} else {
+		ve.detachBtn.SetIcon(theme.ViewFullScreenIcon())
+	}
+	ve.Reload()
+}
+
 // LoadKey loads a key's value into the editor
 func (ve *ValueEditor) LoadKey(key models.RedisKey) {
+	// Live refresh is per key; reloading the same key after an edit keeps it on
+	if ve.currentKey == nil || ve.currentKey.Key != key.Key {
+		ve.liveCheck.SetChecked(false)
+	}
 	ve.currentKey = &key
 	ve.keyLabel.SetText(key.Key)
-	ve.typeLabel.SetText(fmt.Sprintf("Type: %s", key.Type))
+	ve.typeLabel.SetText(i18n.T("Type: %s", key.Type))
+	ve.setTTLLabel(key.TTL)
+	ve.refreshMemory()
 
-	if key.TTL < 0 {
-		ve.ttlLabel.SetText("TTL: No expiry")
-	} else {
-		ve.ttlLabel.SetText(fmt.Sprintf("TTL: %ds", key.TTL))
+	if ve.detailsOpen {
+		ve.refreshDetails(func() { ve.loadValueEditor(key, false) })
+		return
+	}
+	ve.loadValueEditor(key, false)
+}
+
+// Reload re-reads the open key, e.g. after an undo
+func (ve *ValueEditor) Reload() {
+	if ve.currentKey != nil {
+		ve.LoadKey(*ve.currentKey)
+	}
+}
+
+// startLive re-reads the current key every LiveRefreshSecs until stopped
+func (ve *ValueEditor) startLive() {
+	ve.stopLiveRefresh()
+	if ve.currentKey == nil {
+		ve.liveCheck.SetChecked(false)
+		return
+	}
+
+	interval := max(config.Get().LiveRefreshSecs, 1)
+	stop := make(chan struct{})
+	ve.stopLive = stop
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(ve.liveReload)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// stopLiveRefresh stops re-reading the current key
+func (ve *ValueEditor) stopLiveRefresh() {
+	if ve.stopLive != nil {
+		close(ve.stopLive)
+		ve.stopLive = nil
 	}
+}
 
-	ve.loadValueEditor(key)
+// liveReload re-reads the current key's value and TTL in place, skipping a tick
+// while the previous read is still running
+func (ve *ValueEditor) liveReload() {
+	if ve.stopLive == nil || ve.currentKey == nil || ve.client == nil || ve.reloading {
+		return
+	}
+	key := ve.currentKey
+	client := ve.client
+	var exists bool
+	ve.reloading = true
+	ve.worker.Go(func(ctx context.Context) (err error) {
+		exists, err = client.WithContext(ctx).KeyExists(key.Key)
+		return
+	}, func(err error) {
+		ve.reloading = false
+		if err != nil || ve.currentKey != key {
+			return
+		}
+		if !exists {
+			// Popped, deleted or expired; there is nothing left to watch
+			ve.liveCheck.SetChecked(false)
+			ve.setContent(widget.NewLabel(i18n.T("Key no longer exists")))
+			return
+		}
+		ve.refreshTTL()
+		ve.loadValueEditor(*key, true)
+	})
 }
 
-func (ve *ValueEditor) loadValueEditor(key models.RedisKey) {
+// loadValueEditor fetches key's value and rebuilds the editor for it. A quiet
+// load keeps showing the current editor until the new value arrives.
+func (ve *ValueEditor) loadValueEditor(key models.RedisKey, quiet bool) {
 	if ve.client == nil {
 		return
 	}
 
-	var content fyne.CanvasObject
+	// Each type fetches its value off the UI thread, then builds its editor from the result
+	var fetch func(c *redis.Client) error
+	var build func() fyne.CanvasObject
 
 	switch key.Type {
 	case "string":
-		content = ve.buildStringEditor(key)
+		var value string
+		var length int64
+		var detected []string
+		fetch = func(c *redis.Client) (err error) {
+			// Very large strings are read a chunk at a time by their own viewer
+			if length, err = c.StringLength(key.Key); err != nil || length > largeStringBytes {
+				return err
+			}
+			if value, err = c.GetString(key.Key); err == nil && !looksLikeJSON(value) {
+				detected = decode.Detect([]byte(value))
+			}
+			return
+		}
+		build = func() fyne.CanvasObject {
+			if length > largeStringBytes {
+				return ve.buildLargeStringEditor(key, length)
+			}
+			return newDecodeView(ve.window, value, detected, ve.buildStringEditor(key, value))
+		}
 	case "list":
-		content = ve.buildListEditor(key)
+		// Collections load their first page; the editor's pager fetches the rest
+		var items []string
+		var total int64
+		fetch = func(c *redis.Client) (err error) {
+			if total, err = c.CollectionLength(key.Key, key.Type); err != nil {
+				return err
+			}
+			items, err = c.GetListRange(key.Key, 0, collectionPageSize())
+			return err
+		}
+		build = func() fyne.CanvasObject { return ve.buildListEditor(key, items, total) }
 	case "set":
-		content = ve.buildSetEditor(key)
+		var members []string
+		var total int64
+		var cursor uint64
+		fetch = func(c *redis.Client) (err error) {
+			if total, err = c.CollectionLength(key.Key, key.Type); err != nil {
+				return err
+			}
+			members, cursor, err = c.ScanSet(key.Key, 0, "", collectionPageSize())
+			return err
+		}
+		build = func() fyne.CanvasObject { return ve.buildSetEditor(key, members, total, cursor) }
 	case "hash":
-		content = ve.buildHashEditor(key)
+		var hash map[string]string
+		var total int64
+		var cursor uint64
+		fetch = func(c *redis.Client) (err error) {
+			if total, err = c.CollectionLength(key.Key, key.Type); err != nil {
+				return err
+			}
+			hash, cursor, err = c.ScanHash(key.Key, 0, "", collectionPageSize())
+			return err
+		}
+		build = func() fyne.CanvasObject { return ve.buildHashEditor(key, hash, total, cursor) }
 	case "zset":
-		content = ve.buildZSetEditor(key)
+		var members []models.ScoredValue
+		var total int64
+		fetch = func(c *redis.Client) (err error) {
+			if total, err = c.CollectionLength(key.Key, key.Type); err != nil {
+				return err
+			}
+			members, err = c.GetSortedSetRange(key.Key, 0, collectionPageSize())
+			return err
+		}
+		build = func() fyne.CanvasObject { return ve.buildZSetEditor(key, members, total) }
+	case "stream":
+		var entries []models.StreamEntry
+		var groups []models.StreamGroup
+		var length int64
+		fetch = func(c *redis.Client) (err error) {
+			if length, err = c.StreamLength(key.Key); err != nil {
+				return err
+			}
+			if entries, err = c.GetStream(key.Key, streamPageSize); err != nil {
+				return err
+			}
+			groups, err = c.GetStreamGroups(key.Key)
+			return err
+		}
+		build = func() fyne.CanvasObject { return ve.buildStreamEditor(key, entries, length, groups) }
+	case redis.JSONType:
+		var doc string
+		fetch = func(c *redis.Client) (err error) {
+			// MODULE LIST may be denied by ACLs, in which case the read is tried anyway
+			if loaded, err := c.HasJSONModule(); err == nil && !loaded {
+				return redis.ErrJSONModuleMissing
+			}
+			doc, err = c.JSONGet(key.Key, "$")
+			return err
+		}
+		build = func() fyne.CanvasObject { return ve.buildJSONEditor(key, doc) }
 	default:
-		content = widget.NewLabel("Unsupported key type: " + key.Type)
+		ve.setContent(widget.NewLabel(i18n.T("Unsupported key type: %s", key.Type)))
+		return
+	}
+
+	if !quiet {
+		ve.setContent(widget.NewLabel(i18n.T("Loading...")))
 	}
+	current := ve.currentKey
+	client := ve.client
+	ve.worker.Go(func(ctx context.Context) error {
+		return fetch(client.WithContext(ctx))
+	}, func(err error) {
+		// Ignore results for a key that is no longer displayed
+		if ve.currentKey != current {
+			return
+		}
+		if err != nil {
+			ve.setContent(widget.NewLabel(i18n.T("Error: %s", err)))
+			return
+		}
+		ve.setContent(build())
+	})
+}
 
+func (ve *ValueEditor) setContent(content fyne.CanvasObject) {
+	ve.stopQueuePoll()
+	ve.stopCounterPoll()
 	ve.contentArea.RemoveAll()
 	ve.contentArea.Add(content)
 	ve.contentArea.Refresh()
 }
 
-func (ve *ValueEditor) buildStringEditor(key models.RedisKey) fyne.CanvasObject {
-	value, err := ve.client.GetString(key.Key)
-	if err != nil {
-		return widget.NewLabel("Error: " + err.Error())
-	}
-
+func (ve *ValueEditor) buildStringEditor(key models.RedisKey, value string) fyne.CanvasObject {
 	entry := widget.NewMultiLineEntry()
 	entry.SetText(value)
-	entry.Wrapping = fyne.TextWrapWord
+	entry.TextStyle = valueTextStyle()
+	entry.Wrapping = valueWrapping()
+
+	save := func(value string) {
+		var snapshots []models.KeySnapshot
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) error {
+			snapshots = snapshotKeys(c, []string{key.Key})
+			return c.SetString(key.Key, value)
+		}, func() {
+			ve.undo.push(ve.client, i18n.T("Saved '%s'", key.Key), snapshots, true)
+			ve.worker.Report(i18n.T("Saved '%s'", key.Key), nil)
+			if ve.onKeyUpdated != nil {
+				ve.onKeyUpdated()
+			}
+		})
+	}
 
-	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), func() {
-		err := ve.client.SetString(key.Key, entry.Text)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+	hint := widget.NewLabelWithStyle(i18n.T("Edit the value above and click Save"), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+
+	// Copies the value as shown, including unsaved edits
+	copyBtn := widget.NewButtonWithIcon(i18n.T("Copy"), theme.ContentCopyIcon(), func() {
+		fyne.CurrentApp().Clipboard().SetContent(entry.Text)
+	})
+
+	if !looksLikeJSON(value) {
+		saveBtn := widget.NewButtonWithIcon(i18n.T("Save"), theme.DocumentSaveIcon(), func() {
+			save(entry.Text)
+		})
+		setWritable(ve.client, saveBtn)
+		buttons := container.NewBorder(nil, nil, nil, copyBtn, saveBtn)
+		editor := fyne.CanvasObject(container.NewBorder(nil, container.NewVBox(hint, buttons), nil, nil, entry))
+		if isCounter(value) {
+			// Integers change atomically on the server instead of by rewriting them
+			counterBar := ve.counterButtons(key, func(int64) { ve.LoadKey(key) })
+			editor = ve.withCounterMode(key, container.NewBorder(nil, container.NewVBox(hint, counterBar, buttons), nil, nil, entry))
+		}
+		return withViewToggle("Treat as bitmap", editor,
+			func() fyne.CanvasObject { return ve.buildBitmapEditor(key) })
+	}
+
+	// JSON values open formatted; Raw shows the value exactly as stored
+	formatted, _ := formatJSON(value)
+	entry.SetText(formatted)
+	entry.TextStyle = fyne.TextStyle{Monospace: true}
+	entry.Wrapping = fyne.TextWrapOff
+	body := container.NewStack(entry)
+	// Display modes, also what the mode radio shows
+	jsonModeRaw, jsonModeFormatted, jsonModeTree := i18n.T("Raw"), i18n.T("Formatted"), i18n.T("Tree")
+	mode := jsonModeFormatted
+
+	modes := widget.NewRadioGroup([]string{jsonModeRaw, jsonModeFormatted, jsonModeTree}, nil)
+	modes.Horizontal = true
+	modes.Required = true
+	modes.SetSelected(mode)
+	modes.OnChanged = func(selected string) {
+		switch selected {
+		case jsonModeRaw:
+			if formatted, err := formatJSON(value); err == nil && entry.Text == formatted {
+				entry.SetText(value)
+			} else if compact, err := compactJSON(entry.Text); err == nil {
+				entry.SetText(compact)
+			}
+			entry.TextStyle = valueTextStyle()
+			entry.Wrapping = valueWrapping()
+			body.Objects = []fyne.CanvasObject{entry}
+		case jsonModeFormatted:
+			formatted, err := formatJSON(entry.Text)
+			if err != nil {
+				ShowErrorDialog(ve.window, "Invalid JSON", err)
+				modes.SetSelected(mode)
+				return
+			}
+			entry.SetText(formatted)
+			entry.TextStyle = fyne.TextStyle{Monospace: true}
+			entry.Wrapping = fyne.TextWrapOff
+			body.Objects = []fyne.CanvasObject{entry}
+		case jsonModeTree:
+			nodes, err := parseJSONTree(entry.Text)
+			if err != nil {
+				ShowErrorDialog(ve.window, "Invalid JSON", err)
+				modes.SetSelected(mode)
+				return
+			}
+			body.Objects = []fyne.CanvasObject{newJSONTree(nodes)}
+		}
+		mode = selected
+		entry.Refresh()
+		body.Refresh()
+	}
+
+	// Formatted and tree edits are validated and stored compact
+	saveBtn := widget.NewButtonWithIcon(i18n.T("Save"), theme.DocumentSaveIcon(), func() {
+		text := entry.Text
+		if mode == jsonModeRaw {
+			save(text)
 			return
 		}
-		ShowInfoDialog(ve.window, "Success", "Value saved")
-		if ve.onKeyUpdated != nil {
-			ve.onKeyUpdated()
+		compact, err := compactJSON(text)
+		if err != nil {
+			ShowConfirmDialog(ve.window, "Invalid JSON",
+				i18n.T("The value is not valid JSON: %v\n\nSave it anyway?", err),
+				func() { save(text) })
+			return
 		}
+		save(compact)
 	})
 
-	hint := widget.NewLabelWithStyle("Edit the value above and click Save", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	hint.SetText(i18n.T("JSON value - edit it in Raw or Formatted mode and click Save"))
+	setWritable(ve.client, saveBtn)
+	buttons := container.NewBorder(nil, nil, nil, copyBtn, saveBtn)
+	return withViewToggle("Treat as bitmap", container.NewBorder(modes, container.NewVBox(hint, buttons), nil, nil, body),
+		func() fyne.CanvasObject { return ve.buildBitmapEditor(key) })
+}
 
-	return container.NewBorder(nil, container.NewVBox(hint, saveBtn), nil, nil, entry)
+// withViewToggle adds a checkbox that swaps editor for an alternative view of the
+// same key, e.g. a string as a bitmap. The alternative is rebuilt each time it is shown.
+func withViewToggle(label string, editor fyne.CanvasObject, alternative func() fyne.CanvasObject) fyne.CanvasObject {
+	body := container.NewStack(editor)
+	toggle := widget.NewCheck(i18n.T(label), func(on bool) {
+		if on {
+			body.Objects = []fyne.CanvasObject{alternative()}
+		} else {
+			body.Objects = []fyne.CanvasObject{editor}
+		}
+		body.Refresh()
+	})
+	return container.NewBorder(toggle, nil, nil, nil, body)
 }
 
-func (ve *ValueEditor) buildListEditor(key models.RedisKey) fyne.CanvasObject {
-	items, err := ve.client.GetList(key.Key)
-	if err != nil {
-		return widget.NewLabel("Error: " + err.Error())
+func (ve *ValueEditor) buildListEditor(key models.RedisKey, items []string, total int64) fyne.CanvasObject {
+	// The editor shows a window of the list's indexes, resolved against LLEN
+	// each time it loads; items[i] is the element at index base+i
+	window := listWindow{start: 0, stop: -1}
+	var base int64
+	winStart, winStop, length := int64(0), total-1, total
+
+	// Indexes found by the last "Find in list" search, highlighted in the table
+	var matches []int64
+	matchSet := make(map[int64]bool)
+	matchPos := 0
+
+	// Lists have no server-side MATCH, so the filter narrows the loaded elements;
+	// rows holds the position in items of each displayed element
+	filter := ""
+	var rows []int
+	filterRows := func() {
+		rows = rows[:0]
+		for i, item := range items {
+			if strings.Contains(item, filter) {
+				rows = append(rows, i)
+			}
+		}
 	}
+	filterRows()
 
 	// Build table-like grid with aligned columns
+	edit := newInlineEdit(ve.window)
 	table := widget.NewTable(
-		func() (int, int) { return len(items), 2 },
-		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewLabelWithStyle("", fyne.TextAlignTrailing, fyne.TextStyle{}),
-			)
-		},
+		func() (int, int) { return len(rows), 2 },
+		newInlineCell,
 		func(id widget.TableCellID, o fyne.CanvasObject) {
-			box := o.(*fyne.Container)
-			label := box.Objects[0].(*widget.Label)
+			text := edit.cell(id, o)
+			if text == nil {
+				return
+			}
+			pos := rows[id.Row]
+			index := base + int64(pos)
 			if id.Col == 0 {
-				label.SetText(fmt.Sprintf("[%d]", id.Row))
-				label.TextStyle = fyne.TextStyle{Bold: true}
-			} else {
-				label.SetText(items[id.Row])
-				label.TextStyle = fyne.TextStyle{}
+				label := fmt.Sprintf("[%d]", index)
+				needle := ""
+				if matchSet[index] {
+					needle = label
+				}
+				setRichText(text, highlightSegments(label, needle, fyne.TextStyle{Bold: true}))
+				return
+			}
+			needle := filter
+			if matchSet[index] {
+				needle = items[pos]
 			}
+			setRichText(text, highlightSegments(items[pos], needle, valueTextStyle()))
 		},
 	)
-	table.SetColumnWidth(0, 60)
+	edit.table = table
+	table.SetColumnWidth(0, 80)
 	table.SetColumnWidth(1, 400)
 
-	// Double-click to edit
-	table.OnSelected = func(id widget.TableCellID) {
-		if id.Col == 1 && id.Row < len(items) {
-			ve.showEditValueDialog("Value", items[id.Row], func(newVal string) {
-				err := ve.client.ListSet(key.Key, int64(id.Row), newVal)
-				if err != nil {
-					ShowErrorDialog(ve.window, "Error", err)
-					return
-				}
-				ve.LoadKey(key)
-			})
+	// reveal scrolls to and selects the element at index if it is shown
+	reveal := func(index int64) bool {
+		pos := index - base
+		if pos < 0 || pos >= int64(len(items)) {
+			return false
 		}
-		table.UnselectAll()
+		row := sort.SearchInts(rows, int(pos))
+		if row == len(rows) || rows[row] != int(pos) {
+			return false
+		}
+		table.ScrollTo(widget.TableCellID{Row: row, Col: 0})
+		table.Select(widget.TableCellID{Row: row, Col: 0})
+		return true
 	}
 
-	addEntry := widget.NewEntry()
-	addEntry.SetPlaceHolder("New value")
-
-	addLeftBtn := widget.NewButtonWithIcon("Add Left", theme.ContentAddIcon(), func() {
-		if addEntry.Text == "" {
+	// A window change is resolved and read by the pager's next first page, so
+	// pending is set before reloading it; filtering alone leaves it nil
+	var pending, fetched listWindow
+	reloading := false
+	selectFirst := false
+	var fetchedLength int64
+	var reload func()
+	goTo := func(index int64) {
+		if reveal(index) {
 			return
 		}
-		err := ve.client.ListPush(key.Key, addEntry.Text, true)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
-			return
+		pending = listWindow{start: window.start, stop: window.stop, from: index}
+		if abs := window.index(index, length); abs < winStart || abs > winStop {
+			pending.start, pending.stop = 0, -1
 		}
-		addEntry.SetText("")
-		ve.LoadKey(key)
-	})
+		reloading, selectFirst = true, true
+		reload()
+	}
 
-	addRightBtn := widget.NewButtonWithIcon("Add Right", theme.ContentAddIcon(), func() {
-		if addEntry.Text == "" {
+	findEntry := widget.NewEntry()
+	findEntry.SetPlaceHolder(i18n.T("Find in list (exact element)"))
+	findResult := widget.NewLabel("")
+
+	jumpTo := func(pos int) {
+		if len(matches) == 0 {
 			return
 		}
-		err := ve.client.ListPush(key.Key, addEntry.Text, false)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+		matchPos = pos % len(matches)
+		index := matches[matchPos]
+		findResult.SetText(fmt.Sprintf("%d/%d at [%d]", matchPos+1, len(matches), index))
+		goTo(index)
+	}
+
+	find := func() {
+		element := findEntry.Text
+		if element == "" {
 			return
 		}
-		addEntry.SetText("")
-		ve.LoadKey(key)
-	})
-
-	hint := widget.NewLabelWithStyle("Click a value to edit", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+		var found []int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			found, err = c.ListFind(key.Key, element, listFindLimit)
+			return err
+		}, func() {
+			matches = found
+			matchSet = make(map[int64]bool, len(found))
+			for _, idx := range found {
+				matchSet[idx] = true
+			}
+			table.Refresh()
+			if len(found) == 0 {
+				findResult.SetText(i18n.T("Not found"))
+				return
+			}
+			jumpTo(0)
+		})
+	}
+	findEntry.OnSubmitted = func(string) { find() }
 
-	addBar := container.NewVBox(
-		hint,
-		container.NewBorder(nil, nil, nil,
-			container.NewHBox(addLeftBtn, addRightBtn),
-			addEntry,
-		),
+	findBtn := widget.NewButtonWithIcon("", theme.SearchIcon(), find)
+	nextBtn := widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+		jumpTo(matchPos + 1)
+	})
+	if !ve.client.Supports(redis.FeatureListFind) {
+		findEntry.Disable()
+		nextBtn.Disable()
+	}
+	findBar := container.NewBorder(nil, nil, nil,
+		container.NewHBox(findResult, requireFeature(ve.client, redis.FeatureListFind, findBtn), nextBtn),
+		findEntry,
 	)
 
-	return container.NewBorder(nil, addBar, nil, nil, table)
-}
-
-func (ve *ValueEditor) buildSetEditor(key models.RedisKey) fyne.CanvasObject {
-	members, err := ve.client.GetSet(key.Key)
-	if err != nil {
-		return widget.NewLabel("Error: " + err.Error())
+	// write changes the list through op and reads the same window again,
+	// rather than reloading the editor from the head of the list
+	write := func(op func(c *redis.Client) error, done func()) {
+		if refuseReadOnly(ve.window, ve.client) {
+			return
+		}
+		client := ve.client
+		var snapshots []models.KeySnapshot
+		ve.worker.Do(ve.window, client, func(c *redis.Client) error {
+			snapshots = snapshotKeys(c, []string{key.Key})
+			return op(c)
+		}, func() {
+			ve.undo.push(client, i18n.T("Edited '%s'", key.Key), snapshots, false)
+			if done != nil {
+				done()
+			}
+			// The elements may have moved, so earlier search results no longer hold
+			matches, matchSet = nil, make(map[int64]bool)
+			findResult.SetText("")
+			pending = listWindow{start: window.start, stop: window.stop, from: base}
+			reloading = true
+			reload()
+			if ve.onKeyUpdated != nil {
+				ve.onKeyUpdated()
+			}
+		})
 	}
 
-	sort.Strings(members)
-	var selectedMember string
-	var selectedRow int = -1
-
-	table := widget.NewTable(
-		func() (int, int) { return len(members), 1 },
-		func() fyne.CanvasObject {
-			return widget.NewLabel("")
-		},
-		func(id widget.TableCellID, o fyne.CanvasObject) {
-			o.(*widget.Label).SetText(members[id.Row])
-		},
-	)
-	table.SetColumnWidth(0, 450)
-
+	// Clicking a value edits it in place; clicking its index selects it for
+	// copying, inserting next to or removing
+	selected := int64(-1)
 	table.OnSelected = func(id widget.TableCellID) {
-		if id.Row < len(members) {
-			selectedMember = members[id.Row]
-			selectedRow = id.Row
+		if id.Row >= len(rows) {
+			return
+		}
+		index := base + int64(rows[id.Row])
+		value := items[rows[id.Row]]
+		if id.Col == 0 {
+			edit.stop()
+			selected = index
+			return
+		}
+		selected = -1
+		if refuseReadOnly(ve.window, ve.client) {
+			table.UnselectAll()
+			return
+		}
+		save := func(newVal string) {
+			write(func(c *redis.Client) error {
+				return c.ListSet(key.Key, index, newVal)
+			}, nil)
 		}
+		edit.start(id, value, save, func() {
+			ve.showEditValueDialog("Value", value, save)
+		})
+	}
+	// selectedValue is the selected element, ok false when there is none
+	selectedValue := func() (string, bool) {
+		pos := selected - base
+		if selected < 0 || pos >= int64(len(items)) {
+			return "", false
+		}
+		return items[pos], true
 	}
 
+	copyBtn := widget.NewButtonWithIcon(i18n.T("Copy Selected"), theme.ContentCopyIcon(), func() {
+		if value, ok := selectedValue(); ok {
+			fyne.CurrentApp().Clipboard().SetContent(value)
+		}
+	})
+
 	addEntry := widget.NewEntry()
-	addEntry.SetPlaceHolder("New member")
+	addEntry.SetPlaceHolder(i18n.T("New value"))
 
-	addBtn := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), func() {
+	addLeftBtn := widget.NewButtonWithIcon(i18n.T("Add Left"), theme.ContentAddIcon(), func() {
 		if addEntry.Text == "" {
 			return
 		}
-		err := ve.client.SetAdd(key.Key, addEntry.Text)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+		value := addEntry.Text
+		ve.apply(key, func(c *redis.Client) error {
+			return c.ListPush(key.Key, value, true)
+		})
+	})
+
+	addRightBtn := widget.NewButtonWithIcon(i18n.T("Add Right"), theme.ContentAddIcon(), func() {
+		if addEntry.Text == "" {
 			return
 		}
-		addEntry.SetText("")
-		ve.LoadKey(key)
+		value := addEntry.Text
+		ve.apply(key, func(c *redis.Client) error {
+			return c.ListPush(key.Key, value, false)
+		})
 	})
 
-	removeBtn := widget.NewButtonWithIcon("Remove Selected", theme.ContentRemoveIcon(), func() {
-		if selectedMember == "" || selectedRow < 0 {
+	// LINSERT finds its pivot by value, so with duplicates it may pick an
+	// earlier element than the selected one; LPOS tells which it will be
+	insert := func(before bool) {
+		pivot, ok := selectedValue()
+		if addEntry.Text == "" || !ok || refuseReadOnly(ve.window, ve.client) {
 			return
 		}
-		err := ve.client.SetRemove(key.Key, selectedMember)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+		value, at := addEntry.Text, selected
+		var first []int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) error {
+			// Servers before LPOS insert without the check
+			first, _ = c.ListFind(key.Key, pivot, 1)
+			return nil
+		}, func() {
+			run := func() {
+				write(func(c *redis.Client) error {
+					return c.ListInsert(key.Key, before, pivot, value)
+				}, func() { addEntry.SetText("") })
+			}
+			if len(first) == 1 && first[0] != at {
+				ShowConfirmDialog(ve.window, "Insert",
+					i18n.T("LINSERT places the value next to the first element equal to the selected one, which is at [%d] rather than [%d].\n\nInsert it there anyway?", first[0], at),
+					run)
+				return
+			}
+			run()
+		})
+	}
+	insertBeforeBtn := widget.NewButtonWithIcon(i18n.T("Insert Before"), theme.MoveUpIcon(), func() { insert(true) })
+	insertAfterBtn := widget.NewButtonWithIcon(i18n.T("Insert After"), theme.MoveDownIcon(), func() { insert(false) })
+
+	removeBtn := widget.NewButtonWithIcon(i18n.T("Remove Selected..."), theme.ContentRemoveIcon(), func() {
+		value, ok := selectedValue()
+		if !ok || refuseReadOnly(ve.window, ve.client) {
 			return
 		}
-		selectedMember = ""
-		selectedRow = -1
-		ve.LoadKey(key)
+		ve.showListRemove(value, func(count int64) {
+			var removed int64
+			write(func(c *redis.Client) (err error) {
+				removed, err = c.ListRemove(key.Key, count, value)
+				return err
+			}, func() {
+				ve.worker.Report(i18n.T("LREM '%s': removed %d", key.Key, removed), nil)
+			})
+		})
 	})
 
-	addBar := container.NewVBox(
-		container.NewBorder(nil, nil, nil, addBtn, addEntry),
-		removeBtn,
-	)
+	hint := widget.NewLabelWithStyle(i18n.T("Click a value to edit it in place (Enter saves, Esc cancels), or its index to select it"), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	setWritable(ve.client, addLeftBtn, addRightBtn, insertBeforeBtn, insertAfterBtn, removeBtn)
 
-	return container.NewBorder(nil, addBar, nil, nil, table)
-}
+	addBar := container.NewVBox(
+		hint,
+		container.NewBorder(nil, nil, nil,
+			container.NewHBox(addLeftBtn, addRightBtn, insertBeforeBtn, insertAfterBtn),
+			addEntry,
+		),
+		container.NewHBox(copyBtn, removeBtn, ve.sampleButton(key)),
+	)
 
-func (ve *ValueEditor) buildHashEditor(key models.RedisKey) fyne.CanvasObject {
-	hash, err := ve.client.GetHash(key.Key)
-	if err != nil {
-		return widget.NewLabel("Error: " + err.Error())
+	fromEntry := widget.NewEntry()
+	fromEntry.SetPlaceHolder("0")
+	toEntry := widget.NewEntry()
+	toEntry.SetPlaceHolder("-1")
+	prevPageBtn := widget.NewButtonWithIcon("", theme.NavigateBackIcon(), nil)
+	nextPageBtn := widget.NewButtonWithIcon("", theme.NavigateNextIcon(), nil)
+	updatePaging := func() {
+		if base > winStart {
+			prevPageBtn.Enable()
+		} else {
+			prevPageBtn.Disable()
+		}
+		if base+int64(len(items)) <= winStop {
+			nextPageBtn.Enable()
+		} else {
+			nextPageBtn.Disable()
+		}
+	}
+	updatePaging()
+
+	// Further LRANGE pages of the window are appended after the loaded elements
+	var page []string
+	more := int64(len(items)) < total
+	pager, reloadPager := ve.newReloadablePager(total, len(rows), more, "Filter loaded elements", collectionSource{
+		fetch: func(c *redis.Client, _ string, first bool) (err error) {
+			from, stop := base+int64(len(items)), winStop
+			if first {
+				if !reloading {
+					return nil
+				}
+				if fetchedLength, err = c.CollectionLength(key.Key, key.Type); err != nil {
+					return err
+				}
+				fetched = pending.resolve(fetchedLength)
+				from, stop = fetched.from, fetched.stop
+			}
+			page = nil
+			if count := min(collectionPageSize(), stop-from+1); count > 0 {
+				page, err = c.GetListRange(key.Key, from, count)
+			}
+			return err
+		},
+		merge: func(text string, first bool) (int, bool) {
+			filter = text
+			switch {
+			case first && reloading:
+				window = listWindow{start: pending.start, stop: pending.stop}
+				winStart, winStop, length = fetched.start, fetched.stop, fetchedLength
+				base, items, selected = fetched.from, page, -1
+				reloading = false
+				table.UnselectAll()
+				fromEntry.SetText(strconv.FormatInt(window.start, 10))
+				toEntry.SetText(strconv.FormatInt(window.stop, 10))
+				more = len(page) > 0 && base+int64(len(items)) <= winStop
+			case !first:
+				items = append(items, page...)
+				more = len(page) > 0 && base+int64(len(items)) <= winStop
+			}
+			edit.stop()
+			filterRows()
+			table.Refresh()
+			updatePaging()
+			if selectFirst {
+				selectFirst = false
+				if len(rows) > 0 {
+					table.ScrollToTop()
+					table.Select(widget.TableCellID{Row: 0, Col: 0})
+				}
+			}
+			return len(rows), more
+		},
+		count: func(shown int) string {
+			if shown == 0 {
+				return i18n.T("No elements in [%d, %d] (LLEN %d)", winStart, winStop, length)
+			}
+			return i18n.T("Showing [%d-%d] of [%d, %d] (LLEN %d)", base, base+int64(shown)-1, winStart, winStop, length)
+		},
+	})
+	reload = reloadPager
+
+	// The window bar reads the indexes LRANGE takes, negative ones counting
+	// from the tail, and pages through them a page at a time
+	showWindow := func(from int64) {
+		pending = listWindow{start: window.start, stop: window.stop, from: from}
+		reloading = true
+		reload()
+	}
+	prevPageBtn.OnTapped = func() { showWindow(max(base-int64(collectionPageSize()), winStart)) }
+	nextPageBtn.OnTapped = func() { showWindow(base + int64(len(items))) }
+	bound := func(entry *widget.Entry) (int64, error) {
+		text := strings.TrimSpace(entry.Text)
+		if text == "" {
+			text = entry.PlaceHolder
+		}
+		index, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("index %q is not a whole number", text)
+		}
+		return index, nil
+	}
+	showBtn := widget.NewButtonWithIcon(i18n.T("Show"), theme.SearchIcon(), func() {
+		start, err := bound(fromEntry)
+		if err == nil {
+			window.stop, err = bound(toEntry)
+		}
+		if err != nil {
+			ShowErrorDialog(ve.window, "Invalid Range", err)
+			return
+		}
+		window.start = start
+		showWindow(start)
+	})
+	jumpEntry := widget.NewEntry()
+	jumpEntry.SetPlaceHolder(i18n.T("Jump to index"))
+	jumpBtn := widget.NewButton(i18n.T("Go"), func() {
+		index, err := bound(jumpEntry)
+		if err != nil {
+			ShowErrorDialog(ve.window, "Invalid Index", err)
+			return
+		}
+		goTo(index)
+	})
+	jumpEntry.OnSubmitted = func(string) { jumpBtn.OnTapped() }
+	windowBar := container.NewBorder(nil, nil,
+		container.NewHBox(prevPageBtn, nextPageBtn),
+		container.NewHBox(showBtn, widget.NewSeparator(), jumpBtn),
+		container.NewGridWithColumns(3, fromEntry, toEntry, jumpEntry),
+	)
+
+	return ve.withQueueMode(key, container.NewBorder(container.NewVBox(pager, windowBar, findBar), addBar, nil, nil, table))
+}
+
+func (ve *ValueEditor) buildSetEditor(key models.RedisKey, members []string, total int64, cursor uint64) fyne.CanvasObject {
+	// SSCAN can return a member more than once, so pages are merged through seen
+	seen := make(map[string]bool, len(members))
+	unique := members[:0]
+	for _, m := range members {
+		if !seen[m] {
+			seen[m] = true
+			unique = append(unique, m)
+		}
+	}
+	members = unique
+	sort.Strings(members)
+	var selectedMember string
+	var selectedRow int = -1
+	filter := ""
+
+	table := widget.NewTable(
+		func() (int, int) { return len(members), 1 },
+		func() fyne.CanvasObject {
+			return widget.NewRichText()
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			setRichText(o, highlightSegments(members[id.Row], filter, valueTextStyle()))
+		},
+	)
+	table.SetColumnWidth(0, 450)
+
+	table.OnSelected = func(id widget.TableCellID) {
+		if id.Row < len(members) {
+			selectedMember = members[id.Row]
+			selectedRow = id.Row
+		}
 	}
 
+	addEntry := widget.NewEntry()
+	addEntry.SetPlaceHolder(i18n.T("New member"))
+
+	addBtn := widget.NewButtonWithIcon(i18n.T("Add"), theme.ContentAddIcon(), func() {
+		if addEntry.Text == "" {
+			return
+		}
+		member := addEntry.Text
+		ve.apply(key, func(c *redis.Client) error {
+			return c.SetAdd(key.Key, member)
+		})
+	})
+
+	removeBtn := widget.NewButtonWithIcon(i18n.T("Remove Selected"), theme.ContentRemoveIcon(), func() {
+		if selectedMember == "" || selectedRow < 0 {
+			return
+		}
+		member := selectedMember
+		ve.apply(key, func(c *redis.Client) error {
+			return c.SetRemove(key.Key, member)
+		})
+	})
+
+	storeBtn := widget.NewButtonWithIcon(i18n.T("Set Operations..."), theme.ContentCopyIcon(), func() {
+		ve.showCombineStore(key, false)
+	})
+	// Set operations can be viewed on a read-only connection; only storing is refused
+	setWritable(ve.client, addBtn, removeBtn)
+
+	copyBtn := widget.NewButtonWithIcon(i18n.T("Copy Selected"), theme.ContentCopyIcon(), func() {
+		if selectedMember != "" {
+			fyne.CurrentApp().Clipboard().SetContent(selectedMember)
+		}
+	})
+
+	addBar := container.NewVBox(
+		container.NewBorder(nil, nil, nil, addBtn, addEntry),
+		container.NewHBox(removeBtn, storeBtn, copyBtn, ve.sampleButton(key)),
+	)
+
+	// The filter restarts the scan with SSCAN MATCH
+	var page []string
+	var next uint64
+	pager := ve.newCollectionPager(total, len(members), cursor != 0, "Filter members (SSCAN MATCH)", collectionSource{
+		fetch: func(c *redis.Client, text string, first bool) (err error) {
+			from := cursor
+			if first {
+				from = 0
+			}
+			page, next, err = c.ScanSet(key.Key, from, matchPattern(text), collectionPageSize())
+			return err
+		},
+		merge: func(text string, first bool) (int, bool) {
+			if first {
+				filter, members, seen = text, nil, make(map[string]bool)
+			}
+			cursor = next
+			for _, m := range page {
+				if !seen[m] {
+					seen[m] = true
+					members = append(members, m)
+				}
+			}
+			sort.Strings(members)
+			selectedMember, selectedRow = "", -1
+			table.UnselectAll()
+			table.Refresh()
+			return len(members), cursor != 0
+		},
+	})
+
+	return container.NewBorder(pager, addBar, nil, nil, table)
+}
+
+func (ve *ValueEditor) buildHashEditor(key models.RedisKey, hash map[string]string, total int64, cursor uint64) fyne.CanvasObject {
 	// Convert map to sorted slice
 	type fieldValue struct {
-		field string
-		value string
+		field   string
+		value   string
+		staged  bool // changed by an unsaved staged edit
+		deleted bool // staged to be deleted
 	}
+
+	// Staged edits are kept until saved together: a field maps to its new
+	// value, or to nil when it is to be deleted
+	staged := make(map[string]*string)
+
 	var items []fieldValue
-	for k, v := range hash {
-		items = append(items, fieldValue{field: k, value: v})
+	sortItems := func() {
+		items = items[:0]
+		for k, v := range hash {
+			if _, ok := staged[k]; !ok {
+				items = append(items, fieldValue{field: k, value: v})
+			}
+		}
+		for k, v := range staged {
+			if v == nil {
+				items = append(items, fieldValue{field: k, value: hash[k], staged: true, deleted: true})
+			} else {
+				items = append(items, fieldValue{field: k, value: *v, staged: true})
+			}
+		}
+		sort.Slice(items, func(i, j int) bool {
+			return items[i].field < items[j].field
+		})
 	}
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].field < items[j].field
-	})
+	sortItems()
 
 	var selectedField string
 	var selectedRow int = -1
+	filter := ""
 
+	edit := newInlineEdit(ve.window)
 	table := widget.NewTable(
 		func() (int, int) { return len(items), 2 },
-		func() fyne.CanvasObject {
-			return widget.NewLabel("")
-		},
+		newInlineCell,
 		func(id widget.TableCellID, o fyne.CanvasObject) {
-			label := o.(*widget.Label)
+			text := edit.cell(id, o)
+			if text == nil {
+				return
+			}
+			item := items[id.Row]
 			if id.Col == 0 {
-				label.SetText(items[id.Row].field)
-				label.TextStyle = fyne.TextStyle{Bold: true}
+				setRichText(text, highlightSegments(item.field, filter, fyne.TextStyle{Bold: true, Italic: item.staged}))
+				return
+			}
+			style := valueTextStyle()
+			style.Italic = item.staged
+			if item.deleted {
+				setRichText(text, highlightSegments(i18n.T("(deleted)"), "", style))
 			} else {
-				label.SetText(items[id.Row].value)
-				label.TextStyle = fyne.TextStyle{}
+				setRichText(text, highlightSegments(item.value, "", style))
 			}
 		},
 	)
+	edit.table = table
 	table.SetColumnWidth(0, 150)
 	table.SetColumnWidth(1, 300)
 
+	// With Stage edits checked, changes wait to be saved in one transaction
+	// instead of each being written and the hash reloaded
+	dirtyLabel := widget.NewLabel("")
+	dirtyLabel.Importance = widget.WarningImportance
+	saveBtn := widget.NewButtonWithIcon(i18n.T("Save Changes"), theme.DocumentSaveIcon(), nil)
+	saveBtn.Importance = widget.HighImportance
+	discardBtn := widget.NewButtonWithIcon(i18n.T("Discard"), theme.CancelIcon(), nil)
+	updateDirty := func() {
+		if len(staged) == 0 {
+			dirtyLabel.SetText("")
+			saveBtn.Disable()
+			discardBtn.Disable()
+			return
+		}
+		dirtyLabel.SetText(i18n.T("%d unsaved changes", len(staged)))
+		saveBtn.Enable()
+		discardBtn.Enable()
+	}
+	updateDirty()
+	stage := func(field string, value *string) {
+		if original, ok := hash[field]; (value == nil && !ok) || (value != nil && ok && *value == original) {
+			delete(staged, field) // back to what the server has
+		} else {
+			staged[field] = value
+		}
+		sortItems()
+		selectedField, selectedRow = "", -1
+		table.UnselectAll()
+		table.Refresh()
+		updateDirty()
+	}
+	stageCheck := widget.NewCheck(i18n.T("Stage edits"), nil)
+	setField := func(field, value string) {
+		if stageCheck.Checked {
+			stage(field, &value)
+			return
+		}
+		ve.apply(key, func(c *redis.Client) error {
+			return c.HashSet(key.Key, field, value)
+		})
+	}
+	saveBtn.OnTapped = func() {
+		set := make(map[string]string)
+		var del []string
+		for field, value := range staged {
+			if value == nil {
+				del = append(del, field)
+			} else {
+				set[field] = *value
+			}
+		}
+		ve.apply(key, func(c *redis.Client) error {
+			return c.HashApply(key.Key, set, del)
+		})
+	}
+	discardBtn.OnTapped = func() {
+		clear(staged)
+		sortItems()
+		table.Refresh()
+		updateDirty()
+	}
+
 	table.OnSelected = func(id widget.TableCellID) {
 		if id.Row < len(items) {
 			selectedField = items[id.Row].field
 			selectedRow = id.Row
-			if id.Col == 1 {
-				// Click on value column - edit
-				ve.showEditValueDialog("Value", items[id.Row].value, func(newVal string) {
-					err := ve.client.HashSet(key.Key, selectedField, newVal)
-					if err != nil {
-						ShowErrorDialog(ve.window, "Error", err)
-						return
-					}
-					ve.LoadKey(key)
-				})
+			if id.Col == 0 {
+				edit.stop()
+				return
+			}
+			// Click on value column - edit in place
+			if refuseReadOnly(ve.window, ve.client) {
 				table.UnselectAll()
+				return
+			}
+			field, value := selectedField, items[id.Row].value
+			save := func(newVal string) {
+				setField(field, newVal)
 			}
+			edit.start(id, value, save, func() {
+				ve.showEditValueDialog("Value", value, save)
+			})
 		}
 	}
 
 	fieldEntry := widget.NewEntry()
-	fieldEntry.SetPlaceHolder("Field")
+	fieldEntry.SetPlaceHolder(i18n.T("Field"))
 
 	valueEntry := widget.NewEntry()
-	valueEntry.SetPlaceHolder("Value")
+	valueEntry.SetPlaceHolder(i18n.T("Value"))
 
-	setBtn := widget.NewButtonWithIcon("Add/Update", theme.DocumentSaveIcon(), func() {
+	setBtn := widget.NewButtonWithIcon(i18n.T("Add/Update"), theme.DocumentSaveIcon(), func() {
 		if fieldEntry.Text == "" {
 			return
 		}
-		err := ve.client.HashSet(key.Key, fieldEntry.Text, valueEntry.Text)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
-			return
-		}
-		fieldEntry.SetText("")
-		valueEntry.SetText("")
-		ve.LoadKey(key)
+		setField(fieldEntry.Text, valueEntry.Text)
 	})
 
-	removeBtn := widget.NewButtonWithIcon("Remove Selected", theme.ContentRemoveIcon(), func() {
+	removeBtn := widget.NewButtonWithIcon(i18n.T("Remove Selected"), theme.ContentRemoveIcon(), func() {
 		if selectedField == "" || selectedRow < 0 {
 			return
 		}
-		err := ve.client.HashDelete(key.Key, selectedField)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+		field := selectedField
+		if stageCheck.Checked {
+			stage(field, nil)
 			return
 		}
-		selectedField = ""
-		selectedRow = -1
-		ve.LoadKey(key)
+		ve.apply(key, func(c *redis.Client) error {
+			return c.HashDelete(key.Key, field)
+		})
+	})
+
+	copyFieldBtn := widget.NewButtonWithIcon(i18n.T("Copy Field"), theme.ContentCopyIcon(), func() {
+		if selectedField != "" {
+			fyne.CurrentApp().Clipboard().SetContent(selectedField)
+		}
+	})
+	copyValueBtn := widget.NewButtonWithIcon(i18n.T("Copy Value"), theme.ContentCopyIcon(), func() {
+		if selectedRow >= 0 && selectedRow < len(items) && !items[selectedRow].deleted {
+			fyne.CurrentApp().Clipboard().SetContent(items[selectedRow].value)
+		}
 	})
 
-	hint := widget.NewLabelWithStyle("Click a value to edit inline", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	hint := widget.NewLabelWithStyle(i18n.T("Click a value to edit it in place (Enter saves, Esc cancels), or a field to select it"), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	setWritable(ve.client, setBtn, removeBtn, stageCheck)
 
 	addBar := container.NewVBox(
 		hint,
 		container.NewGridWithColumns(2, fieldEntry, valueEntry),
-		container.NewHBox(setBtn, removeBtn),
+		container.NewHBox(setBtn, removeBtn, copyFieldBtn, copyValueBtn, ve.sampleButton(key)),
+		container.NewHBox(stageCheck, dirtyLabel, saveBtn, discardBtn),
 	)
 
-	return container.NewBorder(nil, addBar, nil, nil, table)
-}
+	// The filter restarts the scan with HSCAN MATCH on field names
+	var page map[string]string
+	var next uint64
+	pager := ve.newCollectionPager(total, len(items), cursor != 0, "Filter fields (HSCAN MATCH)", collectionSource{
+		fetch: func(c *redis.Client, text string, first bool) (err error) {
+			from := cursor
+			if first {
+				from = 0
+			}
+			page, next, err = c.ScanHash(key.Key, from, matchPattern(text), collectionPageSize())
+			return err
+		},
+		merge: func(text string, first bool) (int, bool) {
+			if first {
+				filter, hash = text, make(map[string]string)
+			}
+			cursor = next
+			for k, v := range page {
+				hash[k] = v
+			}
+			sortItems()
+			selectedField, selectedRow = "", -1
+			edit.stop()
+			table.UnselectAll()
+			table.Refresh()
+			return len(items), cursor != 0
+		},
+	})
 
-func (ve *ValueEditor) buildZSetEditor(key models.RedisKey) fyne.CanvasObject {
-	members, err := ve.client.GetSortedSet(key.Key)
-	if err != nil {
-		return widget.NewLabel("Error: " + err.Error())
-	}
+	return container.NewBorder(pager, addBar, nil, nil, table)
+}
 
+func (ve *ValueEditor) buildZSetEditor(key models.RedisKey, members []models.ScoredValue, total int64) fyne.CanvasObject {
 	var selectedMember string
 	var selectedRow int = -1
+	filter := ""
 
+	edit := newInlineEdit(ve.window)
 	table := widget.NewTable(
 		func() (int, int) { return len(members), 2 },
-		func() fyne.CanvasObject {
-			return widget.NewLabel("")
-		},
+		newInlineCell,
 		func(id widget.TableCellID, o fyne.CanvasObject) {
-			label := o.(*widget.Label)
+			text := edit.cell(id, o)
+			if text == nil {
+				return
+			}
 			if id.Col == 0 {
-				label.SetText(fmt.Sprintf("%.4f", members[id.Row].Score))
-				label.TextStyle = fyne.TextStyle{Bold: true}
+				setRichText(text, highlightSegments(fmt.Sprintf("%.4f", members[id.Row].Score), "", fyne.TextStyle{Bold: true}))
 			} else {
-				label.SetText(members[id.Row].Member)
-				label.TextStyle = fyne.TextStyle{}
+				setRichText(text, highlightSegments(members[id.Row].Member, filter, valueTextStyle()))
 			}
 		},
 	)
+	edit.table = table
 	table.SetColumnWidth(0, 100)
 	table.SetColumnWidth(1, 350)
 
@@ -513,53 +1519,60 @@ func (ve *ValueEditor) buildZSetEditor(key models.RedisKey) fyne.CanvasObject {
 		if id.Row < len(members) {
 			selectedMember = members[id.Row].Member
 			selectedRow = id.Row
+			member := selectedMember
+			if refuseReadOnly(ve.window, ve.client) {
+				table.UnselectAll()
+				return
+			}
 			if id.Col == 0 {
-				// Click on score - edit score
-				ve.showEditValueDialog("Score", fmt.Sprintf("%.4f", members[id.Row].Score), func(newVal string) {
-					score, err := strconv.ParseFloat(newVal, 64)
+				// Click on score - edit score in place
+				score := strconv.FormatFloat(members[id.Row].Score, 'f', -1, 64)
+				save := func(newVal string) {
+					score, err := strconv.ParseFloat(strings.TrimSpace(newVal), 64)
 					if err != nil {
 						ShowErrorDialog(ve.window, "Invalid Score", fmt.Errorf("score must be a valid number: %w", err))
 						return
 					}
-					// Remove and re-add with new score
-					if err := ve.client.SortedSetRemove(key.Key, selectedMember); err != nil {
-						ShowErrorDialog(ve.window, "Error", err)
-						return
-					}
-					if err := ve.client.SortedSetAdd(key.Key, score, selectedMember); err != nil {
-						ShowErrorDialog(ve.window, "Error", err)
-						return
-					}
-					ve.LoadKey(key)
+					// XX updates the existing member's score in place
+					ve.apply(key, func(c *redis.Client) error {
+						_, err := c.SortedSetAddWithFlags(key.Key, score, member, redis.ZAddFlags{XX: true})
+						return err
+					})
+				}
+				edit.start(id, score, save, func() {
+					ve.showEditValueDialog("Score", score, save)
 				})
-				table.UnselectAll()
 			} else if id.Col == 1 {
-				// Click on member - edit member
-				oldScore := members[id.Row].Score
-				ve.showEditValueDialog("Member", selectedMember, func(newVal string) {
-					// Remove old and add new
-					if err := ve.client.SortedSetRemove(key.Key, selectedMember); err != nil {
-						ShowErrorDialog(ve.window, "Error", err)
+				// Click on member - rename member in place, keeping its score
+				save := func(newVal string) {
+					if newVal == member {
 						return
 					}
-					if err := ve.client.SortedSetAdd(key.Key, oldScore, newVal); err != nil {
-						ShowErrorDialog(ve.window, "Error", err)
-						return
-					}
-					ve.LoadKey(key)
+					ve.apply(key, func(c *redis.Client) error {
+						return c.SortedSetRenameMember(key.Key, member, newVal)
+					})
+				}
+				edit.start(id, member, save, func() {
+					ve.showEditValueDialog("Member", member, save)
 				})
-				table.UnselectAll()
 			}
 		}
 	}
 
 	scoreEntry := widget.NewEntry()
-	scoreEntry.SetPlaceHolder("Score")
+	scoreEntry.SetPlaceHolder(i18n.T("Score"))
 
 	memberEntry := widget.NewEntry()
-	memberEntry.SetPlaceHolder("Member")
+	memberEntry.SetPlaceHolder(i18n.T("Member"))
+
+	// ZADD condition flags
+	conditionSelect := widget.NewSelect([]string{i18n.T("Always"), i18n.T("NX: only new"), i18n.T("XX: only existing")}, nil)
+	conditionSelect.SetSelectedIndex(0)
+	compareSelect := widget.NewSelect([]string{i18n.T("Any score"), i18n.T("GT: only greater"), i18n.T("LT: only less")}, nil)
+	compareSelect.SetSelectedIndex(0)
+	chCheck := widget.NewCheck("CH", nil)
 
-	addBtn := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), func() {
+	addBtn := widget.NewButtonWithIcon(i18n.T("Add"), theme.ContentAddIcon(), func() {
 		if memberEntry.Text == "" {
 			return
 		}
@@ -572,49 +1585,626 @@ func (ve *ValueEditor) buildZSetEditor(key models.RedisKey) fyne.CanvasObject {
 				return
 			}
 		}
-		err := ve.client.SortedSetAdd(key.Key, score, memberEntry.Text)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+		flags := redis.ZAddFlags{
+			NX: conditionSelect.SelectedIndex() == 1,
+			XX: conditionSelect.SelectedIndex() == 2,
+			GT: compareSelect.SelectedIndex() == 1,
+			LT: compareSelect.SelectedIndex() == 2,
+			CH: chCheck.Checked,
+		}
+		if flags.NX && (flags.GT || flags.LT) {
+			ShowErrorDialog(ve.window, "Invalid Flags", fmt.Errorf("NX cannot be combined with GT or LT"))
 			return
 		}
-		scoreEntry.SetText("")
-		memberEntry.SetText("")
-		ve.LoadKey(key)
+		member := memberEntry.Text
+		var changed int64
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			changed, err = c.SortedSetAddWithFlags(key.Key, score, member, flags)
+			return err
+		}, func() {
+			if flags != (redis.ZAddFlags{}) {
+				// ZADD reports added members, or added plus updated ones with CH
+				what := i18n.T("added")
+				if flags.CH {
+					what = i18n.T("added or updated")
+				}
+				ve.worker.Report(i18n.T("ZADD '%s': %d member(s) %s", key.Key, changed, what), nil)
+			}
+			ve.LoadKey(key)
+		})
 	})
 
-	removeBtn := widget.NewButtonWithIcon("Remove Selected", theme.ContentRemoveIcon(), func() {
+	removeBtn := widget.NewButtonWithIcon(i18n.T("Remove Selected"), theme.ContentRemoveIcon(), func() {
 		if selectedMember == "" || selectedRow < 0 {
 			return
 		}
-		err := ve.client.SortedSetRemove(key.Key, selectedMember)
-		if err != nil {
-			ShowErrorDialog(ve.window, "Error", err)
+		member := selectedMember
+		ve.apply(key, func(c *redis.Client) error {
+			return c.SortedSetRemove(key.Key, member)
+		})
+	})
+
+	incrementBtn := widget.NewButtonWithIcon(i18n.T("Increment Score..."), theme.MoveUpIcon(), func() {
+		if selectedMember == "" || selectedRow < 0 {
 			return
 		}
-		selectedMember = ""
-		selectedRow = -1
-		ve.LoadKey(key)
+		ve.incrementScore(key, selectedMember)
+	})
+
+	storeBtn := widget.NewButtonWithIcon(i18n.T("Store Combination..."), theme.ContentCopyIcon(), func() {
+		ve.showCombineStore(key, true)
 	})
 
-	hint := widget.NewLabelWithStyle("Click score or member to edit", fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	hint := widget.NewLabelWithStyle(i18n.T("Click a score or member to edit it in place (Enter saves, Esc cancels)"), fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	setWritable(ve.client, addBtn, removeBtn, incrementBtn, storeBtn)
 
 	addBar := container.NewVBox(
 		hint,
 		container.NewGridWithColumns(2, scoreEntry, memberEntry),
-		container.NewHBox(addBtn, removeBtn),
+		container.NewHBox(conditionSelect, compareSelect, chCheck),
+		container.NewHBox(addBtn, removeBtn, incrementBtn, storeBtn, ve.sampleButton(key)),
+	)
+
+	// Unfiltered pages are ZRANGE windows in score order, or windows of the
+	// rank or score range queried. A filter runs ZSCAN MATCH instead, whose
+	// pages come in hash order and are re-sorted by score.
+	var page []models.ScoredValue
+	var cursor, next uint64
+	var query *models.SortedSetRange
+	var queryTotal, fetchedTotal int64
+	seen := make(map[string]bool)
+	more := int64(len(members)) < total
+	pager, reload := ve.newReloadablePager(total, len(members), more, "Filter members (ZSCAN MATCH)", collectionSource{
+		fetch: func(c *redis.Client, text string, first bool) (err error) {
+			if text == "" {
+				start := int64(len(members))
+				if first {
+					start = 0
+				}
+				if query == nil {
+					page, err = c.GetSortedSetRange(key.Key, start, collectionPageSize())
+					return err
+				}
+				if first {
+					if fetchedTotal, err = c.CountSortedSetRange(key.Key, *query); err != nil {
+						return err
+					}
+				}
+				page, err = c.QuerySortedSet(key.Key, *query, start, collectionPageSize())
+				return err
+			}
+			from := cursor
+			if first {
+				from = 0
+			}
+			page, next, err = c.ScanSortedSet(key.Key, from, matchPattern(text), collectionPageSize())
+			return err
+		},
+		merge: func(text string, first bool) (int, bool) {
+			if first {
+				filter, members, seen = text, nil, make(map[string]bool)
+			}
+			edit.stop()
+			if filter == "" {
+				members = append(members, page...)
+				limit := total
+				if query != nil {
+					queryTotal = fetchedTotal
+					limit = queryTotal
+				}
+				more = len(page) > 0 && int64(len(members)) < limit
+			} else {
+				// ZSCAN can return a member more than once
+				for _, m := range page {
+					if !seen[m.Member] {
+						seen[m.Member] = true
+						members = append(members, m)
+					}
+				}
+				cursor = next
+				more = cursor != 0
+				sort.SliceStable(members, func(i, j int) bool {
+					if members[i].Score != members[j].Score {
+						return members[i].Score < members[j].Score
+					}
+					return members[i].Member < members[j].Member
+				})
+			}
+			selectedMember, selectedRow = "", -1
+			table.UnselectAll()
+			table.Refresh()
+			return len(members), more
+		},
+		count: func(shown int) string {
+			if query == nil {
+				return i18n.T("Showing %d of %d", shown, total)
+			}
+			return i18n.T("Showing %d of %d in range (%d total)", shown, queryTotal, total)
+		},
+	})
+
+	// A range query narrows the unfiltered pages to ranks or scores, for
+	// sorted sets too large to page through from the lowest score
+	fromEntry := widget.NewEntry()
+	toEntry := widget.NewEntry()
+	reverseCheck := widget.NewCheck(i18n.T("Highest first"), nil)
+	// The range modes are all members, by rank and by score
+	rangeSelect := widget.NewSelect([]string{i18n.T("All members"), i18n.T("By rank"), i18n.T("By score")}, nil)
+	rangeSelect.OnChanged = func(string) {
+		switch rangeSelect.SelectedIndex() {
+		case 0:
+			fromEntry.Disable()
+			toEntry.Disable()
+			return
+		case 1:
+			fromEntry.SetPlaceHolder("0")
+			toEntry.SetPlaceHolder("-1")
+		case 2:
+			fromEntry.SetPlaceHolder("-inf")
+			toEntry.SetPlaceHolder("+inf")
+		}
+		fromEntry.Enable()
+		toEntry.Enable()
+	}
+	rangeSelect.SetSelectedIndex(0)
+	showBtn := widget.NewButtonWithIcon(i18n.T("Show"), theme.SearchIcon(), func() {
+		bound := func(entry *widget.Entry) string {
+			if text := strings.TrimSpace(entry.Text); text != "" {
+				return text
+			}
+			return entry.PlaceHolder
+		}
+		switch rangeSelect.SelectedIndex() {
+		case 0:
+			query = nil
+			if reverseCheck.Checked {
+				query = &models.SortedSetRange{Min: "0", Max: "-1", Reverse: true}
+			}
+		default:
+			r := &models.SortedSetRange{
+				ByScore: rangeSelect.SelectedIndex() == 2,
+				Min:     bound(fromEntry),
+				Max:     bound(toEntry),
+				Reverse: reverseCheck.Checked,
+			}
+			for _, b := range []string{r.Min, r.Max} {
+				if err := checkRangeBound(b, r.ByScore); err != nil {
+					ShowErrorDialog(ve.window, "Invalid Range", err)
+					return
+				}
+			}
+			query = r
+		}
+		reload()
+	})
+	rangeBar := container.NewBorder(nil, nil, rangeSelect, container.NewHBox(reverseCheck, showBtn),
+		container.NewGridWithColumns(2, fromEntry, toEntry))
+
+	return withViewToggle("Geo", container.NewBorder(container.NewVBox(pager, rangeBar), addBar, nil, nil, table),
+		func() fyne.CanvasObject { return ve.buildGeoEditor(key, members) })
+}
+
+// incrementScore asks for a delta and adds it to a member's score with
+// ZINCRBY, which unlike rewriting the score can't lose a concurrent update
+func (ve *ValueEditor) incrementScore(key models.RedisKey, member string) {
+	if refuseReadOnly(ve.window, ve.client) {
+		return
+	}
+	deltaEntry := widget.NewEntry()
+	deltaEntry.SetText("1")
+
+	d := dialog.NewForm(i18n.T("Increment Score"), i18n.T("Increment"), i18n.T("Cancel"),
+		[]*widget.FormItem{
+			{Text: i18n.T("Member"), Widget: widget.NewLabel(member)},
+			{Text: i18n.T("Delta"), Widget: deltaEntry, HintText: i18n.T("Added to the score; negative to decrease it")},
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			delta, err := strconv.ParseFloat(strings.TrimSpace(deltaEntry.Text), 64)
+			if err != nil || math.IsNaN(delta) {
+				ShowErrorDialog(ve.window, "Invalid Delta", fmt.Errorf("delta must be a valid number"))
+				return
+			}
+			var score float64
+			var snapshots []models.KeySnapshot
+			ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+				snapshots = snapshotKeys(c, []string{key.Key})
+				score, err = c.SortedSetIncrement(key.Key, member, delta)
+				return err
+			}, func() {
+				ve.undo.push(ve.client, i18n.T("Edited '%s'", key.Key), snapshots, false)
+				ve.worker.Report(i18n.T("ZINCRBY '%s': '%s' is now %s", key.Key, member, strconv.FormatFloat(score, 'f', -1, 64)), nil)
+				ve.LoadKey(key)
+			})
+		}, ve.window)
+	d.Resize(fyne.NewSize(400, 200))
+	d.Show()
+}
+
+// checkRangeBound checks a bound of a sorted set range: a whole rank, or a
+// score that may start with "(" to be exclusive or be -inf or +inf
+func checkRangeBound(bound string, byScore bool) error {
+	if !byScore {
+		if _, err := strconv.ParseInt(bound, 10, 64); err != nil {
+			return fmt.Errorf("rank %q is not a whole number", bound)
+		}
+		return nil
+	}
+	if _, err := strconv.ParseFloat(strings.TrimPrefix(bound, "("), 64); err != nil {
+		return fmt.Errorf("score %q is not a number, -inf or +inf", bound)
+	}
+	return nil
+}
+
+// streamPageSize is how many of the newest stream entries the stream editor shows
+const streamPageSize = 200
+
+func (ve *ValueEditor) buildStreamEditor(key models.RedisKey, entries []models.StreamEntry, length int64, groups []models.StreamGroup) fyne.CanvasObject {
+	var selectedID string
+
+	table := widget.NewTable(
+		func() (int, int) { return len(entries), 2 },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			entry := entries[id.Row]
+			if id.Col == 0 {
+				label.SetText(entry.ID)
+				label.TextStyle = fyne.TextStyle{Bold: true}
+			} else {
+				pairs := make([]string, len(entry.Fields))
+				for i, f := range entry.Fields {
+					pairs[i] = f.Key + "=" + f.Value
+				}
+				label.SetText(strings.Join(pairs, ", "))
+				label.TextStyle = valueTextStyle()
+			}
+		},
+	)
+	table.SetColumnWidth(0, 180)
+	table.SetColumnWidth(1, 400)
+
+	table.OnSelected = func(id widget.TableCellID) {
+		if id.Row < len(entries) {
+			selectedID = entries[id.Row].ID
+		}
+	}
+
+	countLabel := widget.NewLabel(i18n.T("Showing newest %d of %d entries", len(entries), length))
+
+	fieldsEntry := widget.NewMultiLineEntry()
+	fieldsEntry.SetPlaceHolder(i18n.T("field=value, one per line"))
+	fieldsEntry.SetMinRowsVisible(2)
+
+	addBtn := widget.NewButtonWithIcon(i18n.T("Add Entry"), theme.ContentAddIcon(), func() {
+		var fields []models.KeyValue
+		for _, line := range strings.Split(fieldsEntry.Text, "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			parts := strings.SplitN(line, "=", 2)
+			if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+				ShowErrorDialog(ve.window, "Invalid Entry", fmt.Errorf("expected field=value, got %q", line))
+				return
+			}
+			fields = append(fields, models.KeyValue{Key: strings.TrimSpace(parts[0]), Value: parts[1]})
+		}
+		if len(fields) == 0 {
+			return
+		}
+		ve.apply(key, func(c *redis.Client) error {
+			_, err := c.StreamAdd(key.Key, fields)
+			return err
+		})
+	})
+
+	removeBtn := widget.NewButtonWithIcon(i18n.T("Delete Selected"), theme.ContentRemoveIcon(), func() {
+		if selectedID == "" {
+			return
+		}
+		id := selectedID
+		ve.apply(key, func(c *redis.Client) error {
+			return c.StreamDelete(key.Key, id)
+		})
+	})
+
+	maxLenEntry := widget.NewEntry()
+	maxLenEntry.SetPlaceHolder(i18n.T("Max length"))
+
+	trimBtn := widget.NewButtonWithIcon(i18n.T("Trim"), theme.ContentCutIcon(), func() {
+		maxLen, err := strconv.ParseInt(strings.TrimSpace(maxLenEntry.Text), 10, 64)
+		if err != nil || maxLen < 0 {
+			ShowErrorDialog(ve.window, "Invalid Length", fmt.Errorf("max length must be a non-negative number"))
+			return
+		}
+		confirmDestructive(ve.window, "Trim Stream",
+			i18n.T("Trim '%s' to the newest %d entries?", key.Key, maxLen),
+			func() {
+				ve.apply(key, func(c *redis.Client) error {
+					_, err := c.StreamTrim(key.Key, maxLen)
+					return err
+				})
+			})
+	})
+
+	setWritable(ve.client, addBtn, removeBtn, trimBtn)
+
+	addBar := container.NewVBox(
+		countLabel,
+		container.NewBorder(nil, nil, nil, addBtn, fieldsEntry),
+		container.NewHBox(removeBtn, widget.NewSeparator(), maxLenEntry, trimBtn),
+	)
+
+	entriesTab := container.NewBorder(nil, addBar, nil, nil, table)
+
+	return container.NewAppTabs(
+		container.NewTabItem(i18n.T("Entries"), entriesTab),
+		container.NewTabItem(i18n.T("Consumer Groups (%d)", len(groups)), ve.buildStreamGroups(key, groups)),
+	)
+}
+
+// buildJSONEditor shows a RedisJSON document as a tree. The selected value is
+// edited as JSON and written back with JSON.SET at its path.
+func (ve *ValueEditor) buildJSONEditor(key models.RedisKey, doc string) fyne.CanvasObject {
+	nodes, err := parseJSONTree(doc)
+	if err != nil {
+		return widget.NewLabel(i18n.T("Error: %s", err))
+	}
+	root := nodes[""]
+	selected := root
+
+	pathLabel := widget.NewLabelWithStyle("$", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	valueEntry := widget.NewMultiLineEntry()
+	valueEntry.TextStyle = fyne.TextStyle{Monospace: true}
+	valueEntry.Wrapping = fyne.TextWrapOff
+	formatted, _ := formatJSON(doc)
+	valueEntry.SetText(formatted)
+
+	addBtn := widget.NewButtonWithIcon(i18n.T("Add"), theme.ContentAddIcon(), nil)
+	deleteBtn := widget.NewButtonWithIcon(i18n.T("Delete"), theme.DeleteIcon(), nil)
+	deleteBtn.Disable()
+
+	showNode := func(node *jsonNode) {
+		selected = node
+		pathLabel.SetText(node.Path)
+		if (node.Kind == "object" || node.Kind == "array") && !isReadOnly(ve.client) {
+			addBtn.Enable()
+		} else {
+			addBtn.Disable()
+		}
+		if node == root {
+			deleteBtn.Disable()
+			valueEntry.SetText(formatted)
+			return
+		}
+		if !isReadOnly(ve.client) {
+			deleteBtn.Enable()
+		}
+
+		var value string
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			value, err = c.JSONGet(key.Key, node.Path)
+			return
+		}, func() {
+			if selected != node {
+				return
+			}
+			if pretty, err := formatJSON(value); err == nil {
+				value = pretty
+			}
+			valueEntry.SetText(value)
+		})
+	}
+
+	tree := newJSONTree(nodes)
+	tree.OnSelected = func(uid widget.TreeNodeID) {
+		if node, ok := nodes[uid]; ok {
+			showNode(node)
+		}
+	}
+
+	documentBtn := widget.NewButtonWithIcon(i18n.T("Whole Document"), theme.FileIcon(), func() {
+		tree.UnselectAll()
+		showNode(root)
+	})
+
+	saveBtn := widget.NewButtonWithIcon(i18n.T("Save"), theme.DocumentSaveIcon(), func() {
+		value, err := compactJSON(valueEntry.Text)
+		if err != nil {
+			ShowErrorDialog(ve.window, "Invalid JSON", err)
+			return
+		}
+		path := selected.Path
+		ve.apply(key, func(c *redis.Client) error {
+			return c.JSONSet(key.Key, path, value)
+		})
+	})
+	saveBtn.Importance = widget.HighImportance
+
+	deleteBtn.OnTapped = func() {
+		path := selected.Path
+		confirmDestructive(ve.window, "Delete Value", i18n.T("Delete the value at %s?", path), func() {
+			ve.apply(key, func(c *redis.Client) error {
+				return c.JSONDelete(key.Key, path)
+			})
+		})
+	}
+
+	addBtn.OnTapped = func() {
+		parent := selected
+		object := parent.Kind == "object"
+		ShowJSONAddDialog(ve.window, object, func(name, value string) {
+			ve.apply(key, func(c *redis.Client) error {
+				if !object {
+					return c.JSONArrayAppend(key.Key, parent.Path, value)
+				}
+				quoted, _ := json.Marshal(name)
+				return c.JSONSet(key.Key, parent.Path+"["+string(quoted)+"]", value)
+			})
+		})
+	}
+	if root.Kind != "object" && root.Kind != "array" || isReadOnly(ve.client) {
+		addBtn.Disable()
+	}
+	setWritable(ve.client, saveBtn)
+
+	editor := container.NewBorder(
+		pathLabel,
+		container.NewHBox(saveBtn, addBtn, deleteBtn),
+		nil, nil,
+		valueEntry,
 	)
+	split := container.NewHSplit(container.NewBorder(documentBtn, nil, nil, nil, tree), editor)
+	split.Offset = 0.4
+	return split
+}
+
+// showCombineStore lets the user combine key with other keys, view the result
+// and store it
+func (ve *ValueEditor) showCombineStore(key models.RedisKey, sortedSet bool) {
+	combine := (*redis.Client).SetCombine
+	store := (*redis.Client).SetCombineStore
+	if sortedSet {
+		combine = (*redis.Client).SortedSetCombine
+		store = (*redis.Client).SortedSetCombineStore
+	}
+
+	ShowCombineStoreDialog(ve.window, key.Key, sortedSet,
+		func(req models.CombineRequest, show func([]string, error)) {
+			client := ve.client
+			var members []string
+			ve.worker.Go(func(ctx context.Context) (err error) {
+				members, err = combine(client.WithContext(ctx), req)
+				return err
+			}, func(err error) {
+				sort.Strings(members)
+				show(members, err)
+			})
+		},
+		func(req models.CombineRequest) {
+			if refuseReadOnly(ve.window, ve.client) {
+				return
+			}
+			var n int64
+			ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+				n, err = store(c, req)
+				return err
+			}, func() {
+				ve.worker.Report(i18n.T("Stored %d members in '%s'", n, req.Destination), nil)
+				if ve.onKeyUpdated != nil {
+					ve.onKeyUpdated()
+				}
+			})
+		})
+}
+
+// listFindLimit caps how many matching indexes a "Find in list" search returns
+const listFindLimit = 1000
+
+// listWindow is the range of list indexes the list editor shows, as LRANGE
+// takes them: negative indexes count from the tail, -1 being the last element.
+// from is where its first page starts.
+type listWindow struct {
+	start, stop, from int64
+}
+
+// index is i counted from the head of a list of length elements
+func (w listWindow) index(i, length int64) int64 {
+	if i < 0 {
+		return i + length
+	}
+	return i
+}
+
+// resolve counts the window's indexes from the head of a list of length
+// elements, clamps them to the list and keeps from within them
+func (w listWindow) resolve(length int64) listWindow {
+	r := listWindow{
+		start: max(w.index(w.start, length), 0),
+		stop:  min(w.index(w.stop, length), length-1),
+	}
+	r.from = min(max(w.index(w.from, length), r.start), max(r.stop, r.start))
+	return r
+}
+
+// showListRemove asks how many elements equal to value LREM removes, and from
+// which end, calling remove with the count LREM takes
+func (ve *ValueEditor) showListRemove(value string, remove func(count int64)) {
+	countEntry := widget.NewEntry()
+	countEntry.SetText("1")
+	// The options are from the head, from the tail and all of them
+	fromSelect := widget.NewSelect([]string{i18n.T("From the head"), i18n.T("From the tail"), i18n.T("All of them")}, nil)
+	fromSelect.OnChanged = func(string) {
+		if fromSelect.SelectedIndex() == 2 {
+			countEntry.Disable()
+		} else {
+			countEntry.Enable()
+		}
+	}
+	fromSelect.SetSelectedIndex(0)
+	valueLabel := widget.NewLabel(value)
+	valueLabel.Truncation = fyne.TextTruncateEllipsis
+
+	d := dialog.NewForm(i18n.T("Remove Elements"), i18n.T("Remove"), i18n.T("Cancel"),
+		[]*widget.FormItem{
+			{Text: i18n.T("Element"), Widget: valueLabel},
+			{Text: i18n.T("Remove"), Widget: fromSelect, HintText: i18n.T("Which elements equal to it LREM removes")},
+			{Text: i18n.T("Count"), Widget: countEntry},
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if fromSelect.SelectedIndex() == 2 {
+				remove(0)
+				return
+			}
+			count, err := strconv.ParseInt(strings.TrimSpace(countEntry.Text), 10, 64)
+			if err != nil || count <= 0 {
+				ShowErrorDialog(ve.window, "Invalid Count", fmt.Errorf("the count must be a whole number above 0"))
+				return
+			}
+			if fromSelect.SelectedIndex() == 1 {
+				count = -count
+			}
+			remove(count)
+		}, ve.window)
+	d.Resize(fyne.NewSize(400, 250))
+	d.Show()
+}
 
-	return container.NewBorder(nil, addBar, nil, nil, table)
+// apply runs a modification of key in the background and reloads the editor
+// once it succeeds. The key is snapshotted first so the change can be undone.
+func (ve *ValueEditor) apply(key models.RedisKey, op func(c *redis.Client) error) {
+	if refuseReadOnly(ve.window, ve.client) {
+		return
+	}
+	var snapshots []models.KeySnapshot
+	ve.worker.Do(ve.window, ve.client, func(c *redis.Client) error {
+		snapshots = snapshotKeys(c, []string{key.Key})
+		return op(c)
+	}, func() {
+		ve.undo.push(ve.client, i18n.T("Edited '%s'", key.Key), snapshots, false)
+		ve.LoadKey(key)
+	})
 }
 
 func (ve *ValueEditor) showEditValueDialog(fieldName string, currentValue string, onSave func(string)) {
+	if refuseReadOnly(ve.window, ve.client) {
+		return
+	}
 	entry := widget.NewMultiLineEntry()
 	entry.SetText(currentValue)
-	entry.Wrapping = fyne.TextWrapWord
+	entry.TextStyle = valueTextStyle()
+	entry.Wrapping = valueWrapping()
 
-	d := dialog.NewForm(fmt.Sprintf("Edit %s", fieldName), "Save", "Cancel",
+	d := dialog.NewForm(i18n.T("Edit %s", i18n.T(fieldName)), i18n.T("Save"), i18n.T("Cancel"),
 		[]*widget.FormItem{
-			{Text: fieldName, Widget: entry},
+			{Text: i18n.T(fieldName), Widget: entry},
 		},
 		func(save bool) {
 			if save {
@@ -627,11 +2217,17 @@ func (ve *ValueEditor) showEditValueDialog(fieldName string, currentValue string
 
 // Clear clears the editor
 func (ve *ValueEditor) Clear() {
+	ve.liveCheck.SetChecked(false)
+	ve.stopTTLCountdown()
+	ve.stopQueuePoll()
+	ve.stopCounterPoll()
 	ve.currentKey = nil
-	ve.keyLabel.SetText("No key selected")
+	ve.keyLabel.SetText(i18n.T("No key selected"))
 	ve.typeLabel.SetText("")
 	ve.ttlLabel.SetText("")
+	ve.memoryLabel.SetText("")
+	ve.setDetails(nil, "")
 	ve.contentArea.RemoveAll()
-	ve.contentArea.Add(widget.NewLabel("Select a key to view its value"))
+	ve.contentArea.Add(widget.NewLabel(i18n.T("Select a key to view its value")))
 	ve.contentArea.Refresh()
 }
@@ -0,0 +1,328 @@
+package ui
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// exportKeys asks for a destination file and writes the requested keys to it in the background
+func exportKeys(window fyne.Window, worker *Worker, client *redis.Client, req models.ExportRequest) {
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			ShowErrorDialog(window, "Export Error", err)
+			return
+		}
+		if writer == nil {
+			return
+		}
+
+		var exported, skipped int
+		worker.Job(i18n.T("Export to %s", writer.URI().Name()), func(ctx context.Context, job *jobs.Job) error {
+			defer writer.Close()
+			var err error
+			exported, skipped, err = writeExport(client.WithContext(ctx), writer, req, func(done, total int) {
+				job.Update(int64(done), int64(total))
+			})
+			return err
+		}, func(err error) {
+			if errors.Is(err, context.Canceled) {
+				ShowInfoDialog(window, "Export Cancelled", "The export was cancelled; the file is incomplete.")
+				return
+			}
+			if err != nil {
+				ShowErrorDialog(window, "Export Error", err)
+				return
+			}
+			message := i18n.T("Exported %d keys to %s.", exported, writer.URI().Name())
+			if skipped > 0 {
+				message += i18n.T("\n%d keys disappeared during the export and were skipped.", skipped)
+			}
+			if req.Format == models.ExportCommands {
+				message += i18n.T("\n\nReplay it with: redis-cli < %s", writer.URI().Name())
+			}
+			ShowInfoDialog(window, "Export Complete", message)
+		})
+	}, window)
+
+	d.SetFileName("redis-export." + req.Format)
+	extensions := []string{"." + req.Format}
+	if req.Format == models.ExportCommands {
+		extensions = append(extensions, ".sh")
+	}
+	d.SetFilter(storage.NewExtensionFileFilter(extensions))
+	d.Show()
+}
+
+// writeExport writes the requested keys to w from the worker, calling progress
+// after each key. Keys that disappear before they are read are skipped.
+func writeExport(c *redis.Client, w io.Writer, req models.ExportRequest, progress func(done, total int)) (exported, skipped int, err error) {
+	keys := req.Keys
+	if len(keys) == 0 {
+		if keys, err = c.MatchingKeys(req.Pattern); err != nil {
+			return 0, 0, err
+		}
+	}
+
+	out := newDumpWriter(req.Format, w)
+	for i, key := range keys {
+		err := exportKey(c, out, key)
+		if errors.Is(err, redis.ErrKeyNotFound) {
+			// Deleted or expired since it was listed
+			skipped++
+			continue
+		}
+		if err != nil {
+			return exported, skipped, err
+		}
+		exported++
+		progress(i+1, len(keys))
+	}
+	return exported, skipped, out.Close()
+}
+
+// dumpWriter writes key dumps to a file in one of the export formats
+type dumpWriter interface {
+	Write(dump *models.KeyDump) error
+	Close() error
+}
+
+func newDumpWriter(format string, w io.Writer) dumpWriter {
+	switch format {
+	case models.ExportCSV:
+		return &csvDumpWriter{w: csv.NewWriter(w)}
+	case models.ExportCommands:
+		return &commandDumpWriter{w: w}
+	case models.ExportPayloads:
+		return &payloadWriter{jsonDumpWriter{w: w}}
+	}
+	return &jsonDumpWriter{w: w}
+}
+
+// exportKey reads a key the way out writes it: as its DUMP payload for a
+// payload export, or else as its type, TTL and value
+func exportKey(c *redis.Client, out dumpWriter, key string) error {
+	if p, ok := out.(*payloadWriter); ok {
+		payload, err := c.DumpPayload(key)
+		if err != nil {
+			return err
+		}
+		return p.writeItem(payload)
+	}
+	dump, err := c.DumpKey(key)
+	if err != nil {
+		return err
+	}
+	return out.Write(dump)
+}
+
+// jsonDumpWriter streams dumps as an indented JSON array, one object per key
+type jsonDumpWriter struct {
+	w     io.Writer
+	count int
+}
+
+func (j *jsonDumpWriter) Write(dump *models.KeyDump) error {
+	return j.writeItem(dump)
+}
+
+// writeItem appends v to the array
+func (j *jsonDumpWriter) writeItem(v interface{}) error {
+	data, err := json.MarshalIndent(v, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if j.count == 0 {
+		sep = "[\n  "
+	}
+	j.count++
+	_, err = io.WriteString(j.w, sep+string(data))
+	return err
+}
+
+func (j *jsonDumpWriter) Close() error {
+	end := "\n]\n"
+	if j.count == 0 {
+		end = "[]\n"
+	}
+	_, err := io.WriteString(j.w, end)
+	return err
+}
+
+// payloadWriter streams KeyPayloads as a JSON array; exportKey hands it
+// payloads rather than dumps
+type payloadWriter struct {
+	jsonDumpWriter
+}
+
+func (p *payloadWriter) Write(dump *models.KeyDump) error {
+	return fmt.Errorf("a payload export can't write the value of '%s'", dump.Key)
+}
+
+// csvDumpWriter writes one row per key: key, type, ttl and value. Strings are
+// written as-is; other types are written as the JSON of their contents.
+type csvDumpWriter struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (c *csvDumpWriter) Write(dump *models.KeyDump) error {
+	if !c.header {
+		c.header = true
+		if err := c.w.Write([]string{"key", "type", "ttl", "value"}); err != nil {
+			return err
+		}
+	}
+
+	value, err := dumpValueText(dump)
+	if err != nil {
+		return err
+	}
+
+	return c.w.Write([]string{dump.Key, dump.Type, strconv.FormatInt(dump.TTL, 10), value})
+}
+
+// dumpValueText returns a string value as-is and any other value as the JSON of its contents
+func dumpValueText(dump *models.KeyDump) (string, error) {
+	var contents interface{}
+	switch dump.Type {
+	case "string", redis.JSONType:
+		return dump.Value, nil
+	case "list", "set":
+		contents = dump.Items
+	case "hash":
+		contents = dump.Fields
+	case "zset":
+		contents = dump.Members
+	case "stream":
+		contents = dump.Entries
+	}
+	data, err := json.Marshal(contents)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+func (c *csvDumpWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// commandBatchBytes is about how long a command writing a collection's entries
+// grows before the rest go in another command, keeping lines well under the
+// server's 64 KB inline command limit
+const commandBatchBytes = 16 << 10
+
+// commandDumpWriter writes each key as the redis-cli commands that recreate
+// it: a DEL, so that replaying the file over existing data leaves exactly the
+// exported value, then SET, RPUSH, SADD, HSET, ZADD, XADD or JSON.SET, then
+// EXPIRE for keys with a TTL. Arguments are quoted the way redis-cli quotes
+// them.
+type commandDumpWriter struct {
+	w io.Writer
+}
+
+func (c *commandDumpWriter) Write(dump *models.KeyDump) error {
+	if err := c.command("DEL", dump.Key); err != nil {
+		return err
+	}
+
+	var err error
+	switch dump.Type {
+	case "string":
+		err = c.command("SET", dump.Key, dump.Value)
+	case redis.JSONType:
+		err = c.command("JSON.SET", dump.Key, "$", dump.Value)
+	case "list":
+		err = c.batched("RPUSH", dump.Key, len(dump.Items), func(i int) []string { return []string{dump.Items[i]} })
+	case "set":
+		err = c.batched("SADD", dump.Key, len(dump.Items), func(i int) []string { return []string{dump.Items[i]} })
+	case "hash":
+		fields := make([]string, 0, len(dump.Fields))
+		for field := range dump.Fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		err = c.batched("HSET", dump.Key, len(fields), func(i int) []string {
+			return []string{fields[i], dump.Fields[fields[i]]}
+		})
+	case "zset":
+		err = c.batched("ZADD", dump.Key, len(dump.Members), func(i int) []string {
+			m := dump.Members[i]
+			return []string{strconv.FormatFloat(m.Score, 'g', -1, 64), m.Member}
+		})
+	case "stream":
+		// XADD takes one entry at a time, keeping its ID
+		for _, entry := range dump.Entries {
+			args := []string{"XADD", dump.Key, entry.ID}
+			for _, f := range entry.Fields {
+				args = append(args, f.Key, f.Value)
+			}
+			if err = c.command(args...); err != nil {
+				break
+			}
+		}
+	default:
+		err = fmt.Errorf("can't write '%s' of type %s as commands", dump.Key, dump.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	if dump.TTL > 0 {
+		return c.command("EXPIRE", dump.Key, strconv.FormatInt(dump.TTL, 10))
+	}
+	return nil
+}
+
+// batched writes a command adding n entries to key, the entries split over as
+// many commands as commandBatchBytes needs
+func (c *commandDumpWriter) batched(name, key string, n int, entry func(i int) []string) error {
+	args := []string{name, key}
+	size := 0
+	for i := 0; i < n; i++ {
+		for _, arg := range entry(i) {
+			args = append(args, arg)
+			size += len(arg)
+		}
+		if size >= commandBatchBytes {
+			if err := c.command(args...); err != nil {
+				return err
+			}
+			args, size = args[:2], 0
+		}
+	}
+	if len(args) > 2 {
+		return c.command(args...)
+	}
+	return nil
+}
+
+func (c *commandDumpWriter) command(args ...string) error {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = redis.QuoteArg(arg)
+	}
+	_, err := io.WriteString(c.w, strings.Join(quoted, " ")+"\n")
+	return err
+}
+
+func (c *commandDumpWriter) Close() error {
+	return nil
+}
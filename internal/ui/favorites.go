@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// buildFavorites creates the collapsible Favorites section above the keys. It
+// is hidden while the database has no starred keys or folders.
+func (kb *KeyBrowser) buildFavorites() fyne.CanvasObject {
+	kb.favoriteRows = container.NewVBox()
+	item := widget.NewAccordionItem(i18n.T("Favorites"), kb.favoriteRows)
+	item.Open = true
+	kb.favorites = widget.NewAccordion(item)
+	kb.favorites.Hide()
+	return kb.favorites
+}
+
+// favorite returns the favorite for a key, or a tree folder with prefix set, in
+// the current database
+func (kb *KeyBrowser) favorite(key string, prefix bool) models.Favorite {
+	conn := kb.client.Connection()
+	return models.Favorite{ConnectionID: conn.ID, Database: conn.Database, Key: key, Prefix: prefix}
+}
+
+// favoriteMenuItem returns a context menu item that stars or unstars a key or folder
+func (kb *KeyBrowser) favoriteMenuItem(key string, prefix bool) *fyne.MenuItem {
+	fav := kb.favorite(key, prefix)
+	if config.IsFavorite(fav) {
+		return fyne.NewMenuItem(i18n.T("Remove from Favorites"), func() {
+			config.RemoveFavorite(fav)
+			kb.showFavorites()
+		})
+	}
+	return fyne.NewMenuItem(i18n.T("Add to Favorites"), func() {
+		config.AddFavorite(fav)
+		kb.showFavorites()
+	})
+}
+
+// showFavorites lists the starred keys and folders of the current database
+func (kb *KeyBrowser) showFavorites() {
+	kb.favoriteRows.RemoveAll()
+	if kb.client == nil {
+		kb.favorites.Hide()
+		return
+	}
+
+	conn := kb.client.Connection()
+	favorites := config.GetFavorites(conn.ID, conn.Database)
+	for _, fav := range favorites {
+		icon, name := theme.DocumentIcon(), fav.Key
+		if fav.Prefix {
+			icon, name = theme.FolderIcon(), fav.Key+kb.delimiter
+		} else if key, ok := kb.findKey(fav.Key); ok {
+			icon = kb.getKeyIcon(key.Type)
+		}
+
+		row := newContextRow(container.NewHBox(widget.NewIcon(icon), widget.NewLabel(name)))
+		row.onTapped = func() { kb.openFavorite(fav) }
+		row.onSecondaryTapped = func(pos fyne.Position) {
+			menu := fyne.NewMenu("",
+				fyne.NewMenuItem(i18n.T("Open"), func() { kb.openFavorite(fav) }),
+				fyne.NewMenuItem(i18n.T("Remove from Favorites"), func() {
+					config.RemoveFavorite(fav)
+					kb.showFavorites()
+				}),
+			)
+			widget.ShowPopUpMenuAtPosition(menu, kb.window.Canvas(), pos)
+		}
+		kb.favoriteRows.Add(row)
+	}
+
+	if len(favorites) == 0 {
+		kb.favorites.Hide()
+		return
+	}
+	kb.favorites.Items[0].Title = i18n.T("Favorites (%d)", len(favorites))
+	kb.favorites.Refresh()
+	kb.favorites.Show()
+}
+
+// openFavorite scopes the browser to a starred folder or opens a starred key,
+// looking up its type when it isn't among the loaded keys
+func (kb *KeyBrowser) openFavorite(fav models.Favorite) {
+	if kb.client == nil {
+		return
+	}
+	if fav.Prefix {
+		kb.setScope(fav.Key)
+		return
+	}
+	if key, ok := kb.findKey(fav.Key); ok {
+		kb.openKey(key)
+		return
+	}
+
+	name := fav.Key
+	var keyType string
+	kb.worker.Do(kb.window, kb.client, func(c *redis.Client) (err error) {
+		keyType, err = c.GetKeyType(name)
+		if err == nil && keyType == "none" {
+			err = fmt.Errorf("key '%s' no longer exists", name)
+		}
+		return err
+	}, func() {
+		if kb.onKeySelected != nil {
+			kb.onKeySelected(models.RedisKey{Key: name, Type: keyType})
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// deletePrefix finds every key under a tree folder's prefix on the server and
+// lists them for review, so some can be excluded before the rest are deleted
+func (kb *KeyBrowser) deletePrefix(prefix string) {
+	client := kb.client
+	var keys []string
+	var closeProgress func()
+	cancel := kb.worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		keys, err = client.WithContext(ctx).MatchingKeys(redis.PrefixPattern(prefix))
+		return err
+	}, func(err error) {
+		closeProgress()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(kb.window, "Error", err)
+			return
+		}
+		if len(keys) == 0 {
+			ShowInfoDialog(kb.window, "Delete Keys", i18n.T("No keys under '%s'. Nothing to delete.", prefix))
+			return
+		}
+		sort.Strings(keys)
+		ShowFolderDeleteDialog(kb.window, prefix, keys, func(selected []string) {
+			kb.deleteFolderKeys(client, prefix, selected)
+		})
+	})
+	closeProgress = ShowCancellableProgress(kb.window, "Delete Keys", i18n.T("Finding keys under '%s'...", prefix), cancel)
+}
+
+// deleteFolderKeys deletes the reviewed keys in batches with progress and
+// cancel, snapshotting them first so the delete can be undone if they are small
+// enough
+func (kb *KeyBrowser) deleteFolderKeys(client *redis.Client, prefix string, keys []string) {
+	var snapshots []models.KeySnapshot
+	var deleted int64
+	kb.worker.Job(i18n.T("Delete %d keys under '%s'", len(keys), prefix), func(ctx context.Context, job *jobs.Job) error {
+		c := client.WithContext(ctx)
+		job.SetDetail(i18n.T("Snapshotting keys for undo"))
+		snapshots = snapshotKeys(c, keys)
+		job.SetDetail("")
+		var err error
+		deleted, err = c.DeleteKeysWithProgress(keys, func(n int64) {
+			job.Update(n, int64(len(keys)))
+		})
+		return err
+	}, func(err error) {
+		if deleted > 0 {
+			kb.undo.push(client, i18n.T("Deleted %d keys under '%s'", deleted, prefix), snapshots, true)
+			for _, key := range keys {
+				delete(kb.checked, key)
+				if kb.onKeyDeleted != nil {
+					kb.onKeyDeleted(key)
+				}
+			}
+			kb.LoadKeys()
+		}
+		if errors.Is(err, context.Canceled) {
+			ShowInfoDialog(kb.window, "Delete Cancelled", i18n.T("Cancelled after deleting %d keys.", deleted))
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(kb.window, "Delete Keys", err)
+		}
+	})
+}
+
+// ShowFolderDeleteDialog lists the keys under a folder's prefix, all ticked for
+// deletion. Unticking a key excludes it; the filter narrows the list and the
+// Include/Exclude buttons apply to the keys it shows. onDelete receives the
+// keys still ticked.
+func ShowFolderDeleteDialog(window fyne.Window, prefix string, keys []string, onDelete func(keys []string)) {
+	excluded := make(map[string]bool)
+	shown := keys
+	summary := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	updateSummary := func() {
+		summary.SetText(i18n.T("%d of %d keys under '%s' will be deleted", len(keys)-len(excluded), len(keys), prefix))
+	}
+	updateSummary()
+
+	list := widget.NewList(
+		func() int { return len(shown) },
+		func() fyne.CanvasObject { return widget.NewCheck("", nil) },
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			check := o.(*widget.Check)
+			key := shown[id]
+			check.OnChanged = nil
+			check.Text = key
+			check.Checked = !excluded[key]
+			check.Refresh()
+			check.OnChanged = func(on bool) {
+				if on {
+					delete(excluded, key)
+				} else {
+					excluded[key] = true
+				}
+				updateSummary()
+			}
+		},
+	)
+
+	filterEntry := widget.NewEntry()
+	filterEntry.SetPlaceHolder(i18n.T("Filter keys"))
+	filterEntry.OnChanged = func(text string) {
+		text = strings.ToLower(text)
+		shown = nil
+		for _, key := range keys {
+			if strings.Contains(strings.ToLower(key), text) {
+				shown = append(shown, key)
+			}
+		}
+		list.Refresh()
+	}
+
+	setShown := func(include bool) {
+		for _, key := range shown {
+			if include {
+				delete(excluded, key)
+			} else {
+				excluded[key] = true
+			}
+		}
+		updateSummary()
+		list.Refresh()
+	}
+	buttons := container.NewHBox(
+		widget.NewButton(i18n.T("Include Shown"), func() { setShown(true) }),
+		widget.NewButton(i18n.T("Exclude Shown"), func() { setShown(false) }),
+	)
+
+	footer := container.NewVBox()
+	typed := func(fyne.Window, string) bool { return true }
+	if guardPhrase != "" {
+		var entry fyne.CanvasObject
+		entry, typed = newPhraseEntry(guardPhrase)
+		footer.Add(widget.NewSeparator())
+		footer.Add(entry)
+	}
+
+	header := container.NewVBox(summary, container.NewBorder(nil, nil, nil, buttons, filterEntry))
+	content := container.NewBorder(header, footer, nil, nil, list)
+
+	title := i18n.T("Delete '%s*'", prefix)
+	d := dialog.NewCustomConfirm(title, i18n.T("Delete"), i18n.T("Cancel"), content, func(ok bool) {
+		if !ok || !typed(window, title) {
+			return
+		}
+		var selected []string
+		for _, key := range keys {
+			if !excluded[key] {
+				selected = append(selected, key)
+			}
+		}
+		if len(selected) == 0 {
+			ShowInfoDialog(window, title, "Every key was excluded. Nothing to delete.")
+			return
+		}
+		onDelete(selected)
+	}, window)
+	d.Resize(fyne.NewSize(520, 520))
+	d.Show()
+}
@@ -0,0 +1,186 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// generateMaxKeys caps how many keys one run of the generator writes
+	generateMaxKeys = 10_000_000
+	// generateMaxElements caps the elements of each generated collection
+	generateMaxElements = 1_000_000
+	// generateMaxValueSize caps the bytes of each generated value
+	generateMaxValueSize = 64 << 20
+)
+
+// ShowGenerateDialog asks what test keys to write: how they are named and
+// numbered, their type, size and TTL, and whether their values are random.
+// onGenerate is called with the request once writing over any keys of the same
+// names is confirmed.
+func ShowGenerateDialog(window fyne.Window, pattern string, onGenerate func(req models.GenerateRequest)) {
+	patternEntry := widget.NewEntry()
+	patternEntry.SetText(pattern)
+	countEntry := widget.NewEntry()
+	countEntry.SetText("1000")
+	startEntry := widget.NewEntry()
+	startEntry.SetText("1")
+	elementsEntry := widget.NewEntry()
+	elementsEntry.SetText("10")
+	sizeEntry := widget.NewEntry()
+	sizeEntry.SetText("32")
+	ttlEntry := widget.NewEntry()
+	ttlEntry.SetPlaceHolder(i18n.T("No expiry, or e.g. 90s, 2h, 7d"))
+	generateRandom, generateSequential := i18n.T("Random"), i18n.T("Sequential")
+	valuesRadio := widget.NewRadioGroup([]string{generateRandom, generateSequential}, nil)
+	valuesRadio.Horizontal = true
+	valuesRadio.Required = true
+	valuesRadio.SetSelected(generateRandom)
+	estimateLabel := widget.NewLabel("")
+
+	typeSelect := widget.NewSelect([]string{"string", "list", "set", "hash", "zset"}, nil)
+	// estimate shows about how much value data the keys hold, leaving out
+	// names, field names and Redis's own overhead
+	estimate := func() {
+		count, _ := strconv.Atoi(strings.TrimSpace(countEntry.Text))
+		size, _ := strconv.Atoi(strings.TrimSpace(sizeEntry.Text))
+		elements := 1
+		if typeSelect.Selected != "string" {
+			elements, _ = strconv.Atoi(strings.TrimSpace(elementsEntry.Text))
+		}
+		if count <= 0 || size <= 0 || elements <= 0 {
+			estimateLabel.SetText("")
+			return
+		}
+		estimateLabel.SetText(i18n.T("About %s of values", formatBytes(int64(count)*int64(elements)*int64(size))))
+	}
+	typeSelect.OnChanged = func(keyType string) {
+		if keyType == "string" {
+			elementsEntry.Disable()
+		} else {
+			elementsEntry.Enable()
+		}
+		estimate()
+	}
+	for _, entry := range []*widget.Entry{countEntry, elementsEntry, sizeEntry} {
+		entry.OnChanged = func(string) { estimate() }
+	}
+	typeSelect.SetSelected("string")
+
+	form := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: i18n.T("Pattern"), Widget: patternEntry, HintText: i18n.T("%s is replaced by each key's number", redis.GeneratePlaceholder)},
+			{Text: i18n.T("Keys"), Widget: countEntry},
+			{Text: i18n.T("First number"), Widget: startEntry},
+			{Text: i18n.T("Type"), Widget: typeSelect},
+			{Text: i18n.T("Elements"), Widget: elementsEntry, HintText: i18n.T("Items, members or fields of each key")},
+			{Text: i18n.T("Value size"), Widget: sizeEntry, HintText: i18n.T("Bytes of each value")},
+			{Text: i18n.T("Values"), Widget: valuesRadio},
+			{Text: "TTL", Widget: ttlEntry},
+			{Text: "", Widget: estimateLabel},
+		},
+	}
+
+	// read checks the form and returns the request it describes
+	read := func() (models.GenerateRequest, error) {
+		req := models.GenerateRequest{
+			Pattern: strings.TrimSpace(patternEntry.Text),
+			Type:    typeSelect.Selected,
+			Random:  valuesRadio.Selected == generateRandom,
+		}
+		if !strings.Contains(req.Pattern, redis.GeneratePlaceholder) {
+			return req, fmt.Errorf("the pattern needs %s for the key number, e.g. test:%s", redis.GeneratePlaceholder, redis.GeneratePlaceholder)
+		}
+		var err error
+		if req.Count, err = strconv.Atoi(strings.TrimSpace(countEntry.Text)); err != nil || req.Count < 1 || req.Count > generateMaxKeys {
+			return req, fmt.Errorf("the number of keys must be between 1 and %d", generateMaxKeys)
+		}
+		if req.Start, err = strconv.Atoi(strings.TrimSpace(startEntry.Text)); err != nil {
+			return req, fmt.Errorf("the first number must be a whole number")
+		}
+		if req.Type != "string" {
+			if req.Elements, err = strconv.Atoi(strings.TrimSpace(elementsEntry.Text)); err != nil || req.Elements < 1 || req.Elements > generateMaxElements {
+				return req, fmt.Errorf("the number of elements must be between 1 and %d", generateMaxElements)
+			}
+		}
+		if req.ValueSize, err = strconv.Atoi(strings.TrimSpace(sizeEntry.Text)); err != nil || req.ValueSize < 1 || req.ValueSize > generateMaxValueSize {
+			return req, fmt.Errorf("the value size must be between 1 and %d bytes", generateMaxValueSize)
+		}
+		exp, err := parseExpiry(ttlEntry.Text, time.Now())
+		if err != nil {
+			return req, err
+		}
+		if seconds := expirySeconds(exp, time.Now()); seconds > 0 {
+			req.TTL = seconds
+		} else if !exp.Persist() {
+			return req, fmt.Errorf("the expiry must be in the future")
+		}
+		return req, nil
+	}
+
+	var d *dialog.CustomDialog
+	generateBtn := widget.NewButtonWithIcon(i18n.T("Generate"), theme.ConfirmIcon(), func() {
+		req, err := read()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		first := redis.GeneratedKey(req.Pattern, req.Start)
+		last := redis.GeneratedKey(req.Pattern, req.Start+req.Count-1)
+		confirmDestructive(window, "Generate Test Data",
+			i18n.T("Write %d %s keys, '%s' to '%s'? Keys with those names are replaced.", req.Count, req.Type, first, last),
+			func() {
+				d.Hide()
+				onGenerate(req)
+			})
+	})
+	generateBtn.Importance = widget.HighImportance
+	cancelBtn := widget.NewButton(i18n.T("Cancel"), func() { d.Hide() })
+
+	d = dialog.NewCustomWithoutButtons(i18n.T("Generate Test Data"), form, window)
+	d.SetButtons([]fyne.CanvasObject{cancelBtn, generateBtn})
+	d.Resize(fyne.NewSize(480, 0))
+	d.Show()
+}
+
+// generateKeys writes the keys of req with a progress bar, calling onDone if
+// any were written
+func generateKeys(window fyne.Window, worker *Worker, client *redis.Client, req models.GenerateRequest, onDone func()) {
+	var written int
+	var update func(done, total int)
+	var hideProgress func()
+	cancel := worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		written, err = client.WithContext(ctx).Generate(req, func(done int) {
+			fyne.Do(func() { update(done, req.Count) })
+		})
+		return err
+	}, func(err error) {
+		hideProgress()
+		if written > 0 {
+			onDone()
+		}
+		if errors.Is(err, context.Canceled) {
+			ShowInfoDialog(window, "Generate Test Data", i18n.T("Cancelled after writing %d keys.", written))
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Generate Test Data", err)
+			return
+		}
+		worker.Report(i18n.T("Generated %d keys from '%s'", written, req.Pattern), nil)
+	})
+	update, hideProgress = ShowProgressDialog(window, "Generating Test Data", cancel)
+}
@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// buildGeoEditor shows a sorted set created with GEOADD as decoded positions,
+// with GEOADD for new members and GEOSEARCH radius queries
+func (ve *ValueEditor) buildGeoEditor(key models.RedisKey, members []models.ScoredValue) fyne.CanvasObject {
+	var shown []models.GeoMember
+	searching := false
+	unit := "km"
+
+	statusLabel := widget.NewLabel(i18n.T("Loading positions..."))
+	headers := []string{"Member", "Longitude", "Latitude", "Distance"}
+	table := widget.NewTable(
+		func() (int, int) { return len(shown) + 1, len(headers) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(i18n.T(headers[id.Col]))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			m := shown[id.Row-1]
+			switch id.Col {
+			case 0:
+				label.SetText(m.Member)
+			case 1:
+				label.SetText(strconv.FormatFloat(m.Longitude, 'f', 6, 64))
+			case 2:
+				label.SetText(strconv.FormatFloat(m.Latitude, 'f', 6, 64))
+			case 3:
+				if searching {
+					label.SetText(fmt.Sprintf("%.3f %s", m.Distance, unit))
+				} else {
+					label.SetText("")
+				}
+			}
+		},
+	)
+	table.SetColumnWidth(0, 200)
+	table.SetColumnWidth(1, 110)
+	table.SetColumnWidth(2, 110)
+	table.SetColumnWidth(3, 110)
+
+	showAll := func() {
+		names := make([]string, len(members))
+		for i, m := range members {
+			names[i] = m.Member
+		}
+		var positions []models.GeoMember
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			positions, err = c.GeoPositions(key.Key, names)
+			return
+		}, func() {
+			shown, searching = positions, false
+			statusLabel.SetText(i18n.T("%d members with a position", len(shown)))
+			table.Refresh()
+		})
+	}
+
+	parseCoord := func(entry *widget.Entry, name string, limit float64) (float64, bool) {
+		v, err := strconv.ParseFloat(strings.TrimSpace(entry.Text), 64)
+		if err != nil || v < -limit || v > limit {
+			ShowErrorDialog(ve.window, "Invalid "+name, fmt.Errorf("%s must be a number between %g and %g", strings.ToLower(name), -limit, limit))
+			return 0, false
+		}
+		return v, true
+	}
+
+	// GEOADD
+	lonEntry := widget.NewEntry()
+	lonEntry.SetPlaceHolder(i18n.T("Longitude"))
+	latEntry := widget.NewEntry()
+	latEntry.SetPlaceHolder(i18n.T("Latitude"))
+	memberEntry := widget.NewEntry()
+	memberEntry.SetPlaceHolder(i18n.T("Member"))
+	addBtn := widget.NewButtonWithIcon("GEOADD", theme.ContentAddIcon(), func() {
+		if memberEntry.Text == "" {
+			return
+		}
+		lon, ok := parseCoord(lonEntry, "Longitude", 180)
+		if !ok {
+			return
+		}
+		lat, ok := parseCoord(latEntry, "Latitude", 85.05112878)
+		if !ok {
+			return
+		}
+		member := memberEntry.Text
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) error {
+			return c.GeoAdd(key.Key, lon, lat, member)
+		}, func() {
+			// Stay in geo mode rather than reloading the whole editor
+			known := false
+			for _, m := range members {
+				known = known || m.Member == member
+			}
+			if !known {
+				members = append(members, models.ScoredValue{Member: member})
+			}
+			memberEntry.SetText("")
+			showAll()
+		})
+	})
+
+	// GEOSEARCH
+	centerEntry := widget.NewEntry()
+	centerEntry.SetPlaceHolder(i18n.T("Center member, or leave empty to use longitude/latitude above"))
+	radiusEntry := widget.NewEntry()
+	radiusEntry.SetPlaceHolder(i18n.T("Radius"))
+	unitSelect := widget.NewSelect([]string{"m", "km", "mi", "ft"}, nil)
+	unitSelect.SetSelected(unit)
+	searchBtn := widget.NewButtonWithIcon("GEOSEARCH", theme.SearchIcon(), func() {
+		radius, err := strconv.ParseFloat(strings.TrimSpace(radiusEntry.Text), 64)
+		if err != nil || radius <= 0 {
+			ShowErrorDialog(ve.window, "Invalid Radius", fmt.Errorf("radius must be a positive number"))
+			return
+		}
+		req := models.GeoSearchRequest{
+			FromMember: strings.TrimSpace(centerEntry.Text),
+			Radius:     radius,
+			Unit:       unitSelect.Selected,
+		}
+		if req.FromMember == "" {
+			var ok bool
+			if req.Longitude, ok = parseCoord(lonEntry, "Longitude", 180); !ok {
+				return
+			}
+			if req.Latitude, ok = parseCoord(latEntry, "Latitude", 85.05112878); !ok {
+				return
+			}
+		}
+
+		var results []models.GeoMember
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			results, err = c.GeoSearch(key.Key, req)
+			return
+		}, func() {
+			shown, searching, unit = results, true, req.Unit
+			statusLabel.SetText(i18n.T("%d members within %g %s", len(shown), req.Radius, req.Unit))
+			table.Refresh()
+		})
+	})
+	showAllBtn := widget.NewButtonWithIcon(i18n.T("Show All"), theme.ViewRefreshIcon(), showAll)
+
+	setWritable(ve.client, addBtn)
+
+	controls := container.NewVBox(
+		container.NewGridWithColumns(3, lonEntry, latEntry, memberEntry),
+		container.NewHBox(addBtn),
+		widget.NewSeparator(),
+		centerEntry,
+		container.NewBorder(nil, nil, nil, container.NewHBox(unitSelect, searchBtn, showAllBtn), radiusEntry),
+	)
+
+	showAll()
+	return container.NewBorder(statusLabel, controls, nil, nil, table)
+}
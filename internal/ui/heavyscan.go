@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// Commands sent per matching key by the operations that read every key
+const (
+	searchCommandsPerKey   = 3 // TYPE, TTL and at least one read of the value
+	analysisCommandsPerKey = 3 // TYPE, TTL and MEMORY USAGE
+	memoryCommandsPerKey   = 1 // MEMORY USAGE
+)
+
+// confirmHeavyScan runs an operation that reads every key matching pattern. On
+// a database with more keys than the Large Database Warning setting it first
+// shows the estimated commands and offers to limit the operation to a key
+// prefix. run gets the pattern to use.
+func confirmHeavyScan(window fyne.Window, worker *Worker, client *redis.Client, title, pattern string, perKey int, run func(pattern string)) {
+	var cost models.ScanCost
+	worker.Do(window, client, func(c *redis.Client) (err error) {
+		cost, err = c.EstimateScan(perKey)
+		return err
+	}, func() {
+		if cost.Keys <= int64(config.Get().LargeDBKeys) {
+			run(pattern)
+			return
+		}
+
+		message := widget.NewLabel(i18n.T("This database holds %s keys. Reading every key matching '%s' takes %s SCAN calls and up to %s commands in all, which can slow down a busy server.",
+			formatCount(cost.Keys), pattern, formatCount(cost.Scans), formatCount(cost.Commands)))
+		message.Wrapping = fyne.TextWrapWord
+
+		prefixEntry := widget.NewEntry()
+		prefixEntry.SetPlaceHolder(i18n.T("e.g. user: (empty keeps the pattern)"))
+		form := widget.NewForm(widget.NewFormItem(i18n.T("Limit to Prefix"), prefixEntry))
+
+		d := dialog.NewCustomConfirm(i18n.T(title), i18n.T("Run"), i18n.T("Cancel"), container.NewVBox(message, form), func(ok bool) {
+			if !ok {
+				return
+			}
+			if prefix := strings.TrimSpace(prefixEntry.Text); prefix != "" {
+				pattern = redis.PrefixPattern(prefix)
+			}
+			run(pattern)
+		}, window)
+		d.Resize(fyne.NewSize(460, 260))
+		d.Show()
+	})
+}
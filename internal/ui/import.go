@@ -0,0 +1,185 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// importEntry is a key read from an export file, with how to recreate it
+type importEntry struct {
+	key     string
+	restore func(c *redis.Client, replace bool) error
+}
+
+// importKeys asks for a JSON or DUMP payload export and recreates its keys in
+// the background, handling existing keys according to policy. onDone runs
+// after a successful import.
+func importKeys(window fyne.Window, worker *Worker, client *redis.Client, policy string, onDone func()) {
+	d := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			ShowErrorDialog(window, "Import Error", err)
+			return
+		}
+		if reader == nil {
+			return
+		}
+
+		var entries []importEntry
+		if reader.URI().Extension() == "."+models.ExportPayloads {
+			entries, err = readPayloads(reader)
+		} else {
+			entries, err = readDumps(reader)
+		}
+		reader.Close()
+		if err != nil {
+			ShowErrorDialog(window, "Import Error", fmt.Errorf("failed to read %s: %w", reader.URI().Name(), err))
+			return
+		}
+
+		var imported, skipped int
+		var update func(done, total int)
+		var hideProgress func()
+		cancel := worker.GoCancellable(func(ctx context.Context) error {
+			c := client.WithContext(ctx)
+			policy := policy
+
+			for i, entry := range entries {
+				done, total := i, len(entries)
+				fyne.Do(func() { update(done, total) })
+
+				exists, err := c.KeyExists(entry.key)
+				if err != nil {
+					return err
+				}
+
+				overwrite := false
+				if exists {
+					switch policy {
+					case models.ImportSkip:
+						skipped++
+						continue
+					case models.ImportOverwrite:
+						overwrite = true
+					default:
+						choice, all, err := askConflict(ctx, window, entry.key)
+						if err != nil {
+							return err
+						}
+						if all {
+							policy = models.ImportSkip
+							if choice {
+								policy = models.ImportOverwrite
+							}
+						}
+						if !choice {
+							skipped++
+							continue
+						}
+						overwrite = true
+					}
+				}
+
+				if err := entry.restore(c, overwrite); err != nil {
+					return err
+				}
+				imported++
+			}
+			return nil
+		}, func(err error) {
+			hideProgress()
+			if imported > 0 && onDone != nil {
+				onDone()
+			}
+			if errors.Is(err, context.Canceled) {
+				ShowInfoDialog(window, "Import Cancelled", i18n.T("The import was cancelled after %d keys.", imported))
+				return
+			}
+			if err != nil {
+				ShowErrorDialog(window, "Import Error", fmt.Errorf("imported %d keys before failing: %w", imported, err))
+				return
+			}
+			worker.Report(i18n.T("Imported %d keys, skipped %d existing keys", imported, skipped), nil)
+		})
+		update, hideProgress = ShowProgressDialog(window, "Importing Keys", cancel)
+	}, window)
+
+	d.SetFilter(storage.NewExtensionFileFilter([]string{".json", "." + models.ExportPayloads}))
+	d.Show()
+}
+
+// askConflict asks on the UI thread whether to overwrite an existing key and
+// waits for the answer, returning early if the import is cancelled
+func askConflict(ctx context.Context, window fyne.Window, key string) (overwrite, applyToAll bool, err error) {
+	type answer struct{ overwrite, all bool }
+	answers := make(chan answer, 1)
+	fyne.Do(func() {
+		ShowImportConflictDialog(window, key, func(overwrite, all bool) {
+			answers <- answer{overwrite, all}
+		})
+	})
+
+	select {
+	case a := <-answers:
+		return a.overwrite, a.all, nil
+	case <-ctx.Done():
+		return false, false, ctx.Err()
+	}
+}
+
+// readDumps parses a JSON export and checks that every entry can be restored
+func readDumps(r io.Reader) ([]importEntry, error) {
+	var dumps []models.KeyDump
+	if err := json.NewDecoder(r).Decode(&dumps); err != nil {
+		return nil, err
+	}
+
+	entries := make([]importEntry, len(dumps))
+	for i := range dumps {
+		dump := &dumps[i]
+		if dump.Key == "" {
+			return nil, fmt.Errorf("entry %d has no key", i+1)
+		}
+		switch dump.Type {
+		case "string", "list", "set", "hash", "zset", "stream":
+		default:
+			return nil, fmt.Errorf("key '%s' has unsupported type '%s'", dump.Key, dump.Type)
+		}
+		entries[i] = importEntry{key: dump.Key, restore: func(c *redis.Client, replace bool) error {
+			return c.RestoreKey(dump, replace)
+		}}
+	}
+	return entries, nil
+}
+
+// readPayloads parses a DUMP payload export. The server checks each payload's
+// checksum and RDB version when it is restored.
+func readPayloads(r io.Reader) ([]importEntry, error) {
+	var payloads []models.KeyPayload
+	if err := json.NewDecoder(r).Decode(&payloads); err != nil {
+		return nil, err
+	}
+
+	entries := make([]importEntry, len(payloads))
+	for i, payload := range payloads {
+		if payload.Key == "" {
+			return nil, fmt.Errorf("entry %d has no key", i+1)
+		}
+		if len(payload.Payload) == 0 {
+			return nil, fmt.Errorf("key '%s' has no payload", payload.Key)
+		}
+		entries[i] = importEntry{key: payload.Key, restore: func(c *redis.Client, replace bool) error {
+			return c.RestorePayload(payload, replace)
+		}}
+	}
+	return entries, nil
+}
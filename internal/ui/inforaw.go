@@ -0,0 +1,151 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// rawInfoView lists every field of the INFO reply in one accordion item per
+// section, filtered by a search over field names and values
+type rawInfoView struct {
+	content   fyne.CanvasObject
+	search    *widget.Entry
+	status    *widget.Label
+	accordion *widget.Accordion
+
+	sections []models.InfoSection
+	shown    []string                 // section name of each accordion item
+	values   map[string]*widget.Label // value labels by section and field, updated in place
+}
+
+func newRawInfoView() *rawInfoView {
+	v := &rawInfoView{values: make(map[string]*widget.Label)}
+	v.search = widget.NewEntry()
+	v.search.SetPlaceHolder(i18n.T("Search fields and values"))
+	v.search.OnChanged = func(string) { v.rebuild() }
+	v.status = widget.NewLabel("")
+	v.accordion = widget.NewAccordion()
+	v.accordion.MultiOpen = true
+
+	copyAllBtn := widget.NewButtonWithIcon(i18n.T("Copy All"), theme.ContentCopyIcon(), func() {
+		fyne.CurrentApp().Clipboard().SetContent(v.text())
+	})
+	top := container.NewVBox(
+		container.NewBorder(nil, nil, nil, copyAllBtn, v.search),
+		v.status,
+	)
+	v.content = container.NewBorder(top, nil, nil, nil, container.NewVScroll(v.accordion))
+	return v
+}
+
+// show displays a new INFO reply. When it has the same sections and fields as
+// the one shown, as between two refreshes, only the values are updated so the
+// open sections and scroll position stay put.
+func (v *rawInfoView) show(sections []models.InfoSection) {
+	same := len(sections) == len(v.sections)
+	for i := 0; same && i < len(sections); i++ {
+		same = sections[i].Name == v.sections[i].Name && len(sections[i].Fields) == len(v.sections[i].Fields)
+		for j := 0; same && j < len(sections[i].Fields); j++ {
+			same = sections[i].Fields[j].Key == v.sections[i].Fields[j].Key
+		}
+	}
+	v.sections = sections
+	if !same || v.search.Text != "" {
+		v.rebuild()
+		return
+	}
+	for _, section := range sections {
+		for _, field := range section.Fields {
+			if label := v.values[section.Name+":"+field.Key]; label != nil {
+				label.SetText(field.Value)
+			}
+		}
+	}
+}
+
+// clear removes the shown reply
+func (v *rawInfoView) clear() {
+	v.sections = nil
+	v.rebuild()
+}
+
+// rebuild recreates the accordion items for the fields matching the search,
+// keeping sections open that were open before. While searching, every
+// section with a match is opened.
+func (v *rawInfoView) rebuild() {
+	wasOpen := make(map[string]bool)
+	for i, item := range v.accordion.Items {
+		wasOpen[v.shown[i]] = item.Open
+	}
+
+	filter := strings.ToLower(strings.TrimSpace(v.search.Text))
+	clear(v.values)
+	v.shown = v.shown[:0]
+	var items []*widget.AccordionItem
+	matched := 0
+	for _, section := range v.sections {
+		rows := container.NewVBox()
+		for _, field := range section.Fields {
+			if filter != "" && !strings.Contains(strings.ToLower(field.Key), filter) && !strings.Contains(strings.ToLower(field.Value), filter) {
+				continue
+			}
+			rows.Add(v.row(section.Name, field))
+		}
+		if filter != "" && len(rows.Objects) == 0 {
+			continue
+		}
+		matched += len(rows.Objects)
+		item := widget.NewAccordionItem(fmt.Sprintf("%s (%d)", section.Name, len(rows.Objects)), rows)
+		item.Open = wasOpen[section.Name] || filter != ""
+		items = append(items, item)
+		v.shown = append(v.shown, section.Name)
+	}
+	v.accordion.Items = items
+	v.accordion.Refresh()
+
+	switch {
+	case len(v.sections) == 0:
+		v.status.SetText("")
+	case filter != "":
+		v.status.SetText(i18n.T("%d matching fields in %d sections", matched, len(items)))
+	default:
+		v.status.SetText(i18n.T("%d fields in %d sections", matched, len(items)))
+	}
+}
+
+// row shows a field with a button copying its value
+func (v *rawInfoView) row(section string, field models.KeyValue) fyne.CanvasObject {
+	name := widget.NewLabelWithStyle(field.Key, fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	name.Truncation = fyne.TextTruncateEllipsis
+	value := widget.NewLabel(field.Value)
+	value.Truncation = fyne.TextTruncateEllipsis
+	v.values[section+":"+field.Key] = value
+
+	copyBtn := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() {
+		fyne.CurrentApp().Clipboard().SetContent(value.Text)
+	})
+	copyBtn.Importance = widget.LowImportance
+	return container.NewGridWithColumns(2, name, container.NewBorder(nil, nil, nil, copyBtn, value))
+}
+
+// text renders the shown reply in INFO's own format
+func (v *rawInfoView) text() string {
+	var b strings.Builder
+	for i, section := range v.sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "# %s\n", section.Name)
+		for _, field := range section.Fields {
+			fmt.Fprintf(&b, "%s:%s\n", field.Key, field.Value)
+		}
+	}
+	return b.String()
+}
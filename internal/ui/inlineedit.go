@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// inlineEditMaxLen is the longest value edited in place; longer ones and
+// values spanning several lines open the edit dialog instead
+const inlineEditMaxLen = 500
+
+// inlineEntry is a single-line entry that reports Escape and losing focus
+type inlineEntry struct {
+	widget.Entry
+	onCancel func()
+}
+
+func newInlineEntry() *inlineEntry {
+	e := &inlineEntry{}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// TypedKey implements fyne.Focusable
+func (e *inlineEntry) TypedKey(key *fyne.KeyEvent) {
+	if key.Name == fyne.KeyEscape && e.onCancel != nil {
+		e.onCancel()
+		return
+	}
+	e.Entry.TypedKey(key)
+}
+
+// FocusLost implements fyne.Focusable
+func (e *inlineEntry) FocusLost() {
+	e.Entry.FocusLost()
+	if e.onCancel != nil {
+		e.onCancel()
+	}
+}
+
+// inlineEdit edits the cells of a table in place: the edited cell shows an
+// entry instead of its text, Enter commits it and Escape or clicking
+// elsewhere cancels. Cells are created with newInlineCell and drawn through
+// cell.
+type inlineEdit struct {
+	table   *widget.Table
+	window  fyne.Window
+	editing widget.TableCellID // Row is -1 while no cell is edited
+	text    string
+	commit  func(text string)
+}
+
+func newInlineEdit(window fyne.Window) *inlineEdit {
+	return &inlineEdit{window: window, editing: widget.TableCellID{Row: -1}}
+}
+
+// newInlineCell creates a table cell template for an inlineEdit
+func newInlineCell() fyne.CanvasObject {
+	entry := newInlineEntry()
+	entry.Hide()
+	return container.NewStack(widget.NewRichText(), entry)
+}
+
+// cell returns the rich text of a cell to draw, or nil while the cell is
+// edited and shows its entry instead
+func (e *inlineEdit) cell(id widget.TableCellID, o fyne.CanvasObject) *widget.RichText {
+	stack := o.(*fyne.Container)
+	text := stack.Objects[0].(*widget.RichText)
+	entry := stack.Objects[1].(*inlineEntry)
+	if id != e.editing {
+		entry.onCancel = nil
+		entry.OnSubmitted = nil
+		entry.Hide()
+		text.Show()
+		return text
+	}
+
+	text.Hide()
+	entry.OnSubmitted = func(value string) {
+		commit, original := e.commit, e.text
+		e.window.Canvas().Unfocus()
+		e.stop()
+		if value != original {
+			commit(value)
+		}
+	}
+	entry.onCancel = e.stop
+	if !entry.Visible() {
+		entry.TextStyle = valueTextStyle()
+		entry.SetText(e.text)
+		entry.Show()
+	}
+	if e.window.Canvas().Focused() != entry {
+		e.window.Canvas().Focus(entry)
+	}
+	return nil
+}
+
+// start edits a cell holding value, calling commit with the new value when
+// Enter is pressed on a changed value. Values too long or spanning lines
+// to edit in a single-line entry are edited in a dialog through fallback.
+func (e *inlineEdit) start(id widget.TableCellID, value string, commit func(text string), fallback func()) {
+	e.table.UnselectAll()
+	if len(value) > inlineEditMaxLen || strings.ContainsAny(value, "\r\n") {
+		e.stop()
+		fallback()
+		return
+	}
+	e.window.Canvas().Unfocus()
+	e.editing, e.text, e.commit = id, value, commit
+	e.table.Refresh()
+}
+
+// stop ends the edit without committing it
+func (e *inlineEdit) stop() {
+	if e.editing.Row < 0 {
+		return
+	}
+	e.editing = widget.TableCellID{Row: -1}
+	e.commit = nil
+	e.table.Refresh()
+}
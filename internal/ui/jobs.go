@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+)
+
+// jobRefreshInterval limits how often the job queue redraws while jobs report
+// progress
+const jobRefreshInterval = 200 * time.Millisecond
+
+// JobQueue shows the running background jobs in the status bar: the oldest
+// one's title and progress, how many others run, and a button listing every
+// job with its own Cancel button. It is hidden while no job runs.
+type JobQueue struct {
+	widget.BaseWidget
+	container *fyne.Container
+	manager   *jobs.Manager
+	window    fyne.Window
+
+	label       *widget.Label
+	bar         *widget.ProgressBar
+	barInfinite *widget.ProgressBarInfinite
+
+	// The jobs dialog, updated in place while open
+	list    *fyne.Container
+	listIDs []int
+	rows    map[int]*jobRow
+}
+
+// jobRow is a job's line in the jobs dialog
+type jobRow struct {
+	status    *widget.Label
+	bar       *widget.ProgressBar
+	cancelBtn *widget.Button
+}
+
+// NewJobQueue creates the job queue for the jobs of manager
+func NewJobQueue(window fyne.Window, manager *jobs.Manager) *JobQueue {
+	q := &JobQueue{
+		window:  window,
+		manager: manager,
+	}
+	q.ExtendBaseWidget(q)
+	q.buildUI()
+
+	go func() {
+		for range manager.Changes() {
+			fyne.Do(q.update)
+			time.Sleep(jobRefreshInterval)
+		}
+	}()
+	return q
+}
+
+func (q *JobQueue) buildUI() {
+	q.label = widget.NewLabel("")
+	q.label.Truncation = fyne.TextTruncateEllipsis
+	q.bar = widget.NewProgressBar()
+	q.barInfinite = widget.NewProgressBarInfinite()
+	q.barInfinite.Stop()
+	q.barInfinite.Hide()
+
+	listBtn := widget.NewButtonWithIcon(i18n.T("Jobs"), theme.ListIcon(), q.ShowJobs)
+	listBtn.Importance = widget.LowImportance
+
+	height := q.bar.MinSize().Height
+	q.container = container.NewHBox(
+		container.NewGridWrap(fyne.NewSize(280, q.label.MinSize().Height), q.label),
+		container.NewGridWrap(fyne.NewSize(160, height), container.NewStack(q.bar, q.barInfinite)),
+		listBtn,
+	)
+	q.container.Hide()
+}
+
+// CreateRenderer implements fyne.Widget
+func (q *JobQueue) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(q.container)
+}
+
+// update redraws the queue and the open jobs dialog from the manager
+func (q *JobQueue) update() {
+	statuses := q.manager.Jobs()
+	var running []jobs.Status
+	for _, s := range statuses {
+		if s.State == jobs.Running {
+			running = append(running, s)
+		}
+	}
+
+	if len(running) == 0 {
+		q.barInfinite.Stop()
+		q.container.Hide()
+	} else {
+		first := running[0]
+		text := first.Title
+		if first.Detail != "" {
+			text += ": " + first.Detail
+		}
+		if len(running) > 1 {
+			text += i18n.T(" (+%d more)", len(running)-1)
+		}
+		q.label.SetText(text)
+		if fraction := first.Fraction(); fraction >= 0 {
+			q.barInfinite.Stop()
+			q.barInfinite.Hide()
+			q.bar.Show()
+			q.bar.SetValue(fraction)
+		} else {
+			q.bar.Hide()
+			q.barInfinite.Show()
+			q.barInfinite.Start()
+		}
+		q.container.Show()
+	}
+
+	if q.list != nil {
+		q.updateList(statuses)
+	}
+}
+
+// ShowJobs lists the running and recently finished jobs
+func (q *JobQueue) ShowJobs() {
+	q.list = container.NewVBox()
+	q.listIDs = nil
+	q.rows = make(map[int]*jobRow)
+	q.updateList(q.manager.Jobs())
+
+	clearBtn := widget.NewButtonWithIcon(i18n.T("Clear Finished"), theme.DeleteIcon(), q.manager.ClearFinished)
+	content := container.NewBorder(nil, container.NewHBox(clearBtn), nil, nil, container.NewVScroll(q.list))
+
+	d := dialog.NewCustom(i18n.T("Background Jobs"), i18n.T("Close"), content, q.window)
+	d.SetOnClosed(func() {
+		q.list = nil
+		q.rows = nil
+	})
+	d.Resize(fyne.NewSize(560, 400))
+	d.Show()
+}
+
+// updateList refreshes the rows of the jobs dialog, rebuilding them only
+// when jobs were added or removed
+func (q *JobQueue) updateList(statuses []jobs.Status) {
+	same := len(statuses) == len(q.listIDs)
+	for i := 0; same && i < len(statuses); i++ {
+		same = statuses[i].ID == q.listIDs[i]
+	}
+	if !same {
+		q.list.RemoveAll()
+		q.listIDs = q.listIDs[:0]
+		clear(q.rows)
+		if len(statuses) == 0 {
+			q.list.Add(widget.NewLabel(i18n.T("No background jobs")))
+		}
+		for _, s := range statuses {
+			id := s.ID
+			row := &jobRow{
+				status: widget.NewLabel(""),
+				bar:    widget.NewProgressBar(),
+				cancelBtn: widget.NewButtonWithIcon("", theme.CancelIcon(), func() {
+					q.manager.Cancel(id)
+				}),
+			}
+			row.status.Wrapping = fyne.TextWrapWord
+			row.cancelBtn.Importance = widget.LowImportance
+			title := widget.NewLabelWithStyle(s.Title, fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			title.Truncation = fyne.TextTruncateEllipsis
+			q.list.Add(container.NewVBox(
+				container.NewBorder(nil, nil, nil, row.cancelBtn, title),
+				row.status,
+				row.bar,
+				widget.NewSeparator(),
+			))
+			q.rows[id] = row
+			q.listIDs = append(q.listIDs, id)
+		}
+	}
+
+	for _, s := range statuses {
+		row := q.rows[s.ID]
+		row.status.SetText(jobStatusText(s))
+		if fraction := s.Fraction(); fraction >= 0 && s.State == jobs.Running {
+			row.bar.SetValue(fraction)
+			row.bar.Show()
+		} else {
+			row.bar.Hide()
+		}
+		if s.State == jobs.Running {
+			row.cancelBtn.Show()
+		} else {
+			row.cancelBtn.Hide()
+		}
+	}
+}
+
+// jobStatusText describes a job's state, detail and run time
+func jobStatusText(s jobs.Status) string {
+	text := i18n.T(s.State.String())
+	if s.Detail != "" {
+		text += ": " + s.Detail
+	}
+	switch s.State {
+	case jobs.Running:
+		text += i18n.T(" (started %s)", s.Started.Format(time.TimeOnly))
+	case jobs.Failed:
+		text += " (" + s.Err.Error() + ")"
+	default:
+		text += i18n.T(" (took %s)", s.Ended.Sub(s.Started).Round(100*time.Millisecond))
+	}
+	return text
+}
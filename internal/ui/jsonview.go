@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// jsonTreeExpandLimit is the largest document whose tree opens fully expanded
+const jsonTreeExpandLimit = 500
+
+// looksLikeJSON reports whether a string value is a JSON object or array
+func looksLikeJSON(value string) bool {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "{") && !strings.HasPrefix(trimmed, "[") {
+		return false
+	}
+	return json.Valid([]byte(trimmed))
+}
+
+// formatJSON indents a JSON document two spaces per level
+func formatJSON(text string) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(strings.TrimSpace(text)), "", "  "); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// compactJSON removes insignificant whitespace from a JSON document
+func compactJSON(text string) (string, error) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, []byte(strings.TrimSpace(text))); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// jsonNode is one value of a parsed JSON document, keeping object keys in document order
+type jsonNode struct {
+	ID       string
+	Path     string // JSONPath of the value, e.g. $["user"][0]
+	Key      string
+	Kind     string // object, array, string, number, bool or null
+	Value    string // literal text of scalar values
+	Children []*jsonNode
+}
+
+// parseJSONTree parses a document into nodes indexed by ID. The root has ID "".
+func parseJSONTree(text string) (map[string]*jsonNode, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+
+	nodes := make(map[string]*jsonNode)
+	if _, err := decodeJSONNode(dec, "", "$", "", nodes); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err == nil {
+		return nil, fmt.Errorf("unexpected data after the JSON document")
+	}
+	return nodes, nil
+}
+
+func decodeJSONNode(dec *json.Decoder, id, path, key string, nodes map[string]*jsonNode) (*jsonNode, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &jsonNode{ID: id, Path: path, Key: key}
+	nodes[id] = node
+
+	switch t := tok.(type) {
+	case json.Delim:
+		object := t == '{'
+		node.Kind = "array"
+		if object {
+			node.Kind = "object"
+		}
+		// Children are numbered by position so duplicate object keys stay distinct
+		for i := 0; dec.More(); i++ {
+			childKey := "[" + strconv.Itoa(i) + "]"
+			childPath := path + childKey
+			if object {
+				nameTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				childKey = nameTok.(string)
+				quoted, _ := json.Marshal(childKey)
+				childPath = path + "[" + string(quoted) + "]"
+			}
+			child, err := decodeJSONNode(dec, id+"/"+strconv.Itoa(i), childPath, childKey, nodes)
+			if err != nil {
+				return nil, err
+			}
+			node.Children = append(node.Children, child)
+		}
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		node.Kind = "string"
+		node.Value = strconv.Quote(t)
+	case json.Number:
+		node.Kind = "number"
+		node.Value = t.String()
+	case bool:
+		node.Kind = "bool"
+		node.Value = strconv.FormatBool(t)
+	case nil:
+		node.Kind = "null"
+		node.Value = "null"
+	}
+	return node, nil
+}
+
+// newJSONTree builds a collapsible, colour-coded view of a parsed document
+func newJSONTree(nodes map[string]*jsonNode) *widget.Tree {
+	tree := widget.NewTree(
+		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			node, ok := nodes[uid]
+			if !ok {
+				return nil
+			}
+			ids := make([]widget.TreeNodeID, len(node.Children))
+			for i, child := range node.Children {
+				ids[i] = child.ID
+			}
+			return ids
+		},
+		func(uid widget.TreeNodeID) bool {
+			node, ok := nodes[uid]
+			return ok && (node.Kind == "object" || node.Kind == "array")
+		},
+		func(branch bool) fyne.CanvasObject {
+			return widget.NewRichText()
+		},
+		func(uid widget.TreeNodeID, branch bool, o fyne.CanvasObject) {
+			node, ok := nodes[uid]
+			if !ok {
+				return
+			}
+			text := o.(*widget.RichText)
+			text.Segments = jsonNodeSegments(node)
+			text.Refresh()
+		},
+	)
+
+	if len(nodes) <= jsonTreeExpandLimit {
+		tree.OpenAllBranches()
+	}
+	return tree
+}
+
+// jsonNodeSegments renders a node as "key: value", coloured by value kind
+func jsonNodeSegments(node *jsonNode) []widget.RichTextSegment {
+	segment := func(text string, color fyne.ThemeColorName) *widget.TextSegment {
+		return &widget.TextSegment{
+			Text: text,
+			Style: widget.RichTextStyle{
+				ColorName: color,
+				Inline:    true,
+				TextStyle: fyne.TextStyle{Monospace: true},
+			},
+		}
+	}
+
+	segments := []widget.RichTextSegment{segment(node.Key+": ", theme.ColorNamePrimary)}
+	switch node.Kind {
+	case "object":
+		segments = append(segments, segment(fmt.Sprintf("{%d}", len(node.Children)), theme.ColorNamePlaceHolder))
+	case "array":
+		segments = append(segments, segment(fmt.Sprintf("[%d]", len(node.Children)), theme.ColorNamePlaceHolder))
+	case "string":
+		segments = append(segments, segment(node.Value, theme.ColorNameSuccess))
+	case "number":
+		segments = append(segments, segment(node.Value, theme.ColorNameWarning))
+	default:
+		segments = append(segments, segment(node.Value, theme.ColorNameError))
+	}
+	return segments
+}
@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// keyDetailRows are the labelled values of the editor's Details section
+var keyDetailRows = []string{"Encoding", "Refcount", "Idle time", "LFU frequency", "Serialized length"}
+
+// buildDetails creates the collapsed Details section of the editor header and
+// the button that expands it
+func (ve *ValueEditor) buildDetails() (*widget.Button, fyne.CanvasObject) {
+	form := widget.NewForm()
+	ve.detailLabels = make([]*widget.Label, len(keyDetailRows))
+	for i, name := range keyDetailRows {
+		ve.detailLabels[i] = widget.NewLabel("")
+		form.Append(i18n.T(name), ve.detailLabels[i])
+	}
+	ve.detailsBox = container.NewVBox(widget.NewSeparator(), form)
+	ve.detailsBox.Hide()
+
+	ve.detailsBtn = widget.NewButtonWithIcon(i18n.T("Details"), theme.MenuDropDownIcon(), func() {
+		ve.detailsOpen = !ve.detailsOpen
+		if ve.detailsOpen {
+			ve.detailsBtn.SetIcon(theme.MenuDropUpIcon())
+			ve.detailsBox.Show()
+			ve.refreshDetails(nil)
+		} else {
+			ve.detailsBtn.SetIcon(theme.MenuDropDownIcon())
+			ve.detailsBox.Hide()
+		}
+	})
+	ve.detailsBtn.Importance = widget.LowImportance
+	return ve.detailsBtn, ve.detailsBox
+}
+
+// refreshDetails reads the OBJECT metadata of the current key into the Details
+// section, then calls then (if set) whether or not it could be read. Loading a
+// value resets the key's idle time, so LoadKey reads the details first.
+func (ve *ValueEditor) refreshDetails(then func()) {
+	ve.setDetails(nil, "")
+	if ve.currentKey == nil || ve.client == nil {
+		if then != nil {
+			then()
+		}
+		return
+	}
+	key := ve.currentKey
+	client := ve.client
+	var details models.KeyDetails
+	ve.worker.Go(func(ctx context.Context) error {
+		var err error
+		details, err = client.WithContext(ctx).KeyDetails(key.Key)
+		return err
+	}, func(err error) {
+		if ve.currentKey != key {
+			return
+		}
+		if err != nil {
+			ve.setDetails(nil, "unavailable: "+err.Error())
+		} else {
+			ve.setDetails(&details, "")
+		}
+		if then != nil {
+			then()
+		}
+	})
+}
+
+// setDetails shows details, or placeholder in every row when details is nil
+func (ve *ValueEditor) setDetails(details *models.KeyDetails, placeholder string) {
+	if details == nil {
+		for i, label := range ve.detailLabels {
+			text := ""
+			if i == 0 {
+				text = placeholder
+			}
+			label.SetText(text)
+		}
+		return
+	}
+
+	idle := i18n.T("n/a (not tracked under an LFU maxmemory-policy)")
+	if details.IdleSeconds >= 0 {
+		idle = formatTTL(details.IdleSeconds)
+	}
+	freq := "n/a (needs an LFU maxmemory-policy)"
+	if tip := unsupportedTip(ve.client, redis.FeatureObjectFreq); tip != "" {
+		freq = "n/a (" + tip + ")"
+	} else if details.Frequency >= 0 {
+		freq = fmt.Sprint(details.Frequency)
+	}
+	serialized := "n/a (DEBUG OBJECT is disabled)"
+	if details.SerializedLength >= 0 {
+		serialized = formatBytes(details.SerializedLength)
+	}
+	values := []string{details.Encoding, fmt.Sprint(details.RefCount), idle, freq, serialized}
+	for i, label := range ve.detailLabels {
+		label.SetText(values[i])
+	}
+}
@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/models"
+)
+
+// keyDiff is what changed between two loads of the keys
+type keyDiff struct {
+	added   []models.RedisKey // new keys, and keys whose type changed
+	removed []string
+	retimed map[string]int64 // new TTL of keys whose TTL alone changed
+}
+
+// diffKeys compares a fresh load of the keys with the loaded ones by name
+func diffKeys(old, fresh []models.RedisKey) keyDiff {
+	previous := make(map[string]models.RedisKey, len(old))
+	for _, key := range old {
+		previous[key.Key] = key
+	}
+
+	diff := keyDiff{retimed: make(map[string]int64)}
+	for _, key := range fresh {
+		was, ok := previous[key.Key]
+		delete(previous, key.Key)
+		switch {
+		case !ok || was.Type != key.Type:
+			diff.added = append(diff.added, key)
+		case was.TTL != key.TTL:
+			diff.retimed[key.Key] = key.TTL
+		}
+	}
+	for name := range previous {
+		diff.removed = append(diff.removed, name)
+	}
+	return diff
+}
+
+// applyRefresh takes the keys of an auto-refresh, redrawing only what changed so
+// the rows don't flicker and the scroll position and selection are kept
+func (kb *KeyBrowser) applyRefresh(keys []models.RedisKey, next uint64) {
+	diff := diffKeys(kb.keys, keys)
+	kb.keys = keys
+	kb.cursor = next
+
+	if len(diff.added) == 0 && len(diff.removed) == 0 {
+		kb.updateTTLs(diff.retimed)
+		kb.updateCount()
+		return
+	}
+
+	for _, name := range diff.removed {
+		delete(kb.memory, name)
+	}
+	kb.pruneChecked()
+	if kb.updateDelimiter() {
+		kb.clearScope()
+	} else {
+		kb.filterKeys()
+	}
+	kb.showFavorites()
+	kb.measureKeys(diff.added, false)
+}
+
+// updateTTLs sets the TTL of shown keys in place, redrawing just their TTL cells
+// unless the table is sorted by TTL and has to be re-sorted
+func (kb *KeyBrowser) updateTTLs(ttls map[string]int64) {
+	if len(ttls) == 0 {
+		return
+	}
+	if kb.sortColumn == keyColumns[keyColumnTTL].name {
+		kb.filterKeys()
+		return
+	}
+	for i := range kb.filteredKeys {
+		ttl, ok := ttls[kb.filteredKeys[i].Key]
+		if !ok {
+			continue
+		}
+		kb.filteredKeys[i].TTL = ttl
+		if !kb.treeView {
+			kb.keyTable.RefreshItem(widget.TableCellID{Row: i, Col: keyColumnTTL})
+		}
+	}
+}
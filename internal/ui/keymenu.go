@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// contextRow wraps a list or tree row so a right click can open a context menu.
+// The row catches primary taps too, so they are passed on to onTapped.
+type contextRow struct {
+	widget.BaseWidget
+	content           fyne.CanvasObject
+	onTapped          func()
+	onSecondaryTapped func(pos fyne.Position)
+}
+
+func newContextRow(content fyne.CanvasObject) *contextRow {
+	r := &contextRow{content: content}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// Tapped implements fyne.Tappable
+func (r *contextRow) Tapped(*fyne.PointEvent) {
+	if r.onTapped != nil {
+		r.onTapped()
+	}
+}
+
+// TappedSecondary implements fyne.SecondaryTappable
+func (r *contextRow) TappedSecondary(e *fyne.PointEvent) {
+	if r.onSecondaryTapped != nil {
+		r.onSecondaryTapped(e.AbsolutePosition)
+	}
+}
+
+// CreateRenderer implements fyne.Widget
+func (r *contextRow) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(r.content)
+}
+
+// showKeyMenu shows the context menu for a key at pos
+func (kb *KeyBrowser) showKeyMenu(key models.RedisKey, pos fyne.Position) {
+	if kb.client == nil {
+		return
+	}
+
+	renameItem := fyne.NewMenuItem(i18n.T("Rename..."), func() { kb.renameKey(key) })
+	duplicateItem := fyne.NewMenuItem(i18n.T("Duplicate..."), func() { kb.duplicateKey(key.Key) })
+	deleteItem := fyne.NewMenuItem(i18n.T("Delete"), func() { kb.deleteKey(key.Key) })
+	ttlItem := fyne.NewMenuItem(i18n.T("Set TTL..."), func() { kb.setKeyTTL(key) })
+	convertItem := fyne.NewMenuItem(i18n.T("Convert Type..."), func() { kb.convertKey(key) })
+	for _, item := range []*fyne.MenuItem{renameItem, duplicateItem, deleteItem, ttlItem, convertItem} {
+		item.Disabled = isReadOnly(kb.client)
+	}
+	if len(redis.ConvertTargets(key.Type)) == 0 {
+		convertItem.Disabled = true
+	}
+
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem(i18n.T("Open"), func() { kb.openKey(key) }),
+		renameItem,
+		duplicateItem,
+		convertItem,
+		deleteItem,
+		fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(i18n.T("Copy Name"), func() {
+			fyne.CurrentApp().Clipboard().SetContent(key.Key)
+		}),
+		fyne.NewMenuItem(i18n.T("Copy Value"), func() { kb.copyKeyValue(key.Key) }),
+		kb.favoriteMenuItem(key.Key, false),
+		fyne.NewMenuItemSeparator(),
+		ttlItem,
+		fyne.NewMenuItem(i18n.T("Export..."), func() {
+			client := kb.client
+			ShowExportDialog(kb.window, []string{key.Key}, "*", func(req models.ExportRequest) {
+				exportKeys(kb.window, kb.worker, client, req)
+			})
+		}),
+	)
+	widget.ShowPopUpMenuAtPosition(menu, kb.window.Canvas(), pos)
+}
+
+// showFolderMenu shows the context menu for a tree folder at pos
+func (kb *KeyBrowser) showFolderMenu(folder string, pos fyne.Position) {
+	if kb.client == nil {
+		return
+	}
+
+	prefix := folder + kb.delimiter
+	ttlItem := fyne.NewMenuItem(i18n.T("Set TTL Under Prefix..."), func() { kb.showBulkTTL(redis.PrefixPattern(prefix)) })
+	deleteItem := fyne.NewMenuItem(i18n.T("Delete All Under Prefix..."), func() { kb.deletePrefix(prefix) })
+	ttlItem.Disabled = isReadOnly(kb.client)
+	deleteItem.Disabled = isReadOnly(kb.client)
+
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem(i18n.T("Scope Here"), func() { kb.setScope(folder) }),
+		fyne.NewMenuItem(i18n.T("Count Keys"), func() { kb.countPrefix(prefix) }),
+		fyne.NewMenuItem(i18n.T("Copy Prefix"), func() {
+			fyne.CurrentApp().Clipboard().SetContent(prefix)
+		}),
+		kb.favoriteMenuItem(folder, true),
+		fyne.NewMenuItemSeparator(),
+		ttlItem,
+		deleteItem,
+	)
+	widget.ShowPopUpMenuAtPosition(menu, kb.window.Canvas(), pos)
+}
+
+// openKey selects a key, reopening it in the editor if it is already selected
+func (kb *KeyBrowser) openKey(key models.RedisKey) {
+	if kb.treeView {
+		if kb.selectedKey != key.Key {
+			kb.keyTree.Select(key.Key)
+			return
+		}
+	} else {
+		for i, k := range kb.filteredKeys {
+			if k.Key == key.Key && i != kb.selectedIndex {
+				kb.selectRow(i)
+				return
+			}
+		}
+	}
+	if kb.onKeySelected != nil {
+		kb.onKeySelected(key)
+	}
+}
+
+// duplicateKey copies a key with its TTL to a new name in the current database
+func (kb *KeyBrowser) duplicateKey(key string) {
+	ShowDuplicateKeyDialog(kb.window, key, func(newKey string) {
+		kb.worker.Do(kb.window, kb.client, func(c *redis.Client) error {
+			return c.CopyKeyTo(key, c, newKey, false)
+		}, func() {
+			kb.LoadKeys()
+		})
+	})
+}
+
+// copyKeyValue puts a key's value on the clipboard; non-string values are copied as JSON
+func (kb *KeyBrowser) copyKeyValue(key string) {
+	var text string
+	kb.worker.Do(kb.window, kb.client, func(c *redis.Client) error {
+		dump, err := c.DumpKey(key)
+		if err != nil {
+			return err
+		}
+		text, err = dumpValueText(dump)
+		return err
+	}, func() {
+		fyne.CurrentApp().Clipboard().SetContent(text)
+	})
+}
+
+func (kb *KeyBrowser) setKeyTTL(key models.RedisKey) {
+	ShowTTLDialog(kb.window, key.TTL, func(exp models.Expiry) {
+		kb.worker.Do(kb.window, kb.client, func(c *redis.Client) error {
+			return c.SetTTL(key.Key, exp)
+		}, func() {
+			kb.LoadKeys()
+		})
+	})
+}
+
+// countPrefix counts the keys under prefix on the server, including ones not loaded yet
+func (kb *KeyBrowser) countPrefix(prefix string) {
+	client := kb.client
+	var count int64
+	var closeProgress func()
+	cancel := kb.worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		count, err = client.WithContext(ctx).CountMatching(redis.PrefixPattern(prefix))
+		return err
+	}, func(err error) {
+		closeProgress()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(kb.window, "Error", err)
+			return
+		}
+		ShowInfoDialog(kb.window, "Count Keys", i18n.T("%d keys under '%s'", count, prefix))
+	})
+	closeProgress = ShowCancellableProgress(kb.window, "Count Keys", i18n.T("Counting keys under '%s'...", prefix), cancel)
+}
@@ -1,8 +1,9 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"sort"
 	"strings"
 	"time"
 
@@ -10,60 +11,84 @@ import (
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
 	"redis-explorer/internal/models"
 	"redis-explorer/internal/redis"
 )
 
-// TreeNode represents a node in the key tree
-type TreeNode struct {
-	ID       string
-	Name     string
-	FullKey  string
-	IsKey    bool
-	KeyType  string
-	Children map[string]*TreeNode
-}
-
 // KeyBrowser represents the key browser panel
 type KeyBrowser struct {
 	widget.BaseWidget
 	container     *fyne.Container
 	contentArea   *fyne.Container
-	keyList       *widget.List
+	keyTable      *widget.Table
+	tableBox      *fyne.Container // lays out keyTable to fill the list view
 	keyTree       *widget.Tree
 	keys          []models.RedisKey
 	filteredKeys  []models.RedisKey
 	searchEntry   *widget.Entry
+	serverSearch  *widget.Check
 	typeFilter    *widget.Select
 	countLabel    *widget.Label
 	scopeLabel    *widget.Label
 	clearScopeBtn *widget.Button
 	setScopeBtn   *widget.Button
 	client        *redis.Client
+	worker        *Worker
+	undo          *UndoStack
 	onKeySelected func(key models.RedisKey)
 	onKeyDeleted  func(key string)
+	onKeysLoaded  func()
 	window        fyne.Window
 	selectedIndex int
 	selectedKey   string
+	treeSelected  string // node selected in the tree, which selectedKey may have moved on from
+	reselecting   bool   // restoring the selection, so the key isn't reopened
 	treeView      bool
 	viewToggle    *widget.Button
-	treeRoot      *TreeNode
-	treeNodes     map[string]*TreeNode
-	delimiter     string
+	tree          *keyTreeIndex // nil until the tree view is first built
+	treeBuild     int           // bumped per build so a stale background build is dropped
+	delimiter     string        // in use, detected from the keys unless delimSetting is set
+	delimSetting  string        // the connection's delimiter, "" to detect it
+	delimSelect   *widget.Select
+	onDelimChange func(setting, delimiter string)
+	nodeSelect    *widget.Select // cluster master browsed, hidden outside a cluster
+	nodeIDs       []string       // IDs of the nodeSelect options
+	onNodeSelect  func(conn models.ServerConnection)
 	currentScope  string
 	debounceTimer *time.Timer
 	loadingBar    *widget.ProgressBarInfinite
+	cancelLoadBtn *widget.Button
+	cancelLoad    context.CancelFunc
 	isLoading     bool
+	cursor        uint64 // SCAN cursor to continue from, 0 when every key is loaded
+	loadMoreBtn   *widget.Button
+	checked       map[string]bool // keys ticked for batch actions
+	sortColumn    string          // key table column the list view is sorted by
+	sortDesc      bool
+	batchBar      *fyne.Container
+	batchLabel    *widget.Label
+	memoryCheck   *widget.Check
+	memoryTip     *tipLayer
+	memory        map[string]int64   // MEMORY USAGE of loaded keys while the memory column is shown
+	writeButtons  []fyne.Disableable // disabled on read-only connections
+	favorites     *widget.Accordion
+	favoriteRows  *fyne.Container
 }
 
 // NewKeyBrowser creates a new key browser panel
-func NewKeyBrowser(window fyne.Window) *KeyBrowser {
+func NewKeyBrowser(window fyne.Window, worker *Worker, undo *UndoStack) *KeyBrowser {
 	kb := &KeyBrowser{
 		window:        window,
+		worker:        worker,
+		undo:          undo,
 		selectedIndex: -1,
 		treeView:      false,
-		delimiter:     ":",
-		treeNodes:     make(map[string]*TreeNode),
+		delimiter:     redis.DefaultDelimiter,
+		checked:       make(map[string]bool),
+		memory:        make(map[string]int64),
 		currentScope:  "",
 	}
 	kb.ExtendBaseWidget(kb)
@@ -72,26 +97,32 @@ func NewKeyBrowser(window fyne.Window) *KeyBrowser {
 }
 
 func (kb *KeyBrowser) buildUI() {
-	// Count label (must be created first as filterKeys uses it)
-	kb.countLabel = widget.NewLabel("0 keys")
+	// Count label and Load more button (must be created first as filterKeys uses them)
+	kb.countLabel = widget.NewLabel(i18n.T("0 keys"))
+
+	kb.loadMoreBtn = widget.NewButtonWithIcon(i18n.T("Load more"), theme.MoreHorizontalIcon(), func() {
+		kb.loadMore()
+	})
+	kb.loadMoreBtn.Importance = widget.LowImportance
+	kb.loadMoreBtn.Hide()
 
 	// Scope indicator and controls
 	kb.scopeLabel = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
 
-	kb.clearScopeBtn = widget.NewButtonWithIcon("Clear", theme.CancelIcon(), func() {
+	kb.clearScopeBtn = widget.NewButtonWithIcon(i18n.T("Clear"), theme.CancelIcon(), func() {
 		kb.clearScope()
 	})
 	kb.clearScopeBtn.Importance = widget.LowImportance
 	kb.clearScopeBtn.Hide()
 
-	kb.setScopeBtn = widget.NewButtonWithIcon("Scope", theme.SearchIcon(), func() {
+	kb.setScopeBtn = widget.NewButtonWithIcon(i18n.T("Scope"), theme.SearchIcon(), func() {
 		kb.setScopeFromSelection()
 	})
 	kb.setScopeBtn.Importance = widget.LowImportance
 
 	// Search entry with debouncing
 	kb.searchEntry = widget.NewEntry()
-	kb.searchEntry.SetPlaceHolder("Search keys...")
+	kb.searchEntry.SetPlaceHolder(i18n.T("Search keys..."))
 	kb.searchEntry.OnChanged = func(s string) {
 		// Debounce search to avoid excessive filtering on each keystroke
 		if kb.debounceTimer != nil {
@@ -100,60 +131,113 @@ func (kb *KeyBrowser) buildUI() {
 		kb.debounceTimer = time.AfterFunc(300*time.Millisecond, func() {
 			// Update UI on main thread
 			fyne.Do(func() {
-				kb.filterKeys()
+				if kb.serverSearch.Checked {
+					kb.LoadKeys()
+				} else {
+					kb.filterKeys()
+				}
 			})
 		})
 	}
 
+	// Server-side search sends the term to SCAN MATCH instead of filtering loaded keys
+	kb.serverSearch = widget.NewCheck(i18n.T("Server"), func(checked bool) {
+		if checked {
+			kb.searchEntry.SetPlaceHolder(i18n.T("MATCH pattern, e.g. user:* (case-sensitive)"))
+		} else {
+			kb.searchEntry.SetPlaceHolder(i18n.T("Search keys..."))
+		}
+		kb.LoadKeys()
+	})
+
 	// Type filter
-	kb.typeFilter = widget.NewSelect([]string{"All Types", "string", "list", "set", "hash", "zset", "stream"}, func(s string) {
+	kb.typeFilter = widget.NewSelect([]string{i18n.T("All Types"), "string", "list", "set", "hash", "zset", "stream", redis.JSONType}, func(s string) {
 		kb.filterKeys()
 	})
-	kb.typeFilter.SetSelected("All Types")
+	kb.typeFilter.SetSelectedIndex(0)
+
+	// Cluster node picker
+	kb.nodeSelect = widget.NewSelect(nil, kb.selectNode)
+	kb.nodeSelect.PlaceHolder = i18n.T("Node")
+	kb.nodeSelect.Hide()
+
+	// Namespace delimiter for the tree view
+	kb.delimSelect = widget.NewSelect(nil, kb.selectDelimiter)
+	kb.showDelimiter()
+
+	// Optional MEMORY USAGE column, measured for loaded keys only
+	kb.memoryCheck = widget.NewCheck(i18n.T("Memory"), nil)
+	kb.memoryCheck.SetChecked(config.Get().ShowKeyMemory)
+	var memoryBox fyne.CanvasObject
+	memoryBox, kb.memoryTip = withTip(kb.memoryCheck)
+	kb.memoryCheck.OnChanged = func(checked bool) {
+		config.SetShowKeyMemory(checked)
+		if checked {
+			kb.measureKeys(kb.keys, true)
+		}
+		kb.refreshRows()
+	}
 
 	// Build list view
-	kb.keyList = kb.buildListView()
+	kb.keyTable = kb.buildKeyTable()
+	kb.tableBox = container.New(&keyTableLayout{table: kb.keyTable, columns: kb.columnCount}, kb.keyTable)
 
 	// Build tree view
 	kb.keyTree = kb.buildTreeView()
 
 	// Content area that holds either list or tree
-	kb.contentArea = container.NewStack(kb.keyList)
+	kb.contentArea = container.NewStack(kb.tableBox)
 
 	// Loading indicator
 	kb.loadingBar = widget.NewProgressBarInfinite()
 	kb.loadingBar.Hide()
 
+	kb.cancelLoadBtn = widget.NewButtonWithIcon(i18n.T("Cancel"), theme.CancelIcon(), func() {
+		if kb.cancelLoad != nil {
+			kb.cancelLoad()
+		}
+	})
+	kb.cancelLoadBtn.Importance = widget.LowImportance
+	kb.cancelLoadBtn.Hide()
+
 	// View toggle button
-	kb.viewToggle = widget.NewButtonWithIcon("View", theme.ListIcon(), func() {
+	kb.viewToggle = widget.NewButtonWithIcon(i18n.T("View"), theme.ListIcon(), func() {
 		kb.toggleView()
 	})
 	kb.viewToggle.Importance = widget.LowImportance
 
 	// Buttons
-	refreshBtn := widget.NewButtonWithIcon("Refresh", theme.ViewRefreshIcon(), func() {
+	refreshBtn := widget.NewButtonWithIcon(i18n.T("Refresh"), theme.ViewRefreshIcon(), func() {
 		kb.LoadKeys()
 	})
 	refreshBtn.Importance = widget.LowImportance
 
-	newKeyBtn := widget.NewButtonWithIcon("New", theme.ContentAddIcon(), func() {
-		if kb.client == nil {
-			return
-		}
-		ShowNewKeyDialog(kb.window, func(key string, keyType string) {
-			kb.createKey(key, keyType)
-		})
-	})
+	newKeyBtn := widget.NewButtonWithIcon(i18n.T("New"), theme.ContentAddIcon(), kb.ShowNewKey)
 	newKeyBtn.Importance = widget.LowImportance
 
-	deleteBtn := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
+	renameBtn := widget.NewButtonWithIcon(i18n.T("Rename"), theme.DocumentCreateIcon(), func() {
+		kb.renameSelectedKey()
+	})
+	renameBtn.Importance = widget.LowImportance
+
+	deleteBtn := widget.NewButtonWithIcon(i18n.T("Delete"), theme.DeleteIcon(), func() {
 		kb.deleteSelectedKey()
 	})
 	deleteBtn.Importance = widget.LowImportance
 
+	exportBtn := widget.NewButtonWithIcon(i18n.T("Export"), theme.DocumentSaveIcon(), func() {
+		kb.ShowExport()
+	})
+	exportBtn.Importance = widget.LowImportance
+
+	importBtn := widget.NewButtonWithIcon(i18n.T("Import"), theme.UploadIcon(), func() {
+		kb.ShowImport()
+	})
+	importBtn.Importance = widget.LowImportance
+
 	// Search bar with filter
 	searchBar := container.NewBorder(nil, nil, nil,
-		kb.typeFilter,
+		container.NewHBox(kb.nodeSelect, kb.serverSearch, memoryBox, kb.typeFilter),
 		kb.searchEntry,
 	)
 
@@ -163,143 +247,133 @@ func (kb *KeyBrowser) buildUI() {
 	// Button bar with view toggle
 	buttonBar := container.NewHBox(
 		kb.viewToggle,
+		kb.delimSelect,
 		widget.NewSeparator(),
 		refreshBtn,
 		newKeyBtn,
+		renameBtn,
 		deleteBtn,
+		exportBtn,
+		importBtn,
 		widget.NewSeparator(),
 		kb.setScopeBtn,
 	)
 
+	// Batch actions for ticked keys, shown while any key is ticked
+	kb.batchLabel = widget.NewLabel("")
+	batchButton := func(label string, icon fyne.Resource, action func()) *widget.Button {
+		btn := widget.NewButtonWithIcon(i18n.T(label), icon, action)
+		btn.Importance = widget.LowImportance
+		return btn
+	}
+	batchDeleteBtn := batchButton("Delete", theme.DeleteIcon(), kb.deleteCheckedKeys)
+	batchTTLBtn := batchButton("TTL", theme.HistoryIcon(), kb.setCheckedTTL)
+	kb.writeButtons = []fyne.Disableable{newKeyBtn, renameBtn, deleteBtn, importBtn, batchDeleteBtn, batchTTLBtn}
+	kb.batchBar = container.NewHBox(
+		kb.batchLabel,
+		batchButton("All", theme.CheckButtonCheckedIcon(), kb.checkAllVisible),
+		batchDeleteBtn,
+		batchTTLBtn,
+		batchButton("Export", theme.DocumentSaveIcon(), kb.ShowExport),
+		batchButton("Copy", theme.ContentCopyIcon(), kb.copyCheckedNames),
+		batchButton("Clear", theme.CancelIcon(), kb.clearChecked),
+	)
+	kb.batchBar.Hide()
+
 	// Header
 	header := container.NewVBox(
 		container.NewBorder(nil, nil,
-			widget.NewLabelWithStyle("Keys", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(i18n.T("Keys"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			kb.countLabel,
 			nil,
 		),
+		kb.buildFavorites(),
 		scopeBar,
 		searchBar,
 		buttonBar,
-		kb.loadingBar,
+		kb.batchBar,
+		container.NewBorder(nil, nil, nil, kb.cancelLoadBtn, kb.loadingBar),
 	)
 
-	kb.container = container.NewBorder(header, nil, nil, nil, kb.contentArea)
-}
-
-func (kb *KeyBrowser) buildListView() *widget.List {
-	list := widget.NewList(
-		func() int { return len(kb.filteredKeys) },
-		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewIcon(theme.DocumentIcon()),
-				widget.NewLabel("Key Name"),
-				widget.NewLabel("[type]"),
-			)
-		},
-		func(i widget.ListItemID, o fyne.CanvasObject) {
-			box := o.(*fyne.Container)
-			icon := box.Objects[0].(*widget.Icon)
-			nameLabel := box.Objects[1].(*widget.Label)
-			typeLabel := box.Objects[2].(*widget.Label)
-
-			key := kb.filteredKeys[i]
-			nameLabel.SetText(key.Key)
-			typeLabel.SetText(fmt.Sprintf("[%s]", key.Type))
-			icon.SetResource(kb.getKeyIcon(key.Type))
-		},
-	)
-
-	list.OnSelected = func(id widget.ListItemID) {
-		kb.selectedIndex = id
-		if kb.onKeySelected != nil && id >= 0 && id < len(kb.filteredKeys) {
-			kb.selectedKey = kb.filteredKeys[id].Key
-			kb.onKeySelected(kb.filteredKeys[id])
-		}
-	}
-
-	return list
+	kb.container = container.NewBorder(header, kb.loadMoreBtn, nil, nil, kb.contentArea)
 }
 
 func (kb *KeyBrowser) buildTreeView() *widget.Tree {
 	tree := widget.NewTree(
 		// ChildUIDs - returns child IDs for a node
 		func(uid widget.TreeNodeID) []widget.TreeNodeID {
-			if uid == "" {
-				// Root level
-				if kb.treeRoot == nil {
-					return []widget.TreeNodeID{}
-				}
-				return kb.getChildIDs(kb.treeRoot)
-			}
-			node, ok := kb.treeNodes[uid]
-			if !ok || node == nil {
-				return []widget.TreeNodeID{}
-			}
-			return kb.getChildIDs(node)
+			return kb.tree.childIDs(uid)
 		},
 		// IsBranch - returns true if the node has children
 		func(uid widget.TreeNodeID) bool {
 			if uid == "" {
 				return true
 			}
-			node, ok := kb.treeNodes[uid]
-			if !ok || node == nil {
-				return false
-			}
-			return len(node.Children) > 0
+			node, ok := kb.tree.node(uid)
+			return ok && node.IsBranch
 		},
 		// CreateNode - creates a new node widget
 		func(branch bool) fyne.CanvasObject {
-			label := widget.NewLabel("Node")
+			check := widget.NewCheck("", nil)
+			label := widget.NewLabel(i18n.T("Node"))
 			icon := widget.NewIcon(theme.FolderIcon())
 			typeLabel := widget.NewLabel("")
-			row := container.NewHBox(icon, label, typeLabel)
-			return row
+			memoryLabel := widget.NewLabel("")
+			return newContextRow(container.NewHBox(check, icon, label, typeLabel, memoryLabel))
 		},
 		// UpdateNode - updates the node widget
 		func(uid widget.TreeNodeID, branch bool, o fyne.CanvasObject) {
-			node, ok := kb.treeNodes[uid]
-			if !ok || node == nil {
+			node, ok := kb.tree.node(uid)
+			if !ok {
 				return
 			}
 
-			box := o.(*fyne.Container)
-			icon := box.Objects[0].(*widget.Icon)
-			nameLabel := box.Objects[1].(*widget.Label)
-			typeLabel := box.Objects[2].(*widget.Label)
+			row := o.(*contextRow)
+			box := row.content.(*fyne.Container)
+			check := box.Objects[0].(*widget.Check)
+			icon := box.Objects[1].(*widget.Icon)
+			nameLabel := box.Objects[2].(*widget.Label)
+			typeLabel := box.Objects[3].(*widget.Label)
+			memoryLabel := box.Objects[4].(*widget.Label)
 
 			nameLabel.SetText(node.Name)
+			row.onTapped = func() { kb.keyTree.Select(uid) }
 
 			if node.IsKey {
+				check.Show()
+				kb.bindCheck(check, node.FullKey)
 				icon.SetResource(kb.getKeyIcon(node.KeyType))
 				typeLabel.SetText(fmt.Sprintf("[%s]", node.KeyType))
+				memoryLabel.SetText(kb.memoryText(node.FullKey))
+				row.onSecondaryTapped = func(pos fyne.Position) {
+					if key, ok := kb.findKey(node.FullKey); ok {
+						kb.showKeyMenu(key, pos)
+					}
+				}
 			} else {
+				check.Hide()
 				icon.SetResource(theme.FolderIcon())
-				// Count keys in this folder
-				count := kb.countKeysInNode(node)
-				typeLabel.SetText(fmt.Sprintf("(%d)", count))
+				typeLabel.SetText(fmt.Sprintf("(%d)", node.Count))
+				memoryLabel.SetText(kb.folderMemoryText(node))
+				row.onSecondaryTapped = func(pos fyne.Position) {
+					kb.showFolderMenu(uid, pos)
+				}
 			}
 		},
 	)
 
 	tree.OnSelected = func(uid widget.TreeNodeID) {
-		node, ok := kb.treeNodes[uid]
-		if !ok || node == nil {
+		node, ok := kb.tree.node(uid)
+		if !ok {
 			return
 		}
 
 		kb.selectedKey = uid
+		kb.treeSelected = uid
 
-		if node.IsKey {
-			// Find the key in filteredKeys
-			for _, key := range kb.filteredKeys {
-				if key.Key == node.FullKey {
-					if kb.onKeySelected != nil {
-						kb.onKeySelected(key)
-					}
-					break
-				}
+		if node.IsKey && !kb.reselecting {
+			if key, ok := kb.findKey(node.FullKey); ok && kb.onKeySelected != nil {
+				kb.onKeySelected(key)
 			}
 		}
 	}
@@ -307,6 +381,226 @@ func (kb *KeyBrowser) buildTreeView() *widget.Tree {
 	return tree
 }
 
+// bindCheck points a recycled row checkbox at key without firing its old callback
+func (kb *KeyBrowser) bindCheck(check *widget.Check, key string) {
+	check.OnChanged = nil
+	check.SetChecked(kb.checked[key])
+	check.OnChanged = func(on bool) {
+		if on {
+			kb.checked[key] = true
+		} else {
+			delete(kb.checked, key)
+		}
+		kb.updateBatchBar()
+	}
+}
+
+// showsMemory reports whether the memory column is on, which takes a server
+// with MEMORY USAGE
+func (kb *KeyBrowser) showsMemory() bool {
+	return kb.memoryCheck.Checked && !kb.memoryCheck.Disabled()
+}
+
+// measureKeys fetches MEMORY USAGE for keys in the background while the memory
+// column is shown. replace drops earlier measurements, as after a full reload.
+func (kb *KeyBrowser) measureKeys(keys []models.RedisKey, replace bool) {
+	if kb.client == nil || !kb.showsMemory() || len(keys) == 0 && !replace {
+		return
+	}
+
+	names := make([]string, len(keys))
+	for i, key := range keys {
+		names[i] = key.Key
+	}
+	client := kb.client
+	var usage map[string]int64
+	kb.worker.Go(func(ctx context.Context) error {
+		var err error
+		usage, err = client.WithContext(ctx).MemoryUsages(names)
+		return err
+	}, func(err error) {
+		// MEMORY USAGE may be unavailable (old servers, ACLs); leave the column empty
+		if err != nil || kb.client != client {
+			return
+		}
+		if replace {
+			kb.memory = usage
+		} else {
+			for key, mem := range usage {
+				kb.memory[key] = mem
+			}
+		}
+		kb.tree.resetMemory()
+		if kb.sortColumn == keyColumns[keyColumnSize].name {
+			kb.filterKeys()
+		} else {
+			kb.refreshRows()
+		}
+	})
+}
+
+// memoryText is the memory column of a key row, empty while it is hidden or unmeasured
+func (kb *KeyBrowser) memoryText(key string) string {
+	if !kb.showsMemory() {
+		return ""
+	}
+	if mem, ok := kb.memory[key]; ok {
+		return formatBytes(mem)
+	}
+	return ""
+}
+
+// folderMemoryText is the memory of a tree folder, the sum over its loaded
+// keys, marked "~" while some of them are still unmeasured
+func (kb *KeyBrowser) folderMemoryText(node *TreeNode) string {
+	if !kb.showsMemory() {
+		return ""
+	}
+	total := kb.tree.folderMemory(node, kb.memory)
+	if total.measured == 0 {
+		return ""
+	}
+	text := formatBytes(total.bytes)
+	if total.measured < node.Count {
+		text = "~" + text
+	}
+	return text
+}
+
+// checkedKeys returns the ticked keys in display order
+func (kb *KeyBrowser) checkedKeys() []string {
+	var keys []string
+	for _, key := range kb.keys {
+		if kb.checked[key.Key] {
+			keys = append(keys, key.Key)
+		}
+	}
+	return keys
+}
+
+func (kb *KeyBrowser) updateBatchBar() {
+	if len(kb.checked) == 0 {
+		kb.batchBar.Hide()
+		return
+	}
+	kb.batchLabel.SetText(i18n.T("%d selected", len(kb.checked)))
+	kb.batchBar.Show()
+}
+
+// refreshRows redraws the visible list or tree rows, e.g. after ticking keys in bulk
+func (kb *KeyBrowser) refreshRows() {
+	if kb.treeView {
+		kb.keyTree.Refresh()
+	} else {
+		kb.keyTable.Refresh()
+	}
+	kb.updateBatchBar()
+}
+
+func (kb *KeyBrowser) checkAllVisible() {
+	for _, key := range kb.filteredKeys {
+		kb.checked[key.Key] = true
+	}
+	kb.refreshRows()
+}
+
+func (kb *KeyBrowser) clearChecked() {
+	kb.checked = make(map[string]bool)
+	kb.refreshRows()
+}
+
+// pruneChecked drops ticked keys that are no longer loaded
+func (kb *KeyBrowser) pruneChecked() {
+	loaded := make(map[string]bool, len(kb.keys))
+	for _, key := range kb.keys {
+		loaded[key.Key] = true
+	}
+	for key := range kb.checked {
+		if !loaded[key] {
+			delete(kb.checked, key)
+		}
+	}
+	kb.updateBatchBar()
+}
+
+// deleteCheckedKeys previews the types and memory of the ticked keys, then
+// deletes them in batches once confirmed
+func (kb *KeyBrowser) deleteCheckedKeys() {
+	keys := kb.checkedKeys()
+	if kb.client == nil || len(keys) == 0 {
+		return
+	}
+
+	client := kb.client
+	var preview *models.ImpactPreview
+	var closeProgress func()
+	cancel := kb.worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		preview, err = client.WithContext(ctx).PreviewKeys(keys, impactSampleSize)
+		return err
+	}, func(err error) {
+		closeProgress()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(kb.window, "Delete Keys", err)
+			return
+		}
+		ShowImpactDialog(kb.window, i18n.T("Delete %d Keys", len(keys)), preview, "will be removed", "Delete", "", func() {
+			var snapshots []models.KeySnapshot
+			var deleted int64
+			kb.worker.Job(i18n.T("Delete %d keys", len(keys)), func(ctx context.Context, job *jobs.Job) error {
+				c := client.WithContext(ctx)
+				snapshots = snapshotKeys(c, keys)
+				var err error
+				deleted, err = c.DeleteKeysWithProgress(keys, func(n int64) {
+					job.Update(n, int64(len(keys)))
+				})
+				return err
+			}, func(err error) {
+				if deleted > 0 {
+					kb.undo.push(client, i18n.T("Deleted %d keys", deleted), snapshots, true)
+					for _, key := range keys {
+						delete(kb.checked, key)
+						if kb.onKeyDeleted != nil {
+							kb.onKeyDeleted(key)
+						}
+					}
+					kb.LoadKeys()
+				}
+				if err != nil && !errors.Is(err, context.Canceled) {
+					ShowErrorDialog(kb.window, "Delete Keys", err)
+				}
+			})
+		})
+	})
+	closeProgress = ShowCancellableProgress(kb.window, "Delete Keys", "Analyzing keys to be removed...", cancel)
+}
+
+func (kb *KeyBrowser) setCheckedTTL() {
+	keys := kb.checkedKeys()
+	if kb.client == nil || len(keys) == 0 {
+		return
+	}
+
+	ShowTTLDialog(kb.window, -1, func(exp models.Expiry) {
+		kb.worker.Do(kb.window, kb.client, func(c *redis.Client) error {
+			return c.SetTTLs(keys, exp)
+		}, func() {
+			kb.LoadKeys()
+		})
+	})
+}
+
+func (kb *KeyBrowser) copyCheckedNames() {
+	keys := kb.checkedKeys()
+	if len(keys) == 0 {
+		return
+	}
+	fyne.CurrentApp().Clipboard().SetContent(strings.Join(keys, "\n"))
+}
+
 func (kb *KeyBrowser) setScopeFromSelection() {
 	var scopePath string
 
@@ -315,7 +609,7 @@ func (kb *KeyBrowser) setScopeFromSelection() {
 		if kb.selectedKey == "" {
 			return
 		}
-		if node, ok := kb.treeNodes[kb.selectedKey]; ok {
+		if node, ok := kb.tree.node(kb.selectedKey); ok {
 			if node.IsKey {
 				// It's a key - get the parent path
 				lastDelim := strings.LastIndex(kb.selectedKey, kb.delimiter)
@@ -345,7 +639,7 @@ func (kb *KeyBrowser) setScopeFromSelection() {
 
 func (kb *KeyBrowser) setScope(scope string) {
 	kb.currentScope = scope
-	kb.scopeLabel.SetText("Scope: " + scope)
+	kb.scopeLabel.SetText(i18n.T("Scope: %s", scope))
 	kb.clearScopeBtn.Show()
 	kb.filterKeys()
 }
@@ -357,27 +651,6 @@ func (kb *KeyBrowser) clearScope() {
 	kb.filterKeys()
 }
 
-func (kb *KeyBrowser) getChildIDs(node *TreeNode) []widget.TreeNodeID {
-	var ids []widget.TreeNodeID
-	for _, child := range node.Children {
-		ids = append(ids, child.ID)
-	}
-	// Sort for consistent order
-	sort.Strings(ids)
-	return ids
-}
-
-func (kb *KeyBrowser) countKeysInNode(node *TreeNode) int {
-	count := 0
-	if node.IsKey {
-		count = 1
-	}
-	for _, child := range node.Children {
-		count += kb.countKeysInNode(child)
-	}
-	return count
-}
-
 func (kb *KeyBrowser) getKeyIcon(keyType string) fyne.Resource {
 	switch keyType {
 	case "string":
@@ -390,11 +663,27 @@ func (kb *KeyBrowser) getKeyIcon(keyType string) fyne.Resource {
 		return theme.StorageIcon()
 	case "zset":
 		return theme.MenuIcon()
+	case "stream":
+		return theme.MediaFastForwardIcon()
+	case redis.JSONType:
+		return theme.FileTextIcon()
 	default:
 		return theme.FileIcon()
 	}
 }
 
+// TreeView returns whether the keys are shown as a tree rather than a list
+func (kb *KeyBrowser) TreeView() bool {
+	return kb.treeView
+}
+
+// SetTreeView shows the keys as a tree or as a list
+func (kb *KeyBrowser) SetTreeView(tree bool) {
+	if tree != kb.treeView {
+		kb.toggleView()
+	}
+}
+
 func (kb *KeyBrowser) toggleView() {
 	kb.treeView = !kb.treeView
 	kb.contentArea.RemoveAll()
@@ -403,64 +692,103 @@ func (kb *KeyBrowser) toggleView() {
 		kb.viewToggle.SetIcon(theme.FolderIcon())
 		kb.buildKeyTree()
 		kb.contentArea.Add(kb.keyTree)
-		kb.keyTree.Refresh()
 	} else {
 		kb.viewToggle.SetIcon(theme.ListIcon())
-		kb.contentArea.Add(kb.keyList)
-		kb.keyList.Refresh()
+		kb.contentArea.Add(kb.tableBox)
+		kb.keyTable.Refresh()
+		kb.restoreSelection()
 	}
 	kb.contentArea.Refresh()
 }
 
+// buildKeyTree re-indexes the filtered keys for the tree view. Sorting a large
+// key set runs in the background; the tree keeps showing the previous keys
+// until it is done.
 func (kb *KeyBrowser) buildKeyTree() {
-	kb.treeNodes = make(map[string]*TreeNode)
-	kb.treeRoot = &TreeNode{
-		ID:       "",
-		Name:     "root",
-		Children: make(map[string]*TreeNode),
+	kb.treeBuild++
+	if len(kb.filteredKeys) == 0 {
+		kb.tree = newKeyTreeIndex(nil, kb.delimiter)
+		kb.keyTree.Refresh()
+		kb.selectTreeNode()
+		return
 	}
 
-	for _, key := range kb.filteredKeys {
-		kb.addKeyToTree(key)
+	build := kb.treeBuild
+	keys := kb.filteredKeys
+	delimiter := kb.delimiter
+	var tree *keyTreeIndex
+	kb.worker.Go(func(ctx context.Context) error {
+		tree = newKeyTreeIndex(keys, delimiter)
+		return nil
+	}, func(error) {
+		if build != kb.treeBuild {
+			return
+		}
+		kb.tree = tree
+		kb.keyTree.Refresh()
+		kb.selectTreeNode()
+	})
+}
+
+// ShowNewKey asks for the name, type and initial value of a key to create
+func (kb *KeyBrowser) ShowNewKey() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
 	}
+	ShowNewKeyDialog(kb.window, "", kb.createKey)
 }
 
-func (kb *KeyBrowser) addKeyToTree(key models.RedisKey) {
-	parts := strings.Split(key.Key, kb.delimiter)
-	currentNode := kb.treeRoot
-	currentPath := ""
+// ShowNewKeyFromClipboard asks for the name of a string key to create holding
+// the clipboard contents
+func (kb *KeyBrowser) ShowNewKeyFromClipboard() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+	ShowNewKeyDialog(kb.window, fyne.CurrentApp().Clipboard().Content(), kb.createKey)
+}
 
-	for i, part := range parts {
-		if currentPath == "" {
-			currentPath = part
-		} else {
-			currentPath = currentPath + kb.delimiter + part
-		}
+// ShowNewKeyFromTemplate asks for the placeholders of a saved key template
+// and creates the key it makes
+func (kb *KeyBrowser) ShowNewKeyFromTemplate() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+	ShowKeyTemplateDialog(kb.window, kb.createKey)
+}
 
-		isLastPart := i == len(parts)-1
+// CopySelectedName puts the name of the selected key on the clipboard
+func (kb *KeyBrowser) CopySelectedName() {
+	if key := kb.GetSelectedKey(); key != nil {
+		fyne.CurrentApp().Clipboard().SetContent(key.Key)
+	}
+}
 
-		child, exists := currentNode.Children[part]
-		if !exists {
-			child = &TreeNode{
-				ID:       currentPath,
-				Name:     part,
-				FullKey:  key.Key,
-				IsKey:    isLastPart,
-				KeyType:  key.Type,
-				Children: make(map[string]*TreeNode),
-			}
-			currentNode.Children[part] = child
-			kb.treeNodes[currentPath] = child
-		}
+// DeleteSelectedKey asks to delete the selected key
+func (kb *KeyBrowser) DeleteSelectedKey() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+	kb.deleteSelectedKey()
+}
 
-		if isLastPart {
-			// Mark as key if this is the final part
-			child.IsKey = true
-			child.FullKey = key.Key
-			child.KeyType = key.Type
-		}
+// FocusSearch moves keyboard focus to the search entry
+func (kb *KeyBrowser) FocusSearch() {
+	kb.window.Canvas().Focus(kb.searchEntry)
+}
+
+// Keys returns the loaded keys, including those hidden by the filters
+func (kb *KeyBrowser) Keys() []models.RedisKey {
+	return kb.keys
+}
 
-		currentNode = child
+// OpenKey opens a loaded key in the editor, selecting it if it passes the filters
+func (kb *KeyBrowser) OpenKey(key models.RedisKey) {
+	if shown, ok := kb.findKey(key.Key); ok {
+		kb.openKey(shown)
+		return
+	}
+	if kb.onKeySelected != nil {
+		kb.onKeySelected(key)
 	}
 }
 
@@ -470,7 +798,7 @@ func (kb *KeyBrowser) deleteSelectedKey() {
 	if kb.treeView {
 		keyToDelete = kb.selectedKey
 		// Check if it's actually a key (not a folder)
-		if node, ok := kb.treeNodes[keyToDelete]; ok && !node.IsKey {
+		if node, ok := kb.tree.node(keyToDelete); ok && !node.IsKey {
 			return // Can't delete a folder
 		}
 	} else {
@@ -480,52 +808,88 @@ func (kb *KeyBrowser) deleteSelectedKey() {
 		keyToDelete = kb.filteredKeys[kb.selectedIndex].Key
 	}
 
-	if keyToDelete == "" {
+	kb.deleteKey(keyToDelete)
+}
+
+// deleteKey asks for confirmation and deletes a single key
+func (kb *KeyBrowser) deleteKey(keyToDelete string) {
+	if keyToDelete == "" || kb.client == nil {
 		return
 	}
 
-	ShowConfirmDialog(kb.window, "Delete Key",
-		fmt.Sprintf("Are you sure you want to delete '%s'?", keyToDelete),
+	confirmDestructive(kb.window, "Delete Key",
+		i18n.T("Are you sure you want to delete '%s'?", keyToDelete),
 		func() {
-			if kb.client != nil {
-				err := kb.client.DeleteKey(keyToDelete)
-				if err != nil {
-					ShowErrorDialog(kb.window, "Error", err)
-					return
-				}
+			var snapshots []models.KeySnapshot
+			kb.worker.Do(kb.window, kb.client, func(c *redis.Client) error {
+				snapshots = snapshotKeys(c, []string{keyToDelete})
+				return c.DeleteKey(keyToDelete)
+			}, func() {
+				kb.undo.push(kb.client, i18n.T("Deleted '%s'", keyToDelete), snapshots, true)
 				if kb.onKeyDeleted != nil {
 					kb.onKeyDeleted(keyToDelete)
 				}
 				kb.LoadKeys()
-			}
+			})
 		})
 }
 
-func (kb *KeyBrowser) createKey(key string, keyType string) {
-	if kb.client == nil {
+// promptRenameKey asks for a new name and renames the key, using RENAMENX unless
+// overwriting was allowed. onRenamed runs with the new name once it succeeded.
+func promptRenameKey(window fyne.Window, worker *Worker, client *redis.Client, key string, onRenamed func(newKey string)) {
+	if client == nil {
 		return
 	}
+	ShowRenameKeyDialog(window, key, func(newKey string, overwrite bool) {
+		worker.Do(window, client, func(c *redis.Client) error {
+			if overwrite {
+				return c.RenameKey(key, newKey)
+			}
+			renamed, err := c.RenameKeyNX(key, newKey)
+			if err == nil && !renamed {
+				err = fmt.Errorf("key '%s' already exists", newKey)
+			}
+			return err
+		}, func() {
+			onRenamed(newKey)
+		})
+	})
+}
 
-	var err error
-	switch keyType {
-	case "string":
-		err = kb.client.SetString(key, "")
-	case "list":
-		err = kb.client.ListPush(key, "", false)
-	case "set":
-		err = kb.client.SetAdd(key, "")
-	case "hash":
-		err = kb.client.HashSet(key, "field", "")
-	case "zset":
-		err = kb.client.SortedSetAdd(key, 0, "")
+// renameSelectedKey renames the key selected in the list or tree
+func (kb *KeyBrowser) renameSelectedKey() {
+	if key := kb.GetSelectedKey(); key != nil {
+		kb.renameKey(*key)
 	}
+}
 
-	if err != nil {
-		ShowErrorDialog(kb.window, "Error", err)
+// renameKey renames a key and reopens it under its new name
+func (kb *KeyBrowser) renameKey(key models.RedisKey) {
+	promptRenameKey(kb.window, kb.worker, kb.client, key.Key, func(newKey string) {
+		renamed := key
+		renamed.Key = newKey
+		kb.LoadKeys()
+		if kb.onKeySelected != nil {
+			kb.onKeySelected(renamed)
+		}
+	})
+}
+
+// createKey creates a key and opens it, selecting it once the keys are reloaded
+func (kb *KeyBrowser) createKey(dump *models.KeyDump) {
+	if kb.client == nil {
 		return
 	}
 
-	kb.LoadKeys()
+	kb.worker.Do(kb.window, kb.client, func(c *redis.Client) error {
+		return c.CreateKey(dump)
+	}, func() {
+		kb.selectedKey = dump.Key
+		kb.LoadKeys()
+		if kb.onKeySelected != nil {
+			kb.onKeySelected(models.RedisKey{Key: dump.Key, Type: dump.Type, TTL: dump.TTL})
+		}
+	})
 }
 
 func (kb *KeyBrowser) filterKeys() {
@@ -535,9 +899,11 @@ func (kb *KeyBrowser) filterKeys() {
 	if kb.searchEntry != nil {
 		pattern = strings.ToLower(kb.searchEntry.Text)
 	}
-	if kb.typeFilter != nil {
+	// The first type filter option is all types
+	if kb.typeFilter != nil && kb.typeFilter.SelectedIndex() > 0 {
 		typeFilter = kb.typeFilter.Selected
 	}
+	serverSide := kb.serverSearch != nil && kb.serverSearch.Checked
 
 	kb.filteredKeys = nil
 	for _, key := range kb.keys {
@@ -549,30 +915,27 @@ func (kb *KeyBrowser) filterKeys() {
 		}
 
 		// Type filter
-		if typeFilter != "" && typeFilter != "All Types" && key.Type != typeFilter {
+		if typeFilter != "" && key.Type != typeFilter {
 			continue
 		}
 
-		// Search filter
-		if pattern != "" && !strings.Contains(strings.ToLower(key.Key), pattern) {
+		// Search filter (already applied by SCAN MATCH in server-side mode)
+		if pattern != "" && !serverSide && !strings.Contains(strings.ToLower(key.Key), pattern) {
 			continue
 		}
 
 		kb.filteredKeys = append(kb.filteredKeys, key)
 	}
+	kb.sortKeys(kb.filteredKeys)
 
-	if kb.countLabel != nil {
-		kb.countLabel.SetText(fmt.Sprintf("%d keys", len(kb.filteredKeys)))
-	}
+	kb.updateCount()
 
 	if kb.treeView {
 		kb.buildKeyTree()
-		if kb.keyTree != nil {
-			kb.keyTree.Refresh()
-		}
 	} else {
-		if kb.keyList != nil {
-			kb.keyList.Refresh()
+		if kb.keyTable != nil {
+			kb.keyTable.Refresh()
+			kb.restoreSelection()
 		}
 	}
 }
@@ -585,6 +948,18 @@ func (kb *KeyBrowser) CreateRenderer() fyne.WidgetRenderer {
 // SetClient sets the Redis client
 func (kb *KeyBrowser) SetClient(client *redis.Client) {
 	kb.client = client
+	setWritable(client, kb.writeButtons...)
+	if client != nil {
+		kb.setSort(client.Connection())
+	}
+	if tip := unsupportedTip(client, redis.FeatureMemoryUsage); tip != "" {
+		kb.memoryCheck.Disable()
+		kb.memoryTip.SetTip(tip)
+	} else {
+		kb.memoryCheck.Enable()
+		kb.memoryTip.SetTip("")
+	}
+	kb.showNodes()
 }
 
 // LoadKeys loads keys from the connected Redis server asynchronously
@@ -602,15 +977,15 @@ func (kb *KeyBrowser) loadKeysInternal(silent bool) {
 		kb.keys = nil
 		kb.filteredKeys = nil
 		if kb.countLabel != nil {
-			kb.countLabel.SetText("0 keys")
+			kb.countLabel.SetText(i18n.T("0 keys"))
 		}
-		if kb.keyList != nil {
-			kb.keyList.Refresh()
+		if kb.keyTable != nil {
+			kb.keyTable.Refresh()
 		}
-		if kb.keyTree != nil {
+		if kb.treeView {
 			kb.buildKeyTree()
-			kb.keyTree.Refresh()
 		}
+		kb.showFavorites()
 		return
 	}
 
@@ -623,37 +998,296 @@ func (kb *KeyBrowser) loadKeysInternal(silent bool) {
 	if !silent {
 		kb.loadingBar.Show()
 		kb.loadingBar.Start()
+		kb.cancelLoadBtn.Show()
 		if kb.countLabel != nil {
-			kb.countLabel.SetText("Loading...")
+			kb.countLabel.SetText(i18n.T("Loading..."))
 		}
 	}
 
-	// Load keys in background goroutine
-	go func() {
-		keys, err := kb.client.GetAllKeys("*", 10000)
+	// A silent refresh reloads as many keys as are already shown, so it doesn't
+	// throw away pages the user loaded with "Load more"
+	pageSize := config.Get().KeyPageSize
+	if silent && len(kb.keys) > pageSize {
+		pageSize = len(kb.keys)
+	}
 
-		// Update UI on main thread using fyne.Do
-		fyne.Do(func() {
-			kb.isLoading = false
-			if !silent {
-				kb.loadingBar.Stop()
-				kb.loadingBar.Hide()
+	// Load keys in the background; the result is delivered on the UI thread
+	client := kb.client
+	match := kb.scanPattern()
+	var keys []models.RedisKey
+	var next uint64
+	scan := func(ctx context.Context) error {
+		var err error
+		keys, next, err = client.WithContext(ctx).ScanKeysPage(match, 0, pageSize)
+		return err
+	}
+	done := func(err error) {
+		kb.finishLoading(silent)
+		if !kb.handleLoadError(err, silent) {
+			return
+		}
+
+		if silent {
+			kb.applyRefresh(keys, next)
+			return
+		}
+
+		kb.keys = keys
+		kb.cursor = next
+		kb.pruneChecked()
+		if kb.updateDelimiter() {
+			kb.clearScope()
+		} else {
+			kb.filterKeys()
+		}
+		kb.showFavorites()
+		kb.measureKeys(keys, true)
+	}
+	// Auto-refreshes aren't worth listing in the job queue
+	if silent {
+		kb.cancelLoad = kb.worker.GoCancellable(scan, done)
+		return
+	}
+	kb.cancelLoad = kb.worker.Job(i18n.T("Scan keys matching '%s'", match), func(ctx context.Context, _ *jobs.Job) error {
+		return scan(ctx)
+	}, done)
+}
+
+// loadMore fetches the next page of keys and appends it to the loaded keys
+func (kb *KeyBrowser) loadMore() {
+	if kb.client == nil || kb.isLoading || kb.cursor == 0 {
+		return
+	}
+
+	kb.isLoading = true
+	kb.loadingBar.Show()
+	kb.loadingBar.Start()
+	kb.cancelLoadBtn.Show()
+	kb.loadMoreBtn.Disable()
+
+	client := kb.client
+	match := kb.scanPattern()
+	cursor := kb.cursor
+	pageSize := config.Get().KeyPageSize
+	var keys []models.RedisKey
+	var next uint64
+	kb.cancelLoad = kb.worker.Job(i18n.T("Scan more keys matching '%s'", match), func(ctx context.Context, _ *jobs.Job) error {
+		var err error
+		keys, next, err = client.WithContext(ctx).ScanKeysPage(match, cursor, pageSize)
+		return err
+	}, func(err error) {
+		kb.finishLoading(false)
+		if !kb.handleLoadError(err, false) {
+			return
+		}
+
+		// SCAN may return a key more than once across pages
+		seen := make(map[string]bool, len(kb.keys))
+		for _, key := range kb.keys {
+			seen[key.Key] = true
+		}
+		for _, key := range keys {
+			if !seen[key.Key] {
+				seen[key.Key] = true
+				kb.keys = append(kb.keys, key)
 			}
+		}
+		kb.cursor = next
+		kb.filterKeys()
+		kb.measureKeys(keys, false)
+	})
+}
 
-			if err != nil {
-				if kb.countLabel != nil {
-					kb.countLabel.SetText("Error")
-				}
-				if !silent {
-					ShowErrorDialog(kb.window, "Error loading keys", err)
-				}
-				return
+// scanPattern returns the SCAN MATCH pattern for loading keys: the search term in
+// server-side search mode, otherwise every key
+func (kb *KeyBrowser) scanPattern() string {
+	if kb.serverSearch.Checked {
+		return redis.MatchPattern(strings.TrimSpace(kb.searchEntry.Text))
+	}
+	return "*"
+}
+
+// handleLoadError reports a failed or cancelled key load and returns whether
+// the load succeeded and its keys should be used
+func (kb *KeyBrowser) handleLoadError(err error, silent bool) bool {
+	if errors.Is(err, context.Canceled) {
+		// Keep showing the previously loaded keys
+		if kb.countLabel != nil {
+			kb.countLabel.SetText(i18n.T("%d keys (load cancelled)", len(kb.filteredKeys)))
+		}
+		return false
+	}
+	if err != nil {
+		if kb.countLabel != nil {
+			kb.countLabel.SetText(i18n.T("Error"))
+		}
+		if !silent {
+			ShowErrorDialog(kb.window, "Error loading keys", err)
+		}
+		return false
+	}
+	return true
+}
+
+// updateCount shows the number of matching keys and whether more can be loaded
+func (kb *KeyBrowser) updateCount() {
+	if kb.cursor != 0 {
+		kb.loadMoreBtn.Show()
+	} else {
+		kb.loadMoreBtn.Hide()
+	}
+	if kb.countLabel == nil {
+		return
+	}
+	if kb.cursor != 0 {
+		kb.countLabel.SetText(i18n.T("%d keys (more available)", len(kb.filteredKeys)))
+	} else {
+		kb.countLabel.SetText(i18n.T("%d keys", len(kb.filteredKeys)))
+	}
+}
+
+func (kb *KeyBrowser) finishLoading(silent bool) {
+	kb.isLoading = false
+	kb.cancelLoad = nil
+	kb.loadMoreBtn.Enable()
+	if !silent {
+		kb.loadingBar.Stop()
+		kb.loadingBar.Hide()
+		kb.cancelLoadBtn.Hide()
+	}
+	if kb.onKeysLoaded != nil {
+		kb.onKeysLoaded()
+	}
+}
+
+// ShowExport opens the export dialog for the selected key, the current scope or the whole database
+func (kb *KeyBrowser) ShowExport() {
+	if kb.client == nil {
+		return
+	}
+
+	selected := kb.checkedKeys()
+	if len(selected) == 0 {
+		if key := kb.GetSelectedKey(); key != nil {
+			selected = []string{key.Key}
+		}
+	}
+	pattern := "*"
+	if kb.currentScope != "" {
+		pattern = redis.PrefixPattern(kb.currentScope + kb.delimiter)
+	}
+
+	client := kb.client
+	ShowExportDialog(kb.window, selected, pattern, func(req models.ExportRequest) {
+		exportKeys(kb.window, kb.worker, client, req)
+	})
+}
+
+// ShowMigration copies the keys of the current scope, or the whole database,
+// to another database with the migration wizard
+func (kb *KeyBrowser) ShowMigration() {
+	if kb.client == nil {
+		return
+	}
+
+	pattern := "*"
+	if kb.currentScope != "" {
+		pattern = redis.PrefixPattern(kb.currentScope + kb.delimiter)
+	}
+	ShowMigrationWizard(kb.window, kb.worker, kb.client, pattern)
+}
+
+// ShowGenerate writes test keys, named under the current scope if there is one
+func (kb *KeyBrowser) ShowGenerate() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+
+	pattern := "test" + kb.delimiter + redis.GeneratePlaceholder
+	if kb.currentScope != "" {
+		pattern = kb.currentScope + kb.delimiter + pattern
+	}
+	client := kb.client
+	ShowGenerateDialog(kb.window, pattern, func(req models.GenerateRequest) {
+		generateKeys(kb.window, kb.worker, client, req, func() {
+			if kb.client == client {
+				kb.LoadKeys()
 			}
+		})
+	})
+}
 
-			kb.keys = keys
-			kb.filterKeys()
+// ShowCompare compares the keys of the current scope, or the whole database,
+// with another database
+func (kb *KeyBrowser) ShowCompare() {
+	if kb.client == nil {
+		return
+	}
+
+	pattern := "*"
+	if kb.currentScope != "" {
+		pattern = redis.PrefixPattern(kb.currentScope + kb.delimiter)
+	}
+	ShowCompareDialog(kb.window, kb.worker, kb.client, pattern)
+}
+
+// ShowMemoryAnalysis reports memory usage by key prefix for the current scope or the whole database
+func (kb *KeyBrowser) ShowMemoryAnalysis() {
+	if kb.client == nil {
+		return
+	}
+
+	pattern := "*"
+	if kb.currentScope != "" {
+		pattern = redis.PrefixPattern(kb.currentScope + kb.delimiter)
+	}
+
+	client := kb.client
+	ShowMemoryAnalysisDialog(kb.window, pattern, func(pattern string, depth int) {
+		confirmHeavyScan(kb.window, kb.worker, client, "Memory Analysis", pattern, memoryCommandsPerKey, func(pattern string) {
+			analyzeMemory(kb.window, kb.worker, client, pattern, kb.delimiter, depth)
+		})
+	})
+}
+
+// ShowBulkTTL sets a TTL on every key in the current scope or the whole database
+func (kb *KeyBrowser) ShowBulkTTL() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+
+	pattern := "*"
+	if kb.currentScope != "" {
+		pattern = redis.PrefixPattern(kb.currentScope + kb.delimiter)
+	}
+	kb.showBulkTTL(pattern)
+}
+
+func (kb *KeyBrowser) showBulkTTL(pattern string) {
+	client := kb.client
+	ShowBulkTTLDialog(kb.window, pattern, func(pattern string, exp models.Expiry) {
+		setTTLMatching(kb.window, kb.worker, client, pattern, exp, func() {
+			if kb.client == client {
+				kb.LoadKeys()
+			}
 		})
-	}()
+	})
+}
+
+// ShowImport opens the import dialog and reloads the keys once keys were imported
+func (kb *KeyBrowser) ShowImport() {
+	if kb.client == nil || refuseReadOnly(kb.window, kb.client) {
+		return
+	}
+
+	client := kb.client
+	ShowImportDialog(kb.window, func(policy string) {
+		importKeys(kb.window, kb.worker, client, policy, func() {
+			if kb.client == client {
+				kb.LoadKeys()
+			}
+		})
+	})
 }
 
 // SetOnKeySelected sets the callback for key selection
@@ -666,24 +1300,44 @@ func (kb *KeyBrowser) SetOnKeyDeleted(f func(key string)) {
 	kb.onKeyDeleted = f
 }
 
+// SetOnKeysLoaded sets the callback for each finished key load or refresh
+func (kb *KeyBrowser) SetOnKeysLoaded(f func()) {
+	kb.onKeysLoaded = f
+}
+
 // Clear clears the key list
 func (kb *KeyBrowser) Clear() {
 	kb.keys = nil
 	kb.filteredKeys = nil
 	kb.selectedKey = ""
+	kb.treeSelected = ""
+	kb.checked = make(map[string]bool)
+	kb.memory = make(map[string]int64)
+	kb.batchBar.Hide()
+	kb.cursor = 0
+	kb.loadMoreBtn.Hide()
+	kb.finishLoading(false)
 	kb.clearScope()
+	kb.showFavorites()
 	if kb.countLabel != nil {
-		kb.countLabel.SetText("0 keys")
+		kb.countLabel.SetText(i18n.T("0 keys"))
 	}
 	kb.selectedIndex = -1
-	if kb.keyList != nil {
-		kb.keyList.UnselectAll()
-		kb.keyList.Refresh()
+	if kb.keyTable != nil {
+		kb.keyTable.UnselectAll()
+		kb.keyTable.Refresh()
 	}
-	if kb.keyTree != nil {
-		kb.buildKeyTree()
-		kb.keyTree.Refresh()
+	kb.keyTree.UnselectAll()
+}
+
+// findKey looks up a loaded key that passes the current filters by name
+func (kb *KeyBrowser) findKey(name string) (models.RedisKey, bool) {
+	for _, key := range kb.filteredKeys {
+		if key.Key == name {
+			return key, true
+		}
 	}
+	return models.RedisKey{}, false
 }
 
 // GetSelectedKey returns the currently selected key
@@ -0,0 +1,249 @@
+package ui
+
+import (
+	"cmp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// Key table columns; the name is also what a column's sort order is saved as.
+// The slot and node columns are only shown on a cluster.
+var keyColumns = []struct {
+	name  string
+	title string
+	width float32 // 0 for the name column, which takes the remaining width
+}{
+	{"name", "Name", 0},
+	{"type", "Type", 90},
+	{"ttl", "TTL", 100},
+	{"size", "Size", 90},
+	{"slot", "Slot", 70},
+	{"node", "Node", 150},
+}
+
+const (
+	keyColumnName = iota
+	keyColumnType
+	keyColumnTTL
+	keyColumnSize
+	keyColumnSlot
+	keyColumnNode
+)
+
+// keyNameMinWidth is the narrowest the name column gets
+const keyNameMinWidth = 160
+
+// buildKeyTable creates the list view: a table of the filtered keys with a
+// header that sorts by the tapped column
+func (kb *KeyBrowser) buildKeyTable() *widget.Table {
+	table := widget.NewTableWithHeaders(
+		func() (int, int) { return len(kb.filteredKeys), kb.columnCount() },
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return newContextRow(container.NewBorder(nil, nil,
+				container.NewHBox(widget.NewCheck("", nil), widget.NewIcon(theme.DocumentIcon())), nil, label))
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			row := o.(*contextRow)
+			box := row.content.(*fyne.Container)
+			label := box.Objects[0].(*widget.Label)
+			lead := box.Objects[1].(*fyne.Container)
+			check := lead.Objects[0].(*widget.Check)
+			icon := lead.Objects[1].(*widget.Icon)
+
+			key := kb.filteredKeys[id.Row]
+			lead.Hide()
+			switch id.Col {
+			case keyColumnName:
+				lead.Show()
+				kb.bindCheck(check, key.Key)
+				icon.SetResource(kb.getKeyIcon(key.Type))
+				label.SetText(key.Key)
+			case keyColumnType:
+				label.SetText(key.Type)
+			case keyColumnTTL:
+				label.SetText(formatTTL(key.TTL))
+			case keyColumnSize:
+				label.SetText(kb.memoryText(key.Key))
+			case keyColumnSlot:
+				label.SetText(strconv.Itoa(redis.KeySlot(key.Key)))
+			case keyColumnNode:
+				label.SetText(kb.client.KeyNode(key.Key))
+			}
+
+			row.onTapped = func() { kb.selectRow(id.Row) }
+			row.onSecondaryTapped = func(pos fyne.Position) {
+				kb.showKeyMenu(key, pos)
+			}
+		},
+	)
+
+	// Columns are labelled by the header row only
+	table.ShowHeaderColumn = false
+	table.CreateHeader = func() fyne.CanvasObject {
+		btn := widget.NewButton("", nil)
+		btn.Importance = widget.LowImportance
+		btn.Alignment = widget.ButtonAlignLeading
+		return btn
+	}
+	table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		btn := o.(*widget.Button)
+		column := keyColumns[id.Col]
+		btn.SetText(i18n.T(column.title))
+		btn.SetIcon(nil)
+		if column.name == kb.sortColumn {
+			if kb.sortDesc {
+				btn.SetIcon(theme.MenuDropDownIcon())
+			} else {
+				btn.SetIcon(theme.MenuDropUpIcon())
+			}
+		}
+		btn.OnTapped = func() { kb.sortBy(column.name) }
+	}
+
+	for i, column := range keyColumns {
+		if column.width > 0 {
+			table.SetColumnWidth(i, column.width)
+		}
+	}
+
+	table.OnSelected = func(id widget.TableCellID) {
+		kb.selectedIndex = id.Row
+		if id.Row < 0 || id.Row >= len(kb.filteredKeys) {
+			return
+		}
+		kb.selectedKey = kb.filteredKeys[id.Row].Key
+		if kb.onKeySelected != nil && !kb.reselecting {
+			kb.onKeySelected(kb.filteredKeys[id.Row])
+		}
+	}
+	return table
+}
+
+// selectRow selects a row of the key table by its name cell
+func (kb *KeyBrowser) selectRow(row int) {
+	kb.keyTable.Select(widget.TableCellID{Row: row, Col: keyColumnName})
+}
+
+// keyTableLayout fills its only object, the key table, giving the name column
+// the width the other columns leave
+type keyTableLayout struct {
+	table   *widget.Table
+	columns func() int // how many of keyColumns are shown
+}
+
+func (l *keyTableLayout) Layout(objects []fyne.CanvasObject, size fyne.Size) {
+	shown := keyColumns[:l.columns()]
+	width := size.Width - theme.Padding()*float32(len(shown))
+	for _, column := range shown {
+		width -= column.width
+	}
+	l.table.SetColumnWidth(keyColumnName, max(width, keyNameMinWidth))
+	for _, o := range objects {
+		o.Move(fyne.NewPos(0, 0))
+		o.Resize(size)
+	}
+}
+
+func (l *keyTableLayout) MinSize(objects []fyne.CanvasObject) fyne.Size {
+	return l.table.MinSize()
+}
+
+// columnCount is how many of keyColumns the table shows: all of them on a
+// cluster, else those before the slot column
+func (kb *KeyBrowser) columnCount() int {
+	if kb.client != nil && kb.client.IsCluster() {
+		return len(keyColumns)
+	}
+	return keyColumnSlot
+}
+
+// setSort restores the key sort order saved for the connection
+func (kb *KeyBrowser) setSort(conn models.ServerConnection) {
+	kb.sortColumn, kb.sortDesc = conn.KeySort, conn.KeySortDesc
+	if kb.sortColumn == "" {
+		kb.sortColumn = keyColumns[keyColumnName].name
+	}
+	kb.keyTable.Refresh()
+}
+
+// sortBy sorts the key table by a column, reversing the order if it is already
+// sorted by it, and saves the order for the connection. Sorting by size turns
+// on MEMORY USAGE measuring.
+func (kb *KeyBrowser) sortBy(column string) {
+	if column == kb.sortColumn {
+		kb.sortDesc = !kb.sortDesc
+	} else {
+		kb.sortColumn, kb.sortDesc = column, false
+	}
+	if kb.client != nil {
+		config.SetConnectionKeySort(kb.client.Connection().ID, kb.sortColumn, kb.sortDesc)
+	}
+	if column == keyColumns[keyColumnSize].name && !kb.memoryCheck.Checked {
+		kb.memoryCheck.SetChecked(true) // measures the keys, which re-sorts them
+	}
+	kb.filterKeys()
+}
+
+// sortKeys orders keys by the sort column, breaking ties by name. Keys without
+// an expiry sort after expiring ones and unmeasured keys after measured ones,
+// whichever the direction.
+func (kb *KeyBrowser) sortKeys(keys []models.RedisKey) {
+	compare := func(a, b models.RedisKey) (int, bool) {
+		switch kb.sortColumn {
+		case keyColumns[keyColumnType].name:
+			return strings.Compare(a.Type, b.Type), true
+		case keyColumns[keyColumnTTL].name:
+			if (a.TTL < 0) != (b.TTL < 0) {
+				return 0, false
+			}
+			return cmp.Compare(a.TTL, b.TTL), true
+		case keyColumns[keyColumnSize].name:
+			am, aok := kb.memory[a.Key]
+			bm, bok := kb.memory[b.Key]
+			if aok != bok {
+				return 0, false
+			}
+			return cmp.Compare(am, bm), true
+		case keyColumns[keyColumnSlot].name:
+			return cmp.Compare(redis.KeySlot(a.Key), redis.KeySlot(b.Key)), true
+		case keyColumns[keyColumnNode].name:
+			return strings.Compare(kb.client.KeyNode(a.Key), kb.client.KeyNode(b.Key)), true
+		}
+		return strings.Compare(a.Key, b.Key), true
+	}
+	// Only called when exactly one of two keys has a value to sort by
+	hasValue := func(k models.RedisKey) bool {
+		if kb.sortColumn == keyColumns[keyColumnTTL].name {
+			return k.TTL >= 0
+		}
+		_, ok := kb.memory[k.Key]
+		return ok
+	}
+
+	sort.SliceStable(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		c, comparable := compare(a, b)
+		if !comparable {
+			return hasValue(a)
+		}
+		if kb.sortDesc {
+			c = -c
+		}
+		if c != 0 {
+			return c < 0
+		}
+		return a.Key < b.Key
+	})
+}
@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/models"
+)
+
+// TreeNode represents a node in the key tree. Its ID is the key path, so a name
+// that is both a key and a prefix of other keys is a single node.
+type TreeNode struct {
+	ID       string
+	Name     string
+	FullKey  string
+	IsKey    bool
+	KeyType  string
+	IsBranch bool // other keys are nested below it
+	Count    int  // keys at or below the node
+}
+
+// keyTreeIndex answers the tree view's queries from the keys sorted by name.
+// The keys under a prefix are a contiguous run of the sorted slice, so a
+// folder's children are only worked out when it is first shown and are then
+// cached, instead of building every node up front.
+type keyTreeIndex struct {
+	keys      []models.RedisKey // sorted by name
+	delimiter string
+	children  map[string][]widget.TreeNodeID // child IDs by folder ID, "" for the root
+	nodes     map[string]*TreeNode
+	memory    map[string]folderMemory // by folder ID, summed when the folder is shown
+}
+
+// folderMemory is the MEMORY USAGE summed over the measured keys under a folder
+type folderMemory struct {
+	bytes    int64
+	measured int // keys with a measurement
+}
+
+// newKeyTreeIndex sorts a copy of keys; for large key sets call it off the UI
+// thread, the index is cheap to query afterwards
+func newKeyTreeIndex(keys []models.RedisKey, delimiter string) *keyTreeIndex {
+	sorted := make([]models.RedisKey, len(keys))
+	copy(sorted, keys)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	return &keyTreeIndex{
+		keys:      sorted,
+		delimiter: delimiter,
+		children:  make(map[string][]widget.TreeNodeID),
+		nodes:     make(map[string]*TreeNode),
+		memory:    make(map[string]folderMemory),
+	}
+}
+
+// folderMemory sums the measured memory of the keys at or below a folder. Sums are
+// cached until resetMemory, so only the folders on screen are ever added up.
+func (t *keyTreeIndex) folderMemory(node *TreeNode, usage map[string]int64) folderMemory {
+	id := node.ID
+	if total, ok := t.memory[id]; ok {
+		return total
+	}
+	var total folderMemory
+	if mem, ok := usage[node.FullKey]; ok && node.IsKey {
+		total.bytes += mem
+		total.measured++
+	}
+	lo, hi := t.prefixRange(id + t.delimiter)
+	for _, key := range t.keys[lo:hi] {
+		if mem, ok := usage[key.Key]; ok {
+			total.bytes += mem
+			total.measured++
+		}
+	}
+	t.memory[id] = total
+	return total
+}
+
+// resetMemory drops the cached folder sums after the measurements change
+func (t *keyTreeIndex) resetMemory() {
+	if t != nil {
+		clear(t.memory)
+	}
+}
+
+// childIDs returns the sorted child IDs of a folder, or of the root for ""
+func (t *keyTreeIndex) childIDs(id string) []widget.TreeNodeID {
+	if t == nil {
+		return nil
+	}
+	if ids, ok := t.children[id]; ok {
+		return ids
+	}
+
+	prefix := ""
+	if id != "" {
+		prefix = id + t.delimiter
+	}
+	lo, hi := t.prefixRange(prefix)
+
+	var ids []widget.TreeNodeID
+	for i := lo; i < hi; {
+		key := t.keys[i]
+		rest := key.Key[len(prefix):]
+		name, branch := rest, false
+		if t.delimiter != "" {
+			name, _, branch = strings.Cut(rest, t.delimiter)
+		}
+		childID := prefix + name
+
+		node, ok := t.nodes[childID]
+		if !ok {
+			node = &TreeNode{ID: childID, Name: name}
+			t.nodes[childID] = node
+			ids = append(ids, childID)
+		}
+		if branch {
+			// Skip the child's own subtree; it is indexed when the child is expanded
+			_, end := t.prefixRange(childID + t.delimiter)
+			node.IsBranch = true
+			node.Count += end - i
+			i = end
+			continue
+		}
+		node.IsKey = true
+		node.FullKey = key.Key
+		node.KeyType = key.Type
+		node.Count++
+		i++
+	}
+
+	sort.Strings(ids)
+	t.children[id] = ids
+	return ids
+}
+
+// node looks up a node by ID, indexing its parent folder if it hasn't been shown yet
+func (t *keyTreeIndex) node(id string) (*TreeNode, bool) {
+	if t == nil || id == "" {
+		return nil, false
+	}
+	if node, ok := t.nodes[id]; ok {
+		return node, true
+	}
+	parent := ""
+	if t.delimiter != "" {
+		if i := strings.LastIndex(id, t.delimiter); i >= 0 {
+			parent = id[:i]
+		}
+	}
+	t.childIDs(parent)
+	node, ok := t.nodes[id]
+	return node, ok
+}
+
+// prefixRange returns the run of sorted keys starting with prefix
+func (t *keyTreeIndex) prefixRange(prefix string) (lo, hi int) {
+	lo = sort.Search(len(t.keys), func(i int) bool { return t.keys[i].Key >= prefix })
+	hi = lo + sort.Search(len(t.keys)-lo, func(i int) bool {
+		return !strings.HasPrefix(t.keys[lo+i].Key, prefix)
+	})
+	return lo, hi
+}
@@ -0,0 +1,205 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// largeStringBytes is the length above which a string opens in the chunked
+	// viewer instead of being read whole into the editor
+	largeStringBytes = 1 << 20
+	// largeStringChunk is how many bytes of a large string the viewer reads at a time
+	largeStringChunk = 64 << 10
+)
+
+// buildLargeStringEditor shows a string too large to edit whole one chunk at a
+// time, read with GETRANGE. A chunk is written back with SETRANGE, and text is
+// added to the end with APPEND, so neither rewrites the whole value.
+func (ve *ValueEditor) buildLargeStringEditor(key models.RedisKey, length int64) fyne.CanvasObject {
+	var offset int64
+	var chunk string
+
+	rangeLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	entry := widget.NewMultiLineEntry()
+	entry.TextStyle = valueTextStyle()
+	entry.Wrapping = valueWrapping()
+	binaryLabel := widget.NewLabelWithStyle(i18n.T("This chunk isn't UTF-8 text, so it can be viewed but not edited here"),
+		fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+	binaryLabel.Importance = widget.WarningImportance
+	binaryLabel.Hide()
+
+	saveBtn := widget.NewButtonWithIcon(i18n.T("Save Chunk"), theme.DocumentSaveIcon(), nil)
+	var buttons []*widget.Button
+
+	// load reads the chunk starting at from, moved to the nearest character
+	// boundaries so multi-byte characters aren't split between chunks
+	load := func(from int64) {
+		client := ve.client
+		current := ve.currentKey
+		var data []byte
+		var newLength int64
+		ve.worker.Go(func(ctx context.Context) (err error) {
+			c := client.WithContext(ctx)
+			if newLength, err = c.StringLength(key.Key); err != nil {
+				return err
+			}
+			from = min(max(from, 0), max(newLength-1, 0))
+			data, err = c.GetBytes(key.Key, from, from+largeStringChunk-1)
+			return err
+		}, func(err error) {
+			if ve.currentKey != current {
+				return
+			}
+			if err != nil {
+				ShowErrorDialog(ve.window, "Load Error", err)
+				return
+			}
+			length = newLength
+			start, end := runeBounds(data, from+int64(len(data)) >= length)
+			offset, chunk = from+int64(start), string(data[start:end])
+			rangeLabel.SetText(i18n.T("Bytes %d-%d of %d (%s)", offset, offset+int64(len(chunk)), length, formatBytes(length)))
+			entry.SetText(chunk)
+			if utf8.ValidString(chunk) {
+				binaryLabel.Hide()
+				setWritable(ve.client, saveBtn)
+			} else {
+				binaryLabel.Show()
+				saveBtn.Disable()
+			}
+			for _, btn := range buttons {
+				btn.Enable()
+			}
+		})
+	}
+
+	firstBtn := widget.NewButtonWithIcon("", theme.MediaSkipPreviousIcon(), func() { load(0) })
+	prevBtn := widget.NewButtonWithIcon(i18n.T("Previous"), theme.NavigateBackIcon(), func() {
+		load(offset - largeStringChunk)
+	})
+	nextBtn := widget.NewButtonWithIcon(i18n.T("Next"), theme.NavigateNextIcon(), func() {
+		load(offset + int64(len(chunk)))
+	})
+	lastBtn := widget.NewButtonWithIcon("", theme.MediaSkipNextIcon(), func() {
+		load(length - largeStringChunk)
+	})
+	offsetEntry := widget.NewEntry()
+	offsetEntry.SetPlaceHolder(i18n.T("Byte offset"))
+	goBtn := widget.NewButton(i18n.T("Go"), func() {
+		from, err := strconv.ParseInt(strings.TrimSpace(offsetEntry.Text), 10, 64)
+		if err != nil || from < 0 {
+			ShowErrorDialog(ve.window, "Invalid Offset", fmt.Errorf("the offset must be a whole number of bytes from the start"))
+			return
+		}
+		load(from)
+	})
+	offsetEntry.OnSubmitted = func(string) { goBtn.OnTapped() }
+	buttons = []*widget.Button{firstBtn, prevBtn, nextBtn, lastBtn}
+	for _, btn := range buttons {
+		btn.Disable()
+	}
+
+	// write changes the string through op and reads the chunk again
+	write := func(op func(c *redis.Client) error) {
+		if refuseReadOnly(ve.window, ve.client) {
+			return
+		}
+		client := ve.client
+		var snapshots []models.KeySnapshot
+		ve.worker.Do(ve.window, client, func(c *redis.Client) error {
+			snapshots = snapshotKeys(c, []string{key.Key})
+			return op(c)
+		}, func() {
+			ve.undo.push(client, i18n.T("Edited '%s'", key.Key), snapshots, true)
+			load(offset)
+			if ve.onKeyUpdated != nil {
+				ve.onKeyUpdated()
+			}
+		})
+	}
+
+	saveBtn.OnTapped = func() {
+		text, at := entry.Text, offset
+		setRange := func() {
+			write(func(c *redis.Client) error {
+				_, err := c.SetStringRange(key.Key, at, text)
+				return err
+			})
+		}
+		if len(text) == len(chunk) {
+			setRange()
+			return
+		}
+		ShowConfirmDialog(ve.window, "Save Chunk",
+			i18n.T("SETRANGE overwrites %d bytes from offset %d, but the chunk was %d bytes. The bytes after it stay where they are rather than moving, so the value won't read as if the text was inserted or removed.\n\nWrite it anyway?", len(text), at, len(chunk)),
+			setRange)
+	}
+
+	appendEntry := widget.NewEntry()
+	appendEntry.SetPlaceHolder(i18n.T("Text to append"))
+	appendBtn := widget.NewButtonWithIcon(i18n.T("Append"), theme.ContentAddIcon(), func() {
+		text := appendEntry.Text
+		if text == "" {
+			return
+		}
+		write(func(c *redis.Client) error {
+			_, err := c.AppendString(key.Key, text)
+			return err
+		})
+		appendEntry.SetText("")
+	})
+	saveBtn.Disable()
+	setWritable(ve.client, appendBtn)
+
+	hint := widget.NewLabelWithStyle(
+		i18n.T("This value is too large to load at once; it is shown %s at a time", formatBytes(largeStringChunk)),
+		fyne.TextAlignCenter, fyne.TextStyle{Italic: true})
+
+	nav := container.NewBorder(nil, nil,
+		container.NewHBox(firstBtn, prevBtn, nextBtn, lastBtn),
+		goBtn, offsetEntry)
+	top := container.NewVBox(hint, rangeLabel, nav)
+	bottom := container.NewVBox(
+		binaryLabel,
+		saveBtn,
+		container.NewBorder(nil, nil, nil, appendBtn, appendEntry),
+	)
+	load(0)
+	return container.NewBorder(top, bottom, nil, nil, entry)
+}
+
+// runeBounds trims a chunk read from the middle of a string to whole UTF-8
+// characters: continuation bytes at the start belong to the previous chunk,
+// and unless last is set, a character cut off at the end to the next one. A
+// chunk that isn't text is left as it is.
+func runeBounds(data []byte, last bool) (start, end int) {
+	end = len(data)
+	for start < len(data) && start < utf8.UTFMax-1 && !utf8.RuneStart(data[start]) {
+		start++
+	}
+	if !last {
+		for i := end - 1; i >= start && i >= end-utf8.UTFMax; i-- {
+			if utf8.RuneStart(data[i]) {
+				if !utf8.FullRune(data[i:end]) {
+					end = i
+				}
+				break
+			}
+		}
+	}
+	if start >= end || !utf8.Valid(data[start:end]) {
+		return 0, len(data)
+	}
+	return start, end
+}
@@ -0,0 +1,324 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// pingInterval is how often the latency panel pings the server while shown
+	pingInterval = time.Second
+	// pingSamples is how many round trips the PING chart keeps
+	pingSamples = 120
+)
+
+// LatencyPanel helps tell server slowness from network slowness: it charts
+// the app's own PING round trips next to the spikes the server's latency
+// monitor recorded (LATENCY LATEST and HISTORY) and its LATENCY DOCTOR report
+type LatencyPanel struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	worker    *Worker
+	window    fyne.Window
+
+	pingLabel      *widget.Label
+	pingChart      *sparkline
+	pings          []time.Duration
+	pinging        bool
+	stopPing       chan struct{}
+	thresholdLabel *widget.Label
+	eventsTable    *widget.Table
+	events         []models.LatencyEvent
+	historyLabel   *widget.Label
+	historyChart   *sparkline
+	doctorLabel    *widget.Label
+}
+
+// NewLatencyPanel creates a new latency panel
+func NewLatencyPanel(window fyne.Window, worker *Worker) *LatencyPanel {
+	p := &LatencyPanel{
+		window: window,
+		worker: worker,
+	}
+	p.ExtendBaseWidget(p)
+	p.buildUI()
+	return p
+}
+
+func (p *LatencyPanel) buildUI() {
+	p.pingLabel = widget.NewLabel(i18n.T("PING round trip"))
+	p.pingChart = newSparkline()
+
+	p.thresholdLabel = widget.NewLabel("")
+	p.thresholdLabel.Wrapping = fyne.TextWrapWord
+
+	p.eventsTable = widget.NewTable(
+		func() (int, int) { return len(p.events) + 1, 4 },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(i18n.T([]string{"Event", "Latest Spike", "Latest", "Max"}[id.Col]))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			e := p.events[id.Row-1]
+			label.TextStyle = fyne.TextStyle{}
+			switch id.Col {
+			case 0:
+				label.SetText(e.Name)
+			case 1:
+				label.SetText(e.Time.Format(time.DateTime))
+			case 2:
+				label.SetText(e.Latest.String())
+			case 3:
+				label.SetText(e.Max.String())
+			}
+		},
+	)
+	p.eventsTable.SetColumnWidth(0, 180)
+	p.eventsTable.SetColumnWidth(1, 170)
+	p.eventsTable.OnSelected = func(id widget.TableCellID) {
+		if id.Row > 0 && id.Row <= len(p.events) {
+			p.showHistory(p.events[id.Row-1].Name)
+		}
+	}
+
+	p.historyLabel = widget.NewLabel(i18n.T("Select an event to chart its history"))
+	p.historyChart = newSparkline()
+
+	p.doctorLabel = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	p.doctorLabel.Wrapping = fyne.TextWrapWord
+
+	refreshBtn := widget.NewButtonWithIcon(i18n.T("Refresh Events"), theme.ViewRefreshIcon(), p.refreshEvents)
+	doctorBtn := widget.NewButtonWithIcon(i18n.T("Run Doctor"), theme.HelpIcon(), p.runDoctor)
+
+	hint := widget.NewLabelWithStyle(i18n.T("High PING times while the server records no spikes point to the network; spikes recorded by the server point to the server itself."),
+		fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	hint.Wrapping = fyne.TextWrapWord
+
+	top := container.NewVBox(
+		widget.NewLabelWithStyle(i18n.T("App to Server"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		p.pingLabel,
+		p.pingChart,
+		hint,
+		widget.NewSeparator(),
+		container.NewBorder(nil, nil,
+			widget.NewLabelWithStyle(i18n.T("Server Latency Monitor"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			refreshBtn),
+		p.thresholdLabel,
+	)
+	history := container.NewBorder(p.historyLabel, nil, nil, nil, p.historyChart)
+	doctor := container.NewBorder(container.NewHBox(doctorBtn), nil, nil, nil, container.NewVScroll(p.doctorLabel))
+
+	split := container.NewVSplit(container.NewBorder(nil, history, nil, nil, p.eventsTable), doctor)
+	split.SetOffset(0.6)
+	p.container = container.NewBorder(top, nil, nil, nil, split)
+}
+
+// CreateRenderer implements fyne.Widget
+func (p *LatencyPanel) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.container)
+}
+
+// SetClient sets the Redis client
+func (p *LatencyPanel) SetClient(client *redis.Client) {
+	p.client = client
+}
+
+// Start pings the server every pingInterval and reads the latency monitor,
+// while the panel is shown
+func (p *LatencyPanel) Start() {
+	p.Stop()
+	if p.client == nil {
+		return
+	}
+	p.refreshEvents()
+
+	stop := make(chan struct{})
+	p.stopPing = stop
+	go func() {
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(p.ping)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops pinging the server; the PING chart is kept
+func (p *LatencyPanel) Stop() {
+	if p.stopPing != nil {
+		close(p.stopPing)
+		p.stopPing = nil
+	}
+}
+
+// ping measures one round trip, skipping a tick while the last is in flight
+func (p *LatencyPanel) ping() {
+	if p.stopPing == nil || p.client == nil || p.pinging {
+		return
+	}
+	p.pinging = true
+	client := p.client
+	var rtt time.Duration
+	p.worker.Go(func(ctx context.Context) (err error) {
+		rtt, err = client.WithContext(ctx).PingLatency()
+		return err
+	}, func(err error) {
+		p.pinging = false
+		if p.client != client {
+			return
+		}
+		if err != nil {
+			p.pingLabel.SetText(i18n.T("PING failed: %s", err))
+			return
+		}
+		p.pings = append(p.pings, rtt)
+		if len(p.pings) > pingSamples {
+			p.pings = p.pings[len(p.pings)-pingSamples:]
+		}
+		p.showPings()
+	})
+}
+
+// showPings redraws the PING chart and its last, average and worst round trip
+func (p *LatencyPanel) showPings() {
+	if len(p.pings) == 0 {
+		p.pingLabel.SetText(i18n.T("PING round trip"))
+		p.pingChart.SetValues(nil)
+		return
+	}
+	values := make([]float64, len(p.pings))
+	var total, worst time.Duration
+	for i, rtt := range p.pings {
+		values[i] = float64(rtt.Microseconds()) / 1000
+		total += rtt
+		worst = max(worst, rtt)
+	}
+	avg := total / time.Duration(len(p.pings))
+	p.pingLabel.SetText(i18n.T("PING round trip: %s (average %s, worst %s over %d pings)",
+		formatLatency(p.pings[len(p.pings)-1]), formatLatency(avg), formatLatency(worst), len(p.pings)))
+	p.pingChart.SetValues(values)
+}
+
+// refreshEvents reads the latency monitor threshold and its latest events
+func (p *LatencyPanel) refreshEvents() {
+	if p.client == nil {
+		return
+	}
+	client := p.client
+	var events []models.LatencyEvent
+	var threshold time.Duration
+	var thresholdErr error
+	p.worker.Go(func(ctx context.Context) (err error) {
+		c := client.WithContext(ctx)
+		// CONFIG may be denied by ACLs or renamed; the events can still be read
+		threshold, thresholdErr = c.LatencyThreshold()
+		events, err = c.LatencyLatest()
+		return err
+	}, func(err error) {
+		if p.client != client {
+			return
+		}
+		switch {
+		case thresholdErr != nil:
+			p.thresholdLabel.SetText(i18n.T("Latency monitor threshold unknown: %s", thresholdErr))
+		case threshold == 0:
+			p.thresholdLabel.SetText(i18n.T("The latency monitor is off. Set latency-monitor-threshold (e.g. CONFIG SET latency-monitor-threshold 100) to record spikes."))
+		default:
+			p.thresholdLabel.SetText(i18n.T("Recording events that take %s or longer.", threshold))
+		}
+		if err != nil {
+			p.events = nil
+			p.eventsTable.Refresh()
+			ShowErrorDialog(p.window, "Latency Error", err)
+			return
+		}
+		p.events = events
+		p.eventsTable.UnselectAll()
+		p.eventsTable.Refresh()
+		if len(events) == 0 {
+			p.historyLabel.SetText(i18n.T("No latency spikes recorded"))
+		} else {
+			p.historyLabel.SetText(i18n.T("Select an event to chart its history"))
+		}
+		p.historyChart.SetValues(nil)
+	})
+}
+
+// showHistory charts the recorded spikes of an event
+func (p *LatencyPanel) showHistory(event string) {
+	client := p.client
+	var samples []models.LatencySample
+	p.worker.Do(p.window, client, func(c *redis.Client) (err error) {
+		samples, err = c.LatencyHistory(event)
+		return err
+	}, func() {
+		if p.client != client {
+			return
+		}
+		values := make([]float64, len(samples))
+		var worst time.Duration
+		for i, s := range samples {
+			values[i] = float64(s.Latency.Milliseconds())
+			worst = max(worst, s.Latency)
+		}
+		text := i18n.T("'%s': %d spikes", event, len(samples))
+		if len(samples) > 0 {
+			text += i18n.T(" since %s, worst %s", samples[0].Time.Format(time.DateTime), worst)
+		}
+		p.historyLabel.SetText(text)
+		p.historyChart.SetValues(values)
+	})
+}
+
+// runDoctor shows the LATENCY DOCTOR report
+func (p *LatencyPanel) runDoctor() {
+	client := p.client
+	var report string
+	p.worker.Do(p.window, client, func(c *redis.Client) (err error) {
+		report, err = c.LatencyDoctor()
+		return err
+	}, func() {
+		if p.client == client {
+			p.doctorLabel.SetText(report)
+		}
+	})
+}
+
+// Clear stops pinging and removes everything shown
+func (p *LatencyPanel) Clear() {
+	p.Stop()
+	p.pinging = false
+	p.pings = nil
+	p.showPings()
+	p.events = nil
+	p.eventsTable.Refresh()
+	p.thresholdLabel.SetText("")
+	p.historyLabel.SetText(i18n.T("Select an event to chart its history"))
+	p.historyChart.SetValues(nil)
+	p.doctorLabel.SetText("")
+}
+
+// formatLatency renders a round trip in milliseconds with sub-millisecond precision
+func formatLatency(d time.Duration) string {
+	return fmt.Sprintf("%.2f ms", float64(d.Microseconds())/1000)
+}
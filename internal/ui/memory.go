@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// analyzeMemory sums MEMORY USAGE by key prefix in the background and shows the report
+func analyzeMemory(window fyne.Window, worker *Worker, client *redis.Client, pattern, delimiter string, depth int) {
+	var report *models.MemoryReport
+	var closeProgress func()
+	cancel := worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		report, err = client.WithContext(ctx).AnalyzeMemory(pattern, delimiter, depth)
+		return err
+	}, func(err error) {
+		closeProgress()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Memory Analysis", err)
+			return
+		}
+		ShowMemoryReportDialog(window, report)
+	})
+	closeProgress = ShowCancellableProgress(window, "Memory Analysis", i18n.T("Measuring keys matching '%s'...", pattern), cancel)
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"time"
+
+	"redis-explorer/internal/models"
+)
+
+// metricsHistoryLen is how many samples of server metrics are kept per connection
+const metricsHistoryLen = 120
+
+// metricSample is one INFO poll reduced to the charted metrics
+type metricSample struct {
+	at         time.Time
+	opsPerSec  float64
+	usedMemory float64
+	clients    float64
+	hitRate    float64 // percent of lookups that hit since the previous sample
+}
+
+// metricsHistory is a rolling window of samples for one connection
+type metricsHistory struct {
+	samples []metricSample
+	last    *models.ServerInfo
+}
+
+// add records a sample from info. The hit rate is taken over the interval since
+// the last sample, falling back to the server's lifetime counters for the first.
+func (h *metricsHistory) add(info *models.ServerInfo) metricSample {
+	hits, misses := info.KeyspaceHits, info.KeyspaceMisses
+	if h.last != nil && hits >= h.last.KeyspaceHits && misses >= h.last.KeyspaceMisses {
+		hits -= h.last.KeyspaceHits
+		misses -= h.last.KeyspaceMisses
+	}
+	sample := metricSample{
+		at:         time.Now(),
+		opsPerSec:  float64(info.OpsPerSec),
+		usedMemory: float64(info.UsedMemory),
+		clients:    float64(info.ConnectedClients),
+	}
+	if hits+misses > 0 {
+		sample.hitRate = float64(hits) / float64(hits+misses) * 100
+	} else if len(h.samples) > 0 {
+		// No lookups in the interval; carry the previous rate forward
+		sample.hitRate = h.samples[len(h.samples)-1].hitRate
+	}
+
+	h.last = info
+	h.samples = append(h.samples, sample)
+	if len(h.samples) > metricsHistoryLen {
+		h.samples = h.samples[len(h.samples)-metricsHistoryLen:]
+	}
+	return sample
+}
+
+// series returns one metric of every sample, oldest first
+func (h *metricsHistory) series(metric func(metricSample) float64) []float64 {
+	values := make([]float64, len(h.samples))
+	for i, s := range h.samples {
+		values[i] = metric(s)
+	}
+	return values
+}
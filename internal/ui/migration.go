@@ -0,0 +1,268 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// migrationReportFailures caps how many failed keys a migration report lists
+const migrationReportFailures = 500
+
+// ShowMigrationWizard walks through copying the keys matching pattern from the
+// client's database to another database or connection: the keys, the
+// destination, how existing keys, TTLs and load are handled, and a review
+// before the copy starts as a background job
+func ShowMigrationWizard(window fyne.Window, worker *Worker, client *redis.Client, pattern string) {
+	source := client.Connection()
+	connections := config.Get().Connections
+
+	// Keys
+	patternEntry := widget.NewEntry()
+	patternEntry.SetText(pattern)
+	patternEntry.SetPlaceHolder("*")
+	keysStep := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: i18n.T("From"), Widget: widget.NewLabel(i18n.T("%s, DB %d", source.Name, source.Database))},
+			{Text: i18n.T("Pattern"), Widget: patternEntry, HintText: i18n.T("Keys to copy, e.g. user:* (empty for every key)")},
+		},
+	}
+
+	// Destination
+	names := make([]string, len(connections))
+	for i, conn := range connections {
+		names[i] = conn.Name
+	}
+	connSelect := widget.NewSelect(names, nil)
+	for i, conn := range connections {
+		if conn.ID == source.ID {
+			connSelect.SetSelectedIndex(i)
+		}
+	}
+	if connSelect.SelectedIndex() < 0 && len(names) > 0 {
+		connSelect.SetSelectedIndex(0)
+	}
+	dbEntry := widget.NewEntry()
+	dbEntry.SetText(strconv.Itoa(source.Database + 1))
+	destStep := widget.NewForm(
+		widget.NewFormItem(i18n.T("Connection"), connSelect),
+		widget.NewFormItem(i18n.T("Database"), dbEntry),
+	)
+
+	// Options
+	policyRadio := widget.NewRadioGroup([]string{i18n.T("Skip keys that already exist"), i18n.T("Overwrite keys that already exist")}, nil)
+	policyRadio.SetSelected(policyRadio.Options[0])
+	ttlCheck := widget.NewCheck(i18n.T("Keep TTLs"), nil)
+	ttlCheck.SetChecked(true)
+	methodRadio := widget.NewRadioGroup([]string{i18n.T("DUMP/RESTORE through this app"), i18n.T("MIGRATE from server to server")}, nil)
+	methodRadio.SetSelected(methodRadio.Options[0])
+	batchEntry := widget.NewEntry()
+	batchEntry.SetText("100")
+	pauseEntry := widget.NewEntry()
+	pauseEntry.SetText("0")
+	optionsStep := &widget.Form{
+		Items: []*widget.FormItem{
+			{Text: i18n.T("Existing Keys"), Widget: policyRadio},
+			{Text: "", Widget: ttlCheck, HintText: i18n.T("Otherwise the copies don't expire")},
+			{Text: i18n.T("Method"), Widget: methodRadio, HintText: i18n.T("MIGRATE is faster, but the source server must reach the destination's host and port itself")},
+			{Text: i18n.T("Batch Size"), Widget: batchEntry, HintText: i18n.T("Keys copied per round trip (1-10000)")},
+			{Text: i18n.T("Pause (ms)"), Widget: pauseEntry, HintText: i18n.T("Wait between batches to go easy on busy servers (0-60000)")},
+		},
+	}
+
+	// Review
+	reviewLabel := widget.NewLabel("")
+	reviewLabel.Wrapping = fyne.TextWrapWord
+
+	// request reads the steps into a migration, or says what is wrong
+	request := func() (models.MigrationRequest, error) {
+		req := models.MigrationRequest{
+			Pattern:    strings.TrimSpace(patternEntry.Text),
+			Policy:     models.ImportSkip,
+			KeepTTL:    ttlCheck.Checked,
+			UseMigrate: methodRadio.Selected == methodRadio.Options[1],
+		}
+		if req.Pattern == "" {
+			req.Pattern = "*"
+		}
+		if policyRadio.Selected == policyRadio.Options[1] {
+			req.Policy = models.ImportOverwrite
+		}
+		index := connSelect.SelectedIndex()
+		if index < 0 {
+			return req, fmt.Errorf("choose a destination connection")
+		}
+		req.Target = connections[index]
+		if req.Target.ID == source.ID {
+			// Use the settings the active connection was opened with
+			req.Target = source
+		}
+		db, err := strconv.Atoi(dbEntry.Text)
+		if err != nil || db < 0 {
+			return req, fmt.Errorf("database must be a non-negative number")
+		}
+		req.Target.Database = db
+		if req.Target.ID == source.ID && db == source.Database {
+			return req, fmt.Errorf("the destination is the database the keys are copied from")
+		}
+		if req.BatchSize, err = strconv.Atoi(batchEntry.Text); err != nil || req.BatchSize < 1 || req.BatchSize > 10000 {
+			return req, fmt.Errorf("batch size must be between 1 and 10000")
+		}
+		pause, err := strconv.Atoi(pauseEntry.Text)
+		if err != nil || pause < 0 || pause > 60000 {
+			return req, fmt.Errorf("pause must be between 0 and 60000 milliseconds")
+		}
+		req.Pause = time.Duration(pause) * time.Millisecond
+		return req, nil
+	}
+
+	steps := []struct {
+		title   string
+		content fyne.CanvasObject
+	}{
+		{i18n.T("Keys"), keysStep},
+		{i18n.T("Destination"), destStep},
+		{i18n.T("Options"), optionsStep},
+		{i18n.T("Review"), reviewLabel},
+	}
+
+	stepLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	body := container.NewStack()
+	var d *dialog.CustomDialog
+	var backBtn, nextBtn *widget.Button
+	step := 0
+	show := func(i int) {
+		step = i
+		stepLabel.SetText(i18n.T("Step %d of %d: %s", i+1, len(steps), steps[i].title))
+		body.Objects = []fyne.CanvasObject{steps[i].content}
+		body.Refresh()
+		if i == 0 {
+			backBtn.Disable()
+		} else {
+			backBtn.Enable()
+		}
+		if i == len(steps)-1 {
+			nextBtn.SetText(i18n.T("Start"))
+		} else {
+			nextBtn.SetText(i18n.T("Next"))
+		}
+	}
+
+	backBtn = widget.NewButton(i18n.T("Back"), func() { show(step - 1) })
+	nextBtn = widget.NewButton(i18n.T("Next"), func() {
+		req, err := request()
+		// Each step is checked once its fields have been filled in
+		if err != nil && step > 0 {
+			dialog.ShowError(err, window)
+			return
+		}
+		switch step {
+		case len(steps) - 2:
+			describeMigration(reviewLabel, source, req)
+		case len(steps) - 1:
+			d.Hide()
+			runMigration(window, worker, client, req)
+			return
+		}
+		show(step + 1)
+	})
+	nextBtn.Importance = widget.HighImportance
+	cancelBtn := widget.NewButton(i18n.T("Cancel"), func() { d.Hide() })
+
+	content := container.NewBorder(
+		container.NewVBox(stepLabel, widget.NewSeparator()),
+		container.NewHBox(cancelBtn, layout.NewSpacer(), backBtn, nextBtn),
+		nil, nil, body)
+	d = dialog.NewCustomWithoutButtons(i18n.T("Migrate Keys"), content, window)
+	show(0)
+	d.Resize(fyne.NewSize(560, 480))
+	d.Show()
+}
+
+// describeMigration sums up a migration for the wizard's review step
+func describeMigration(label *widget.Label, source models.ServerConnection, req models.MigrationRequest) {
+	policy := i18n.T("Keys that already exist there are skipped.")
+	if req.Policy == models.ImportOverwrite {
+		policy = i18n.T("Keys that already exist there are overwritten.")
+	}
+	ttl := i18n.T("TTLs are kept.")
+	if !req.KeepTTL {
+		ttl = i18n.T("The copies don't expire.")
+	}
+	method := i18n.T("Values go through this app with DUMP and RESTORE")
+	if req.UseMigrate {
+		method = i18n.T("%s sends the values to %s:%d with MIGRATE", source.Name, req.Target.Host, req.Target.Port)
+	}
+	label.SetText(i18n.T("Copy the keys matching '%s' from %s, DB %d to %s, DB %d.", req.Pattern, source.Name, source.Database, req.Target.Name, req.Target.Database) +
+		"\n\n" + policy + " " + ttl + "\n" +
+		i18n.T("%s, %d keys at a time with %s between batches.", method, req.BatchSize, req.Pause) +
+		"\n\n" + i18n.T("The keys stay in the source database."))
+}
+
+// runMigration copies the keys as a background job and reports what it did
+func runMigration(window fyne.Window, worker *Worker, client *redis.Client, req models.MigrationRequest) {
+	var report *models.MigrationReport
+	title := i18n.T("Migrate '%s' to %s, DB %d", req.Pattern, req.Target.Name, req.Target.Database)
+	worker.Job(title, func(ctx context.Context, job *jobs.Job) error {
+		var err error
+		report, err = client.WithContext(ctx).Migrate(req, func(done, total int) {
+			job.Update(int64(done), int64(total))
+		})
+		return err
+	}, func(err error) {
+		switch {
+		case errors.Is(err, context.Canceled):
+			if report != nil {
+				ShowMigrationReport(window, report, i18n.T("The migration was cancelled."))
+			}
+		case err != nil && report == nil:
+			ShowErrorDialog(window, "Migration Error", err)
+		case err != nil:
+			ShowMigrationReport(window, report, i18n.T("The migration stopped: %v", err))
+		default:
+			ShowMigrationReport(window, report, "")
+		}
+	})
+}
+
+// ShowMigrationReport shows how many keys a migration copied and skipped, and
+// the keys that failed with why. note, if set, says why it ended early.
+func ShowMigrationReport(window fyne.Window, report *models.MigrationReport, note string) {
+	summary := i18n.T("Copied %d keys, skipped %d, %d failed.", report.Copied, report.Skipped, len(report.Failures))
+	if note != "" {
+		summary = note + "\n" + summary
+	}
+	content := container.NewVBox(widget.NewLabel(summary))
+	if len(report.Failures) == 0 {
+		dialog.ShowCustom(i18n.T("Migration Report"), i18n.T("Close"), content, window)
+		return
+	}
+
+	failures := report.Failures[:min(len(report.Failures), migrationReportFailures)]
+	list := widget.NewList(
+		func() int { return len(failures) },
+		func() fyne.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			o.(*widget.Label).SetText(failures[id].Key + ": " + failures[id].Error)
+		})
+	if len(failures) < len(report.Failures) {
+		content.Add(widget.NewLabel(i18n.T("The first %d failed keys:", len(failures))))
+	}
+	d := dialog.NewCustom(i18n.T("Migration Report"), i18n.T("Close"), container.NewBorder(content, nil, nil, nil, list), window)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+}
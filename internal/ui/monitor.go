@@ -0,0 +1,315 @@
+package ui
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// monitorBufferLimit caps how many commands the monitor keeps in memory
+	monitorBufferLimit = 10000
+	// monitorFlushInterval is how often newly received commands are shown
+	monitorFlushInterval = 250 * time.Millisecond
+)
+
+// Monitor is a panel that streams the commands the server executes via MONITOR
+type Monitor struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	window    fyne.Window
+
+	startBtn    *widget.Button
+	pauseCheck  *widget.Check
+	filterEntry *widget.Entry
+	statusLabel *widget.Label
+	table       *widget.Table
+
+	entries  []models.MonitorEntry
+	filtered []models.MonitorEntry
+	dropped  int
+	stop     context.CancelFunc
+
+	mu             sync.Mutex
+	pending        []models.MonitorEntry // received but not yet shown, guarded by mu
+	pendingDropped int                   // guarded by mu
+}
+
+// NewMonitor creates a new monitor panel
+func NewMonitor(window fyne.Window) *Monitor {
+	m := &Monitor{
+		window: window,
+	}
+	m.ExtendBaseWidget(m)
+	m.buildUI()
+	return m
+}
+
+func (m *Monitor) buildUI() {
+	warning := widget.NewLabelWithStyle(
+		i18n.T("MONITOR echoes every command the server runs and can cut its throughput in half. Avoid it on busy production servers."),
+		fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	warning.Wrapping = fyne.TextWrapWord
+	warning.Importance = widget.WarningImportance
+
+	m.startBtn = widget.NewButtonWithIcon(i18n.T("Start"), theme.MediaPlayIcon(), func() {
+		if m.stop != nil {
+			m.Stop()
+			return
+		}
+		ShowConfirmDialog(m.window, "Start MONITOR",
+			"MONITOR streams every command the server executes and noticeably slows down busy servers.\n\nStart monitoring?",
+			m.start)
+	})
+	m.startBtn.Importance = widget.HighImportance
+
+	m.pauseCheck = widget.NewCheck(i18n.T("Pause"), func(paused bool) {
+		if !paused {
+			m.flush()
+		}
+	})
+
+	m.filterEntry = widget.NewEntry()
+	m.filterEntry.SetPlaceHolder(i18n.T("Filter by client, command or arguments..."))
+	m.filterEntry.OnChanged = func(string) {
+		m.applyFilter()
+	}
+
+	clearBtn := widget.NewButtonWithIcon(i18n.T("Clear"), theme.ContentClearIcon(), func() {
+		m.entries = nil
+		m.dropped = 0
+		m.applyFilter()
+	})
+
+	exportBtn := widget.NewButtonWithIcon(i18n.T("Export"), theme.DocumentSaveIcon(), func() {
+		m.export()
+	})
+
+	m.statusLabel = widget.NewLabel(i18n.T("Not monitoring"))
+
+	headers := []string{"Time", "Client", "DB", "Command", "Arguments"}
+	m.table = widget.NewTable(
+		func() (int, int) { return len(m.filtered) + 1, len(headers) },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(i18n.T(headers[id.Col]))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			label.TextStyle = fyne.TextStyle{}
+			entry := m.filtered[id.Row-1]
+			switch id.Col {
+			case 0:
+				label.SetText(entry.Time.Format("15:04:05.000"))
+			case 1:
+				label.SetText(entry.Client)
+			case 2:
+				label.SetText(strconv.Itoa(entry.DB))
+			case 3:
+				label.SetText(entry.Command)
+			case 4:
+				label.SetText(strings.Join(entry.Args, " "))
+			}
+		},
+	)
+	m.table.SetColumnWidth(0, 110)
+	m.table.SetColumnWidth(1, 160)
+	m.table.SetColumnWidth(2, 40)
+	m.table.SetColumnWidth(3, 120)
+	m.table.SetColumnWidth(4, 400)
+
+	toolbar := container.NewBorder(nil, nil,
+		container.NewHBox(m.startBtn, m.pauseCheck),
+		container.NewHBox(clearBtn, exportBtn),
+		m.filterEntry,
+	)
+
+	header := container.NewVBox(warning, toolbar, m.statusLabel)
+	m.container = container.NewBorder(header, nil, nil, nil, m.table)
+}
+
+// CreateRenderer implements fyne.Widget
+func (m *Monitor) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(m.container)
+}
+
+// SetClient sets the Redis client, stopping any running monitor
+func (m *Monitor) SetClient(client *redis.Client) {
+	m.Stop()
+	m.client = client
+}
+
+func (m *Monitor) start() {
+	if m.client == nil || m.stop != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.stop = cancel
+	m.startBtn.SetText(i18n.T("Stop"))
+	m.startBtn.SetIcon(theme.MediaStopIcon())
+	m.startBtn.Importance = widget.DangerImportance
+	m.startBtn.Refresh()
+	m.updateStatus()
+
+	client := m.client.WithContext(ctx)
+	go func() {
+		err := client.Monitor(func(entry models.MonitorEntry) {
+			m.mu.Lock()
+			// While paused the pending queue is capped like the buffer itself
+			if len(m.pending) >= monitorBufferLimit {
+				m.pending = m.pending[1:]
+				m.pendingDropped++
+			}
+			m.pending = append(m.pending, entry)
+			m.mu.Unlock()
+		})
+		fyne.Do(func() {
+			if ctx.Err() == nil {
+				m.Stop()
+			}
+			if err != nil && !errors.Is(err, context.Canceled) {
+				ShowErrorDialog(m.window, "Monitor Error", err)
+			}
+		})
+	}()
+
+	// Show received commands in batches rather than one refresh per command
+	go func() {
+		ticker := time.NewTicker(monitorFlushInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				fyne.Do(m.flush)
+			}
+		}
+	}()
+}
+
+// Stop stops monitoring; commands received so far are kept
+func (m *Monitor) Stop() {
+	if m.stop == nil {
+		return
+	}
+	m.stop()
+	m.stop = nil
+	m.flush()
+	m.startBtn.SetText(i18n.T("Start"))
+	m.startBtn.SetIcon(theme.MediaPlayIcon())
+	m.startBtn.Importance = widget.HighImportance
+	m.startBtn.Refresh()
+	m.updateStatus()
+}
+
+// flush moves received commands into the buffer and refreshes the table
+func (m *Monitor) flush() {
+	if m.pauseCheck.Checked {
+		return
+	}
+
+	m.mu.Lock()
+	pending := m.pending
+	m.dropped += m.pendingDropped
+	m.pending = nil
+	m.pendingDropped = 0
+	m.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	m.entries = append(m.entries, pending...)
+	if over := len(m.entries) - monitorBufferLimit; over > 0 {
+		m.entries = append([]models.MonitorEntry(nil), m.entries[over:]...)
+		m.dropped += over
+	}
+	m.applyFilter()
+	m.table.ScrollToBottom()
+}
+
+func (m *Monitor) applyFilter() {
+	filter := strings.ToLower(strings.TrimSpace(m.filterEntry.Text))
+	if filter == "" {
+		m.filtered = m.entries
+	} else {
+		m.filtered = nil
+		for _, entry := range m.entries {
+			text := strings.ToLower(entry.Client + " " + entry.Command + " " + strings.Join(entry.Args, " "))
+			if strings.Contains(text, filter) {
+				m.filtered = append(m.filtered, entry)
+			}
+		}
+	}
+	m.table.Refresh()
+	m.updateStatus()
+}
+
+func (m *Monitor) updateStatus() {
+	state := i18n.T("Not monitoring")
+	if m.stop != nil {
+		state = i18n.T("Monitoring")
+	}
+	status := i18n.T("%s - %d of %d commands shown", state, len(m.filtered), len(m.entries))
+	if m.dropped > 0 {
+		status += i18n.T(" (%d oldest dropped)", m.dropped)
+	}
+	m.statusLabel.SetText(status)
+}
+
+// export saves the filtered commands as CSV
+func (m *Monitor) export() {
+	if len(m.filtered) == 0 {
+		ShowInfoDialog(m.window, "Export", "There are no commands to export.")
+		return
+	}
+	entries := m.filtered
+
+	d := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			ShowErrorDialog(m.window, "Export Error", err)
+			return
+		}
+		if writer == nil {
+			return
+		}
+		defer writer.Close()
+
+		w := csv.NewWriter(writer)
+		w.Write([]string{"time", "client", "db", "command", "args"})
+		for _, entry := range entries {
+			w.Write([]string{
+				entry.Time.Format(time.RFC3339Nano),
+				entry.Client,
+				strconv.Itoa(entry.DB),
+				entry.Command,
+				strings.Join(entry.Args, " "),
+			})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			ShowErrorDialog(m.window, "Export Error", err)
+		}
+	}, m.window)
+	d.SetFileName("monitor.csv")
+	d.Show()
+}
@@ -0,0 +1,178 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// ShowNewKeyDialog asks for the name, type, initial value and optional TTL of a
+// key to create. Redis has no empty collections, so a list, set, hash or sorted
+// set needs at least one entry; blank entries are left out. value pre-fills the
+// string value, e.g. with the clipboard contents. The filled-in form can also
+// be saved as a key template.
+func ShowNewKeyDialog(window fyne.Window, value string, onCreate func(dump *models.KeyDump)) {
+	keyEntry := widget.NewEntry()
+	keyEntry.SetPlaceHolder(i18n.T("Key name"))
+
+	ttlEntry := widget.NewEntry()
+	ttlEntry.SetPlaceHolder(i18n.T("No expiry, or e.g. 90s, 2h, 7d"))
+
+	valueEntry := widget.NewMultiLineEntry()
+	valueEntry.SetPlaceHolder(i18n.T("Value (may be empty)"))
+	valueEntry.SetText(value)
+	itemsEntry := widget.NewMultiLineEntry()
+	fields := newPairRows(i18n.T("Field"), i18n.T("Value"), i18n.T("Add field"))
+	members := newPairRows(i18n.T("Member"), i18n.T("Score"), i18n.T("Add member"))
+
+	valueArea := container.NewStack()
+	valueItem := &widget.FormItem{Text: i18n.T("Value"), Widget: valueArea}
+	form := &widget.Form{}
+
+	typeSelect := widget.NewSelect([]string{"string", "list", "set", "hash", "zset"}, func(keyType string) {
+		var content fyne.CanvasObject
+		switch keyType {
+		case "list":
+			valueItem.Text, content = i18n.T("Items"), itemsEntry
+			itemsEntry.SetPlaceHolder(i18n.T("One item per line, first item at the head"))
+		case "set":
+			valueItem.Text, content = i18n.T("Members"), itemsEntry
+			itemsEntry.SetPlaceHolder(i18n.T("One member per line"))
+		case "hash":
+			valueItem.Text, content = i18n.T("Fields"), fields.content()
+		case "zset":
+			valueItem.Text, content = i18n.T("Members"), members.content()
+		default:
+			valueItem.Text, content = i18n.T("Value"), valueEntry
+		}
+		valueArea.Objects = []fyne.CanvasObject{content}
+		valueArea.Refresh()
+		form.Refresh()
+	})
+
+	form.Items = []*widget.FormItem{
+		{Text: i18n.T("Key"), Widget: keyEntry},
+		{Text: i18n.T("Type"), Widget: typeSelect},
+		valueItem,
+		{Text: "TTL", Widget: ttlEntry},
+	}
+	typeSelect.SetSelected("string")
+
+	// The form is read as a template without placeholders, so creating a key
+	// and saving the form as a template check it the same way
+	formTemplate := func() models.KeyTemplate {
+		t := models.KeyTemplate{
+			Pattern: strings.TrimSpace(keyEntry.Text),
+			Type:    typeSelect.Selected,
+			TTL:     strings.TrimSpace(ttlEntry.Text),
+		}
+		switch t.Type {
+		case "string":
+			t.Value = valueEntry.Text
+		case "list", "set":
+			for _, line := range strings.Split(itemsEntry.Text, "\n") {
+				if line = strings.TrimRight(line, "\r"); strings.TrimSpace(line) != "" {
+					t.Items = append(t.Items, line)
+				}
+			}
+		case "hash", "zset":
+			pairs := fields
+			if t.Type == "zset" {
+				pairs = members
+			}
+			for _, pair := range pairs.values() {
+				t.Fields = append(t.Fields, models.KeyValue{Key: pair[0], Value: pair[1]})
+			}
+		}
+		return t
+	}
+
+	var d *dialog.CustomDialog
+	createBtn := widget.NewButton(i18n.T("Create"), func() {
+		dump, err := templateDump(formTemplate(), nil, time.Now())
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		d.Hide()
+		onCreate(dump)
+	})
+	createBtn.Importance = widget.HighImportance
+	templateBtn := widget.NewButtonWithIcon(i18n.T("Save as Template..."), theme.DocumentSaveIcon(), func() {
+		promptSaveTemplate(window, formTemplate())
+	})
+	cancelBtn := widget.NewButton(i18n.T("Cancel"), func() { d.Hide() })
+
+	d = dialog.NewCustomWithoutButtons(i18n.T("New Key"), form, window)
+	d.SetButtons([]fyne.CanvasObject{cancelBtn, templateBtn, createBtn})
+	d.Resize(fyne.NewSize(460, 420))
+	d.Show()
+	window.Canvas().Focus(keyEntry)
+}
+
+// pairRows edits a list of two-column rows, such as hash fields and values
+type pairRows struct {
+	left, right string // column placeholders
+	rows        *fyne.Container
+	entries     [][2]*widget.Entry
+	box         fyne.CanvasObject
+}
+
+// newPairRows creates the rows with left and right as the column placeholders
+// and add as the label of the button that adds a row
+func newPairRows(left, right, add string) *pairRows {
+	p := &pairRows{left: left, right: right, rows: container.NewVBox()}
+	p.add()
+	addBtn := widget.NewButtonWithIcon(add, theme.ContentAddIcon(), p.add)
+	addBtn.Importance = widget.LowImportance
+	p.box = container.NewVBox(p.rows, container.NewHBox(addBtn))
+	return p
+}
+
+// content returns the rows with their Add button
+func (p *pairRows) content() fyne.CanvasObject {
+	return p.box
+}
+
+// add appends an empty row
+func (p *pairRows) add() {
+	leftEntry, rightEntry := widget.NewEntry(), widget.NewEntry()
+	leftEntry.SetPlaceHolder(p.left)
+	rightEntry.SetPlaceHolder(p.right)
+	entries := [2]*widget.Entry{leftEntry, rightEntry}
+
+	var row *fyne.Container
+	removeBtn := widget.NewButtonWithIcon("", theme.ContentRemoveIcon(), func() {
+		for i, e := range p.entries {
+			if e == entries {
+				p.entries = append(p.entries[:i], p.entries[i+1:]...)
+				break
+			}
+		}
+		p.rows.Remove(row)
+	})
+	removeBtn.Importance = widget.LowImportance
+	row = container.NewBorder(nil, nil, nil, removeBtn, container.NewGridWithColumns(2, leftEntry, rightEntry))
+
+	p.entries = append(p.entries, entries)
+	p.rows.Add(row)
+}
+
+// values returns the rows that aren't entirely blank
+func (p *pairRows) values() [][2]string {
+	var values [][2]string
+	for _, e := range p.entries {
+		if strings.TrimSpace(e[0].Text) == "" && strings.TrimSpace(e[1].Text) == "" {
+			continue
+		}
+		values = append(values, [2]string{e[0].Text, e[1].Text})
+	}
+	return values
+}
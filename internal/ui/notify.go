@@ -0,0 +1,121 @@
+package ui
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// memoryCheckInterval is how often used memory is compared to the alert limit
+	memoryCheckInterval = 15 * time.Second
+	// expiredNotifyInterval gathers the keys expiring within it into one
+	// notification, so a burst of expiries doesn't flood the desktop
+	expiredNotifyInterval = 5 * time.Second
+)
+
+// notify raises a desktop notification, shown by the OS even while the app is
+// in the background. It may be called from any goroutine.
+func notify(title, content string) {
+	fyne.Do(func() {
+		fyne.CurrentApp().SendNotification(fyne.NewNotification(title, content))
+	})
+}
+
+// startAlerts watches the active connection for the conditions chosen in the
+// notification settings
+func (a *App) startAlerts() {
+	settings := config.Get().Notifications
+	if settings.MemoryAboveMB <= 0 && !settings.KeyExpired {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	a.stopAlerting = cancel
+	client := a.client.WithContext(ctx)
+	name := a.currentConn.Name
+
+	if settings.MemoryAboveMB > 0 {
+		go watchMemory(ctx, client, name, int64(settings.MemoryAboveMB)<<20)
+	}
+	if settings.KeyExpired {
+		go a.watchExpired(ctx, client, name, settings.ExpiredPattern)
+	}
+}
+
+// stopAlerts stops watching for alert conditions
+func (a *App) stopAlerts() {
+	if a.stopAlerting != nil {
+		a.stopAlerting()
+		a.stopAlerting = nil
+	}
+}
+
+// watchMemory notifies each time the server's used memory rises above limit
+func watchMemory(ctx context.Context, client *redis.Client, name string, limit int64) {
+	ticker := time.NewTicker(memoryCheckInterval)
+	defer ticker.Stop()
+	above := false
+	for {
+		select {
+		case <-ticker.C:
+			used, err := client.UsedMemory()
+			if err != nil {
+				continue
+			}
+			if used > limit && !above {
+				notify(i18n.T("Memory Alert"), i18n.T("'%s' uses %s of memory, more than %s", name, formatBytes(used), formatBytes(limit)))
+			}
+			above = used > limit
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// watchExpired notifies of the keys matching pattern that expire, gathering
+// those within expiredNotifyInterval of each other
+func (a *App) watchExpired(ctx context.Context, client *redis.Client, name, pattern string) {
+	var mu sync.Mutex
+	var count int
+	var first string
+	go func() {
+		ticker := time.NewTicker(expiredNotifyInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mu.Lock()
+				n, key := count, first
+				count = 0
+				mu.Unlock()
+				switch {
+				case n == 1:
+					notify(i18n.T("Key Expired"), i18n.T("'%s' expired on '%s'", key, name))
+				case n > 1:
+					notify(i18n.T("Keys Expired"), i18n.T("%d keys expired on '%s', among them '%s'", n, name, key))
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	err := client.WatchExpired(pattern, func(key string) {
+		mu.Lock()
+		if count == 0 {
+			first = key
+		}
+		count++
+		mu.Unlock()
+	})
+	if err != nil && ctx.Err() == nil {
+		fyne.Do(func() {
+			a.statusBar.ShowResult(i18n.T("Expired key notifications stopped"), err)
+		})
+	}
+}
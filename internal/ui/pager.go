@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"context"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/redis"
+)
+
+// collectionPageSize is how many elements a collection editor loads at a time
+func collectionPageSize() int64 {
+	if size := config.Get().CollectionPageSize; size > 0 {
+		return int64(size)
+	}
+	return 1000
+}
+
+// collectionSource is how a collection editor loads its elements page by page
+type collectionSource struct {
+	// fetch reads the next page off the UI thread. filter is the active filter
+	// text, or "" for every element; first is set when the filter changed and
+	// loading starts over.
+	fetch func(c *redis.Client, filter string, first bool) error
+	// merge adds the fetched page to the editor on the UI thread, replacing the
+	// shown elements when first is set, and reports how many elements are shown
+	// and whether more remain to be loaded
+	merge func(filter string, first bool) (shown int, more bool)
+	// count, if set, is the count label's text while no filter is active, for
+	// editors that narrow their elements other than by the filter
+	count func(shown int) string
+}
+
+// newCollectionPager builds the header of a collection editor: a filter box, the
+// number of elements shown out of total, and a button that loads the next page
+func (ve *ValueEditor) newCollectionPager(total int64, shown int, more bool, placeholder string, src collectionSource) fyne.CanvasObject {
+	pager, _ := ve.newReloadablePager(total, shown, more, placeholder, src)
+	return pager
+}
+
+// newReloadablePager is newCollectionPager that also returns a function that
+// clears the filter and loads the first page again, for controls that change
+// what src fetches
+func (ve *ValueEditor) newReloadablePager(total int64, shown int, more bool, placeholder string, src collectionSource) (fyne.CanvasObject, func()) {
+	pageSize := collectionPageSize()
+	countLabel := widget.NewLabel("")
+	filterEntry := widget.NewEntry()
+	filterEntry.SetPlaceHolder(i18n.T(placeholder))
+	var nextBtn *widget.Button
+	filter := ""
+
+	update := func() {
+		switch {
+		case filter == "" && src.count != nil:
+			countLabel.SetText(src.count(shown))
+		case filter == "":
+			countLabel.SetText(i18n.T("Showing %d of %d", shown, total))
+		default:
+			countLabel.SetText(i18n.T("%d matching %q (%d total)", shown, filter, total))
+		}
+		if more {
+			nextBtn.Show()
+		} else {
+			nextBtn.Hide()
+		}
+	}
+
+	load := func(text string, first bool) {
+		nextBtn.Disable()
+		current := ve.currentKey
+		client := ve.client
+		ve.worker.Go(func(ctx context.Context) error {
+			return src.fetch(client.WithContext(ctx), text, first)
+		}, func(err error) {
+			nextBtn.Enable()
+			// The editor was rebuilt for another key while the page loaded
+			if ve.currentKey != current {
+				return
+			}
+			if err != nil {
+				ShowErrorDialog(ve.window, "Load Error", err)
+				return
+			}
+			filter = text
+			shown, more = src.merge(filter, first)
+			update()
+		})
+	}
+
+	nextBtn = widget.NewButtonWithIcon(i18n.T("Load next %d", pageSize), theme.MoveDownIcon(), func() {
+		load(filter, false)
+	})
+	filterEntry.OnSubmitted = func(text string) {
+		if text != filter {
+			load(text, true)
+		}
+	}
+	clearBtn := widget.NewButtonWithIcon("", theme.ContentClearIcon(), func() {
+		filterEntry.SetText("")
+		if filter != "" {
+			load("", true)
+		}
+	})
+
+	update()
+	reload := func() {
+		filterEntry.SetText("")
+		load("", true)
+	}
+	return container.NewVBox(
+		container.NewBorder(nil, nil, nil, clearBtn, filterEntry),
+		container.NewBorder(nil, nil, nil, nextBtn, countLabel),
+	), reload
+}
+
+// matchPattern is the SCAN MATCH glob for a filter, "" when there is none
+func matchPattern(filter string) string {
+	if filter == "" {
+		return ""
+	}
+	return redis.ContainsPattern(filter)
+}
+
+// highlightSegments renders text for a rich text table cell, picking out each
+// occurrence of needle
+func highlightSegments(text, needle string, style fyne.TextStyle) []widget.RichTextSegment {
+	segment := func(text string, color fyne.ThemeColorName, style fyne.TextStyle) *widget.TextSegment {
+		return &widget.TextSegment{
+			Text:  text,
+			Style: widget.RichTextStyle{ColorName: color, Inline: true, TextStyle: style},
+		}
+	}
+
+	var segments []widget.RichTextSegment
+	for needle != "" {
+		i := strings.Index(text, needle)
+		if i < 0 {
+			break
+		}
+		if i > 0 {
+			segments = append(segments, segment(text[:i], theme.ColorNameForeground, style))
+		}
+		highlighted := style
+		highlighted.Bold = true
+		segments = append(segments, segment(needle, theme.ColorNamePrimary, highlighted))
+		text = text[i+len(needle):]
+	}
+	if text != "" || len(segments) == 0 {
+		segments = append(segments, segment(text, theme.ColorNameForeground, style))
+	}
+	return segments
+}
+
+// setRichText replaces the content of a rich text table cell
+func setRichText(o fyne.CanvasObject, segments []widget.RichTextSegment) {
+	text := o.(*widget.RichText)
+	text.Segments = segments
+	text.Refresh()
+}
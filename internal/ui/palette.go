@@ -0,0 +1,195 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// paletteResultLimit is how many matches the command palette lists
+const paletteResultLimit = 50
+
+// paletteItem is a command palette entry: a menu action or a key to open
+type paletteItem struct {
+	label  string
+	detail string // the menu the action is in, or the key's type
+	run    func()
+}
+
+// ShowCommandPalette lets the user fuzzy-search the menu actions and, once
+// something is typed, the loaded keys. Enter runs the highlighted match and
+// the arrow keys move the highlight.
+func ShowCommandPalette(window fyne.Window, menu *fyne.MainMenu, keys []models.RedisKey, openKey func(models.RedisKey)) {
+	var commands []paletteItem
+	if menu != nil {
+		for _, m := range menu.Items {
+			commands = append(commands, menuCommands(m.Label, m.Items)...)
+		}
+	}
+
+	var d dialog.Dialog
+	var results []paletteItem
+	selected := 0
+	highlighting := false
+	run := func(item paletteItem) {
+		d.Hide()
+		item.run()
+	}
+
+	list := widget.NewList(
+		func() int { return len(results) },
+		func() fyne.CanvasObject {
+			detail := widget.NewLabel("")
+			detail.Importance = widget.LowImportance
+			return container.NewBorder(nil, nil, nil, detail, widget.NewLabel(""))
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			row := o.(*fyne.Container)
+			row.Objects[0].(*widget.Label).SetText(results[id].label)
+			row.Objects[1].(*widget.Label).SetText(results[id].detail)
+		},
+	)
+	// Tapping a row runs it; the arrow keys only move the highlight
+	list.OnSelected = func(id widget.ListItemID) {
+		selected = id
+		if !highlighting {
+			run(results[id])
+		}
+	}
+	highlight := func(id widget.ListItemID) {
+		highlighting = true
+		list.Select(id)
+		list.ScrollTo(id)
+		highlighting = false
+	}
+
+	entry := newHistoryEntry()
+	entry.SetPlaceHolder(i18n.T("Type a command or key name"))
+	search := func(query string) {
+		results = searchPalette(query, commands, keys, openKey)
+		selected = 0
+		list.UnselectAll()
+		list.Refresh()
+		if len(results) > 0 {
+			highlight(0)
+		}
+	}
+	entry.OnChanged = search
+	entry.OnSubmitted = func(string) {
+		if selected < len(results) {
+			run(results[selected])
+		}
+	}
+	entry.onUp = func() {
+		if selected > 0 {
+			highlight(selected - 1)
+		}
+	}
+	entry.onDown = func() {
+		if selected < len(results)-1 {
+			highlight(selected + 1)
+		}
+	}
+	search("")
+
+	hint := widget.NewLabel(i18n.T("Up and Down move the highlight, Enter runs it"))
+	hint.Importance = widget.LowImportance
+	content := container.NewBorder(entry, hint, nil, nil, list)
+
+	d = dialog.NewCustom(i18n.T("Command Palette"), i18n.T("Close"), content, window)
+	d.Resize(fyne.NewSize(520, 420))
+	d.Show()
+	window.Canvas().Focus(entry)
+}
+
+// menuCommands flattens a menu into palette commands, naming submenu actions
+// after their path, e.g. "Move to Group > prod"
+func menuCommands(menu string, items []*fyne.MenuItem) []paletteItem {
+	var commands []paletteItem
+	for _, item := range items {
+		if item.IsSeparator || item.Disabled {
+			continue
+		}
+		if item.ChildMenu != nil {
+			commands = append(commands, menuCommands(menu+" > "+item.Label, item.ChildMenu.Items)...)
+			continue
+		}
+		if item.Action == nil {
+			continue
+		}
+		label := strings.TrimSuffix(item.Label, "...")
+		commands = append(commands, paletteItem{label: label, detail: menu, run: item.Action})
+	}
+	return commands
+}
+
+// searchPalette ranks the commands and keys matching query, best first. An
+// empty query lists every command and no keys.
+func searchPalette(query string, commands []paletteItem, keys []models.RedisKey, openKey func(models.RedisKey)) []paletteItem {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return commands
+	}
+
+	type match struct {
+		item  paletteItem
+		score int
+	}
+	var matches []match
+	for _, c := range commands {
+		if score, ok := fuzzyScore(query, c.label); ok {
+			matches = append(matches, match{c, score + 1}) // commands win ties with keys
+		}
+	}
+	for _, key := range keys {
+		if score, ok := fuzzyScore(query, key.Key); ok {
+			matches = append(matches, match{paletteItem{
+				label:  key.Key,
+				detail: key.Type,
+				run:    func() { openKey(key) },
+			}, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+
+	results := make([]paletteItem, 0, min(len(matches), paletteResultLimit))
+	for i := 0; i < len(matches) && i < paletteResultLimit; i++ {
+		results = append(results, matches[i].item)
+	}
+	return results
+}
+
+// fuzzyScore reports whether the characters of pattern appear in order in text,
+// ignoring case, and scores the match: consecutive characters, characters that
+// start a word and matches near the start score higher, long texts lower.
+func fuzzyScore(pattern, text string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(text)
+	score, pi, run := 0, 0, 0
+	for ti := 0; ti < len(t) && pi < len(p); ti++ {
+		if unicode.ToLower(t[ti]) != p[pi] {
+			run = 0
+			continue
+		}
+		run++
+		score += run * 4
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) || unicode.IsUpper(t[ti]) {
+			score += 6
+		}
+		if pi == 0 {
+			score -= min(ti, 20)
+		}
+		pi++
+	}
+	if pi < len(p) {
+		return 0, false
+	}
+	return score - min(len(t)/4, 20), true
+}
@@ -0,0 +1,291 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// queuePollInterval is how often the queue view re-reads the list
+	queuePollInterval = 2 * time.Second
+	// queueSamples is how many length samples the chart keeps, two minutes' worth
+	queueSamples = 60
+	// queueRateWindow is how far back the rate estimate looks
+	queueRateWindow = time.Minute
+	// queueEndItems is how many of the newest and oldest elements are shown
+	queueEndItems = 5
+)
+
+// queueSample is the length of a queue at a point in time
+type queueSample struct {
+	at     time.Time
+	length int64
+}
+
+// withQueueMode adds a "Queue mode" checkbox that swaps the list editor for the
+// queue view. Unlike withViewToggle it stops the view's polling when it is left.
+func (ve *ValueEditor) withQueueMode(key models.RedisKey, editor fyne.CanvasObject) fyne.CanvasObject {
+	body := container.NewStack(editor)
+	toggle := widget.NewCheck(i18n.T("Queue mode"), func(on bool) {
+		ve.stopQueuePoll()
+		if on {
+			body.Objects = []fyne.CanvasObject{ve.buildQueueView(key)}
+		} else {
+			body.Objects = []fyne.CanvasObject{editor}
+		}
+		body.Refresh()
+	})
+	return container.NewBorder(toggle, nil, nil, nil, body)
+}
+
+// stopQueuePoll stops the queue view from re-reading its list
+func (ve *ValueEditor) stopQueuePoll() {
+	if ve.stopQueue != nil {
+		close(ve.stopQueue)
+		ve.stopQueue = nil
+	}
+}
+
+// buildQueueView shows a list used as a job queue: its length over time, a
+// rate estimate, the newest and oldest elements, and actions that pop an
+// element or move the oldest one to a dead-letter list
+func (ve *ValueEditor) buildQueueView(key models.RedisKey) fyne.CanvasObject {
+	lengthLabel := widget.NewLabelWithStyle(i18n.T("Length: ..."), fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	rateLabel := widget.NewLabel(i18n.T("Measuring rate..."))
+	chart := newSparkline()
+
+	endLabels := func() ([]*widget.Label, fyne.CanvasObject) {
+		labels := make([]*widget.Label, queueEndItems)
+		box := container.NewVBox()
+		for i := range labels {
+			labels[i] = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+			labels[i].Truncation = fyne.TextTruncateEllipsis
+			box.Add(labels[i])
+		}
+		return labels, box
+	}
+	newestLabels, newestBox := endLabels()
+	oldestLabels, oldestBox := endLabels()
+
+	var samples []queueSample
+	var ends models.ListEnds
+	newestLeft := true
+
+	showEnds := func() {
+		newest, oldest := ends.Head, reversed(ends.Tail)
+		if !newestLeft {
+			newest, oldest = reversed(ends.Tail), ends.Head
+		}
+		fill := func(labels []*widget.Label, items []string) {
+			for i, label := range labels {
+				text := ""
+				if i < len(items) {
+					text = strings.ReplaceAll(items[i], "\n", " ")
+				} else if i == 0 {
+					text = "(empty)"
+				}
+				label.SetText(text)
+			}
+		}
+		fill(newestLabels, newest)
+		fill(oldestLabels, oldest)
+	}
+
+	queuePushLeft, queuePushRight := i18n.T("LPUSH (newest on the left)"), i18n.T("RPUSH (newest on the right)")
+	pushRadio := widget.NewRadioGroup([]string{queuePushLeft, queuePushRight}, func(choice string) {
+		newestLeft = choice != queuePushRight
+		showEnds()
+	})
+	pushRadio.Horizontal = true
+	pushRadio.Required = true
+	pushRadio.SetSelected(queuePushLeft)
+
+	polling := false
+	poll := func() {
+		if polling || ve.client == nil || ve.currentKey == nil || ve.currentKey.Key != key.Key {
+			return
+		}
+		polling = true
+		client := ve.client
+		var read models.ListEnds
+		ve.worker.Go(func(ctx context.Context) (err error) {
+			read, err = client.WithContext(ctx).ListEnds(key.Key, queueEndItems)
+			return err
+		}, func(err error) {
+			polling = false
+			if err != nil {
+				rateLabel.SetText(i18n.T("Error: %s", err))
+				return
+			}
+			ends = read
+			samples = append(samples, queueSample{at: time.Now(), length: ends.Length})
+			if len(samples) > queueSamples {
+				samples = samples[len(samples)-queueSamples:]
+			}
+			values := make([]float64, len(samples))
+			for i, s := range samples {
+				values[i] = float64(s.length)
+			}
+			chart.SetValues(values)
+			lengthLabel.SetText(i18n.T("Length: %d", ends.Length))
+			rateLabel.SetText(queueRate(samples))
+			showEnds()
+		})
+	}
+
+	// Popped or moved elements are kept here so they can be copied
+	poppedEntry := widget.NewMultiLineEntry()
+	poppedEntry.Wrapping = fyne.TextWrapWord
+	poppedEntry.SetMinRowsVisible(2)
+	poppedEntry.SetPlaceHolder(i18n.T("Popped elements appear here"))
+
+	// take removes the oldest or newest element through op, keeping a snapshot
+	// of the keys it changes for undo
+	take := func(label string, keys []string, op func(c *redis.Client) (string, error)) {
+		if refuseReadOnly(ve.window, ve.client) {
+			return
+		}
+		client := ve.client
+		var value string
+		var snapshots []models.KeySnapshot
+		var empty bool
+		ve.worker.Do(ve.window, client, func(c *redis.Client) (err error) {
+			snapshots = snapshotKeys(c, keys)
+			value, err = op(c)
+			if errors.Is(err, redis.ErrKeyNotFound) {
+				empty, err = true, nil
+			}
+			return err
+		}, func() {
+			if empty {
+				ShowInfoDialog(ve.window, "Queue Empty", "There is nothing to take from the queue.")
+				return
+			}
+			ve.undo.push(client, label, snapshots, true)
+			poppedEntry.SetText(value)
+			poll()
+			if ve.onKeyUpdated != nil {
+				ve.onKeyUpdated()
+			}
+		})
+	}
+
+	popOldestBtn := widget.NewButton(i18n.T("Pop Oldest"), func() {
+		left := !newestLeft
+		take(i18n.T("Popped from '%s'", key.Key), []string{key.Key}, func(c *redis.Client) (string, error) {
+			return c.ListPop(key.Key, left)
+		})
+	})
+	popNewestBtn := widget.NewButton(i18n.T("Pop Newest"), func() {
+		left := newestLeft
+		take(i18n.T("Popped from '%s'", key.Key), []string{key.Key}, func(c *redis.Client) (string, error) {
+			return c.ListPop(key.Key, left)
+		})
+	})
+
+	deadEntry := widget.NewEntry()
+	deadEntry.SetText(key.Key + ":dead")
+	deadEntry.SetPlaceHolder(i18n.T("Dead-letter list"))
+	moveBtn := widget.NewButtonWithIcon(i18n.T("Move Oldest"), theme.MailForwardIcon(), func() {
+		dst := strings.TrimSpace(deadEntry.Text)
+		if dst == "" || dst == key.Key {
+			ShowErrorDialog(ve.window, "Invalid Key", fmt.Errorf("enter a dead-letter list other than the queue"))
+			return
+		}
+		// The dead-letter list is pushed the same way as the queue, so it reads alike
+		fromLeft, toLeft := !newestLeft, newestLeft
+		take(i18n.T("Moved from '%s' to '%s'", key.Key, dst), []string{key.Key, dst}, func(c *redis.Client) (string, error) {
+			return c.ListMove(key.Key, dst, fromLeft, toLeft)
+		})
+	})
+	setWritable(ve.client, popOldestBtn, popNewestBtn, moveBtn)
+
+	stop := make(chan struct{})
+	ve.stopQueue = stop
+	go func() {
+		ticker := time.NewTicker(queuePollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(func() {
+					if ve.stopQueue == stop {
+						poll()
+					}
+				})
+			case <-stop:
+				return
+			}
+		}
+	}()
+	poll()
+
+	endsGrid := container.NewGridWithColumns(2,
+		container.NewVBox(widget.NewLabelWithStyle(i18n.T("Newest"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), newestBox),
+		container.NewVBox(widget.NewLabelWithStyle(i18n.T("Oldest (next to be consumed)"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), oldestBox),
+	)
+	actions := container.NewVBox(
+		widget.NewSeparator(),
+		container.NewHBox(popOldestBtn, popNewestBtn),
+		container.NewBorder(nil, nil, widget.NewLabel(i18n.T("Dead letter:")), moveBtn, deadEntry),
+		poppedEntry,
+	)
+	return container.NewVScroll(container.NewVBox(
+		container.NewHBox(widget.NewLabel(i18n.T("Producers push with")), pushRadio),
+		container.NewHBox(lengthLabel, rateLabel),
+		chart,
+		widget.NewSeparator(),
+		endsGrid,
+		actions,
+	))
+}
+
+// queueRate describes the net change of a queue's length over the last
+// queueRateWindow of samples: producers minus consumers
+func queueRate(samples []queueSample) string {
+	if len(samples) < 2 {
+		return i18n.T("Measuring rate...")
+	}
+	last := samples[len(samples)-1]
+	first := samples[0]
+	for _, s := range samples {
+		if last.at.Sub(s.at) <= queueRateWindow {
+			first = s
+			break
+		}
+	}
+	elapsed := last.at.Sub(first.at).Seconds()
+	if elapsed <= 0 {
+		return i18n.T("Measuring rate...")
+	}
+	rate := float64(last.length-first.length) / elapsed
+	over := i18n.T(" over the last %ds", int(elapsed))
+	switch {
+	case rate <= -0.05:
+		return i18n.T("Draining at %.1f/s%s, empty in about %s", -rate, over, formatTTL(int64(float64(last.length)/-rate)))
+	case rate >= 0.05:
+		return i18n.T("Growing at %.1f/s%s", rate, over)
+	default:
+		return i18n.T("Steady%s", over)
+	}
+}
+
+// reversed returns a reversed copy of items
+func reversed(items []string) []string {
+	out := make([]string, len(items))
+	for i, item := range items {
+		out[len(items)-1-i] = item
+	}
+	return out
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"fyne.io/fyne/v2"
+	"redis-explorer/internal/redis"
+)
+
+// guardPhrase must be typed before destructive actions run against the active
+// connection; it is empty unless the connection is tagged as production
+var guardPhrase string
+
+// SetGuardPhrase sets the phrase destructive actions on the active connection
+// are confirmed with; empty asks for a plain confirmation
+func SetGuardPhrase(phrase string) {
+	guardPhrase = phrase
+}
+
+// isReadOnly reports whether client refuses write commands
+func isReadOnly(client *redis.Client) bool {
+	return client != nil && client.ReadOnly()
+}
+
+// setWritable enables the controls that change data unless client is read-only
+func setWritable(client *redis.Client, objs ...fyne.Disableable) {
+	for _, obj := range objs {
+		if isReadOnly(client) {
+			obj.Disable()
+		} else {
+			obj.Enable()
+		}
+	}
+}
+
+// refuseReadOnly tells the user a change isn't allowed on a read-only connection
+// and reports whether it was refused
+func refuseReadOnly(window fyne.Window, client *redis.Client) bool {
+	if !isReadOnly(client) {
+		return false
+	}
+	ShowInfoDialog(window, "Read-Only Connection",
+		"This connection is read-only. Edit the connection to allow changes.")
+	return true
+}
+
+// confirmDestructive asks before an action that deletes data, requiring the
+// guard phrase on production connections
+func confirmDestructive(window fyne.Window, title, message string, onConfirm func()) {
+	if guardPhrase == "" {
+		ShowConfirmDialog(window, title, message, onConfirm)
+		return
+	}
+	ShowTypeToConfirmDialog(window, title, message, guardPhrase, "Confirm", onConfirm)
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// sampleSize is how many random elements a sample draws unless changed
+	sampleSize = 20
+	// sampleLimit caps the sample size, so a sample stays cheap on a huge collection
+	sampleLimit = 1000
+)
+
+// sampleColumns names what a collection type's samples hold, the first column
+// being empty for sets, whose samples are bare members
+var sampleColumns = map[string][2]string{
+	"set":  {"", "Member"},
+	"hash": {"Field", "Value"},
+	"zset": {"Score", "Member"},
+	"list": {"Index", "Element"},
+}
+
+// sampleButton opens a random sample of the elements of a set, hash, sorted
+// set or list, to see what a collection too large to page through holds
+func (ve *ValueEditor) sampleButton(key models.RedisKey) fyne.CanvasObject {
+	btn := widget.NewButtonWithIcon(i18n.T("Sample"), theme.ListIcon(), func() {
+		ve.showSample(key)
+	})
+	if key.Type == "hash" || key.Type == "zset" {
+		return requireFeature(ve.client, redis.FeatureRandomField, btn)
+	}
+	return btn
+}
+
+// showSample shows a dialog of random elements of key, drawn again on request
+func (ve *ValueEditor) showSample(key models.RedisKey) {
+	columns := sampleColumns[key.Type]
+	var samples []models.KeyValue
+	bare := columns[0] == ""
+
+	table := widget.NewTableWithHeaders(
+		func() (int, int) {
+			if bare {
+				return len(samples), 1
+			}
+			return len(samples), 2
+		},
+		func() fyne.CanvasObject {
+			label := widget.NewLabel("")
+			label.Truncation = fyne.TextTruncateEllipsis
+			return label
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Col == 0 && !bare {
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				label.SetText(samples[id.Row].Key)
+				return
+			}
+			label.TextStyle = valueTextStyle()
+			label.SetText(samples[id.Row].Value)
+		},
+	)
+	table.ShowHeaderColumn = false
+	table.CreateHeader = func() fyne.CanvasObject {
+		return widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+	}
+	table.UpdateHeader = func(id widget.TableCellID, o fyne.CanvasObject) {
+		col := id.Col
+		if bare {
+			col = 1
+		}
+		o.(*widget.Label).SetText(i18n.T(columns[col]))
+	}
+	if bare {
+		table.SetColumnWidth(0, 560)
+	} else {
+		table.SetColumnWidth(0, 160)
+		table.SetColumnWidth(1, 400)
+	}
+	// Clicking an element copies it
+	table.OnSelected = func(id widget.TableCellID) {
+		if id.Row < len(samples) {
+			fyne.CurrentApp().Clipboard().SetContent(samples[id.Row].Value)
+		}
+		table.UnselectAll()
+	}
+
+	countEntry := widget.NewEntry()
+	countEntry.SetText(strconv.Itoa(sampleSize))
+	statusLabel := widget.NewLabel("")
+
+	var drawBtn *widget.Button
+	draw := func() {
+		n, err := strconv.Atoi(strings.TrimSpace(countEntry.Text))
+		if err != nil || n <= 0 || n > sampleLimit {
+			ShowErrorDialog(ve.window, "Invalid Sample Size", fmt.Errorf("the sample size must be a whole number from 1 to %d", sampleLimit))
+			return
+		}
+		drawBtn.Disable()
+		statusLabel.SetText(i18n.T("Sampling..."))
+		client := ve.client
+		var picked []models.KeyValue
+		ve.worker.Go(func(ctx context.Context) (err error) {
+			picked, err = client.WithContext(ctx).SampleCollection(key.Key, key.Type, n)
+			return err
+		}, func(err error) {
+			drawBtn.Enable()
+			if err != nil {
+				statusLabel.SetText(i18n.T("Error: %s", err))
+				return
+			}
+			samples = picked
+			statusLabel.SetText(i18n.T("%d random elements; click one to copy it", len(samples)))
+			table.Refresh()
+			table.ScrollToTop()
+		})
+	}
+	drawBtn = widget.NewButtonWithIcon(i18n.T("Sample Again"), theme.ViewRefreshIcon(), draw)
+	countEntry.OnSubmitted = func(string) { draw() }
+
+	bar := container.NewHBox(widget.NewLabel(i18n.T("Size")),
+		container.NewGridWrap(fyne.NewSize(100, countEntry.MinSize().Height), countEntry), drawBtn)
+	content := container.NewBorder(container.NewVBox(bar, statusLabel), nil, nil, nil, table)
+
+	d := dialog.NewCustom(i18n.T("Random Sample of '%s'", key.Key), i18n.T("Close"), content, ve.window)
+	d.Resize(fyne.NewSize(620, 480))
+	d.Show()
+	draw()
+}
@@ -0,0 +1,388 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// ScriptPanel is a scratchpad for Lua scripts: the script runs with EVAL or,
+// once loaded into the server's script cache, EVALSHA, and scripts can be kept
+// in a library saved with the settings
+type ScriptPanel struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	worker    *Worker
+	window    fyne.Window
+
+	scripts  []models.SavedScript
+	cached   map[string]bool // SHA1 -> in the server's script cache
+	library  *widget.List
+	selected int
+
+	nameEntry *widget.Entry
+	body      *widget.Entry
+	keysEntry *widget.Entry
+	argsEntry *widget.Entry
+	readOnly  *widget.Check
+	shaLabel  *widget.Label
+	output    *widget.Label
+	loadBtn   *widget.Button
+	flushBtn  *widget.Button
+	running   bool
+}
+
+// NewScriptPanel creates a new script panel
+func NewScriptPanel(window fyne.Window, worker *Worker) *ScriptPanel {
+	p := &ScriptPanel{
+		window:   window,
+		worker:   worker,
+		cached:   make(map[string]bool),
+		selected: -1,
+	}
+	p.ExtendBaseWidget(p)
+	p.buildUI()
+	p.reloadLibrary()
+	return p
+}
+
+func (p *ScriptPanel) buildUI() {
+	p.library = widget.NewList(
+		func() int { return len(p.scripts) },
+		func() fyne.CanvasObject {
+			status := widget.NewLabel("")
+			status.Importance = widget.LowImportance
+			name := widget.NewLabel("")
+			name.Truncation = fyne.TextTruncateEllipsis
+			return container.NewBorder(nil, nil, nil, status, name)
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			box := o.(*fyne.Container)
+			script := p.scripts[id]
+			box.Objects[0].(*widget.Label).SetText(script.Name)
+			status := ""
+			if p.cached[redis.ScriptSHA(script.Body)] {
+				status = "cached"
+			}
+			box.Objects[1].(*widget.Label).SetText(status)
+		},
+	)
+	p.library.OnSelected = func(id widget.ListItemID) {
+		p.selected = id
+		p.open(p.scripts[id])
+	}
+
+	newBtn := widget.NewButtonWithIcon("", theme.ContentAddIcon(), p.newScript)
+	saveBtn := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), p.saveScript)
+	deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), p.deleteScript)
+	checkBtn := widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), p.checkCache)
+	libraryBar := container.NewHBox(newBtn, saveBtn, deleteBtn, layout.NewSpacer(), checkBtn)
+	libraryPane := container.NewBorder(
+		container.NewVBox(widget.NewLabelWithStyle(i18n.T("Saved Scripts"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), libraryBar),
+		nil, nil, nil, p.library)
+
+	p.nameEntry = widget.NewEntry()
+	p.nameEntry.SetPlaceHolder(i18n.T("Script name, for saving"))
+
+	p.body = widget.NewMultiLineEntry()
+	p.body.TextStyle = fyne.TextStyle{Monospace: true}
+	p.body.SetPlaceHolder("return redis.call('GET', KEYS[1])")
+	p.body.OnChanged = func(string) { p.updateSHA() }
+
+	p.keysEntry = widget.NewEntry()
+	p.keysEntry.TextStyle = fyne.TextStyle{Monospace: true}
+	p.keysEntry.SetPlaceHolder(`KEYS, e.g. user:1 "key with spaces"`)
+	p.argsEntry = widget.NewEntry()
+	p.argsEntry.TextStyle = fyne.TextStyle{Monospace: true}
+	p.argsEntry.SetPlaceHolder(i18n.T("ARGV, quoted like console arguments"))
+
+	p.readOnly = widget.NewCheck(i18n.T("Read-only (EVAL_RO)"), nil)
+
+	runBtn := widget.NewButtonWithIcon(i18n.T("Run"), theme.MediaPlayIcon(), func() { p.run(false) })
+	runBtn.Importance = widget.HighImportance
+	runSHABtn := widget.NewButtonWithIcon(i18n.T("Run Cached"), theme.MediaFastForwardIcon(), func() { p.run(true) })
+	p.loadBtn = widget.NewButtonWithIcon(i18n.T("Load"), theme.UploadIcon(), p.loadScript)
+	p.flushBtn = widget.NewButtonWithIcon(i18n.T("Flush Cache"), theme.ContentClearIcon(), p.flushCache)
+
+	p.shaLabel = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	p.shaLabel.Importance = widget.LowImportance
+	p.shaLabel.Truncation = fyne.TextTruncateEllipsis
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Name"), p.nameEntry),
+		widget.NewFormItem("KEYS", p.keysEntry),
+		widget.NewFormItem("ARGV", p.argsEntry),
+	)
+	buttons := container.NewHBox(runBtn, runSHABtn, p.loadBtn, p.flushBtn, p.readOnly)
+	editorPane := container.NewBorder(nil, container.NewVBox(form, p.shaLabel, buttons), nil, nil, p.body)
+
+	p.output = widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	p.output.Wrapping = fyne.TextWrapBreak
+	p.output.Selectable = true
+	clearBtn := widget.NewButtonWithIcon("", theme.ContentClearIcon(), func() { p.output.SetText("") })
+	clearBtn.Importance = widget.LowImportance
+	resultPane := container.NewBorder(
+		container.NewBorder(nil, nil, widget.NewLabelWithStyle(i18n.T("Result"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), clearBtn),
+		nil, nil, nil, container.NewVScroll(p.output))
+
+	workArea := container.NewVSplit(editorPane, resultPane)
+	workArea.SetOffset(0.65)
+	split := container.NewHSplit(libraryPane, workArea)
+	split.SetOffset(0.25)
+
+	p.container = container.NewStack(split)
+	p.updateSHA()
+}
+
+// CreateRenderer implements fyne.Widget
+func (p *ScriptPanel) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.container)
+}
+
+// SetClient sets the Redis client. Read-only connections only allow EVAL_RO
+// and EVALSHA_RO, so the read-only option is ticked for them.
+func (p *ScriptPanel) SetClient(client *redis.Client) {
+	p.client = client
+	p.running = false
+	p.cached = make(map[string]bool)
+	setWritable(client, p.loadBtn, p.flushBtn)
+	if isReadOnly(client) {
+		p.readOnly.SetChecked(true)
+	}
+	p.library.Refresh()
+	p.updateSHA()
+	if client != nil {
+		p.checkCache()
+	}
+}
+
+// reloadLibrary lists the saved scripts again, keeping the selected one
+func (p *ScriptPanel) reloadLibrary() {
+	name := p.nameEntry.Text
+	p.scripts = config.GetScripts()
+	p.selected = -1
+	p.library.UnselectAll()
+	for i, s := range p.scripts {
+		if s.Name == name {
+			p.selected = i
+			p.library.Select(i)
+			break
+		}
+	}
+	p.library.Refresh()
+}
+
+// open shows a saved script in the editor
+func (p *ScriptPanel) open(script models.SavedScript) {
+	p.nameEntry.SetText(script.Name)
+	p.body.SetText(script.Body)
+	p.keysEntry.SetText(script.Keys)
+	p.argsEntry.SetText(script.Args)
+}
+
+func (p *ScriptPanel) newScript() {
+	p.selected = -1
+	p.library.UnselectAll()
+	p.open(models.SavedScript{})
+	p.output.SetText("")
+	p.window.Canvas().Focus(p.nameEntry)
+}
+
+func (p *ScriptPanel) saveScript() {
+	script := models.SavedScript{
+		Name: strings.TrimSpace(p.nameEntry.Text),
+		Body: p.body.Text,
+		Keys: p.keysEntry.Text,
+		Args: p.argsEntry.Text,
+	}
+	if script.Name == "" {
+		dialog.ShowError(fmt.Errorf("name the script to save it"), p.window)
+		return
+	}
+	if err := config.SaveScript(script); err != nil {
+		ShowErrorDialog(p.window, "Save Script", err)
+		return
+	}
+	p.nameEntry.SetText(script.Name)
+	p.reloadLibrary()
+}
+
+func (p *ScriptPanel) deleteScript() {
+	if p.selected < 0 || p.selected >= len(p.scripts) {
+		ShowInfoDialog(p.window, "Delete Script", "Select a saved script to delete.")
+		return
+	}
+	name := p.scripts[p.selected].Name
+	ShowConfirmDialog(p.window, "Delete Script", i18n.T("Delete the saved script '%s'?", name), func() {
+		if err := config.DeleteScript(name); err != nil {
+			ShowErrorDialog(p.window, "Delete Script", err)
+			return
+		}
+		p.newScript()
+		p.reloadLibrary()
+	})
+}
+
+// updateSHA shows the SHA1 of the script being edited and whether the server
+// has it cached
+func (p *ScriptPanel) updateSHA() {
+	if p.body.Text == "" {
+		p.shaLabel.SetText("")
+		return
+	}
+	sha := redis.ScriptSHA(p.body.Text)
+	status := i18n.T("not loaded")
+	if p.cached[sha] {
+		status = i18n.T("cached on the server")
+	}
+	p.shaLabel.SetText(i18n.T("SHA1 %s (%s)", sha, status))
+}
+
+// args parses the KEYS and ARGV entries the way the console parses a command
+func (p *ScriptPanel) args() (keys, args []string, err error) {
+	if keys, err = redis.ParseCommandLine(p.keysEntry.Text); err != nil {
+		return nil, nil, fmt.Errorf("KEYS: %w", err)
+	}
+	if args, err = redis.ParseCommandLine(p.argsEntry.Text); err != nil {
+		return nil, nil, fmt.Errorf("ARGV: %w", err)
+	}
+	return keys, args, nil
+}
+
+// run evaluates the script, by its SHA1 when cached is set
+func (p *ScriptPanel) run(cached bool) {
+	body := p.body.Text
+	if p.running || strings.TrimSpace(body) == "" {
+		return
+	}
+	if p.client == nil {
+		p.output.SetText("(error) Not connected")
+		return
+	}
+	keys, args, err := p.args()
+	if err != nil {
+		p.output.SetText("(error) " + err.Error())
+		return
+	}
+
+	client := p.client
+	readOnly := p.readOnly.Checked
+	sha := redis.ScriptSHA(body)
+	var reply models.CommandReply
+	var took time.Duration
+	p.running = true
+	p.output.SetText(i18n.T("Running..."))
+	p.worker.Go(func(ctx context.Context) error {
+		c := client.WithContext(ctx)
+		start := time.Now()
+		var err error
+		if cached {
+			reply, err = c.EvalScriptSHA(sha, keys, args, readOnly)
+		} else {
+			reply, err = c.EvalScript(body, keys, args, readOnly)
+		}
+		took = time.Since(start)
+		return err
+	}, func(err error) {
+		p.running = false
+		if p.client != client {
+			return
+		}
+		if err != nil {
+			p.output.SetText("(error) " + err.Error())
+			return
+		}
+		p.output.SetText(formatReply(reply, "") + fmt.Sprintf("\n\n(%s)", took.Round(time.Microsecond)))
+		// EVAL caches the script too; a NOSCRIPT reply means it isn't cached
+		p.cached[sha] = reply.Kind != models.ReplyError || !strings.HasPrefix(reply.Text, "NOSCRIPT")
+		p.library.Refresh()
+		p.updateSHA()
+	})
+}
+
+// loadScript adds the script to the server's cache with SCRIPT LOAD
+func (p *ScriptPanel) loadScript() {
+	body := p.body.Text
+	if p.client == nil || strings.TrimSpace(body) == "" || refuseReadOnly(p.window, p.client) {
+		return
+	}
+	var sha string
+	p.worker.Do(p.window, p.client, func(c *redis.Client) error {
+		var err error
+		sha, err = c.LoadScript(body)
+		return err
+	}, func() {
+		p.cached[sha] = true
+		p.output.SetText(i18n.T("Loaded as %s", sha))
+		p.library.Refresh()
+		p.updateSHA()
+	})
+}
+
+// checkCache asks the server which saved scripts, and the one being edited,
+// are in its script cache
+func (p *ScriptPanel) checkCache() {
+	if p.client == nil {
+		return
+	}
+	shas := make([]string, 0, len(p.scripts)+1)
+	for _, s := range p.scripts {
+		shas = append(shas, redis.ScriptSHA(s.Body))
+	}
+	if p.body.Text != "" {
+		shas = append(shas, redis.ScriptSHA(p.body.Text))
+	}
+	client := p.client
+	var cached map[string]bool
+	p.worker.Go(func(ctx context.Context) error {
+		var err error
+		cached, err = client.WithContext(ctx).ScriptsCached(shas)
+		return err
+	}, func(err error) {
+		if p.client != client {
+			return
+		}
+		if err != nil {
+			log.Printf("Checking the script cache failed: %v", err)
+			return
+		}
+		for sha, ok := range cached {
+			p.cached[sha] = ok
+		}
+		p.library.Refresh()
+		p.updateSHA()
+	})
+}
+
+// flushCache empties the server's script cache with SCRIPT FLUSH
+func (p *ScriptPanel) flushCache() {
+	if p.client == nil || refuseReadOnly(p.window, p.client) {
+		return
+	}
+	confirmDestructive(p.window, "Flush Script Cache",
+		"Remove every cached script from the server? Clients running scripts by EVALSHA will get NOSCRIPT errors until they load them again.",
+		func() {
+			p.worker.Do(p.window, p.client, func(c *redis.Client) error {
+				return c.FlushScripts()
+			}, func() {
+				p.cached = make(map[string]bool)
+				p.output.SetText(i18n.T("Script cache flushed"))
+				p.library.Refresh()
+				p.updateSHA()
+			})
+		})
+}
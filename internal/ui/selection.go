@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+
+	"redis-explorer/internal/models"
+)
+
+// The list and tree views share the selection through selectedKey, the name of
+// the selected key or tree folder. The widgets keep their own scroll position
+// and open branches, so reloading the keys or toggling the view only has to
+// point each view's selection back at selectedKey.
+
+// restoreSelection selects the list view row of the selected key, which moves
+// when the keys are reloaded or re-sorted, without reopening it. The row is
+// unselected while the key is filtered out or gone, or a folder is selected.
+func (kb *KeyBrowser) restoreSelection() {
+	if kb.treeView {
+		return
+	}
+	row := -1
+	if kb.selectedKey != "" {
+		row = slices.IndexFunc(kb.filteredKeys, func(key models.RedisKey) bool {
+			return key.Key == kb.selectedKey
+		})
+	}
+	if row < 0 {
+		if kb.selectedIndex >= 0 {
+			kb.keyTable.UnselectAll()
+			kb.selectedIndex = -1
+		}
+		return
+	}
+	if row == kb.selectedIndex {
+		return
+	}
+	kb.reselecting = true
+	kb.selectRow(row)
+	kb.reselecting = false
+}
+
+// selectTreeNode selects the selected key's node in the tree view after it was
+// selected in the list view, opening the folders above it so it can be seen
+func (kb *KeyBrowser) selectTreeNode() {
+	if !kb.treeView || kb.selectedKey == kb.treeSelected {
+		return
+	}
+	if _, ok := kb.tree.node(kb.selectedKey); !ok {
+		kb.keyTree.UnselectAll()
+		kb.treeSelected = ""
+		return
+	}
+
+	if kb.delimiter != "" {
+		for i := strings.Index(kb.selectedKey, kb.delimiter); i >= 0; {
+			kb.keyTree.OpenBranch(kb.selectedKey[:i])
+			next := strings.Index(kb.selectedKey[i+len(kb.delimiter):], kb.delimiter)
+			if next < 0 {
+				break
+			}
+			i += len(kb.delimiter) + next
+		}
+	}
+	kb.reselecting = true
+	kb.keyTree.Select(kb.selectedKey)
+	kb.reselecting = false
+}
@@ -1,13 +1,21 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
 	"redis-explorer/internal/redis"
 )
 
@@ -16,15 +24,23 @@ type ServerInfo struct {
 	widget.BaseWidget
 	container   *fyne.Container
 	client      *redis.Client
+	worker      *Worker
 	window      fyne.Window
+	refreshing  bool
 	dbSelector  *widget.Select
+	dbCount     int
+	dbKeys      map[int]int64 // keys per database from the last INFO keyspace
+	flushBtn    *widget.Button
 	onDBChanged func(db int)
+	onDBFlushed func()
 
 	// Info labels
 	versionLabel    *widget.Label
 	modeLabel       *widget.Label
 	osLabel         *widget.Label
 	uptimeLabel     *widget.Label
+	protocolLabel   *widget.Label
+	modulesLabel    *widget.Label
 	clientsLabel    *widget.Label
 	memoryLabel     *widget.Label
 	memoryPeakLabel *widget.Label
@@ -34,12 +50,51 @@ type ServerInfo struct {
 	missesLabel     *widget.Label
 	hitRateLabel    *widget.Label
 	lastRefreshLabel *widget.Label
+
+	// Persistence labels, with a warning shown when a save or write failed
+	rdbLabel       *widget.Label
+	changesLabel   *widget.Label
+	aofLabel       *widget.Label
+	persistWarning *widget.Icon
+	bgsaveBtn      *widget.Button
+	rewriteBtn     *widget.Button
+
+	// The All INFO tab lists the complete INFO reply, read while it is open
+	tabs    *container.AppTabs
+	rawTab  *container.TabItem
+	rawInfo *rawInfoView
+
+	// The Commands tab reads INFO commandstats while it is open
+	statsTab *container.TabItem
+	cmdStats *commandStatsView
+
+	// The Latency tab pings the server while it is open
+	latencyTab *container.TabItem
+	latency    *LatencyPanel
+
+	// Metric charts, fed by polling INFO while connected
+	histories    map[string]*metricsHistory // by connection ID, kept across reconnects
+	history      *metricsHistory
+	stopMetrics  chan struct{}
+	metricCharts []*metricChart
+}
+
+// metricChart is a sparkline of one metric with a title showing its latest value
+type metricChart struct {
+	title  string
+	label  *widget.Label
+	chart  *sparkline
+	metric func(metricSample) float64
+	format func(float64) string
 }
 
 // NewServerInfo creates a new server info panel
-func NewServerInfo(window fyne.Window) *ServerInfo {
+func NewServerInfo(window fyne.Window, worker *Worker) *ServerInfo {
 	si := &ServerInfo{
-		window: window,
+		window:    window,
+		worker:    worker,
+		dbCount:   16,
+		histories: make(map[string]*metricsHistory),
 	}
 	si.ExtendBaseWidget(si)
 	si.buildUI()
@@ -47,25 +102,23 @@ func NewServerInfo(window fyne.Window) *ServerInfo {
 }
 
 func (si *ServerInfo) buildUI() {
-	// Database selector
-	dbOptions := make([]string, 16)
-	for i := 0; i < 16; i++ {
-		dbOptions[i] = fmt.Sprintf("DB %d", i)
-	}
-	si.dbSelector = widget.NewSelect(dbOptions, func(s string) {
-		var db int
-		fmt.Sscanf(s, "DB %d", &db)
-		if si.onDBChanged != nil {
+	// Database selector; options are labelled with key counts, so the database
+	// is the option's index
+	si.dbSelector = widget.NewSelect(nil, func(string) {
+		if db := si.dbSelector.SelectedIndex(); db >= 0 && si.onDBChanged != nil {
 			si.onDBChanged(db)
 		}
 	})
-	si.dbSelector.SetSelectedIndex(0)
+	si.setDBOptions(0)
 
 	// Info labels
 	si.versionLabel = widget.NewLabel("-")
 	si.modeLabel = widget.NewLabel("-")
 	si.osLabel = widget.NewLabel("-")
 	si.uptimeLabel = widget.NewLabel("-")
+	si.protocolLabel = widget.NewLabel("-")
+	si.modulesLabel = widget.NewLabel("-")
+	si.modulesLabel.Wrapping = fyne.TextWrapWord
 	si.clientsLabel = widget.NewLabel("-")
 	si.memoryLabel = widget.NewLabel("-")
 	si.memoryPeakLabel = widget.NewLabel("-")
@@ -74,64 +127,117 @@ func (si *ServerInfo) buildUI() {
 	si.hitsLabel = widget.NewLabel("-")
 	si.missesLabel = widget.NewLabel("-")
 	si.hitRateLabel = widget.NewLabel("-")
+	si.rdbLabel = widget.NewLabel("-")
+	si.changesLabel = widget.NewLabel("-")
+	si.aofLabel = widget.NewLabel("-")
+	si.persistWarning = widget.NewIcon(theme.WarningIcon())
+	si.persistWarning.Hide()
 	si.lastRefreshLabel = widget.NewLabelWithStyle("-", fyne.TextAlignTrailing, fyne.TextStyle{Italic: true})
 
-	refreshBtn := widget.NewButtonWithIcon("Refresh", theme.ViewRefreshIcon(), func() {
+	refreshBtn := widget.NewButtonWithIcon(i18n.T("Refresh"), theme.ViewRefreshIcon(), func() {
 		si.Refresh()
 	})
 
+	// Metrics section
+	si.metricCharts = []*metricChart{
+		{title: i18n.T("Ops/sec"), metric: func(s metricSample) float64 { return s.opsPerSec },
+			format: func(v float64) string { return fmt.Sprintf("%.0f", v) }},
+		{title: i18n.T("Memory"), metric: func(s metricSample) float64 { return s.usedMemory },
+			format: func(v float64) string { return formatBytes(int64(v)) }},
+		{title: i18n.T("Clients"), metric: func(s metricSample) float64 { return s.clients },
+			format: func(v float64) string { return fmt.Sprintf("%.0f", v) }},
+		{title: i18n.T("Hit Rate"), metric: func(s metricSample) float64 { return s.hitRate },
+			format: func(v float64) string { return fmt.Sprintf("%.1f%%", v) }},
+	}
+	charts := container.NewGridWithColumns(2)
+	for _, mc := range si.metricCharts {
+		mc.label = widget.NewLabel(mc.title)
+		mc.chart = newSparkline()
+		charts.Add(container.NewBorder(mc.label, nil, nil, nil, mc.chart))
+	}
+	metricsSection := container.NewVBox(
+		widget.NewLabelWithStyle(i18n.T("Metrics"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		charts,
+	)
+
 	// Server section
 	serverSection := container.NewVBox(
-		widget.NewLabelWithStyle("Server", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Server"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		container.NewGridWithColumns(2,
-			widget.NewLabel("Version:"), si.versionLabel,
-			widget.NewLabel("Mode:"), si.modeLabel,
-			widget.NewLabel("OS:"), si.osLabel,
-			widget.NewLabel("Uptime:"), si.uptimeLabel,
+			widget.NewLabel(i18n.T("Version:")), si.versionLabel,
+			widget.NewLabel(i18n.T("Mode:")), si.modeLabel,
+			widget.NewLabel(i18n.T("OS:")), si.osLabel,
+			widget.NewLabel(i18n.T("Uptime:")), si.uptimeLabel,
+			widget.NewLabel(i18n.T("Protocol:")), si.protocolLabel,
+			widget.NewLabel(i18n.T("Modules:")), si.modulesLabel,
 		),
 	)
 
 	// Clients section
 	clientsSection := container.NewVBox(
-		widget.NewLabelWithStyle("Clients", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Clients"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		container.NewGridWithColumns(2,
-			widget.NewLabel("Connected:"), si.clientsLabel,
+			widget.NewLabel(i18n.T("Connected:")), si.clientsLabel,
 		),
 	)
 
 	// Memory section
 	memorySection := container.NewVBox(
-		widget.NewLabelWithStyle("Memory", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Memory"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		container.NewGridWithColumns(2,
-			widget.NewLabel("Used:"), si.memoryLabel,
-			widget.NewLabel("Peak:"), si.memoryPeakLabel,
+			widget.NewLabel(i18n.T("Used:")), si.memoryLabel,
+			widget.NewLabel(i18n.T("Peak:")), si.memoryPeakLabel,
 		),
 	)
 
 	// Keyspace section
 	keyspaceSection := container.NewVBox(
-		widget.NewLabelWithStyle("Keyspace", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Keyspace"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		container.NewGridWithColumns(2,
-			widget.NewLabel("Total Keys:"), si.totalKeysLabel,
-			widget.NewLabel("Expired:"), si.expiredLabel,
-			widget.NewLabel("Hits:"), si.hitsLabel,
-			widget.NewLabel("Misses:"), si.missesLabel,
-			widget.NewLabel("Hit Rate:"), si.hitRateLabel,
+			widget.NewLabel(i18n.T("Total Keys:")), si.totalKeysLabel,
+			widget.NewLabel(i18n.T("Expired:")), si.expiredLabel,
+			widget.NewLabel(i18n.T("Hits:")), si.hitsLabel,
+			widget.NewLabel(i18n.T("Misses:")), si.missesLabel,
+			widget.NewLabel(i18n.T("Hit Rate:")), si.hitRateLabel,
 		),
 	)
 
+	// Persistence section
+	si.bgsaveBtn = widget.NewButtonWithIcon("BGSAVE", theme.DocumentSaveIcon(), func() {
+		si.startPersistence("BGSAVE", (*redis.Client).BGSave)
+	})
+	si.rewriteBtn = widget.NewButtonWithIcon("BGREWRITEAOF", theme.ViewRefreshIcon(), func() {
+		si.startPersistence("BGREWRITEAOF", (*redis.Client).BGRewriteAOF)
+	})
+	persistenceSection := container.NewVBox(
+		container.NewHBox(widget.NewLabelWithStyle(i18n.T("Persistence"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}), si.persistWarning),
+		container.NewGridWithColumns(2,
+			widget.NewLabel(i18n.T("Last RDB save:")), si.rdbLabel,
+			widget.NewLabel(i18n.T("Changes since:")), si.changesLabel,
+			widget.NewLabel(i18n.T("AOF:")), si.aofLabel,
+		),
+		container.NewHBox(si.bgsaveBtn, si.rewriteBtn),
+	)
+
+	si.flushBtn = widget.NewButtonWithIcon(i18n.T("Flush DB"), theme.DeleteIcon(), func() {
+		si.ShowFlushDB()
+	})
+	si.flushBtn.Importance = widget.DangerImportance
+
 	// Database section
 	dbSection := container.NewVBox(
-		widget.NewLabelWithStyle("Database", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
-		si.dbSelector,
+		widget.NewLabelWithStyle(i18n.T("Database"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		container.NewBorder(nil, nil, nil, si.flushBtn, si.dbSelector),
 	)
 
 	header := container.NewBorder(nil, nil,
-		widget.NewLabelWithStyle("Server Info", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		widget.NewLabelWithStyle(i18n.T("Server Info"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 		container.NewHBox(si.lastRefreshLabel, refreshBtn),
 	)
 
 	content := container.NewVBox(
+		metricsSection,
+		widget.NewSeparator(),
 		serverSection,
 		widget.NewSeparator(),
 		clientsSection,
@@ -140,12 +246,37 @@ func (si *ServerInfo) buildUI() {
 		widget.NewSeparator(),
 		keyspaceSection,
 		widget.NewSeparator(),
+		persistenceSection,
+		widget.NewSeparator(),
 		dbSection,
 	)
 
 	scroll := container.NewVScroll(content)
 
-	si.container = container.NewBorder(header, nil, nil, nil, scroll)
+	si.rawInfo = newRawInfoView()
+	si.rawTab = container.NewTabItem(i18n.T("All INFO"), si.rawInfo.content)
+	si.latency = NewLatencyPanel(si.window, si.worker)
+	si.latencyTab = container.NewTabItem(i18n.T("Latency"), si.latency)
+	si.cmdStats = newCommandStatsView()
+	si.statsTab = container.NewTabItem(i18n.T("Commands"), si.cmdStats.content)
+	si.tabs = container.NewAppTabs(container.NewTabItem(i18n.T("Overview"), scroll), si.rawTab, si.statsTab, si.latencyTab)
+	si.tabs.OnSelected = func(tab *container.TabItem) {
+		if tab == si.latencyTab {
+			si.latency.Start()
+		} else {
+			si.latency.Stop()
+		}
+		switch tab {
+		case si.statsTab:
+			// Opening the tab starts counting anew
+			si.cmdStats.clear()
+			si.Refresh()
+		case si.rawTab:
+			si.Refresh()
+		}
+	}
+
+	si.container = container.NewBorder(header, nil, nil, nil, si.tabs)
 }
 
 // CreateRenderer implements fyne.Widget
@@ -156,16 +287,72 @@ func (si *ServerInfo) CreateRenderer() fyne.WidgetRenderer {
 // SetClient sets the Redis client
 func (si *ServerInfo) SetClient(client *redis.Client) {
 	si.client = client
+	setWritable(client, si.flushBtn, si.bgsaveBtn, si.rewriteBtn)
+	si.latency.SetClient(client)
+	if client != nil && si.tabs.Selected() == si.latencyTab {
+		si.latency.Start()
+	}
 	if client != nil {
 		// Update database selector with actual count from server
-		dbCount := client.GetDatabaseCount()
-		dbOptions := make([]string, dbCount)
-		for i := 0; i < dbCount; i++ {
-			dbOptions[i] = fmt.Sprintf("DB %d", i)
+		var dbCount int
+		si.worker.Go(func(ctx context.Context) error {
+			dbCount = client.WithContext(ctx).GetDatabaseCount()
+			return nil
+		}, func(error) {
+			if si.client != client {
+				return
+			}
+			si.dbCount = dbCount
+			si.setDBOptions(client.Connection().Database)
+		})
+		si.showHello(client)
+	}
+}
+
+// showHello reads the protocol, client ID and modules of the connection from
+// HELLO, which don't change while connected
+func (si *ServerInfo) showHello(client *redis.Client) {
+	var hello *models.ServerHello
+	si.worker.Go(func(ctx context.Context) (err error) {
+		hello, err = client.WithContext(ctx).Hello()
+		return err
+	}, func(err error) {
+		if si.client != client {
+			return
+		}
+		if err != nil {
+			si.protocolLabel.SetText(i18n.T("RESP2 (the server has no HELLO)"))
+			si.modulesLabel.SetText("-")
+			return
+		}
+		si.protocolLabel.SetText(i18n.T("RESP%d, client ID %d", hello.Protocol, hello.ID))
+		if len(hello.Modules) == 0 {
+			si.modulesLabel.SetText(i18n.T("None"))
+		} else {
+			si.modulesLabel.SetText(strings.Join(hello.Modules, ", "))
+		}
+	})
+}
+
+// setDBOptions lists the databases with their key counts, marking the ones
+// that hold keys, and shows db as selected without switching to it
+func (si *ServerInfo) setDBOptions(db int) {
+	options := make([]string, si.dbCount)
+	for i := range options {
+		options[i] = i18n.T("DB %d", i)
+		switch keys := si.dbKeys[i]; {
+		case keys == 1:
+			options[i] = i18n.T("● DB %d (1 key)", i)
+		case keys > 1:
+			options[i] = i18n.T("● DB %d (%s keys)", i, formatCount(keys))
 		}
-		si.dbSelector.Options = dbOptions
-		si.dbSelector.Refresh()
 	}
+	si.dbSelector.Options = options
+	si.dbSelector.Selected = ""
+	if db >= 0 && db < len(options) {
+		si.dbSelector.Selected = options[db]
+	}
+	si.dbSelector.Refresh()
 }
 
 // SetOnDBChanged sets the callback for database change
@@ -173,6 +360,103 @@ func (si *ServerInfo) SetOnDBChanged(f func(db int)) {
 	si.onDBChanged = f
 }
 
+// SetOnDBFlushed sets the callback for after the current database, or every
+// database, is flushed
+func (si *ServerInfo) SetOnDBFlushed(f func()) {
+	si.onDBFlushed = f
+}
+
+// ShowFlushDB previews the keys in the current database and flushes it once
+// the database name has been typed to confirm
+func (si *ServerInfo) ShowFlushDB() {
+	if si.client == nil || refuseReadOnly(si.window, si.client) {
+		return
+	}
+	client := si.client
+	dbName := fmt.Sprintf("DB %d", client.Connection().Database)
+
+	var preview *models.ImpactPreview
+	var closeProgress func()
+	cancel := si.worker.GoCancellable(func(ctx context.Context) error {
+		var err error
+		preview, err = client.WithContext(ctx).PreviewImpact("*", impactScanLimit, impactSampleSize)
+		return err
+	}, func(err error) {
+		closeProgress()
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(si.window, "Error", err)
+			return
+		}
+		ShowImpactDialog(si.window, i18n.T("Flush %s", dbName), preview, "will be removed", "Flush", dbName, func() {
+			si.worker.Do(si.window, client, func(c *redis.Client) error {
+				return c.FlushDB()
+			}, si.flushed)
+		})
+	})
+	closeProgress = ShowCancellableProgress(si.window, i18n.T("Flush %s", dbName), "Analyzing keys to be removed...", cancel)
+}
+
+// ShowFlushAll counts the keys in every database and flushes them all once the
+// connection name has been typed to confirm
+func (si *ServerInfo) ShowFlushAll() {
+	if si.client == nil || refuseReadOnly(si.window, si.client) {
+		return
+	}
+	client := si.client
+	name := client.Connection().Name
+
+	var sizes map[int]int64
+	si.worker.Do(si.window, client, func(c *redis.Client) (err error) {
+		sizes, err = c.KeyspaceSizes()
+		return err
+	}, func() {
+		if len(sizes) == 0 {
+			ShowInfoDialog(si.window, "Flush All Databases", "Every database is already empty. Nothing to change.")
+			return
+		}
+		dbs := make([]int, 0, len(sizes))
+		var total int64
+		for db, n := range sizes {
+			dbs = append(dbs, db)
+			total += n
+		}
+		sort.Ints(dbs)
+		counts := make([]string, len(dbs))
+		for i, db := range dbs {
+			counts[i] = fmt.Sprintf("DB %d: %d", db, sizes[db])
+		}
+
+		message := i18n.T("%d keys in %d databases on '%s' will be removed (%s).",
+			total, len(dbs), name, strings.Join(counts, ", "))
+		ShowTypeToConfirmDialog(si.window, "Flush All Databases", message, name, "Flush All", func() {
+			si.worker.Do(si.window, client, func(c *redis.Client) error {
+				return c.FlushAll()
+			}, si.flushed)
+		})
+	})
+}
+
+// startPersistence runs BGSAVE or BGREWRITEAOF and refreshes to show it running
+func (si *ServerInfo) startPersistence(command string, start func(c *redis.Client) error) {
+	if si.client == nil || refuseReadOnly(si.window, si.client) {
+		return
+	}
+	si.worker.Do(si.window, si.client, start, func() {
+		si.worker.Report(i18n.T("%s started in the background", command), nil)
+		si.Refresh()
+	})
+}
+
+func (si *ServerInfo) flushed() {
+	if si.onDBFlushed != nil {
+		si.onDBFlushed()
+	}
+	si.Refresh()
+}
+
 // Refresh updates the server info display
 func (si *ServerInfo) Refresh() {
 	if si.client == nil {
@@ -180,22 +464,128 @@ func (si *ServerInfo) Refresh() {
 		return
 	}
 
-	info, err := si.client.GetServerInfo()
-	if err != nil {
-		si.clearInfo()
-		si.lastRefreshLabel.SetText("Error: " + err.Error())
+	// Skip if the previous refresh is still in flight (e.g. auto-refresh on a slow server)
+	if si.refreshing {
 		return
 	}
+	si.refreshing = true
+
+	client := si.client
+	raw := si.tabs.Selected() == si.rawTab
+	stats := si.tabs.Selected() == si.statsTab
+	var info *models.ServerInfo
+	var sections []models.InfoSection
+	var cmdStats []models.CommandStat
+	si.worker.Go(func(ctx context.Context) error {
+		var err error
+		c := client.WithContext(ctx)
+		if info, err = c.GetServerInfo(); err != nil {
+			return err
+		}
+		switch {
+		case raw:
+			sections, err = c.InfoSections()
+		case stats:
+			cmdStats, err = c.CommandStats()
+		}
+		return err
+	}, func(err error) {
+		si.refreshing = false
+		if si.client != client {
+			return
+		}
+		if err != nil {
+			si.clearInfo()
+			si.lastRefreshLabel.SetText(i18n.T("Error: %s", err))
+			return
+		}
+		si.showInfo(info)
+		switch {
+		case raw:
+			si.rawInfo.show(sections)
+		case stats:
+			si.cmdStats.show(cmdStats)
+		}
+		if si.history != nil {
+			si.history.add(info)
+			si.showHistory()
+		}
+	})
+}
+
+// StartMetrics polls INFO on the configured interval, charting the samples in the
+// history kept for connectionID
+func (si *ServerInfo) StartMetrics(connectionID string) {
+	si.StopMetrics()
+
+	si.history = si.histories[connectionID]
+	if si.history == nil {
+		si.history = &metricsHistory{}
+		si.histories[connectionID] = si.history
+	}
+	si.showHistory()
+
+	interval := config.Get().MetricsIntervalSecs
+	if interval <= 0 {
+		return
+	}
+	stop := make(chan struct{})
+	si.stopMetrics = stop
+	go func() {
+		ticker := time.NewTicker(time.Duration(interval) * time.Second)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				fyne.Do(si.Refresh)
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// StopMetrics stops polling INFO; the history is kept for the next connection
+func (si *ServerInfo) StopMetrics() {
+	if si.stopMetrics != nil {
+		close(si.stopMetrics)
+		si.stopMetrics = nil
+	}
+}
 
+// showHistory redraws the metric charts from the current history
+func (si *ServerInfo) showHistory() {
+	var samples []metricSample
+	if si.history != nil {
+		samples = si.history.samples
+	}
+	span := ""
+	if len(samples) > 1 {
+		span = " over " + si.formatUptime(int64(samples[len(samples)-1].at.Sub(samples[0].at).Seconds()))
+	}
+	for _, mc := range si.metricCharts {
+		if len(samples) == 0 {
+			mc.label.SetText(mc.title)
+			mc.chart.SetValues(nil)
+			continue
+		}
+		mc.label.SetText(fmt.Sprintf("%s: %s%s", mc.title, mc.format(mc.metric(samples[len(samples)-1])), span))
+		mc.chart.SetValues(si.history.series(mc.metric))
+	}
+}
+
+func (si *ServerInfo) showInfo(info *models.ServerInfo) {
 	si.versionLabel.SetText(info.Version)
 	si.modeLabel.SetText(info.Mode)
 	si.osLabel.SetText(info.OS)
 	si.uptimeLabel.SetText(si.formatUptime(info.Uptime))
 	si.clientsLabel.SetText(fmt.Sprintf("%d", info.ConnectedClients))
 	si.memoryLabel.SetText(info.UsedMemoryHuman)
-	si.memoryPeakLabel.SetText(si.formatBytes(info.UsedMemoryPeak))
+	si.memoryPeakLabel.SetText(formatBytes(info.UsedMemoryPeak))
 	si.totalKeysLabel.SetText(fmt.Sprintf("%d", info.TotalKeys))
 	si.expiredLabel.SetText(fmt.Sprintf("%d", info.ExpiredKeys))
+	si.dbKeys = info.Keyspace
+	si.setDBOptions(si.dbSelector.SelectedIndex())
 	si.hitsLabel.SetText(fmt.Sprintf("%d", info.KeyspaceHits))
 	si.missesLabel.SetText(fmt.Sprintf("%d", info.KeyspaceMisses))
 
@@ -205,11 +595,68 @@ func (si *ServerInfo) Refresh() {
 		hitRate := float64(info.KeyspaceHits) / float64(totalOps) * 100
 		si.hitRateLabel.SetText(fmt.Sprintf("%.2f%%", hitRate))
 	} else {
-		si.hitRateLabel.SetText("N/A")
+		si.hitRateLabel.SetText(i18n.T("N/A"))
 	}
 
+	si.showPersistence(info.Persistence)
+
 	// Update refresh timestamp
-	si.lastRefreshLabel.SetText("Updated: " + time.Now().Format("15:04:05"))
+	si.lastRefreshLabel.SetText(i18n.T("Updated: %s", time.Now().Format("15:04:05")))
+}
+
+// showPersistence fills the Persistence section, showing the warning icon
+// when the last RDB save or AOF write failed
+func (si *ServerInfo) showPersistence(p models.PersistenceStatus) {
+	rdb := p.LastSave.Format(time.DateTime)
+	if p.LastSave.IsZero() || p.LastSave.Unix() == 0 {
+		rdb = i18n.T("never")
+	}
+	rdbFailed := !p.LastOK
+	if rdbFailed {
+		rdb += i18n.T(" (last BGSAVE FAILED)")
+	}
+	if p.InProgress {
+		rdb += i18n.T(", saving now")
+	}
+	si.rdbLabel.SetText(rdb)
+	si.changesLabel.SetText(formatCount(p.ChangesSinceSave))
+
+	aofFailed := false
+	if !p.AOFEnabled {
+		si.aofLabel.SetText(i18n.T("off"))
+	} else {
+		aof := i18n.T("on, %s", formatBytes(p.AOFSize))
+		switch {
+		case p.AOFRewriting:
+			aof += i18n.T(", rewriting now")
+		case p.AOFRewriteScheduled:
+			aof += i18n.T(", rewrite scheduled")
+		}
+		if !p.AOFLastRewriteOK {
+			aof += i18n.T(" (last rewrite FAILED)")
+			aofFailed = true
+		}
+		if !p.AOFLastWriteOK {
+			aof += i18n.T(" (last write FAILED)")
+			aofFailed = true
+		}
+		si.aofLabel.SetText(aof)
+	}
+
+	setImportance := func(label *widget.Label, failed bool) {
+		label.Importance = widget.MediumImportance
+		if failed {
+			label.Importance = widget.DangerImportance
+		}
+		label.Refresh()
+	}
+	setImportance(si.rdbLabel, rdbFailed)
+	setImportance(si.aofLabel, aofFailed)
+	if rdbFailed || aofFailed {
+		si.persistWarning.Show()
+	} else {
+		si.persistWarning.Hide()
+	}
 }
 
 func (si *ServerInfo) clearInfo() {
@@ -225,7 +672,15 @@ func (si *ServerInfo) clearInfo() {
 	si.hitsLabel.SetText("-")
 	si.missesLabel.SetText("-")
 	si.hitRateLabel.SetText("-")
+	si.rdbLabel.Importance = widget.MediumImportance
+	si.rdbLabel.SetText("-")
+	si.changesLabel.SetText("-")
+	si.aofLabel.Importance = widget.MediumImportance
+	si.aofLabel.SetText("-")
+	si.persistWarning.Hide()
 	si.lastRefreshLabel.SetText("-")
+	si.rawInfo.clear()
+	si.cmdStats.clear()
 }
 
 func (si *ServerInfo) formatUptime(seconds int64) string {
@@ -241,7 +696,24 @@ func (si *ServerInfo) formatUptime(seconds int64) string {
 	return fmt.Sprintf("%dm", mins)
 }
 
-func (si *ServerInfo) formatBytes(bytes int64) string {
+// formatCount renders n with thousands separators, e.g. "1,204"
+func formatCount(n int64) string {
+	if n < 0 {
+		return "-" + formatCount(-n)
+	}
+	digits := strconv.FormatInt(n, 10)
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// formatBytes renders a byte count using binary units
+func formatBytes(bytes int64) string {
 	const (
 		KB = 1024
 		MB = KB * 1024
@@ -262,6 +734,15 @@ func (si *ServerInfo) formatBytes(bytes int64) string {
 
 // Clear clears the server info
 func (si *ServerInfo) Clear() {
+	si.refreshing = false
+	si.StopMetrics()
+	si.history = nil
+	si.showHistory()
 	si.clearInfo()
-	si.dbSelector.SetSelectedIndex(0)
+	si.dbCount = 16
+	si.dbKeys = nil
+	si.setDBOptions(0)
+	si.latency.Clear()
+	si.protocolLabel.SetText("-")
+	si.modulesLabel.SetText("-")
 }
@@ -2,21 +2,91 @@ package ui
 
 import (
 	"fmt"
+	"image/color"
+	"strings"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
 	"redis-explorer/internal/models"
 )
 
+// groupNodePrefix marks the tree node IDs of connection groups; other nodes are
+// connection IDs
+const groupNodePrefix = "group:"
+
+// groupColors are the color tags a connection group can have, by config name
+var groupColors = []struct {
+	name  string
+	color color.Color
+}{
+	{"red", color.NRGBA{R: 0xe5, G: 0x39, B: 0x35, A: 0xff}},
+	{"orange", color.NRGBA{R: 0xfb, G: 0x8c, B: 0x00, A: 0xff}},
+	{"yellow", color.NRGBA{R: 0xfd, G: 0xd8, B: 0x35, A: 0xff}},
+	{"green", color.NRGBA{R: 0x43, G: 0xa0, B: 0x47, A: 0xff}},
+	{"blue", color.NRGBA{R: 0x1e, G: 0x88, B: 0xe5, A: 0xff}},
+	{"purple", color.NRGBA{R: 0x8e, G: 0x24, B: 0xaa, A: 0xff}},
+}
+
+func groupColor(name string) color.Color {
+	for _, c := range groupColors {
+		if c.name == name {
+			return c.color
+		}
+	}
+	return color.Transparent
+}
+
+// connectionRow is a sidebar tree row that can be dragged onto another row to
+// reorder connections or move them between groups
+type connectionRow struct {
+	contextRow
+	onDragged func(e *fyne.DragEvent)
+	onDragEnd func()
+}
+
+func newConnectionRow(content fyne.CanvasObject) *connectionRow {
+	r := &connectionRow{contextRow: contextRow{content: content}}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// Dragged implements fyne.Draggable
+func (r *connectionRow) Dragged(e *fyne.DragEvent) {
+	if r.onDragged != nil {
+		r.onDragged(e)
+	}
+}
+
+// DragEnd implements fyne.Draggable
+func (r *connectionRow) DragEnd() {
+	if r.onDragEnd != nil {
+		r.onDragEnd()
+	}
+}
+
+// dropTarget is where a dragged connection would land: on a group row, or
+// above or below a connection row
+type dropTarget struct {
+	node  widget.TreeNodeID
+	below bool
+}
+
 // Sidebar represents the connection sidebar panel
 type Sidebar struct {
 	widget.BaseWidget
 	container    *fyne.Container
-	connList     *widget.List
+	connTree     *widget.Tree
 	connections  []models.ServerConnection
+	groups       []models.ConnectionGroup
+	grouped      map[string][]models.ServerConnection
+	rows         map[*connectionRow]widget.TreeNodeID
+	dragging     string
+	drop         dropTarget
 	selectedID   string
 	onConnect    func(conn models.ServerConnection)
 	onDisconnect func()
@@ -25,13 +95,16 @@ type Sidebar struct {
 	window       fyne.Window
 	isConnected  bool
 	statusLabel  *widget.Label
+	statusIcon   *widget.Icon
 }
 
 // NewSidebar creates a new sidebar
 func NewSidebar(window fyne.Window) *Sidebar {
 	s := &Sidebar{
 		window:      window,
-		statusLabel: widget.NewLabel("Disconnected"),
+		statusLabel: widget.NewLabel(i18n.T("Disconnected")),
+		statusIcon:  widget.NewIcon(theme.InfoIcon()),
+		rows:        make(map[*connectionRow]widget.TreeNodeID),
 	}
 	s.ExtendBaseWidget(s)
 	s.loadConnections()
@@ -42,117 +115,166 @@ func NewSidebar(window fyne.Window) *Sidebar {
 func (s *Sidebar) loadConnections() {
 	cfg := config.Get()
 	s.connections = cfg.Connections
-	if cfg.LastConnectionID != "" {
-		s.selectedID = cfg.LastConnectionID
-	} else if len(s.connections) > 0 {
-		s.selectedID = s.connections[0].ID
+	if _, ok := s.connection(s.selectedID); !ok {
+		s.selectedID = ""
+	}
+	if s.selectedID == "" {
+		if cfg.LastConnectionID != "" {
+			s.selectedID = cfg.LastConnectionID
+		} else if len(s.connections) > 0 {
+			s.selectedID = s.connections[0].ID
+		}
+	}
+
+	// Groups show in config order, followed by any only named by a connection
+	s.groups = append([]models.ConnectionGroup(nil), cfg.ConnectionGroups...)
+	s.grouped = make(map[string][]models.ServerConnection)
+	known := make(map[string]bool)
+	for _, g := range s.groups {
+		known[g.Name] = true
+	}
+	for _, conn := range s.connections {
+		if conn.Group != "" && !known[conn.Group] {
+			known[conn.Group] = true
+			s.groups = append(s.groups, models.ConnectionGroup{Name: conn.Group})
+		}
+		s.grouped[conn.Group] = append(s.grouped[conn.Group], conn)
 	}
 }
 
 func (s *Sidebar) buildUI() {
-	// Connection list
-	s.connList = widget.NewList(
-		func() int { return len(s.connections) },
-		func() fyne.CanvasObject {
-			return container.NewHBox(
-				widget.NewIcon(theme.ComputerIcon()),
-				widget.NewLabel("Connection Name"),
-			)
+	s.connTree = widget.NewTree(
+		func(uid widget.TreeNodeID) []widget.TreeNodeID {
+			var ids []widget.TreeNodeID
+			if uid == "" {
+				for _, g := range s.groups {
+					ids = append(ids, groupNodePrefix+g.Name)
+				}
+			}
+			for _, conn := range s.grouped[strings.TrimPrefix(uid, groupNodePrefix)] {
+				ids = append(ids, conn.ID)
+			}
+			return ids
 		},
-		func(i widget.ListItemID, o fyne.CanvasObject) {
-			box := o.(*fyne.Container)
-			label := box.Objects[1].(*widget.Label)
-			label.SetText(s.connections[i].Name)
+		func(uid widget.TreeNodeID) bool {
+			return uid == "" || strings.HasPrefix(uid, groupNodePrefix)
+		},
+		func(branch bool) fyne.CanvasObject {
+			swatch := canvas.NewRectangle(color.Transparent)
+			swatch.SetMinSize(fyne.NewSize(12, 12))
+			swatch.CornerRadius = 3
+			above := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+			above.SetMinSize(fyne.NewSize(0, 2))
+			below := canvas.NewRectangle(theme.Color(theme.ColorNamePrimary))
+			below.SetMinSize(fyne.NewSize(0, 2))
+			return newConnectionRow(container.NewBorder(above, below,
+				container.NewHBox(container.NewCenter(swatch), widget.NewIcon(theme.ComputerIcon())), nil,
+				widget.NewLabel(i18n.T("Connection Name"))))
+		},
+		func(uid widget.TreeNodeID, branch bool, o fyne.CanvasObject) {
+			row := o.(*connectionRow)
+			s.rows[row] = uid
+			box := row.content.(*fyne.Container)
+			label := box.Objects[0].(*widget.Label)
+			above := box.Objects[1].(*canvas.Rectangle)
+			below := box.Objects[2].(*canvas.Rectangle)
+			left := box.Objects[3].(*fyne.Container)
+			swatch := left.Objects[0].(*fyne.Container).Objects[0].(*canvas.Rectangle)
+			icon := left.Objects[1].(*widget.Icon)
+
+			above.Hidden = s.dragging == "" || s.drop.node != uid || s.drop.below
+			below.Hidden = s.dragging == "" || s.drop.node != uid || !s.drop.below
+			row.onDragged = func(e *fyne.DragEvent) { s.dragged(uid, e) }
+			row.onDragEnd = s.dragEnd
+
+			if name, ok := strings.CutPrefix(uid, groupNodePrefix); ok {
+				swatch.FillColor = groupColor(s.group(name).Color)
+				swatch.Show()
+				icon.SetResource(theme.FolderIcon())
+				label.SetText(fmt.Sprintf("%s (%d)", name, len(s.grouped[name])))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				row.onTapped = func() { s.connTree.ToggleBranch(uid) }
+				row.onSecondaryTapped = func(pos fyne.Position) { s.showGroupMenu(name, pos) }
+			} else {
+				conn, _ := s.connection(uid)
+				swatch.Hide()
+				icon.SetResource(theme.ComputerIcon())
+				label.SetText(conn.Name)
+				label.TextStyle = fyne.TextStyle{}
+				row.onTapped = func() { s.connTree.Select(uid) }
+				row.onSecondaryTapped = func(pos fyne.Position) { s.showConnectionMenu(conn, pos) }
+			}
+			swatch.Refresh()
+			label.Refresh()
+			above.Refresh()
+			below.Refresh()
 		},
 	)
 
-	s.connList.OnSelected = func(id widget.ListItemID) {
-		if id >= 0 && id < len(s.connections) {
-			s.selectedID = s.connections[id].ID
+	s.connTree.OnSelected = func(uid widget.TreeNodeID) {
+		if !strings.HasPrefix(uid, groupNodePrefix) {
+			s.selectedID = uid
 		}
 	}
-
-	// Select the last used connection
-	for i, conn := range s.connections {
-		if conn.ID == s.selectedID {
-			s.connList.Select(i)
-			break
+	s.connTree.OnBranchOpened = func(uid widget.TreeNodeID) {
+		if name, ok := strings.CutPrefix(uid, groupNodePrefix); ok && s.group(name).Collapsed {
+			config.SetGroupCollapsed(name, false)
+			s.loadConnections()
 		}
 	}
+	s.connTree.OnBranchClosed = func(uid widget.TreeNodeID) {
+		if name, ok := strings.CutPrefix(uid, groupNodePrefix); ok && !s.group(name).Collapsed {
+			config.SetGroupCollapsed(name, true)
+			s.loadConnections()
+		}
+	}
+	s.restoreTree()
 
 	// Buttons
-	connectBtn := widget.NewButtonWithIcon("Connect", theme.LoginIcon(), func() {
-		if s.onConnect != nil && s.selectedID != "" {
-			for _, conn := range s.connections {
-				if conn.ID == s.selectedID {
-					s.onConnect(conn)
-					break
-				}
-			}
+	connectBtn := widget.NewButtonWithIcon(i18n.T("Connect"), theme.LoginIcon(), func() {
+		if conn, ok := s.connection(s.selectedID); ok && s.onConnect != nil {
+			s.onConnect(conn)
 		}
 	})
 
-	disconnectBtn := widget.NewButtonWithIcon("Disconnect", theme.LogoutIcon(), func() {
+	disconnectBtn := widget.NewButtonWithIcon(i18n.T("Disconnect"), theme.LogoutIcon(), func() {
 		if s.onDisconnect != nil {
 			s.onDisconnect()
 		}
 	})
 
-	addBtn := widget.NewButtonWithIcon("Add", theme.ContentAddIcon(), func() {
+	addBtn := widget.NewButtonWithIcon(i18n.T("Add"), theme.ContentAddIcon(), func() {
 		ShowConnectionDialog(s.window, nil, func(conn models.ServerConnection) {
 			config.AddConnection(conn)
-			s.loadConnections()
-			s.connList.Refresh()
+			s.RefreshConnections()
 		})
 	})
 
-	editBtn := widget.NewButtonWithIcon("Edit", theme.DocumentCreateIcon(), func() {
-		if s.selectedID == "" {
-			return
-		}
-		for _, conn := range s.connections {
-			if conn.ID == s.selectedID {
-				ShowConnectionDialog(s.window, &conn, func(updated models.ServerConnection) {
-					config.UpdateConnection(updated)
-					s.loadConnections()
-					s.connList.Refresh()
-					if s.onEdit != nil {
-						s.onEdit(updated)
-					}
-				})
-				break
-			}
+	editBtn := widget.NewButtonWithIcon(i18n.T("Edit"), theme.DocumentCreateIcon(), func() {
+		if conn, ok := s.connection(s.selectedID); ok {
+			s.editConnection(conn)
 		}
 	})
 
-	deleteBtn := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
-		if s.selectedID == "" || s.selectedID == "default" {
-			return
-		}
-		for _, conn := range s.connections {
-			if conn.ID == s.selectedID {
-				ShowConfirmDialog(s.window, "Delete Connection",
-					fmt.Sprintf("Are you sure you want to delete '%s'?", conn.Name),
-					func() {
-						config.RemoveConnection(conn.ID)
-						s.loadConnections()
-						s.connList.Refresh()
-						if s.onDelete != nil {
-							s.onDelete(conn)
-						}
-					})
-				break
-			}
+	deleteBtn := widget.NewButtonWithIcon(i18n.T("Delete"), theme.DeleteIcon(), func() {
+		if conn, ok := s.connection(s.selectedID); ok {
+			s.deleteConnection(conn)
 		}
 	})
 
-	buttonBar := container.NewHBox(addBtn, editBtn, deleteBtn)
+	copyURLBtn := widget.NewButtonWithIcon("", theme.ContentCopyIcon(), func() {
+		if conn, ok := s.connection(s.selectedID); ok {
+			ShowCopyURLDialog(s.window, conn)
+		}
+	})
+
+	buttonBar := container.NewHBox(addBtn, editBtn, deleteBtn, copyURLBtn)
 
 	// Status
 	statusContainer := container.NewVBox(
 		widget.NewSeparator(),
 		container.NewHBox(
-			widget.NewIcon(theme.InfoIcon()),
+			s.statusIcon,
 			s.statusLabel,
 		),
 	)
@@ -160,7 +282,7 @@ func (s *Sidebar) buildUI() {
 	// Build the layout
 	s.container = container.NewBorder(
 		container.NewVBox(
-			widget.NewLabelWithStyle("Connections", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+			widget.NewLabelWithStyle(i18n.T("Connections"), fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
 			buttonBar,
 		),
 		container.NewVBox(
@@ -170,7 +292,7 @@ func (s *Sidebar) buildUI() {
 			disconnectBtn,
 		),
 		nil, nil,
-		s.connList,
+		s.connTree,
 	)
 }
 
@@ -202,15 +324,238 @@ func (s *Sidebar) SetOnDelete(f func(conn models.ServerConnection)) {
 // SetConnected updates the connection status display
 func (s *Sidebar) SetConnected(connected bool, connName string) {
 	s.isConnected = connected
+	s.statusIcon.SetResource(theme.InfoIcon())
 	if connected {
-		s.statusLabel.SetText(fmt.Sprintf("Connected: %s", connName))
+		s.statusLabel.SetText(i18n.T("Connected: %s", connName))
 	} else {
-		s.statusLabel.SetText("Disconnected")
+		s.statusLabel.SetText(i18n.T("Disconnected"))
 	}
 }
 
+// SetStale marks the active connection as not responding to keep-alive pings
+func (s *Sidebar) SetStale(connName string) {
+	s.statusIcon.SetResource(theme.WarningIcon())
+	s.statusLabel.SetText(i18n.T("Not responding: %s", connName))
+}
+
+// SetConnecting shows that a connection attempt is in progress
+func (s *Sidebar) SetConnecting(connName string) {
+	s.statusLabel.SetText(i18n.T("Connecting: %s...", connName))
+}
+
 // RefreshConnections reloads connections from config
 func (s *Sidebar) RefreshConnections() {
 	s.loadConnections()
-	s.connList.Refresh()
+	s.restoreTree()
+	s.connTree.Refresh()
+}
+
+// restoreTree opens the groups that aren't collapsed and selects the selected
+// connection
+func (s *Sidebar) restoreTree() {
+	for _, g := range s.groups {
+		if !g.Collapsed {
+			s.connTree.OpenBranch(groupNodePrefix + g.Name)
+		}
+	}
+	if _, ok := s.connection(s.selectedID); ok {
+		s.connTree.Select(s.selectedID)
+	}
+}
+
+func (s *Sidebar) connection(id string) (models.ServerConnection, bool) {
+	for _, conn := range s.connections {
+		if conn.ID == id {
+			return conn, true
+		}
+	}
+	return models.ServerConnection{}, false
+}
+
+func (s *Sidebar) group(name string) models.ConnectionGroup {
+	for _, g := range s.groups {
+		if g.Name == name {
+			return g
+		}
+	}
+	return models.ConnectionGroup{Name: name}
+}
+
+func (s *Sidebar) editConnection(conn models.ServerConnection) {
+	ShowConnectionDialog(s.window, &conn, func(updated models.ServerConnection) {
+		config.UpdateConnection(updated)
+		s.RefreshConnections()
+		if s.onEdit != nil {
+			s.onEdit(updated)
+		}
+	})
+}
+
+func (s *Sidebar) deleteConnection(conn models.ServerConnection) {
+	if conn.ID == "default" {
+		return
+	}
+	ShowConfirmDialog(s.window, "Delete Connection",
+		i18n.T("Are you sure you want to delete '%s'?", conn.Name),
+		func() {
+			config.RemoveConnection(conn.ID)
+			s.RefreshConnections()
+			if s.onDelete != nil {
+				s.onDelete(conn)
+			}
+		})
+}
+
+// moveConnection moves a connection into group before the connection beforeID
+// (last when empty) and opens the group to show it
+func (s *Sidebar) moveConnection(id, group, beforeID string) {
+	config.MoveConnection(id, group, beforeID)
+	if group != "" && s.group(group).Collapsed {
+		config.SetGroupCollapsed(group, false)
+	}
+	s.RefreshConnections()
+}
+
+// showConnectionMenu shows the context menu for a connection at pos
+func (s *Sidebar) showConnectionMenu(conn models.ServerConnection, pos fyne.Position) {
+	var groupItems []*fyne.MenuItem
+	for _, g := range s.groups {
+		name := g.Name
+		item := fyne.NewMenuItem(name, func() { s.moveConnection(conn.ID, name, "") })
+		item.Checked = conn.Group == name
+		groupItems = append(groupItems, item)
+	}
+	noGroup := fyne.NewMenuItem(i18n.T("No Group"), func() { s.moveConnection(conn.ID, "", "") })
+	noGroup.Checked = conn.Group == ""
+	groupItems = append(groupItems, noGroup, fyne.NewMenuItemSeparator(),
+		fyne.NewMenuItem(i18n.T("New Group..."), func() {
+			ShowGroupNameDialog(s.window, "New Group", "", func(name string) {
+				s.moveConnection(conn.ID, name, "")
+			})
+		}))
+	moveItem := fyne.NewMenuItem(i18n.T("Move to Group"), nil)
+	moveItem.ChildMenu = fyne.NewMenu("", groupItems...)
+
+	menu := fyne.NewMenu("",
+		fyne.NewMenuItem(i18n.T("Connect"), func() {
+			s.connTree.Select(conn.ID)
+			if s.onConnect != nil {
+				s.onConnect(conn)
+			}
+		}),
+		fyne.NewMenuItem(i18n.T("Edit..."), func() { s.editConnection(conn) }),
+		fyne.NewMenuItem(i18n.T("Copy URL..."), func() { ShowCopyURLDialog(s.window, conn) }),
+		fyne.NewMenuItem(i18n.T("Delete"), func() { s.deleteConnection(conn) }),
+		fyne.NewMenuItemSeparator(),
+		moveItem,
+	)
+	widget.ShowPopUpMenuAtPosition(menu, s.window.Canvas(), pos)
+}
+
+// showGroupMenu shows the context menu for a connection group at pos
+func (s *Sidebar) showGroupMenu(name string, pos fyne.Position) {
+	current := s.group(name).Color
+	setColor := func(c string) func() {
+		return func() {
+			config.SetGroupColor(name, c)
+			s.RefreshConnections()
+		}
+	}
+	noColor := fyne.NewMenuItem(i18n.T("None"), setColor(""))
+	noColor.Checked = current == ""
+	colorItems := []*fyne.MenuItem{noColor}
+	for _, c := range groupColors {
+		item := fyne.NewMenuItem(strings.ToUpper(c.name[:1])+c.name[1:], setColor(c.name))
+		item.Checked = current == c.name
+		colorItems = append(colorItems, item)
+	}
+	colorItem := fyne.NewMenuItem(i18n.T("Color"), nil)
+	colorItem.ChildMenu = fyne.NewMenu("", colorItems...)
+
+	menu := fyne.NewMenu("",
+		colorItem,
+		fyne.NewMenuItem(i18n.T("Rename..."), func() {
+			ShowGroupNameDialog(s.window, "Rename Group", name, func(newName string) {
+				if newName != name {
+					config.RenameGroup(name, newName)
+					s.RefreshConnections()
+				}
+			})
+		}),
+		fyne.NewMenuItem(i18n.T("Remove Group"), func() {
+			ShowConfirmDialog(s.window, "Remove Group",
+				i18n.T("Remove group '%s'? Its connections are kept, ungrouped.", name), func() {
+					config.RemoveGroup(name)
+					s.RefreshConnections()
+				})
+		}),
+	)
+	widget.ShowPopUpMenuAtPosition(menu, s.window.Canvas(), pos)
+}
+
+// dragged tracks a connection being dragged, marking the row it would be dropped on
+func (s *Sidebar) dragged(uid widget.TreeNodeID, e *fyne.DragEvent) {
+	if strings.HasPrefix(uid, groupNodePrefix) {
+		return
+	}
+	drop := s.dropAt(e.AbsolutePosition)
+	if s.dragging != uid || drop != s.drop {
+		s.dragging, s.drop = uid, drop
+		s.connTree.Refresh()
+	}
+}
+
+// dropAt finds the row under pos and whether pos is in its lower half
+func (s *Sidebar) dropAt(pos fyne.Position) dropTarget {
+	driver := fyne.CurrentApp().Driver()
+	treePos := driver.AbsolutePositionForObject(s.connTree)
+	inside := func(p, at fyne.Position, size fyne.Size) bool {
+		return p.X >= at.X && p.X < at.X+size.Width && p.Y >= at.Y && p.Y < at.Y+size.Height
+	}
+	if !inside(pos, treePos, s.connTree.Size()) {
+		return dropTarget{}
+	}
+	for row, uid := range s.rows {
+		// Recycled rows that are off screen aren't inside the tree
+		at := driver.AbsolutePositionForObject(row)
+		if !row.Visible() || !inside(at, treePos, s.connTree.Size()) || !inside(pos, at, row.Size()) {
+			continue
+		}
+		return dropTarget{node: uid, below: pos.Y >= at.Y+row.Size().Height/2}
+	}
+	return dropTarget{}
+}
+
+// dragEnd drops the dragged connection into a group, or above or below
+// another connection and into its group
+func (s *Sidebar) dragEnd() {
+	id, drop := s.dragging, s.drop
+	s.dragging, s.drop = "", dropTarget{}
+	s.connTree.Refresh()
+	if id == "" || drop.node == "" || drop.node == id {
+		return
+	}
+
+	if name, ok := strings.CutPrefix(drop.node, groupNodePrefix); ok {
+		s.moveConnection(id, name, "")
+		return
+	}
+	onto, ok := s.connection(drop.node)
+	if !ok {
+		return
+	}
+	before := onto.ID
+	if drop.below {
+		before = ""
+		siblings := s.grouped[onto.Group]
+		for i, conn := range siblings {
+			if conn.ID == onto.ID && i+1 < len(siblings) {
+				before = siblings[i+1].ID
+			}
+		}
+		if before == id {
+			return // already right below it
+		}
+	}
+	s.moveConnection(id, onto.Group, before)
 }
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// sparkline is a small line chart of a series of values, scaled to fit its size
+type sparkline struct {
+	widget.BaseWidget
+	values []float64
+}
+
+func newSparkline() *sparkline {
+	s := &sparkline{}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// SetValues replaces the charted values, oldest first
+func (s *sparkline) SetValues(values []float64) {
+	s.values = values
+	s.Refresh()
+}
+
+// CreateRenderer implements fyne.Widget
+func (s *sparkline) CreateRenderer() fyne.WidgetRenderer {
+	r := &sparklineRenderer{
+		s:          s,
+		background: canvas.NewRectangle(theme.Color(theme.ColorNameInputBackground)),
+	}
+	r.Refresh()
+	return r
+}
+
+type sparklineRenderer struct {
+	s          *sparkline
+	background *canvas.Rectangle
+	lines      []*canvas.Line
+}
+
+func (r *sparklineRenderer) Layout(size fyne.Size) {
+	r.background.Resize(size)
+
+	values := r.s.values
+	if len(values) < 2 {
+		return
+	}
+	lo, hi := values[0], values[0]
+	for _, v := range values {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	pad := theme.Padding()
+	height := size.Height - 2*pad
+	step := (size.Width - 2*pad) / float32(len(values)-1)
+	point := func(i int) fyne.Position {
+		y := height / 2
+		if hi > lo {
+			y = height - float32((values[i]-lo)/(hi-lo))*height
+		}
+		return fyne.NewPos(pad+float32(i)*step, pad+y)
+	}
+	for i, line := range r.lines {
+		line.Position1 = point(i)
+		line.Position2 = point(i + 1)
+	}
+}
+
+func (r *sparklineRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(120, 50)
+}
+
+func (r *sparklineRenderer) Refresh() {
+	r.background.FillColor = theme.Color(theme.ColorNameInputBackground)
+	r.background.Refresh()
+
+	want := max(len(r.s.values)-1, 0)
+	for len(r.lines) < want {
+		r.lines = append(r.lines, canvas.NewLine(color.Transparent))
+	}
+	r.lines = r.lines[:want]
+	for _, line := range r.lines {
+		line.StrokeColor = theme.Color(theme.ColorNamePrimary)
+		line.StrokeWidth = 2
+	}
+	r.Layout(r.s.Size())
+	canvas.Refresh(r.s)
+}
+
+func (r *sparklineRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{r.background}
+	for _, line := range r.lines {
+		objects = append(objects, line)
+	}
+	return objects
+}
+
+func (r *sparklineRenderer) Destroy() {}
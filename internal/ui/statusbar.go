@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+)
+
+// StatusBar runs along the bottom of the main window, showing the active
+// connection, database and key count, the result of the last operation and
+// the background job queue. Operations report their results through
+// Worker.Report instead of interrupting with a dialog.
+type StatusBar struct {
+	widget.BaseWidget
+	container *fyne.Container
+
+	connIcon    *widget.Icon
+	connLabel   *widget.Label
+	dbLabel     *widget.Label
+	keysLabel   *widget.Label
+	resultIcon  *widget.Icon
+	resultLabel *widget.Label
+}
+
+// NewStatusBar creates a status bar showing no connection, with the job queue
+// at its end
+func NewStatusBar(jobQueue *JobQueue) *StatusBar {
+	s := &StatusBar{}
+	s.ExtendBaseWidget(s)
+
+	s.connIcon = widget.NewIcon(theme.StorageIcon())
+	s.connLabel = widget.NewLabel("")
+	s.dbLabel = widget.NewLabel("")
+	s.keysLabel = widget.NewLabel("")
+	s.resultIcon = widget.NewIcon(nil)
+	s.resultIcon.Hide()
+	s.resultLabel = widget.NewLabel("")
+	s.resultLabel.Truncation = fyne.TextTruncateEllipsis
+
+	info := container.NewHBox(s.connIcon, s.connLabel, s.dbLabel, s.keysLabel, widget.NewSeparator(), s.resultIcon)
+	s.container = container.NewBorder(widget.NewSeparator(), nil, info, jobQueue, s.resultLabel)
+	s.Clear()
+	return s
+}
+
+// CreateRenderer implements fyne.Widget
+func (s *StatusBar) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.container)
+}
+
+// SetConnection shows the connection and database in use; the key count is
+// unknown until SetKeyCount
+func (s *StatusBar) SetConnection(name string, db int) {
+	s.connLabel.SetText(name)
+	s.connLabel.Importance = widget.MediumImportance
+	s.connLabel.Refresh()
+	s.SetDatabase(db)
+}
+
+// SetDatabase shows the database in use
+func (s *StatusBar) SetDatabase(db int) {
+	s.dbLabel.SetText(i18n.T("DB %d", db))
+	s.dbLabel.Show()
+	s.keysLabel.SetText("")
+}
+
+// SetKeyCount shows how many keys the database holds
+func (s *StatusBar) SetKeyCount(keys int64) {
+	s.keysLabel.SetText(i18n.T("%s keys", formatCount(keys)))
+}
+
+// SetStale marks the connection as not responding
+func (s *StatusBar) SetStale() {
+	s.connLabel.Importance = widget.DangerImportance
+	s.connLabel.Refresh()
+}
+
+// SetRecovered marks the connection as responding again
+func (s *StatusBar) SetRecovered() {
+	s.connLabel.Importance = widget.MediumImportance
+	s.connLabel.Refresh()
+}
+
+// ShowResult shows the outcome of the last operation with the time it
+// finished. A non-nil err shows it as a failure.
+func (s *StatusBar) ShowResult(message string, err error) {
+	text := time.Now().Format(time.TimeOnly) + "  " + message
+	if err != nil {
+		text += ": " + err.Error()
+		s.resultIcon.SetResource(theme.NewErrorThemedResource(theme.ErrorIcon()))
+		s.resultLabel.Importance = widget.DangerImportance
+	} else {
+		s.resultIcon.SetResource(theme.NewSuccessThemedResource(theme.ConfirmIcon()))
+		s.resultLabel.Importance = widget.MediumImportance
+	}
+	s.resultIcon.Show()
+	s.resultLabel.SetText(text)
+}
+
+// Clear shows that no connection is active and forgets the last result
+func (s *StatusBar) Clear() {
+	s.connLabel.SetText(i18n.T("Not connected"))
+	s.connLabel.Importance = widget.LowImportance
+	s.connLabel.Refresh()
+	s.dbLabel.SetText("")
+	s.dbLabel.Hide()
+	s.keysLabel.SetText("")
+	s.resultIcon.Hide()
+	s.resultLabel.SetText("")
+}
@@ -0,0 +1,356 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// streamPendingLimit is how many pending entries the dashboard lists at once
+const streamPendingLimit = 200
+
+// buildStreamGroups is the consumer group dashboard of a stream: its groups,
+// and for the selected group its consumers and pending entries (XPENDING),
+// with XACK, XCLAIM and XAUTOCLAIM to recover entries a consumer never
+// acknowledged
+func (ve *ValueEditor) buildStreamGroups(key models.RedisKey, groups []models.StreamGroup) fyne.CanvasObject {
+	if len(groups) == 0 {
+		return widget.NewLabel(i18n.T("No consumer groups"))
+	}
+
+	var group string
+	var consumers []models.StreamConsumer
+	var pending []models.StreamPending
+	selectedPending := -1
+
+	groupsTable := widget.NewTable(
+		func() (int, int) { return len(groups) + 1, 5 },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(i18n.T([]string{"Group", "Consumers", "Pending", "Last Delivered", "Lag"}[id.Col]))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			g := groups[id.Row-1]
+			label.TextStyle = fyne.TextStyle{}
+			switch id.Col {
+			case 0:
+				label.SetText(g.Name)
+			case 1:
+				label.SetText(strconv.FormatInt(g.Consumers, 10))
+			case 2:
+				label.SetText(strconv.FormatInt(g.Pending, 10))
+			case 3:
+				label.SetText(g.LastDeliveredID)
+			case 4:
+				if g.Lag < 0 {
+					label.SetText("?")
+				} else {
+					label.SetText(strconv.FormatInt(g.Lag, 10))
+				}
+			}
+		},
+	)
+	groupsTable.SetColumnWidth(0, 150)
+	groupsTable.SetColumnWidth(3, 180)
+
+	consumersTable := widget.NewTable(
+		func() (int, int) { return len(consumers) + 1, 4 },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(i18n.T([]string{"Consumer", "Pending", "Idle", "Inactive"}[id.Col]))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			c := consumers[id.Row-1]
+			label.TextStyle = fyne.TextStyle{}
+			switch id.Col {
+			case 0:
+				label.SetText(c.Name)
+			case 1:
+				label.SetText(strconv.FormatInt(c.Pending, 10))
+			case 2:
+				label.SetText(formatIdle(c.Idle))
+			case 3:
+				switch {
+				case c.Inactive < 0:
+					label.SetText(i18n.T("never read"))
+				case c.Inactive == 0:
+					label.SetText("")
+				default:
+					label.SetText(formatIdle(c.Inactive))
+				}
+			}
+		},
+	)
+	consumersTable.SetColumnWidth(0, 180)
+
+	pendingTable := widget.NewTable(
+		func() (int, int) { return len(pending) + 1, 4 },
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.TableCellID, o fyne.CanvasObject) {
+			label := o.(*widget.Label)
+			if id.Row == 0 {
+				label.SetText(i18n.T([]string{"Entry", "Consumer", "Idle", "Deliveries"}[id.Col]))
+				label.TextStyle = fyne.TextStyle{Bold: true}
+				return
+			}
+			p := pending[id.Row-1]
+			label.TextStyle = fyne.TextStyle{}
+			switch id.Col {
+			case 0:
+				label.SetText(p.ID)
+			case 1:
+				label.SetText(p.Consumer)
+			case 2:
+				label.SetText(formatIdle(p.Idle))
+			case 3:
+				label.SetText(strconv.FormatInt(p.Deliveries, 10))
+			}
+		},
+	)
+	pendingTable.SetColumnWidth(0, 180)
+	pendingTable.SetColumnWidth(1, 150)
+	pendingTable.OnSelected = func(id widget.TableCellID) {
+		selectedPending = id.Row - 1
+	}
+
+	groupLabel := widget.NewLabelWithStyle(i18n.T("Select a group to see its consumers and pending entries"), fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	pendingLabel := widget.NewLabel("")
+
+	// The first consumer filter option lists every consumer's entries
+	allConsumers := i18n.T("All consumers")
+	consumerSelect := widget.NewSelect([]string{allConsumers}, nil)
+	consumerSelect.SetSelected(allConsumers)
+	minIdleEntry := widget.NewEntry()
+	minIdleEntry.SetPlaceHolder(i18n.T("Min idle, e.g. 5m"))
+	claimEntry := widget.NewEntry()
+	claimEntry.SetPlaceHolder(i18n.T("Consumer to claim for"))
+
+	minIdle := func() (time.Duration, bool) {
+		text := strings.TrimSpace(minIdleEntry.Text)
+		if text == "" {
+			return 0, true
+		}
+		if secs, err := strconv.ParseInt(text, 10, 64); err == nil && secs >= 0 {
+			return time.Duration(secs) * time.Second, true
+		}
+		if secs, ok := parseDurationSeconds(text); ok {
+			return time.Duration(secs) * time.Second, true
+		}
+		ShowErrorDialog(ve.window, "Invalid Idle Time", fmt.Errorf("'%s' is not a duration: use seconds or e.g. 90s, 5m, 2h", text))
+		return 0, false
+	}
+
+	// loadGroup re-reads the groups, then the consumers and pending entries of
+	// the selected group, after a change or when the filter changes
+	loadGroup := func() {
+		if group == "" {
+			return
+		}
+		idle, ok := minIdle()
+		if !ok {
+			return
+		}
+		consumer := ""
+		if consumerSelect.SelectedIndex() > 0 {
+			consumer = consumerSelect.Selected
+		}
+		name := group
+		var readGroups []models.StreamGroup
+		var readConsumers []models.StreamConsumer
+		var readPending []models.StreamPending
+		ve.worker.Do(ve.window, ve.client, func(c *redis.Client) (err error) {
+			if readGroups, err = c.GetStreamGroups(key.Key); err != nil {
+				return err
+			}
+			if readConsumers, err = c.GetStreamConsumers(key.Key, name); err != nil {
+				return err
+			}
+			readPending, err = c.GetStreamPending(key.Key, name, consumer, idle, streamPendingLimit)
+			return err
+		}, func() {
+			if group != name {
+				return
+			}
+			groups, consumers, pending = readGroups, readConsumers, readPending
+			groupsTable.Refresh()
+			consumersTable.Refresh()
+			selectedPending = -1
+			pendingTable.UnselectAll()
+			pendingTable.Refresh()
+
+			options := []string{allConsumers}
+			for _, c := range consumers {
+				options = append(options, c.Name)
+			}
+			consumerSelect.Options = options
+			consumerSelect.Refresh()
+
+			groupLabel.SetText(i18n.T("Group '%s'", name))
+			if len(pending) == streamPendingLimit {
+				pendingLabel.SetText(i18n.T("Showing the oldest %d pending entries", len(pending)))
+			} else {
+				pendingLabel.SetText(i18n.T("%d pending entries", len(pending)))
+			}
+		})
+	}
+	consumerSelect.OnChanged = func(string) { loadGroup() }
+	minIdleEntry.OnSubmitted = func(string) { loadGroup() }
+
+	groupsTable.OnSelected = func(id widget.TableCellID) {
+		if id.Row == 0 || id.Row > len(groups) {
+			return
+		}
+		if name := groups[id.Row-1].Name; name != group {
+			// Set without OnChanged, which would load the group a second time
+			group = name
+			consumerSelect.Selected = allConsumers
+			consumerSelect.Refresh()
+		}
+		loadGroup()
+	}
+
+	// change runs a write against the group, keeping a snapshot of the stream
+	// (whose DUMP includes its groups) for undo, then reloads the group
+	change := func(label string, op func(c *redis.Client) (string, error)) {
+		if group == "" || refuseReadOnly(ve.window, ve.client) {
+			return
+		}
+		client := ve.client
+		var snapshots []models.KeySnapshot
+		var result string
+		ve.worker.Do(ve.window, client, func(c *redis.Client) (err error) {
+			snapshots = snapshotKeys(c, []string{key.Key})
+			result, err = op(c)
+			return err
+		}, func() {
+			ve.undo.push(client, label, snapshots, false)
+			loadGroup()
+			ve.worker.Report(result, nil)
+		})
+	}
+
+	selectedIDs := func() []string {
+		if selectedPending < 0 || selectedPending >= len(pending) {
+			return nil
+		}
+		return []string{pending[selectedPending].ID}
+	}
+	shownIDs := func() []string {
+		ids := make([]string, len(pending))
+		for i, p := range pending {
+			ids[i] = p.ID
+		}
+		return ids
+	}
+	ack := func(ids []string) {
+		name := group
+		change(i18n.T("Acknowledged %d entries of '%s'", len(ids), key.Key), func(c *redis.Client) (string, error) {
+			n, err := c.StreamAck(key.Key, name, ids)
+			return i18n.T("Acknowledged %d entries", n), err
+		})
+	}
+
+	ackBtn := widget.NewButtonWithIcon(i18n.T("Ack Selected"), theme.ConfirmIcon(), func() {
+		if ids := selectedIDs(); ids != nil {
+			ack(ids)
+		}
+	})
+	ackAllBtn := widget.NewButton(i18n.T("Ack All Shown"), func() {
+		ids := shownIDs()
+		if len(ids) == 0 {
+			return
+		}
+		confirmDestructive(ve.window, "Acknowledge Entries",
+			i18n.T("Acknowledge the %d pending entries shown in group '%s'? They won't be redelivered.", len(ids), group),
+			func() { ack(ids) })
+	})
+
+	claimFor := func() (string, time.Duration, bool) {
+		consumer := strings.TrimSpace(claimEntry.Text)
+		if consumer == "" {
+			ShowErrorDialog(ve.window, "No Consumer", fmt.Errorf("enter the consumer to claim the entries for"))
+			return "", 0, false
+		}
+		idle, ok := minIdle()
+		return consumer, idle, ok
+	}
+	claimBtn := widget.NewButton(i18n.T("Claim Selected"), func() {
+		ids := selectedIDs()
+		if ids == nil {
+			return
+		}
+		consumer, idle, ok := claimFor()
+		if !ok {
+			return
+		}
+		name := group
+		change(i18n.T("Claimed entries of '%s'", key.Key), func(c *redis.Client) (string, error) {
+			claimed, err := c.StreamClaim(key.Key, name, consumer, idle, ids)
+			if err == nil && len(claimed) == 0 {
+				return i18n.T("Nothing was claimed: the entry is no longer pending or was idle for less than the minimum."), nil
+			}
+			return i18n.T("Claimed %d entries for '%s'", len(claimed), consumer), err
+		})
+	})
+	autoClaimBtn := widget.NewButton(i18n.T("Auto-Claim Idle"), func() {
+		consumer, idle, ok := claimFor()
+		if !ok {
+			return
+		}
+		name := group
+		confirmDestructive(ve.window, "Auto-Claim Entries",
+			i18n.T("Hand every entry of group '%s' idle for at least %s to '%s'?", name, formatIdle(idle), consumer),
+			func() {
+				change(i18n.T("Auto-claimed entries of '%s'", key.Key), func(c *redis.Client) (string, error) {
+					n, err := c.StreamAutoClaim(key.Key, name, consumer, idle)
+					return i18n.T("Claimed %d entries for '%s'", n, consumer), err
+				})
+			})
+	})
+	refreshBtn := widget.NewButtonWithIcon("", theme.ViewRefreshIcon(), loadGroup)
+	setWritable(ve.client, ackBtn, ackAllBtn, claimBtn, autoClaimBtn)
+
+	filterBar := container.NewBorder(nil, nil, widget.NewLabel(i18n.T("Show")), refreshBtn,
+		container.NewGridWithColumns(2, consumerSelect, minIdleEntry))
+	actionBar := container.NewVBox(
+		pendingLabel,
+		container.NewHBox(ackBtn, ackAllBtn),
+		container.NewBorder(nil, nil, nil, container.NewHBox(claimBtn, autoClaimBtn), claimEntry),
+	)
+	pendingBox := container.NewBorder(filterBar, actionBar, nil, nil, pendingTable)
+
+	details := container.NewHSplit(consumersTable, pendingBox)
+	details.SetOffset(0.35)
+	split := container.NewVSplit(groupsTable, container.NewBorder(groupLabel, nil, nil, nil, details))
+	split.SetOffset(0.3)
+	return split
+}
+
+// formatIdle renders an idle time, showing milliseconds below a second
+func formatIdle(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return formatTTL(int64(d.Seconds()))
+}
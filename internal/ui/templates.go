@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/google/uuid"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// placeholderPattern matches a {placeholder} of a key template
+var placeholderPattern = regexp.MustCompile(`\{(\w+)\}`)
+
+// builtinPlaceholders are filled in when a key is created rather than asked for
+var builtinPlaceholders = []string{"uuid", "timestamp"}
+
+// templatePlaceholders returns the placeholders of a template that are asked
+// for, in the order they first appear
+func templatePlaceholders(t models.KeyTemplate) []string {
+	texts := append([]string{t.Pattern, t.Value, t.TTL}, t.Items...)
+	for _, f := range t.Fields {
+		texts = append(texts, f.Key, f.Value)
+	}
+	var names []string
+	for _, text := range texts {
+		for _, m := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(builtinPlaceholders, m[1]) && !slices.Contains(names, m[1]) {
+				names = append(names, m[1])
+			}
+		}
+	}
+	return names
+}
+
+// fillPlaceholders replaces the placeholders of text that values has
+func fillPlaceholders(text string, values map[string]string) string {
+	if len(values) == 0 {
+		return text
+	}
+	return placeholderPattern.ReplaceAllStringFunc(text, func(m string) string {
+		if value, ok := values[m[1:len(m)-1]]; ok {
+			return value
+		}
+		return m
+	})
+}
+
+// templateValues adds the built-in placeholders to the values typed for a
+// template, one uuid and timestamp for the whole key
+func templateValues(typed map[string]string, now time.Time) map[string]string {
+	values := map[string]string{
+		"uuid":      uuid.New().String(),
+		"timestamp": strconv.FormatInt(now.Unix(), 10),
+	}
+	for name, value := range typed {
+		values[name] = value
+	}
+	return values
+}
+
+// templateDump builds the key a template creates, with its placeholders
+// filled from values. Redis has no empty collections, so a list, set, hash or
+// sorted set needs at least one entry.
+func templateDump(t models.KeyTemplate, values map[string]string, now time.Time) (*models.KeyDump, error) {
+	key := strings.TrimSpace(fillPlaceholders(t.Pattern, values))
+	if key == "" {
+		return nil, fmt.Errorf("key name is required")
+	}
+	exp, err := parseExpiry(fillPlaceholders(t.TTL, values), now)
+	if err != nil {
+		return nil, err
+	}
+
+	dump := &models.KeyDump{Key: key, Type: t.Type, TTL: expirySeconds(exp, now)}
+	switch t.Type {
+	case "string":
+		dump.Value = fillPlaceholders(t.Value, values)
+	case "list", "set":
+		for _, item := range t.Items {
+			dump.Items = append(dump.Items, fillPlaceholders(item, values))
+		}
+		if len(dump.Items) == 0 {
+			return nil, fmt.Errorf("a %s needs at least one entry", t.Type)
+		}
+	case "hash":
+		dump.Fields = make(map[string]string)
+		for _, f := range t.Fields {
+			if f.Key == "" {
+				return nil, fmt.Errorf("the value '%s' has no field name", f.Value)
+			}
+			dump.Fields[fillPlaceholders(f.Key, values)] = fillPlaceholders(f.Value, values)
+		}
+		if len(dump.Fields) == 0 {
+			return nil, fmt.Errorf("a hash needs at least one field")
+		}
+	case "zset":
+		for _, f := range t.Fields {
+			member := fillPlaceholders(f.Key, values)
+			score := 0.0
+			if text := strings.TrimSpace(fillPlaceholders(f.Value, values)); text != "" {
+				if score, err = strconv.ParseFloat(text, 64); err != nil {
+					return nil, fmt.Errorf("the score of '%s' is not a number", member)
+				}
+			}
+			dump.Members = append(dump.Members, models.ScoredValue{Member: member, Score: score})
+		}
+		if len(dump.Members) == 0 {
+			return nil, fmt.Errorf("a sorted set needs at least one member")
+		}
+	default:
+		return nil, fmt.Errorf("key type is required")
+	}
+	return dump, nil
+}
+
+// promptSaveTemplate asks for a name and saves t, filled in from the New Key
+// dialog, as a key template
+func promptSaveTemplate(window fyne.Window, t models.KeyTemplate) {
+	if t.Pattern == "" || t.Type == "" {
+		dialog.ShowError(fmt.Errorf("fill in at least the key name and type to save them as a template"), window)
+		return
+	}
+	nameEntry := widget.NewEntry()
+	nameEntry.SetPlaceHolder(i18n.T("e.g. Session"))
+	d := dialog.NewForm(i18n.T("Save as Template"), i18n.T("Save"), i18n.T("Cancel"),
+		[]*widget.FormItem{
+			{Text: i18n.T("Template"), Widget: nameEntry, HintText: i18n.T("A template of the same name is replaced")},
+			{Text: i18n.T("Key"), Widget: widget.NewLabel(t.Pattern),
+				HintText: i18n.T("{name} placeholders are asked for on creation; {uuid} and {timestamp} are filled in")},
+		},
+		func(ok bool) {
+			if !ok {
+				return
+			}
+			if t.Name = strings.TrimSpace(nameEntry.Text); t.Name == "" {
+				dialog.ShowError(fmt.Errorf("name the template to save it"), window)
+				return
+			}
+			if err := config.SaveKeyTemplate(t); err != nil {
+				ShowErrorDialog(window, "Save Template", err)
+			}
+		}, window)
+	d.Resize(fyne.NewSize(440, 220))
+	d.Show()
+	window.Canvas().Focus(nameEntry)
+}
+
+// ShowKeyTemplateDialog creates a key from a saved template, asking for the
+// template's placeholders and showing the key name they make
+func ShowKeyTemplateDialog(window fyne.Window, onCreate func(dump *models.KeyDump)) {
+	templates := config.GetKeyTemplates()
+	if len(templates) == 0 {
+		ShowInfoDialog(window, "New Key from Template",
+			"There are no key templates yet. Fill in the New Key dialog with the shape of a key and click Save as Template to make one.")
+		return
+	}
+
+	var current models.KeyTemplate
+	var entries map[string]*widget.Entry
+	typed := func() map[string]string {
+		values := make(map[string]string, len(entries))
+		for name, entry := range entries {
+			values[name] = entry.Text
+		}
+		return values
+	}
+
+	keyLabel := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+	keyLabel.Truncation = fyne.TextTruncateEllipsis
+	typeLabel := widget.NewLabel("")
+	ttlEntry := widget.NewEntry()
+	ttlEntry.SetPlaceHolder(i18n.T("No expiry, or e.g. 90s, 2h, 7d"))
+	placeholderForm := widget.NewForm()
+	updateKey := func() {
+		keyLabel.SetText(fillPlaceholders(current.Pattern, typed()))
+	}
+
+	names := make([]string, len(templates))
+	for i, t := range templates {
+		names[i] = t.Name
+	}
+	var deleteBtn *widget.Button
+	templateSelect := widget.NewSelect(names, func(name string) {
+		i := slices.Index(names, name)
+		if i < 0 {
+			return
+		}
+		current = templates[i]
+		typeLabel.SetText(current.Type)
+		ttlEntry.SetText(current.TTL)
+		entries = make(map[string]*widget.Entry)
+		placeholderForm.Items = nil
+		for _, p := range templatePlaceholders(current) {
+			entry := widget.NewEntry()
+			entry.OnChanged = func(string) { updateKey() }
+			entries[p] = entry
+			placeholderForm.Append(p, entry)
+		}
+		placeholderForm.Refresh()
+		updateKey()
+		deleteBtn.Enable()
+	})
+
+	var d *dialog.CustomDialog
+	deleteBtn = widget.NewButtonWithIcon(i18n.T("Delete Template"), theme.DeleteIcon(), func() {
+		name := current.Name
+		ShowConfirmDialog(window, "Delete Template", i18n.T("Delete the key template '%s'?", name), func() {
+			if err := config.DeleteKeyTemplate(name); err != nil {
+				ShowErrorDialog(window, "Delete Template", err)
+				return
+			}
+			d.Hide()
+		})
+	})
+	deleteBtn.Importance = widget.LowImportance
+	deleteBtn.Disable()
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Template"), container.NewBorder(nil, nil, nil, deleteBtn, templateSelect)),
+		widget.NewFormItem(i18n.T("Type"), typeLabel),
+		widget.NewFormItem(i18n.T("Key"), keyLabel),
+		widget.NewFormItem(i18n.T("TTL"), ttlEntry),
+	)
+	content := container.NewVBox(form, widget.NewSeparator(), placeholderForm)
+
+	createBtn := widget.NewButton(i18n.T("Create"), func() {
+		if current.Name == "" {
+			return
+		}
+		for name, entry := range entries {
+			if entry.Text == "" {
+				dialog.ShowError(fmt.Errorf("fill in {%s}", name), window)
+				return
+			}
+		}
+		t := current
+		t.TTL = ttlEntry.Text
+		now := time.Now()
+		dump, err := templateDump(t, templateValues(typed(), now), now)
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		d.Hide()
+		onCreate(dump)
+	})
+	createBtn.Importance = widget.HighImportance
+	cancelBtn := widget.NewButton(i18n.T("Cancel"), func() { d.Hide() })
+
+	d = dialog.NewCustomWithoutButtons(i18n.T("New Key from Template"), content, window)
+	d.SetButtons([]fyne.CanvasObject{cancelBtn, createBtn})
+	d.Resize(fyne.NewSize(460, 360))
+	d.Show()
+	templateSelect.SetSelectedIndex(0)
+}
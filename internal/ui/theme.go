@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"fmt"
 	"image/color"
+	"strconv"
+	"strings"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
+	"redis-explorer/internal/config"
 	"redis-explorer/internal/models"
 )
 
@@ -134,6 +138,20 @@ var solarizedTheme = &CustomTheme{
 
 // GetTheme returns the theme for the given name
 func GetTheme(name models.ThemeName) fyne.Theme {
+	if name == models.ThemeAuto {
+		auto := config.Get().AutoTheme
+		return &autoTheme{light: GetTheme(auto.Light), dark: GetTheme(auto.Dark)}
+	}
+	if custom, ok := name.CustomName(); ok {
+		if def := config.GetCustomTheme(custom); def != nil {
+			return buildCustomTheme(*def)
+		}
+	}
+	return builtinTheme(name)
+}
+
+// builtinTheme returns the built-in theme for the given name, or the dark theme
+func builtinTheme(name models.ThemeName) *CustomTheme {
 	switch name {
 	case models.ThemeLight:
 		return lightTheme
@@ -148,6 +166,40 @@ func GetTheme(name models.ThemeName) fyne.Theme {
 	}
 }
 
+// autoTheme follows the system's light or dark preference, drawing with the
+// light or dark theme the Auto (System) theme was set to
+type autoTheme struct {
+	light, dark fyne.Theme
+}
+
+// current is the theme for the system's present variant
+func (t *autoTheme) current(variant fyne.ThemeVariant) fyne.Theme {
+	if variant == theme.VariantLight {
+		return t.light
+	}
+	return t.dark
+}
+
+// Color implements fyne.Theme
+func (t *autoTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
+	return t.current(variant).Color(name, variant)
+}
+
+// Font implements fyne.Theme
+func (t *autoTheme) Font(style fyne.TextStyle) fyne.Resource {
+	return t.current(fyne.CurrentApp().Settings().ThemeVariant()).Font(style)
+}
+
+// Icon implements fyne.Theme
+func (t *autoTheme) Icon(name fyne.ThemeIconName) fyne.Resource {
+	return t.current(fyne.CurrentApp().Settings().ThemeVariant()).Icon(name)
+}
+
+// Size implements fyne.Theme
+func (t *autoTheme) Size(name fyne.ThemeSizeName) float32 {
+	return t.current(fyne.CurrentApp().Settings().ThemeVariant()).Size(name)
+}
+
 // Color implements fyne.Theme
 func (t *CustomTheme) Color(name fyne.ThemeColorName, variant fyne.ThemeVariant) color.Color {
 	switch name {
@@ -238,3 +290,109 @@ func (t *CustomTheme) Size(name fyne.ThemeSizeName) float32 {
 	}
 	return theme.DefaultTheme().Size(name)
 }
+
+// themeColors are the colors a custom theme can replace, by their name in
+// models.CustomTheme
+var themeColors = []struct {
+	name  string
+	label string
+	field func(t *CustomTheme) *color.Color
+}{
+	{"background", "Background", func(t *CustomTheme) *color.Color { return &t.backgroundColor }},
+	{"foreground", "Text", func(t *CustomTheme) *color.Color { return &t.foregroundColor }},
+	{"primary", "Primary", func(t *CustomTheme) *color.Color { return &t.primaryColor }},
+	{"hover", "Hover and Headers", func(t *CustomTheme) *color.Color { return &t.hoverColor }},
+	{"input_background", "Inputs and Buttons", func(t *CustomTheme) *color.Color { return &t.inputBgColor }},
+	{"disabled", "Disabled", func(t *CustomTheme) *color.Color { return &t.disabledColor }},
+	{"scroll_bar", "Scroll Bar", func(t *CustomTheme) *color.Color { return &t.scrollBarColor }},
+	{"separator", "Separator", func(t *CustomTheme) *color.Color { return &t.separatorColor }},
+	{"shadow", "Shadow", func(t *CustomTheme) *color.Color { return &t.shadowColor }},
+	{"error", "Error", func(t *CustomTheme) *color.Color { return &t.errorColor }},
+	{"success", "Success", func(t *CustomTheme) *color.Color { return &t.successColor }},
+	{"warning", "Warning", func(t *CustomTheme) *color.Color { return &t.warningColor }},
+}
+
+// buildCustomTheme copies a custom theme's base theme and replaces the colors
+// it sets. Colors that don't parse keep the base theme's.
+func buildCustomTheme(def models.CustomTheme) *CustomTheme {
+	t := *builtinTheme(def.Base)
+	t.name = models.CustomThemeName(def.Name)
+	for _, c := range themeColors {
+		if value, ok := def.Colors[c.name]; ok {
+			if parsed, err := parseHexColor(value); err == nil {
+				*c.field(&t) = parsed
+			}
+		}
+	}
+	return &t
+}
+
+// parseHexColor parses a color written as #rrggbb or #rrggbbaa
+func parseHexColor(s string) (color.NRGBA, error) {
+	hex := strings.TrimPrefix(strings.TrimSpace(s), "#")
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 8 || err != nil {
+		return color.NRGBA{}, fmt.Errorf("'%s' is not a color like #1e1e1e or #1e1e1e80", s)
+	}
+	return color.NRGBA{R: uint8(value >> 24), G: uint8(value >> 16), B: uint8(value >> 8), A: uint8(value)}, nil
+}
+
+// hexColor writes a color as #rrggbb, or #rrggbbaa if it is translucent
+func hexColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 255 {
+		return fmt.Sprintf("#%02x%02x%02x", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("#%02x%02x%02x%02x", n.R, n.G, n.B, n.A)
+}
+
+// Text size bounds for the appearance settings and Ctrl +/-
+const (
+	defaultTextSize = 14
+	minTextSize     = 10
+	maxTextSize     = 28
+)
+
+// appearanceTheme applies the appearance settings to a theme: text is drawn
+// at the configured size, with headings and captions scaled alike
+type appearanceTheme struct {
+	fyne.Theme
+	textSize float32
+}
+
+// Size implements fyne.Theme
+func (t *appearanceTheme) Size(name fyne.ThemeSizeName) float32 {
+	size := t.Theme.Size(name)
+	switch name {
+	case theme.SizeNameText, theme.SizeNameHeadingText, theme.SizeNameSubHeadingText, theme.SizeNameCaptionText:
+		return size * t.textSize / defaultTextSize
+	}
+	return size
+}
+
+// applyTheme shows the app in the configured theme and appearance
+func applyTheme(app fyne.App) {
+	previewTheme(app, GetTheme(config.Get().Theme))
+}
+
+// previewTheme shows the app in a theme, with the configured appearance,
+// without selecting it
+func previewTheme(app fyne.App, t fyne.Theme) {
+	app.Settings().SetTheme(&appearanceTheme{Theme: t, textSize: config.Get().Appearance.TextSize})
+}
+
+// valueTextStyle is the text style of key values, monospace if so configured
+func valueTextStyle() fyne.TextStyle {
+	return fyne.TextStyle{Monospace: config.Get().Appearance.MonospaceValues}
+}
+
+// valueWrapping is how long key values wrap in editors
+func valueWrapping() fyne.TextWrap {
+	if config.Get().Appearance.WrapValues {
+		return fyne.TextWrapWord
+	}
+	return fyne.TextWrapOff
+}
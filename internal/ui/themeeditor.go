@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"image/color"
+	"slices"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// ShowThemeEditor edits a custom theme: its name, the built-in theme it starts
+// from and the colors it replaces. The app previews the theme while it is
+// edited and goes back to the configured theme if the edit is cancelled.
+// onSave receives the saved theme's name.
+func ShowThemeEditor(window fyne.Window, def models.CustomTheme, onSave func(models.ThemeName)) {
+	app := fyne.CurrentApp()
+	colors := make(map[string]string, len(def.Colors))
+	for name, value := range def.Colors {
+		colors[name] = value
+	}
+	if !slices.Contains(models.AllThemes(), def.Base) {
+		def.Base = models.ThemeDark
+	}
+
+	nameEntry := widget.NewEntry()
+	nameEntry.SetText(def.Name)
+	nameEntry.SetPlaceHolder(i18n.T("My Theme"))
+
+	bases := models.AllThemes()
+	baseNames := make([]string, len(bases))
+	for i, t := range bases {
+		baseNames[i] = t.DisplayName()
+	}
+	baseSelect := widget.NewSelect(baseNames, nil)
+
+	entries := make([]*widget.Entry, len(themeColors))
+	swatches := make([]*canvas.Rectangle, len(themeColors))
+	preview := func() {
+		def.Colors = colors
+		t := buildCustomTheme(def)
+		base := builtinTheme(def.Base)
+		for i, c := range themeColors {
+			swatches[i].FillColor = *c.field(t)
+			swatches[i].Refresh()
+			entries[i].SetPlaceHolder(hexColor(*c.field(base)))
+		}
+		previewTheme(app, t)
+	}
+
+	form := container.New(layout.NewFormLayout())
+	for i, c := range themeColors {
+		name := c.name
+		entry := widget.NewEntry()
+		entry.SetText(colors[name])
+		entry.Validator = func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return nil
+			}
+			_, err := parseHexColor(s)
+			return err
+		}
+		entry.OnChanged = func(s string) {
+			if strings.TrimSpace(s) == "" {
+				delete(colors, name)
+			} else if _, err := parseHexColor(s); err == nil {
+				colors[name] = strings.TrimSpace(s)
+			} else {
+				return
+			}
+			preview()
+		}
+		entries[i] = entry
+
+		swatch := canvas.NewRectangle(color.Transparent)
+		swatch.SetMinSize(fyne.NewSize(24, 24))
+		swatch.CornerRadius = 4
+		swatches[i] = swatch
+
+		pickBtn := widget.NewButtonWithIcon("", theme.ColorPaletteIcon(), func() {
+			picker := dialog.NewColorPicker(i18n.T("Pick %s", i18n.T(c.label)), "", func(picked color.Color) {
+				entry.SetText(hexColor(picked))
+			}, window)
+			picker.Advanced = true
+			picker.SetColor(swatch.FillColor)
+			picker.Show()
+		})
+		pickBtn.Importance = widget.LowImportance
+
+		form.Add(widget.NewLabel(i18n.T(c.label)))
+		form.Add(container.NewBorder(nil, nil, container.NewCenter(swatch), pickBtn, entry))
+	}
+
+	baseSelect.OnChanged = func(string) {
+		def.Base = bases[baseSelect.SelectedIndex()]
+		preview()
+	}
+	for i, t := range bases {
+		if t == def.Base {
+			baseSelect.SetSelectedIndex(i)
+		}
+	}
+
+	hint := widget.NewLabelWithStyle(i18n.T("Empty colors keep the base theme's. Saving under an existing name replaces that theme."),
+		fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	hint.Wrapping = fyne.TextWrapWord
+	top := widget.NewForm(
+		widget.NewFormItem(i18n.T("Name"), nameEntry),
+		widget.NewFormItem(i18n.T("Based on"), baseSelect),
+	)
+	content := container.NewBorder(container.NewVBox(top, hint, widget.NewSeparator()), nil, nil, nil, container.NewVScroll(form))
+
+	d := dialog.NewCustomConfirm(i18n.T("Theme Editor"), i18n.T("Save"), i18n.T("Cancel"), content, func(save bool) {
+		name := strings.TrimSpace(nameEntry.Text)
+		if !save || name == "" {
+			applyTheme(app)
+			if save {
+				ShowErrorDialog(window, "Theme Editor", fmt.Errorf("the theme needs a name"))
+			}
+			return
+		}
+		def.Name = name
+		def.Colors = colors
+		if err := config.SaveCustomTheme(def); err != nil {
+			applyTheme(app)
+			ShowErrorDialog(window, "Theme Editor", err)
+			return
+		}
+		onSave(models.CustomThemeName(name))
+	}, window)
+	d.Resize(fyne.NewSize(520, 640))
+	d.Show()
+}
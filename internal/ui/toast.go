@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const (
+	// toastFor is how long a toast stays up
+	toastFor = 4 * time.Second
+	// actionToastFor is how long a toast with an action stays up, leaving
+	// time to reach its button
+	actionToastFor = 8 * time.Second
+)
+
+// toasts holds the toast shown in each window; a new one replaces it
+var toasts = make(map[fyne.Window]*widget.PopUp)
+
+// ToastAction is a button offered on a toast, such as Undo
+type ToastAction struct {
+	Label    string
+	Icon     fyne.Resource
+	OnTapped func()
+}
+
+// ShowToast briefly shows a message at the bottom of the window. Unlike
+// ShowInfoDialog it doesn't block the window or need dismissing.
+func ShowToast(window fyne.Window, message string) {
+	showToast(window, message, nil, toastFor)
+}
+
+// ShowToastWithAction is like ShowToast with a button running action, which
+// also closes the toast
+func ShowToastWithAction(window fyne.Window, message string, action ToastAction) {
+	showToast(window, message, &action, actionToastFor)
+}
+
+func showToast(window fyne.Window, message string, action *ToastAction, duration time.Duration) {
+	if old := toasts[window]; old != nil {
+		old.Hide()
+	}
+
+	var popup *widget.PopUp
+	hide := func() {
+		popup.Hide()
+		if toasts[window] == popup {
+			delete(toasts, window)
+		}
+	}
+
+	label := widget.NewLabel(message)
+	row := container.NewHBox(widget.NewIcon(theme.InfoIcon()), label)
+	if action != nil {
+		actionBtn := widget.NewButtonWithIcon(action.Label, action.Icon, func() {
+			hide()
+			action.OnTapped()
+		})
+		actionBtn.Importance = widget.HighImportance
+		row.Add(actionBtn)
+	}
+	closeBtn := widget.NewButtonWithIcon("", theme.WindowCloseIcon(), hide)
+	closeBtn.Importance = widget.LowImportance
+	row.Add(closeBtn)
+
+	popup = widget.NewPopUp(row, window.Canvas())
+	toasts[window] = popup
+
+	size := popup.MinSize()
+	area := window.Canvas().Size()
+	popup.ShowAtPosition(fyne.NewPos((area.Width-size.Width)/2, area.Height-size.Height-theme.Padding()*4))
+	time.AfterFunc(duration, func() { fyne.Do(hide) })
+}
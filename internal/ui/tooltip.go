@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// tipLayer lies over a control that is disabled for a reason, such as a
+// command the server lacks, and shows the reason while the pointer is over the
+// control or after it is tapped. Fyne has no tooltips of its own.
+type tipLayer struct {
+	widget.BaseWidget
+	tip     string
+	overlay *tipOverlay
+}
+
+// withTip stacks a tip layer, hidden until it is given a tip, over obj
+func withTip(obj fyne.CanvasObject) (fyne.CanvasObject, *tipLayer) {
+	t := &tipLayer{}
+	t.ExtendBaseWidget(t)
+	t.Hide()
+	return container.NewStack(obj, t), t
+}
+
+// SetTip sets the reason shown over the control; empty removes the layer, so
+// the control gets the pointer again
+func (t *tipLayer) SetTip(tip string) {
+	t.tip = tip
+	t.hideTip()
+	if tip == "" {
+		t.Hide()
+	} else {
+		t.Show()
+	}
+}
+
+// CreateRenderer implements fyne.Widget
+func (t *tipLayer) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(canvas.NewRectangle(color.Transparent))
+}
+
+// MouseIn implements desktop.Hoverable
+func (t *tipLayer) MouseIn(*desktop.MouseEvent) {
+	t.showTip()
+}
+
+// MouseMoved implements desktop.Hoverable
+func (t *tipLayer) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable
+func (t *tipLayer) MouseOut() {}
+
+// Tapped shows the tip where there is no pointer to hover with
+func (t *tipLayer) Tapped(*fyne.PointEvent) {
+	t.showTip()
+}
+
+func (t *tipLayer) showTip() {
+	c := fyne.CurrentApp().Driver().CanvasForObject(t)
+	if t.tip == "" || t.overlay != nil || c == nil {
+		return
+	}
+	t.overlay = newTipOverlay(t, c)
+	c.Overlays().Add(t.overlay)
+}
+
+func (t *tipLayer) hideTip() {
+	if t.overlay != nil {
+		t.overlay.canvas.Overlays().Remove(t.overlay)
+		t.overlay = nil
+	}
+}
+
+// tipOverlay shows a tip under its control. It covers the control too, as
+// only the top overlay gets pointer events while one is shown, and goes away
+// once the pointer leaves both.
+type tipOverlay struct {
+	widget.BaseWidget
+	layer  *tipLayer
+	canvas fyne.Canvas
+	label  *widget.Label
+	bg     *canvas.Rectangle
+}
+
+func newTipOverlay(layer *tipLayer, c fyne.Canvas) *tipOverlay {
+	o := &tipOverlay{layer: layer, canvas: c, label: widget.NewLabel(layer.tip)}
+	o.bg = canvas.NewRectangle(theme.Color(theme.ColorNameOverlayBackground))
+	o.bg.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	o.bg.StrokeWidth = 1
+	o.bg.CornerRadius = theme.InputRadiusSize()
+	o.ExtendBaseWidget(o)
+
+	// The tip goes under the control, moved left if it would leave the window
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(layer)
+	area, tip := layer.Size(), o.label.MinSize()
+	x := max(min(pos.X, c.Size().Width-tip.Width), 0)
+	o.Move(fyne.NewPos(x, pos.Y))
+	o.Resize(fyne.NewSize(max(pos.X+area.Width, x+tip.Width)-x, area.Height+tip.Height))
+	o.bg.Move(fyne.NewPos(0, area.Height))
+	o.bg.Resize(tip)
+	o.label.Move(fyne.NewPos(0, area.Height))
+	o.label.Resize(tip)
+	return o
+}
+
+// CreateRenderer implements fyne.Widget
+func (o *tipOverlay) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(container.NewWithoutLayout(o.bg, o.label))
+}
+
+// MouseIn implements desktop.Hoverable
+func (o *tipOverlay) MouseIn(*desktop.MouseEvent) {}
+
+// MouseMoved implements desktop.Hoverable
+func (o *tipOverlay) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable
+func (o *tipOverlay) MouseOut() {
+	o.layer.hideTip()
+}
+
+// Tapped dismisses the tip
+func (o *tipOverlay) Tapped(*fyne.PointEvent) {
+	o.layer.hideTip()
+}
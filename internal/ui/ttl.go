@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+)
+
+// formatTTL renders a TTL in seconds as its largest unit and the next one down,
+// e.g. "2h 13m"
+func formatTTL(seconds int64) string {
+	if seconds < 0 {
+		return i18n.T("No expiry")
+	}
+	units := []struct {
+		suffix string
+		size   int64
+	}{{"d", 86400}, {"h", 3600}, {"m", 60}, {"s", 1}}
+
+	for i, u := range units {
+		if seconds < u.size && u.size > 1 {
+			continue
+		}
+		text := fmt.Sprintf("%d%s", seconds/u.size, u.suffix)
+		if i+1 < len(units) {
+			next := units[i+1]
+			if n := seconds % u.size / next.size; n > 0 {
+				text += fmt.Sprintf(" %d%s", n, next.suffix)
+			}
+		}
+		return text
+	}
+	return ""
+}
+
+// expirySeconds is the TTL in seconds an expiry sets from now, or -1 if it
+// removes the expiry
+func expirySeconds(exp models.Expiry, now time.Time) int64 {
+	if !exp.At.IsZero() {
+		return int64(math.Ceil(exp.At.Sub(now).Seconds()))
+	}
+	if exp.Seconds <= 0 {
+		return -1
+	}
+	return exp.Seconds
+}
+
+// expiryLayouts are the absolute expiry formats accepted by parseExpiry, in local time
+var expiryLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseExpiry reads a TTL as plain seconds ("90"), a duration ("90s", "2h",
+// "1h30m", "7d", "2w") or an absolute local time ("2025-06-01 12:00"). Empty
+// text or 0 removes the expiry.
+func parseExpiry(text string, now time.Time) (models.Expiry, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return models.Expiry{}, nil
+	}
+
+	if seconds, err := strconv.ParseInt(text, 10, 64); err == nil {
+		if seconds < 0 {
+			return models.Expiry{}, fmt.Errorf("TTL must be non-negative")
+		}
+		return models.Expiry{Seconds: seconds}, nil
+	}
+
+	if seconds, ok := parseDurationSeconds(text); ok {
+		return models.Expiry{Seconds: seconds}, nil
+	}
+
+	for _, layout := range expiryLayouts {
+		at, err := time.ParseInLocation(layout, text, time.Local)
+		if err != nil {
+			continue
+		}
+		if !at.After(now) {
+			return models.Expiry{}, fmt.Errorf("expiry time %s is in the past", at.Format("2006-01-02 15:04:05"))
+		}
+		return models.Expiry{At: at}, nil
+	}
+
+	return models.Expiry{}, fmt.Errorf("'%s' is not a TTL: use seconds, a duration like 90s, 2h or 7d, or a time like 2006-01-02 15:04", text)
+}
+
+// parseDurationSeconds parses a sequence of number and unit pairs, with units
+// w, d, h, m and s
+func parseDurationSeconds(text string) (int64, bool) {
+	sizes := map[string]int64{"w": 7 * 86400, "d": 86400, "h": 3600, "m": 60, "s": 1}
+
+	var total int64
+	for text != "" {
+		digits := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+		if digits <= 0 {
+			return 0, false
+		}
+		n, err := strconv.ParseInt(text[:digits], 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		text = strings.TrimLeft(text[digits:], " ")
+		unit := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsLetter(r) })
+		if unit < 0 {
+			unit = len(text)
+		}
+		size, ok := sizes[strings.ToLower(text[:unit])]
+		if !ok {
+			return 0, false
+		}
+		total += n * size
+		text = strings.TrimLeft(text[unit:], " ")
+	}
+	return total, true
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"log"
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	undoLimit    = 20       // changes the undo stack keeps
+	undoMaxBytes = 32 << 20 // DUMP payloads kept, per change and in total
+)
+
+// undoChange is a delete or overwrite with the keys as they were before it
+type undoChange struct {
+	label        string // what was done, e.g. "Deleted 'user:1'"
+	connectionID string
+	database     int
+	snapshots    []models.KeySnapshot
+	size         int
+}
+
+// UndoStack keeps DUMP snapshots of the keys deleted or overwritten this
+// session, so the latest changes can be rolled back with RESTORE. The oldest
+// changes are dropped past undoLimit or undoMaxBytes.
+type UndoStack struct {
+	window   fyne.Window
+	worker   *Worker
+	changes  []*undoChange // oldest first
+	size     int
+	onUndone func()
+}
+
+// NewUndoStack creates an empty undo stack
+func NewUndoStack(window fyne.Window, worker *Worker) *UndoStack {
+	return &UndoStack{window: window, worker: worker}
+}
+
+// SetOnUndone sets the callback for after a change is undone
+func (u *UndoStack) SetOnUndone(f func()) {
+	u.onUndone = f
+}
+
+// snapshotKeys DUMPs keys about to be changed, from the worker. A failure only
+// means the change can't be undone, so it is logged rather than returned.
+func snapshotKeys(c *redis.Client, keys []string) []models.KeySnapshot {
+	snapshots, err := c.SnapshotKeys(keys, undoMaxBytes)
+	if err != nil {
+		log.Printf("Not keeping an undo snapshot of %d key(s): %v", len(keys), err)
+		return nil
+	}
+	return snapshots
+}
+
+// push records a change made through client, optionally offering to undo it
+// in a toast
+func (u *UndoStack) push(client *redis.Client, label string, snapshots []models.KeySnapshot, toast bool) {
+	if len(snapshots) == 0 {
+		return
+	}
+	conn := client.Connection()
+	change := &undoChange{label: label, connectionID: conn.ID, database: conn.Database, snapshots: snapshots}
+	for _, s := range snapshots {
+		change.size += len(s.Payload)
+	}
+
+	u.changes = append(u.changes, change)
+	u.size += change.size
+	for len(u.changes) > undoLimit || u.size > undoMaxBytes {
+		u.size -= u.changes[0].size
+		u.changes = u.changes[1:]
+	}
+
+	if toast {
+		ShowToastWithAction(u.window, label, ToastAction{
+			Label:    i18n.T("Undo"),
+			Icon:     theme.ContentUndoIcon(),
+			OnTapped: func() { u.undo(client, change) },
+		})
+	}
+}
+
+// Undo rolls back the latest change, which must have been made in the
+// database client is using
+func (u *UndoStack) Undo(client *redis.Client) {
+	if len(u.changes) == 0 {
+		ShowInfoDialog(u.window, "Undo", "There is nothing to undo.")
+		return
+	}
+	u.undo(client, u.changes[len(u.changes)-1])
+}
+
+// undo restores the keys of a change over their current values
+func (u *UndoStack) undo(client *redis.Client, change *undoChange) {
+	if client == nil || refuseReadOnly(u.window, client) {
+		return
+	}
+	if !slices.Contains(u.changes, change) {
+		ShowInfoDialog(u.window, "Undo", "That change has already been undone.")
+		return
+	}
+	conn := client.Connection()
+	if conn.ID != change.connectionID || conn.Database != change.database {
+		ShowInfoDialog(u.window, "Undo",
+			i18n.T("\"%s\" was done in DB %d of another connection. Connect to it to undo.", change.label, change.database))
+		return
+	}
+
+	u.worker.Do(u.window, client, func(c *redis.Client) error {
+		return c.RestoreSnapshots(change.snapshots)
+	}, func() {
+		if i := slices.Index(u.changes, change); i >= 0 {
+			u.changes = slices.Delete(u.changes, i, i+1)
+			u.size -= change.size
+		}
+		if u.onUndone != nil {
+			u.onUndone()
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package ui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+// valueSearchLimit is how many matching keys a search lists before it stops
+const valueSearchLimit = 1000
+
+// ValueSearch is a panel that searches the values of the keys matching a
+// pattern for a term in the background, listing matching keys as they are
+// found. Tapping a match opens its key.
+type ValueSearch struct {
+	widget.BaseWidget
+	container *fyne.Container
+	client    *redis.Client
+	worker    *Worker
+	window    fyne.Window
+	onOpenKey func(key models.RedisKey)
+
+	termEntry    *widget.Entry
+	patternEntry *widget.Entry
+	caseCheck    *widget.Check
+	statusLabel  *widget.Label
+	runBtn       *widget.Button
+	cancelBtn    *widget.Button
+	list         *widget.List
+	matches      []models.ValueMatch
+	cancel       context.CancelFunc
+}
+
+// NewValueSearch creates a new value search panel
+func NewValueSearch(window fyne.Window, worker *Worker) *ValueSearch {
+	s := &ValueSearch{
+		window: window,
+		worker: worker,
+	}
+	s.ExtendBaseWidget(s)
+	s.buildUI()
+	return s
+}
+
+func (s *ValueSearch) buildUI() {
+	s.termEntry = widget.NewEntry()
+	s.termEntry.SetPlaceHolder(i18n.T("Text to find in values"))
+	s.termEntry.OnSubmitted = func(string) { s.run() }
+
+	s.patternEntry = widget.NewEntry()
+	s.patternEntry.SetPlaceHolder(i18n.T("Keys to search, e.g. user:* (empty for every key)"))
+	s.patternEntry.OnSubmitted = func(string) { s.run() }
+
+	s.caseCheck = widget.NewCheck(i18n.T("Match case"), nil)
+
+	s.statusLabel = widget.NewLabel(i18n.T("Searches string contents, list items, set and sorted set members, and hash fields and values."))
+	s.statusLabel.Wrapping = fyne.TextWrapWord
+
+	s.runBtn = widget.NewButtonWithIcon(i18n.T("Search"), theme.SearchIcon(), s.run)
+	s.cancelBtn = widget.NewButtonWithIcon(i18n.T("Cancel"), theme.CancelIcon(), func() {
+		if s.cancel != nil {
+			s.cancel()
+		}
+	})
+	s.cancelBtn.Hide()
+
+	s.list = widget.NewList(
+		func() int { return len(s.matches) },
+		func() fyne.CanvasObject {
+			key := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			key.Truncation = fyne.TextTruncateEllipsis
+			where := widget.NewLabel("")
+			where.Importance = widget.LowImportance
+			snippet := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Monospace: true})
+			snippet.Truncation = fyne.TextTruncateEllipsis
+			return container.NewVBox(container.NewBorder(nil, nil, nil, where, key), snippet)
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			box := o.(*fyne.Container)
+			header := box.Objects[0].(*fyne.Container)
+			match := s.matches[id]
+			header.Objects[0].(*widget.Label).SetText(match.Key.Key)
+			header.Objects[1].(*widget.Label).SetText(match.Key.Type + ", " + match.Where)
+			box.Objects[1].(*widget.Label).SetText(match.Snippet)
+		},
+	)
+	s.list.OnSelected = func(id widget.ListItemID) {
+		if s.onOpenKey != nil {
+			s.onOpenKey(s.matches[id].Key)
+		}
+	}
+
+	form := widget.NewForm(
+		widget.NewFormItem(i18n.T("Find"), s.termEntry),
+		widget.NewFormItem(i18n.T("Keys"), s.patternEntry),
+	)
+	toolbar := container.NewVBox(
+		form,
+		container.NewHBox(s.runBtn, s.cancelBtn, s.caseCheck),
+		s.statusLabel,
+		widget.NewSeparator(),
+	)
+
+	s.container = container.NewBorder(toolbar, nil, nil, nil, s.list)
+}
+
+// CreateRenderer implements fyne.Widget
+func (s *ValueSearch) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(s.container)
+}
+
+// SetClient sets the Redis client
+func (s *ValueSearch) SetClient(client *redis.Client) {
+	s.client = client
+}
+
+// SetOnOpenKey sets the callback for when a match is tapped
+func (s *ValueSearch) SetOnOpenKey(f func(key models.RedisKey)) {
+	s.onOpenKey = f
+}
+
+// Focus puts the cursor in the search term entry
+func (s *ValueSearch) Focus() {
+	s.window.Canvas().Focus(s.termEntry)
+}
+
+// Clear cancels a running search and removes its matches
+func (s *ValueSearch) Clear() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.matches = nil
+	s.list.UnselectAll()
+	s.list.Refresh()
+	s.statusLabel.SetText(i18n.T("Searches string contents, list items, set and sorted set members, and hash fields and values."))
+}
+
+// run searches the keys matching the pattern, once confirmed on a large
+// database
+func (s *ValueSearch) run() {
+	if s.client == nil || s.cancel != nil || s.termEntry.Text == "" {
+		return
+	}
+	pattern := strings.TrimSpace(s.patternEntry.Text)
+	if pattern == "" {
+		pattern = "*"
+	}
+	confirmHeavyScan(s.window, s.worker, s.client, "Value Search", pattern, searchCommandsPerKey, s.search)
+}
+
+// search searches the keys matching pattern as a cancellable background job,
+// adding matches to the list as they are found
+func (s *ValueSearch) search(pattern string) {
+	term := s.termEntry.Text
+	if s.client == nil || s.cancel != nil || term == "" {
+		return
+	}
+	s.patternEntry.SetText(pattern)
+
+	s.matches = nil
+	s.list.UnselectAll()
+	s.list.Refresh()
+	s.runBtn.Disable()
+	s.cancelBtn.Show()
+	s.statusLabel.SetText(i18n.T("Searching..."))
+
+	client := s.client
+	caseSensitive := s.caseCheck.Checked
+	var searched int64
+	limited := false
+	s.cancel = s.worker.GoCancellable(func(ctx context.Context) error {
+		return client.WithContext(ctx).SearchValues(pattern, term, caseSensitive, func(match models.ValueMatch) {
+			fyne.Do(func() {
+				if s.client != client || limited {
+					return
+				}
+				s.matches = append(s.matches, match)
+				s.list.Refresh()
+				if len(s.matches) >= valueSearchLimit && s.cancel != nil {
+					limited = true
+					s.cancel()
+				}
+			})
+		}, func(n int64) {
+			fyne.Do(func() {
+				searched = n
+				if s.cancel != nil {
+					s.statusLabel.SetText(i18n.T("Searching... %d keys searched, %d matches", n, len(s.matches)))
+				}
+			})
+		})
+	}, func(err error) {
+		s.cancel = nil
+		s.runBtn.Enable()
+		s.cancelBtn.Hide()
+		if s.client != client {
+			return
+		}
+		switch {
+		case limited:
+			s.statusLabel.SetText(i18n.T("Stopped at the first %d matching keys; narrow the key pattern to see the rest.", valueSearchLimit))
+		case errors.Is(err, context.Canceled):
+			s.statusLabel.SetText(i18n.T("Search cancelled after %d keys, %d matches", searched, len(s.matches)))
+		case err != nil:
+			s.statusLabel.SetText(i18n.T("Search failed"))
+			ShowErrorDialog(s.window, "Search Error", err)
+		default:
+			s.statusLabel.SetText(i18n.T("Searched %d keys, %d matches", searched, len(s.matches)))
+		}
+	})
+}
@@ -0,0 +1,409 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/config"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/models"
+	"redis-explorer/internal/redis"
+)
+
+const (
+	// watchHistory is how many numeric readings a watch's trend line shows
+	watchHistory = 60
+	// watchFlashInterval is how fast the row of an alerting watch flashes
+	watchFlashInterval = 500 * time.Millisecond
+)
+
+// watchIntervals are the polling intervals offered, in seconds
+var watchIntervals = []int{1, 2, 5, 10, 30, 60}
+
+// watchState is a watch with what its polls read so far
+type watchState struct {
+	watch    models.Watch
+	reading  models.WatchReading
+	err      error
+	values   []float64
+	alerting bool
+}
+
+// WatchPanel polls chosen keys and read-only commands on an interval, showing
+// each one's current value and trend, and flashes and notifies when a value
+// crosses its alert thresholds
+type WatchPanel struct {
+	widget.BaseWidget
+	container *fyne.Container
+	window    fyne.Window
+	client    *redis.Client
+	connID    string
+
+	intervalSelect *widget.Select
+	statusLabel    *widget.Label
+	list           *widget.List
+
+	watches []*watchState
+	flashOn bool
+	stop    context.CancelFunc
+}
+
+// NewWatchPanel creates a new watch panel
+func NewWatchPanel(window fyne.Window) *WatchPanel {
+	p := &WatchPanel{
+		window: window,
+	}
+	p.ExtendBaseWidget(p)
+	p.buildUI()
+	return p
+}
+
+func (p *WatchPanel) buildUI() {
+	addBtn := widget.NewButtonWithIcon(i18n.T("Add Watch..."), theme.ContentAddIcon(), func() {
+		ShowWatchDialog(p.window, nil, func(w models.Watch) {
+			p.watches = append(p.watches, &watchState{watch: w})
+			p.saveWatches()
+		})
+	})
+	addBtn.Importance = widget.HighImportance
+
+	options := make([]string, len(watchIntervals))
+	for i, secs := range watchIntervals {
+		options[i] = i18n.T("Every %ds", secs)
+	}
+	p.intervalSelect = widget.NewSelect(options, func(selected string) {
+		secs := watchIntervals[slices.Index(options, selected)]
+		if secs == config.Get().WatchIntervalSecs {
+			return
+		}
+		config.SetWatchInterval(secs)
+		if p.client != nil {
+			p.start()
+		}
+	})
+	p.intervalSelect.SetSelectedIndex(watchIntervalIndex(config.Get().WatchIntervalSecs))
+
+	p.statusLabel = widget.NewLabel(i18n.T("Connect to poll watches"))
+
+	p.list = widget.NewList(
+		func() int { return len(p.watches) },
+		func() fyne.CanvasObject {
+			background := canvas.NewRectangle(color.Transparent)
+			name := widget.NewLabelWithStyle("", fyne.TextAlignLeading, fyne.TextStyle{Bold: true})
+			name.Truncation = fyne.TextTruncateEllipsis
+			value := widget.NewLabel("")
+			value.Truncation = fyne.TextTruncateEllipsis
+			chart := newSparkline()
+			editBtn := widget.NewButtonWithIcon("", theme.DocumentCreateIcon(), nil)
+			editBtn.Importance = widget.LowImportance
+			removeBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), nil)
+			removeBtn.Importance = widget.LowImportance
+			right := container.NewHBox(container.NewGridWrap(fyne.NewSize(160, 40), chart), editBtn, removeBtn)
+			info := container.NewGridWithColumns(2, name, value)
+			return container.NewStack(background, container.NewBorder(nil, nil, nil, right, info))
+		},
+		func(id widget.ListItemID, o fyne.CanvasObject) {
+			stack := o.(*fyne.Container)
+			background := stack.Objects[0].(*canvas.Rectangle)
+			row := stack.Objects[1].(*fyne.Container)
+			info := row.Objects[0].(*fyne.Container)
+			right := row.Objects[1].(*fyne.Container)
+			name := info.Objects[0].(*widget.Label)
+			value := info.Objects[1].(*widget.Label)
+			chart := right.Objects[0].(*fyne.Container).Objects[0].(*sparkline)
+			editBtn := right.Objects[1].(*widget.Button)
+			removeBtn := right.Objects[2].(*widget.Button)
+
+			state := p.watches[id]
+			name.SetText(describeWatch(state.watch))
+			switch {
+			case state.err != nil:
+				value.SetText(state.err.Error())
+				value.Importance = widget.DangerImportance
+			case state.alerting:
+				value.SetText(state.reading.Text)
+				value.Importance = widget.DangerImportance
+			default:
+				value.SetText(state.reading.Text)
+				value.Importance = widget.MediumImportance
+			}
+			value.Refresh()
+			chart.SetValues(state.values)
+
+			background.FillColor = color.Transparent
+			if state.alerting && p.flashOn {
+				background.FillColor = theme.Color(theme.ColorNameError)
+			}
+			background.Refresh()
+
+			editBtn.OnTapped = func() {
+				ShowWatchDialog(p.window, &state.watch, func(w models.Watch) {
+					if w.Expression != state.watch.Expression || w.Command != state.watch.Command {
+						*state = watchState{}
+					}
+					state.watch = w
+					state.alerting = state.reading.Numeric && w.Alerting(state.reading.Value)
+					p.saveWatches()
+				})
+			}
+			removeBtn.OnTapped = func() {
+				for i, s := range p.watches {
+					if s == state {
+						p.watches = append(p.watches[:i], p.watches[i+1:]...)
+						break
+					}
+				}
+				p.saveWatches()
+			}
+		},
+	)
+
+	hint := widget.NewLabelWithStyle(
+		i18n.T("Keys show their value if they are strings and their length otherwise. Commands must only read, as they run on every poll."),
+		fyne.TextAlignLeading, fyne.TextStyle{Italic: true})
+	hint.Wrapping = fyne.TextWrapWord
+
+	toolbar := container.NewHBox(addBtn, p.intervalSelect, p.statusLabel)
+	p.container = container.NewBorder(container.NewVBox(toolbar, hint), nil, nil, nil, p.list)
+}
+
+// CreateRenderer implements fyne.Widget
+func (p *WatchPanel) CreateRenderer() fyne.WidgetRenderer {
+	return widget.NewSimpleRenderer(p.container)
+}
+
+// SetClient sets the Redis client and polls the watches of its connection,
+// or stops polling when client is nil
+func (p *WatchPanel) SetClient(client *redis.Client) {
+	p.Stop()
+	p.client = client
+	p.watches = nil
+	p.connID = ""
+	if client == nil {
+		p.statusLabel.SetText(i18n.T("Connect to poll watches"))
+		p.list.Refresh()
+		return
+	}
+	conn := client.Connection()
+	p.connID = conn.ID
+	for _, w := range conn.Watches {
+		p.watches = append(p.watches, &watchState{watch: w})
+	}
+	p.list.Refresh()
+	p.start()
+}
+
+// start (re)starts polling the watches on the configured interval
+func (p *WatchPanel) start() {
+	p.Stop()
+	interval := time.Duration(config.Get().WatchIntervalSecs) * time.Second
+	ctx, cancel := context.WithCancel(context.Background())
+	p.stop = cancel
+	p.statusLabel.SetText(i18n.T("Polling every %ds", config.Get().WatchIntervalSecs))
+
+	client := p.client.WithContext(ctx)
+	go func() {
+		poll := time.NewTicker(interval)
+		defer poll.Stop()
+		flash := time.NewTicker(watchFlashInterval)
+		defer flash.Stop()
+		p.poll(ctx, client)
+		for {
+			select {
+			case <-poll.C:
+				p.poll(ctx, client)
+			case <-flash.C:
+				fyne.Do(p.flash)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops polling the watches
+func (p *WatchPanel) Stop() {
+	if p.stop != nil {
+		p.stop()
+		p.stop = nil
+	}
+}
+
+// poll reads every watch once, off the UI goroutine, and shows the readings
+func (p *WatchPanel) poll(ctx context.Context, client *redis.Client) {
+	var states []*watchState
+	fyne.DoAndWait(func() {
+		states = append(states, p.watches...)
+	})
+	readings := make([]models.WatchReading, len(states))
+	errs := make([]error, len(states))
+	for i, state := range states {
+		readings[i], errs[i] = client.ReadWatch(state.watch)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	fyne.Do(func() {
+		if ctx.Err() != nil {
+			return
+		}
+		for i, state := range states {
+			p.record(state, readings[i], errs[i])
+		}
+		p.list.Refresh()
+	})
+}
+
+// record stores a watch's reading and notifies when the watch starts alerting
+func (p *WatchPanel) record(state *watchState, reading models.WatchReading, err error) {
+	state.reading, state.err = reading, err
+	if err != nil {
+		return
+	}
+	alerting := false
+	if reading.Numeric {
+		state.values = append(state.values, reading.Value)
+		if len(state.values) > watchHistory {
+			state.values = state.values[len(state.values)-watchHistory:]
+		}
+		alerting = state.watch.Alerting(reading.Value)
+	}
+	if alerting && !state.alerting {
+		notify(i18n.T("Watch alert"), i18n.T("%s is %s", describeWatch(state.watch), reading.Text))
+	}
+	state.alerting = alerting
+}
+
+// flash toggles the highlight of alerting watches
+func (p *WatchPanel) flash() {
+	for _, state := range p.watches {
+		if state.alerting {
+			p.flashOn = !p.flashOn
+			p.list.Refresh()
+			return
+		}
+	}
+	p.flashOn = false
+}
+
+// saveWatches shows the changed watches and saves them with the connection
+func (p *WatchPanel) saveWatches() {
+	p.list.Refresh()
+	watches := make([]models.Watch, len(p.watches))
+	for i, state := range p.watches {
+		watches[i] = state.watch
+	}
+	if p.connID != "" {
+		config.SetConnectionWatches(p.connID, watches)
+	}
+}
+
+// describeWatch is how a watch is listed
+func describeWatch(w models.Watch) string {
+	if w.Command {
+		return "> " + w.Expression
+	}
+	return w.Expression
+}
+
+// watchIntervalIndex is the offered interval nearest to secs
+func watchIntervalIndex(secs int) int {
+	for i, s := range watchIntervals {
+		if s >= secs {
+			return i
+		}
+	}
+	return len(watchIntervals) - 1
+}
+
+// ShowWatchDialog asks for a key or command to watch and its alert thresholds.
+// watch, if set, is the watch being edited.
+func ShowWatchDialog(window fyne.Window, watch *models.Watch, onSave func(models.Watch)) {
+	kinds := []string{i18n.T("Key"), i18n.T("Command")}
+	kindRadio := widget.NewRadioGroup(kinds, nil)
+	kindRadio.Horizontal = true
+	kindRadio.Required = true
+	kindRadio.SetSelected(kinds[0])
+
+	expressionEntry := widget.NewEntry()
+	expressionEntry.SetPlaceHolder("queue:jobs  /  INFO memory")
+	aboveEntry := widget.NewEntry()
+	aboveEntry.SetPlaceHolder(i18n.T("Optional"))
+	belowEntry := widget.NewEntry()
+	belowEntry.SetPlaceHolder(i18n.T("Optional"))
+
+	title := i18n.T("Add Watch")
+	if watch != nil {
+		title = i18n.T("Edit Watch")
+		if watch.Command {
+			kindRadio.SetSelected(kinds[1])
+		}
+		expressionEntry.SetText(watch.Expression)
+		aboveEntry.SetText(formatThreshold(watch.Above))
+		belowEntry.SetText(formatThreshold(watch.Below))
+	}
+
+	items := []*widget.FormItem{
+		{Text: i18n.T("Watch"), Widget: kindRadio},
+		{Text: i18n.T("Key or Command"), Widget: expressionEntry},
+		{Text: i18n.T("Alert Above"), Widget: aboveEntry},
+		{Text: i18n.T("Alert Below"), Widget: belowEntry, HintText: i18n.T("Numeric readings outside these bounds flash and notify")},
+	}
+	d := dialog.NewForm(title, i18n.T("Save"), i18n.T("Cancel"), items, func(save bool) {
+		if !save {
+			return
+		}
+		w := models.Watch{
+			Expression: strings.TrimSpace(expressionEntry.Text),
+			Command:    kindRadio.Selected == kinds[1],
+		}
+		if w.Expression == "" {
+			dialog.ShowError(fmt.Errorf("enter a key or command to watch"), window)
+			return
+		}
+		var err error
+		if w.Above, err = optionalFloat(aboveEntry.Text); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if w.Below, err = optionalFloat(belowEntry.Text); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		onSave(w)
+	}, window)
+	d.Resize(fyne.NewSize(450, 300))
+	d.Show()
+}
+
+// optionalFloat parses an optional number, nil when text is blank
+func optionalFloat(text string) (*float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	n, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a number", text)
+	}
+	return &n, nil
+}
+
+// formatThreshold shows an optional threshold, blank when unset
+func formatThreshold(n *float64) string {
+	if n == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*n, 'f', -1, 64)
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"context"
+	"errors"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"redis-explorer/internal/i18n"
+	"redis-explorer/internal/jobs"
+	"redis-explorer/internal/redis"
+)
+
+// Worker runs Redis operations off the UI thread. Every operation gets a context
+// that is cancelled by CancelAll (e.g. on disconnect), results are delivered back
+// on the UI thread via fyne.Do, and a shared busy indicator is shown while any
+// operation is in flight. The indicator's Stop button calls CancelRunning.
+// Long operations run as jobs, which also report progress to the job queue,
+// and finished operations can Report their result to the status bar.
+//
+// All methods must be called from the UI thread.
+type Worker struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	ops       context.Context // parent of operation contexts, cancelled by CancelRunning
+	cancelOps context.CancelFunc
+	pending   int
+	indicator *widget.ProgressBarInfinite
+	stopBtn   *widget.Button
+	bar       *fyne.Container
+	jobs      *jobs.Manager
+	onReport  func(message string, err error)
+}
+
+// NewWorker creates a new worker with an idle busy indicator
+func NewWorker() *Worker {
+	w := &Worker{
+		indicator: widget.NewProgressBarInfinite(),
+		jobs:      jobs.NewManager(),
+	}
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.ops, w.cancelOps = context.WithCancel(w.ctx)
+	w.stopBtn = widget.NewButtonWithIcon(i18n.T("Stop"), theme.MediaStopIcon(), w.CancelRunning)
+	w.stopBtn.Importance = widget.LowImportance
+	w.bar = container.NewBorder(nil, nil, nil, w.stopBtn, w.indicator)
+	w.indicator.Stop()
+	w.bar.Hide()
+	return w
+}
+
+// Indicator returns the busy indicator widget to place in the layout
+func (w *Worker) Indicator() fyne.CanvasObject {
+	return w.bar
+}
+
+// Go runs work in a goroutine and calls done on the UI thread with its error.
+// If CancelAll was called before the operation finished, done is not called.
+func (w *Worker) Go(work func(ctx context.Context) error, done func(err error)) {
+	w.run(w.ops, work, done)
+}
+
+// GoCancellable is like Go but gives the operation its own context. The returned
+// function cancels just this operation, in which case done still runs and
+// receives the context error, so callers can reset their UI (e.g. hide a Cancel button).
+func (w *Worker) GoCancellable(work func(ctx context.Context) error, done func(err error)) context.CancelFunc {
+	ctx, cancel := context.WithCancel(w.ops)
+	w.run(ctx, work, func(err error) {
+		cancel()
+		if done != nil {
+			done(err)
+		}
+	})
+	return cancel
+}
+
+// Job is like GoCancellable for long operations: the work is tracked as a job
+// titled title, which reports its progress to the job queue through job and
+// can be cancelled from there as well as through the returned function.
+func (w *Worker) Job(title string, work func(ctx context.Context, job *jobs.Job) error, done func(err error)) context.CancelFunc {
+	root := w.ctx
+	w.begin()
+	job := w.jobs.Start(w.ops, title, work, func(err error) {
+		fyne.Do(func() {
+			w.end()
+			if root.Err() != nil {
+				return
+			}
+			switch {
+			case err == nil:
+				w.Report(i18n.T("%s done", title), nil)
+			case errors.Is(err, context.Canceled):
+				w.Report(i18n.T("%s cancelled", title), nil)
+			default:
+				w.Report(title, err)
+			}
+			if done != nil {
+				done(err)
+			}
+		})
+	})
+	return job.Cancel
+}
+
+// SetOnReport sets where Report sends operation results
+func (w *Worker) SetOnReport(f func(message string, err error)) {
+	w.onReport = f
+}
+
+// Report shows the result of a finished operation without interrupting, e.g.
+// "Saved 'user:1'"; a non-nil err reports it as having failed
+func (w *Worker) Report(message string, err error) {
+	if w.onReport != nil {
+		w.onReport(message, err)
+	}
+}
+
+// Jobs returns the manager tracking the worker's jobs
+func (w *Worker) Jobs() *jobs.Manager {
+	return w.jobs
+}
+
+func (w *Worker) run(ctx context.Context, work func(ctx context.Context) error, done func(err error)) {
+	root := w.ctx
+	w.begin()
+
+	go func() {
+		err := work(ctx)
+		fyne.Do(func() {
+			w.end()
+			if root.Err() != nil {
+				return
+			}
+			if done != nil {
+				done(err)
+			}
+		})
+	}()
+}
+
+// Do runs op against the client off the UI thread. Failures are reported in an
+// error dialog on window, except for operations stopped by CancelRunning;
+// onSuccess (if set) runs on the UI thread otherwise.
+func (w *Worker) Do(window fyne.Window, client *redis.Client, op func(c *redis.Client) error, onSuccess func()) {
+	if client == nil {
+		return
+	}
+	w.Go(func(ctx context.Context) error {
+		return op(client.WithContext(ctx))
+	}, func(err error) {
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			ShowErrorDialog(window, "Error", err)
+			return
+		}
+		if onSuccess != nil {
+			onSuccess()
+		}
+	})
+}
+
+// CancelRunning stops every in-flight operation. Unlike CancelAll their done
+// callbacks still run, receiving the context error.
+func (w *Worker) CancelRunning() {
+	w.cancelOps()
+	w.ops, w.cancelOps = context.WithCancel(w.ctx)
+}
+
+// CancelAll cancels every in-flight operation. Their done callbacks are dropped.
+func (w *Worker) CancelAll() {
+	w.cancel()
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	w.ops, w.cancelOps = context.WithCancel(w.ctx)
+}
+
+func (w *Worker) begin() {
+	w.pending++
+	if w.pending == 1 {
+		w.bar.Show()
+		w.indicator.Start()
+	}
+}
+
+func (w *Worker) end() {
+	w.pending--
+	if w.pending <= 0 {
+		w.pending = 0
+		w.indicator.Stop()
+		w.bar.Hide()
+	}
+}
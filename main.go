@@ -1,10 +1,20 @@
 package main
 
 import (
+	"flag"
+	"os"
+
 	"redis-explorer/internal/ui"
 )
 
 func main() {
+	url := flag.String("url", "", "redis:// or rediss:// URL to connect to on startup, without saving it (default $REDIS_URL)")
+	flag.Parse()
+	if *url == "" {
+		*url = os.Getenv("REDIS_URL")
+	}
+
 	app := ui.NewApp()
+	app.SetStartupURL(*url)
 	app.Run()
 }